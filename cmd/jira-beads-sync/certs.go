@@ -0,0 +1,24 @@
+//go:build embedcerts
+
+package main
+
+import (
+	"crypto/x509"
+	_ "embed"
+)
+
+// caBundle is the Mozilla CA bundle, committed and pinned in the Makefile
+//
+//go:embed certs/cacert.pem
+var caBundle []byte
+
+// The embedded roots are only used when the system has none, e.g. in a
+// scratch container or on an air-gapped host without a CA package, so a
+// corporate CA installed on the system keeps working.
+func init() {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		panic("embedded CA bundle contains no certificates")
+	}
+	x509.SetFallbackRoots(roots)
+}
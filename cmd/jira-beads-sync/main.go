@@ -1,14 +1,40 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/bdgraph"
 	"github.com/conallob/jira-beads-sync/internal/beads"
 	"github.com/conallob/jira-beads-sync/internal/config"
 	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/gitscope"
+	"github.com/conallob/jira-beads-sync/internal/integrity"
 	"github.com/conallob/jira-beads-sync/internal/jira"
+	"github.com/conallob/jira-beads-sync/internal/jqlbuilder"
+	"github.com/conallob/jira-beads-sync/internal/lock"
+	"github.com/conallob/jira-beads-sync/internal/routing"
+	"github.com/conallob/jira-beads-sync/internal/serve"
+	"github.com/conallob/jira-beads-sync/internal/simulate"
+	"github.com/conallob/jira-beads-sync/internal/syncstate"
+	"gopkg.in/yaml.v3"
 )
 
 // Build-time variables injected via ldflags by goreleaser
@@ -28,37 +54,87 @@ func main() {
 
 	switch command {
 	case "quickstart", "fetch":
-		if len(os.Args) < 3 {
+		fs := flag.NewFlagSet("quickstart", flag.ExitOnError)
+		scope := fs.String("scope", "", "Limit the sync scope; \"branch\" syncs issues referenced by the current git branch")
+		commits := fs.Int("commits", gitscope.DefaultCommitDepth, "Number of recent commits to scan with --scope branch")
+		preview := newPreviewFlags(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		var err error
+		switch {
+		case *scope == "branch":
+			err = runBranchScope(*commits, preview.mode(), preview.growth())
+		case *scope != "":
+			fmt.Fprintf(os.Stderr, "Error: unknown scope %q (supported: branch)\n\n", *scope)
+			printUsage()
+			os.Exit(1)
+		case fs.NArg() < 1:
 			fmt.Fprintf(os.Stderr, "Error: quickstart requires a Jira URL or issue key\n\n")
 			printUsage()
 			os.Exit(1)
+		case fs.NArg() > 1:
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", extraArgsError("quickstart", fs.Args()[1:]))
+			printUsage()
+			os.Exit(1)
+		default:
+			err = runQuickstart(fs.Arg(0), preview.mode(), preview.growth())
 		}
-		if err := runQuickstart(os.Args[2]); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exitOnError(err)
+	case "sync":
+		fs := flag.NewFlagSet("sync", flag.ExitOnError)
+		preset := fs.String("preset", "", "Sync a built-in query; \"due-soon\" syncs team issues due within --days")
+		days := fs.Int("days", jira.DefaultDueSoonDays, "Horizon in days for --preset due-soon")
+		preview := newPreviewFlags(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		if *preset == "" {
+			fmt.Fprintf(os.Stderr, "Error: sync requires --preset (supported: %s); pushing beads changes to Jira is not available yet\n\n", strings.Join(jira.Presets, ", "))
+			printUsage()
 			os.Exit(1)
 		}
-	case "fetch-by-label", "label":
-		if len(os.Args) < 3 {
-			fmt.Fprintf(os.Stderr, "Error: fetch-by-label requires a label argument\n\n")
+		if fs.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", extraArgsError("sync", fs.Args()))
 			printUsage()
 			os.Exit(1)
 		}
-		if err := runFetchByLabel(os.Args[2]); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if *days <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --days must be a positive number of days, got %d\n\n", *days)
+			printUsage()
 			os.Exit(1)
 		}
-	case "fetch-jql", "jql":
-		if len(os.Args) < 3 {
-			fmt.Fprintf(os.Stderr, "Error: fetch-jql requires a JQL query argument\n\n")
+		exitOnError(runPreset(*preset, *days, preview.mode(), preview.growth()))
+	case "fetch-by-label", "label":
+		fs := flag.NewFlagSet("fetch-by-label", flag.ExitOnError)
+		preview := newPreviewFlags(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() < 1 {
+			fmt.Fprintf(os.Stderr, "Error: fetch-by-label requires a label argument\n\n")
 			printUsage()
 			os.Exit(1)
 		}
-		// Join all remaining args as the JQL query
-		jqlQuery := strings.Join(os.Args[2:], " ")
-		if err := runFetchByJQL(jqlQuery); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if fs.NArg() > 1 {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", extraArgsError("fetch-by-label", fs.Args()[1:]))
+			printUsage()
+			os.Exit(1)
+		}
+		exitOnError(runFetchByLabel(fs.Arg(0), preview.mode(), preview.growth()))
+	case "jql-builder":
+		if len(os.Args) > 2 {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", extraArgsError("jql-builder", os.Args[2:]))
 			os.Exit(1)
 		}
+		exitOnError(runJQLBuilder())
+	case "fetch-jql", "jql", "--jql":
+		fs := flag.NewFlagSet("fetch-jql", flag.ExitOnError)
+		full := fs.Bool("full", false, "Ignore sync state and fetch every matching issue")
+		preview := newPreviewFlags(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		// Join all remaining args as the JQL query; without one the
+		// saved sync scope is synced
+		jqlQuery := strings.Join(fs.Args(), " ")
+		exitOnError(runFetchByJQL(jqlQuery, *full, preview.mode(), preview.growth()))
 	case "annotate":
 		if len(os.Args) < 4 {
 			fmt.Fprintf(os.Stderr, "Error: annotate requires <issue-id> and <repository> arguments\n\n")
@@ -69,16 +145,193 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-	case "convert":
+	case "impact":
 		if len(os.Args) < 3 {
-			fmt.Fprintf(os.Stderr, "Error: convert requires a file argument\n\n")
+			fmt.Fprintf(os.Stderr, "Error: impact requires a beads issue ID\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		if err := runImpact(os.Args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "fetch-attachment":
+		fs := flag.NewFlagSet("fetch-attachment", flag.ExitOnError)
+		output := fs.String("o", "", "Write the file here instead of to its filename in the current directory (\"-\" for stdout)")
+		force := fs.Bool("force", false, "Overwrite an existing file")
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() < 2 {
+			fmt.Fprintf(os.Stderr, "Error: fetch-attachment requires <issue-id> and <filename|attachment-id> arguments\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		if err := runFetchAttachment(fs.Arg(0), fs.Arg(1), *output, *force); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "relabel":
+		fs := flag.NewFlagSet("relabel", flag.ExitOnError)
+		dryRun := fs.Bool("dry-run", false, "Show which issues would be relabelled without writing; exits 2 if any would")
+		push := fs.Bool("push", false, "Also rename the labels in Jira (needs labels in push.allowed_fields)")
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() != 0 && fs.NArg() != 2 {
+			fmt.Fprintf(os.Stderr, "Error: relabel takes either no arguments or <old-label> <new-label>\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		var renames map[string]string
+		if fs.NArg() == 2 {
+			renames = map[string]string{fs.Arg(0): fs.Arg(1)}
+		}
+		exitOnError(runRelabel(renames, *dryRun, *push))
+	case "push-cc":
+		fs := flag.NewFlagSet("push-cc", flag.ExitOnError)
+		dryRun := fs.Bool("dry-run", false, "Show which CCs would be added without updating Jira; exits 2 if any would")
+		_ = fs.Parse(os.Args[2:])
+		exitOnError(runPushCC(*dryRun))
+	case "list", "ls":
+		fs := flag.NewFlagSet("list", flag.ExitOnError)
+		status := fs.String("status", "", "Only show these statuses (comma-separated, e.g. open,in_progress)")
+		epic := fs.String("epic", "", "Only show issues in this epic (\"none\" for issues without one)")
+		assignee := fs.String("assignee", "", "Only show issues assigned to this user")
+		label := fs.String("label", "", "Only show issues with this label")
+		priority := fs.String("priority", "", "Only show these priorities (comma-separated, e.g. 0,1)")
+		all := fs.Bool("all", false, "Include closed issues")
+		noColor := fs.Bool("no-color", false, "Disable colored output")
+		_ = fs.Parse(os.Args[2:])
+
+		priorities, err := parsePriorities(*priority)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		filter := beads.ListFilter{
+			Statuses:   splitList(*status),
+			Epic:       *epic,
+			Assignee:   *assignee,
+			Label:      *label,
+			Priorities: priorities,
+			HideClosed: !*all,
+		}
+		if err := runList(filter, !*noColor && colorTerminal()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "serve":
+		fs := flag.NewFlagSet("serve", flag.ExitOnError)
+		addr := fs.String("addr", serve.DefaultAddr, "Address to listen on for Jira webhooks")
+		debounce := fs.Duration("debounce", serve.DefaultDebounce, "Wait this long for further events before writing a batch")
+		commitChanges := fs.Bool("commit", false, "Commit .beads/ to git after each batch")
+		tenants := fs.String("tenants", "", "Serve every repository listed in this tenants file")
+		_ = fs.Parse(os.Args[2:])
+
+		if err := runServe(*addr, *debounce, *commitChanges, *tenants); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "simulate":
+		fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+		fixture := fs.String("fixture", "", "Jira export file or directory of exports to copy issues from (built-in template if unset)")
+		issues := fs.Int("issues", simulate.DefaultIssues, "Number of synthetic issues to generate")
+		epicSize := fs.Int("epic-size", simulate.DefaultEpicSize, "Synthetic issues per epic")
+		out := fs.String("out", "", "Keep the generated .beads files in this directory instead of a temporary one")
+		force := fs.Bool("force", false, "Write to --out even if its .beads directory already holds files")
+		_ = fs.Parse(os.Args[2:])
+
+		if err := runSimulate(simulate.Options{Issues: *issues, EpicSize: *epicSize, Fixture: *fixture}, *out, *force); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "stress":
+		fs := flag.NewFlagSet("stress", flag.ExitOnError)
+		duration := fs.Duration("duration", serve.DefaultStressDuration, "How long to generate events")
+		issues := fs.Int("issues", serve.DefaultStressIssues, "Number of simulated Jira issues")
+		servers := fs.Int("servers", serve.DefaultStressServers, "Webhook servers sharing the repository")
+		manual := fs.Int("manual-runs", 3, "Manual syncs started at random times")
+		out := fs.String("out", "", "Keep the repository in this directory instead of a temporary one")
+		_ = fs.Parse(os.Args[2:])
+
+		exitOnError(runStress(serve.StressOptions{
+			Issues:     *issues,
+			Duration:   *duration,
+			Servers:    *servers,
+			ManualRuns: *manual,
+		}, *out))
+	case "compare-mappings":
+		fs := flag.NewFlagSet("compare-mappings", flag.ExitOnError)
+		in := fs.String("in", "", "Jira JSON file to convert, - for standard input (required)")
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() != 2 {
+			fmt.Fprintf(os.Stderr, "Error: compare-mappings requires <old-mapping> and <new-mapping> arguments\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		if *in == "" {
+			fmt.Fprintf(os.Stderr, "Error: compare-mappings requires --in with a Jira JSON file to convert\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		exitOnError(runCompareMappings(fs.Arg(0), fs.Arg(1), *in))
+	case "explain":
+		fs := flag.NewFlagSet("explain", flag.ExitOnError)
+		in := fs.String("in", "", "Jira JSON file to explain from, - for standard input (default: fetch the issue from Jira)")
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() != 1 {
+			fmt.Fprintf(os.Stderr, "Error: explain requires a Jira issue key or URL\n\n")
 			printUsage()
 			os.Exit(1)
 		}
-		if err := runConvert(os.Args[2]); err != nil {
+		exitOnError(runExplain(fs.Arg(0), *in))
+	case "coordination":
+		fs := flag.NewFlagSet("coordination", flag.ExitOnError)
+		all := fs.Bool("all", false, "Also list epics below the thresholds")
+		_ = fs.Parse(os.Args[2:])
+
+		exitOnError(runCoordination(*all))
+	case "roadmap":
+		fs := flag.NewFlagSet("roadmap", flag.ExitOnError)
+		format := fs.String("format", "yaml", "Output format: yaml or mermaid")
+		output := fs.String("o", "", "Output file, or - for stdout (default roadmap.yaml or roadmap.mmd)")
+		_ = fs.Parse(os.Args[2:])
+
+		exitOnError(runRoadmap(*format, *output))
+	case "verify-bd":
+		if err := runVerifyBD(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "verify-integrity":
+		if err := runVerifyIntegrity(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+	case "convert":
+		fs := flag.NewFlagSet("convert", flag.ExitOnError)
+		in := fs.String("in", "", "Jira JSON file to convert, - for standard input")
+		out := fs.String("out", "", "Directory to write .beads/ to (default: current directory)")
+		preview := newPreviewFlags(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		if fs.NArg() > 1 {
+			fmt.Fprintf(os.Stderr, "Error: %v\n\n", extraArgsError("convert", fs.Args()[1:]))
+			os.Exit(1)
+		}
+		if *in == "" && fs.NArg() > 0 {
+			*in = fs.Arg(0)
+		} else if *in != "" && fs.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Error: convert takes either --in or a file argument, not both\n\n")
+			os.Exit(1)
+		}
+		if *in == "" {
+			fmt.Fprintf(os.Stderr, "Error: convert requires a file argument or --in\n\n")
+			printUsage()
+			os.Exit(1)
+		}
+		exitOnError(runConvert(*in, *out, preview.mode(), preview.growth()))
 	case "configure", "config":
 		if err := runConfigure(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -102,31 +355,21 @@ func main() {
 	}
 }
 
-func runQuickstart(urlOrKey string) error {
+func runQuickstart(urlOrKey string, preview previewMode, growth growthPolicy) error {
 	fmt.Println("jira-beads-sync quickstart")
 	fmt.Println("========================")
 	fmt.Println()
 
-	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Println("⚠ No configuration found. Let's set it up!")
-		fmt.Println()
-		cfg, err = config.PromptForConfig()
-		if err != nil {
-			return fmt.Errorf("failed to configure: %w", err)
-		}
-		if err := cfg.Save(); err != nil {
-			fmt.Printf("⚠ Warning: failed to save config: %v\n", err)
-		} else {
-			fmt.Println("✓ Configuration saved")
-			fmt.Println()
-		}
+		return err
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w. Run 'jira-beads-sync configure' to set up", err)
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
 	}
+	defer releaseLock(lease)
 
 	// Parse issue key from URL if needed
 	var issueKey string
@@ -153,7 +396,7 @@ func runQuickstart(urlOrKey string) error {
 	fmt.Println()
 
 	// Create Jira client
-	client := jira.NewClient(baseURL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.AuthMethod)
+	client := newJiraClient(cfg, baseURL)
 
 	// Fetch issue and dependencies
 	fmt.Printf("Fetching %s and its dependencies...\n", issueKey)
@@ -164,26 +407,243 @@ func runQuickstart(urlOrKey string) error {
 
 	fmt.Printf("\n✓ Fetched %d issue(s)\n\n", len(jiraExport.Issues))
 
-	// Convert to beads format
+	return convertAndRender(cfg, client, jiraExport, syncOptions{baseURL: client.BaseURL(), lease: lease, preview: preview, growth: growth})
+}
+
+// runBranchScope syncs only the issues referenced by the current git branch
+// and recent commits, plus the issues blocking them
+func runBranchScope(commits int, preview previewMode, growth growthPolicy) error {
+	fmt.Println("jira-beads-sync quickstart --scope branch")
+	fmt.Println("=========================================")
+	fmt.Println()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lease)
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	issueKeys, err := gitscope.NewDetector(workDir, commits).IssueKeys()
+	if err != nil {
+		return fmt.Errorf("failed to detect issue keys: %w", err)
+	}
+	if len(issueKeys) == 0 {
+		return fmt.Errorf("no issue keys found in the current branch name or last %d commits", commits)
+	}
+
+	fmt.Printf("Issue keys referenced by this branch: %s\n\n", strings.Join(issueKeys, ", "))
+
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+
+	jiraExport, err := client.FetchIssuesWithBlockers(issueKeys)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	fmt.Printf("\n✓ Fetched %d issue(s) (including blockers)\n\n", len(jiraExport.Issues))
+
+	return convertAndRender(cfg, client, jiraExport, syncOptions{baseURL: client.BaseURL(), lease: lease, preview: preview, growth: growth})
+}
+
+// loadConfig loads the configuration, prompting for it interactively if none exists
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println("⚠ No configuration found. Let's set it up!")
+		fmt.Println()
+		cfg, err = config.PromptForConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			fmt.Printf("⚠ Warning: failed to save config: %v\n", err)
+		} else {
+			fmt.Println("✓ Configuration saved")
+			fmt.Println()
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w. Run 'jira-beads-sync configure' to set up", err)
+	}
+
+	return cfg, nil
+}
+
+// syncOptions controls how convertAndRender writes a sync
+type syncOptions struct {
+	baseURL    string             // Jira instance the issues came from, for links in the records
+	state      *syncstate.State   // Records each issue's version, if set
+	properties jira.PropertyStore // Also keeps the state in Jira issue properties, if set with state
+	merge      bool               // Merge into the existing files instead of replacing them
+	lease      *lock.Lease        // Checked before writing
+	preview    previewMode        // Print what would change instead of writing
+	growth     growthPolicy       // What to do when the sync exceeds the growth limits
+}
+
+// convertAndRender converts fetched Jira issues and writes them to the
+// .beads directory in the current working directory, looking up component
+// leads and watchers with owners when configured. Nothing is written if the
+// sync lease has been lost; a dry run prints the changes instead and
+// returns errPendingChanges if there are any.
+func convertAndRender(cfg *config.Config, owners jira.OwnershipFetcher, jiraExport *jirapb.Export, run syncOptions) error {
 	outputDir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	opts, err := converterOptions(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.Converter.ComponentOwners {
+		fmt.Println("Resolving component leads...")
+		leads, err := owners.FetchComponentOwners(jiraExport)
+		if err != nil {
+			fmt.Printf("⚠ Warning: failed to resolve component owners: %v\n", err)
+		}
+		opts.ComponentOwners = leads
+	}
+	if cfg.Converter.Watchers {
+		fmt.Println("Fetching watchers...")
+		watchers, err := owners.FetchWatchers(context.Background(), jiraExport)
+		if err != nil {
+			fmt.Printf("⚠ Warning: failed to fetch watchers: %v\n", err)
+		}
+		opts.Watchers = watchers
+	}
+
 	fmt.Println("Converting to beads format...")
-	protoConverter := converter.NewProtoConverter()
-	beadsExport, err := protoConverter.Convert(jiraExport)
+	protoConverter := converter.NewProtoConverterWithOptions(opts)
+	beadsExport, warnings, err := protoConverter.ConvertWithWarnings(jiraExport)
 	if err != nil {
 		return fmt.Errorf("failed to convert: %w", err)
 	}
+	printWarnings(warnings)
+	optedOut := protoConverter.OptedOut()
+	if len(optedOut) > 0 {
+		label := opts.OptOutLabel
+		if label == "" {
+			label = converter.DefaultOptOutLabel
+		}
+		fmt.Printf("Skipped %d issue(s) labelled %s: %s\n", len(optedOut), label, strings.Join(optedOut, ", "))
+	}
+	if skippedTypes := protoConverter.SkippedTypes(); len(skippedTypes) > 0 {
+		fmt.Printf("Skipped %d issue(s) of types mapped to skip: %s\n", len(skippedTypes), strings.Join(skippedTypes, ", "))
+		optedOut = append(optedOut, skippedTypes...)
+	}
 
-	// Render to JSONL
-	jsonlRenderer := beads.NewJSONLRenderer(outputDir)
-	if err := jsonlRenderer.RenderExport(beadsExport); err != nil {
+	var changed []string
+	if run.state != nil {
+		if run.properties != nil {
+			restoreSyncState(run.properties, run.state, beadsExport)
+		}
+		changed = recordSyncState(run.state, beadsExport)
+	}
+
+	// Plan first, so syncs that would bloat the repository are caught before
+	// anything is written
+	format := cfg.Output.BeadsFormat()
+	var plan *beads.Plan
+	if router := newRouter(cfg); router != nil {
+		plan, err = routing.PlanAll(outputDir, router.Split(jiraExport, beadsExport), run.merge, format, rendererOptions(cfg, run.baseURL))
+	} else {
+		var renderer beads.Renderer
+		if renderer, err = beads.NewRendererWithOptions(outputDir, format, rendererOptions(cfg, run.baseURL)); err == nil {
+			plan, err = renderer.PlanExport(beadsExport, run.merge)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to plan: %w", err)
+	}
+	if err := checkGrowth(cfg, run.growth)(plan.Growth()); err != nil {
+		return err
+	}
+	if run.preview != previewOff {
+		return printPlan(plan, run.preview)
+	}
+
+	// Another runner may have taken over the lock while fetching
+	if err := run.lease.Check(context.Background()); err != nil {
+		return err
+	}
+
+	if router := newRouter(cfg); router != nil {
+		exports := router.Split(jiraExport, beadsExport)
+		changes, err := routing.RenderAll(outputDir, exports, run.merge, format, rendererOptions(cfg, run.baseURL))
+		if err != nil {
+			return fmt.Errorf("failed to render: %w", err)
+		}
+		printKeyChanges(changes)
+
+		var dirs []string
+		for _, repo := range routing.Repos(exports) {
+			dirs = append(dirs, routing.ResolveRepo(outputDir, repo))
+		}
+		if run.merge {
+			if err := removeOptedOut(cfg, optedOut, dirs...); err != nil {
+				return err
+			}
+		}
+		if err := writeManifests(cfg, dirs...); err != nil {
+			return err
+		}
+		if run.properties != nil {
+			storeSyncProperties(run.properties, run.state, changed)
+		}
+		printCoordinationAlerts(cfg, dirs...)
+		checkBDGraph(cfg, dirs...)
+
+		fmt.Println("\n✓ Conversion complete!")
+		for _, repo := range routing.Repos(exports) {
+			fmt.Printf("  %d epic(s), %d issue(s) written to %s/.beads/\n",
+				len(exports[repo].Epics), len(exports[repo].Issues), routing.ResolveRepo(outputDir, repo))
+		}
+		return nil
+	}
+
+	renderer, err := beads.NewRendererWithOptions(outputDir, format, rendererOptions(cfg, run.baseURL))
+	if err != nil {
+		return err
+	}
+	render := renderer.RenderExport
+	if run.merge {
+		render = renderer.MergeExport
+	}
+	if err := render(beadsExport); err != nil {
 		return fmt.Errorf("failed to render: %w", err)
 	}
+	printKeyChanges(renderer.KeyChanges())
+	if run.merge {
+		if err := removeOptedOut(cfg, optedOut, outputDir); err != nil {
+			return err
+		}
+	}
+	if err := writeManifests(cfg, outputDir); err != nil {
+		return err
+	}
+	if run.properties != nil {
+		storeSyncProperties(run.properties, run.state, changed)
+	}
+	printCoordinationAlerts(cfg, outputDir)
+	checkBDGraph(cfg, outputDir)
 
 	fmt.Println("\n✓ Conversion complete!")
+	if format != beads.FormatJSONL {
+		fmt.Printf("  %d epic(s) and %d issue(s) written to %s/.beads/issues.jsonl in bd format\n",
+			len(beadsExport.Epics), len(beadsExport.Issues), outputDir)
+		return nil
+	}
 	if len(beadsExport.Epics) > 0 {
 		fmt.Printf("  %d epic(s) written to %s/.beads/epics.jsonl\n", len(beadsExport.Epics), outputDir)
 	}
@@ -228,7 +688,7 @@ func runWhoami() error {
 	fmt.Println()
 
 	// Create Jira client
-	client := jira.NewClient(cfg.Jira.BaseURL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.AuthMethod)
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
 
 	// Test authentication by fetching current user
 	fmt.Println("Testing Jira connection...")
@@ -253,17 +713,76 @@ func runWhoami() error {
 	return nil
 }
 
-func runConvert(jiraFile string) error {
-	// Get current directory as output directory
-	outputDir, err := os.Getwd()
+// runConvert converts Jira JSON from a file, or standard input when
+// jiraFile is "-", writing .beads/ to outputDir or the current directory
+func runConvert(jiraFile, outputDir string, preview previewMode, growth growthPolicy) error {
+	if outputDir == "" {
+		var err error
+		if outputDir, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+	}
+
+	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lease)
+
+	opts, err := converterOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	pipeline := converter.NewPipelineWithOptions(outputDir, opts)
+	if err := pipeline.SetFormat(cfg.Output.BeadsFormat()); err != nil {
+		return err
+	}
+	if err := pipeline.SetDurability(cfg.Output.RendererOptions().Durability); err != nil {
+		return err
+	}
+	if err := pipeline.SetSource(rendererOptions(cfg, cfg.Jira.BaseURL).Source); err != nil {
+		return err
+	}
+	if router := newRouter(cfg); router != nil {
+		pipeline.SetRouter(router)
 	}
+	pipeline.SetGrowthCheck(checkGrowth(cfg, growth))
 
-	pipeline := converter.NewPipeline(outputDir)
+	var input io.Reader = os.Stdin
+	source := "standard input"
+	if jiraFile != "-" {
+		file, err := os.Open(jiraFile)
+		if err != nil {
+			return fmt.Errorf("failed to open Jira file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		input, source = file, jiraFile
+	}
 
-	fmt.Printf("Converting %s to beads format...\n", jiraFile)
-	if err := pipeline.ConvertFile(jiraFile); err != nil {
+	fmt.Printf("Converting %s to beads format...\n", source)
+	if preview != previewOff {
+		plan, err := pipeline.PlanReader(input)
+		if err != nil {
+			return err
+		}
+		printWarnings(pipeline.Warnings())
+		if err := checkGrowth(cfg, growth)(plan.Growth()); err != nil {
+			return err
+		}
+		return printPlan(plan, preview)
+	}
+	if err := pipeline.ConvertReader(input); err != nil {
+		return err
+	}
+	printWarnings(pipeline.Warnings())
+	printKeyChanges(pipeline.KeyChanges())
+	if err := writeManifests(cfg, pipeline.OutputDirs()...); err != nil {
 		return err
 	}
 
@@ -272,34 +791,24 @@ func runConvert(jiraFile string) error {
 	return nil
 }
 
-func runFetchByLabel(label string) error {
+func runFetchByLabel(label string, preview previewMode, growth growthPolicy) error {
 	fmt.Println("jira-beads-sync fetch-by-label")
 	fmt.Println("==============================")
 	fmt.Println()
 
-	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Println("⚠ No configuration found. Let's set it up!")
-		fmt.Println()
-		cfg, err = config.PromptForConfig()
-		if err != nil {
-			return fmt.Errorf("failed to configure: %w", err)
-		}
-		if err := cfg.Save(); err != nil {
-			fmt.Printf("⚠ Warning: failed to save config: %v\n", err)
-		} else {
-			fmt.Println("✓ Configuration saved")
-			fmt.Println()
-		}
+		return err
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w. Run 'jira-beads-sync configure' to set up", err)
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
 	}
+	defer releaseLock(lease)
 
 	// Create Jira client
-	client := jira.NewClient(cfg.Jira.BaseURL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.AuthMethod)
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
 
 	// Fetch issues by label
 	jiraExport, err := client.FetchIssuesByLabel(label)
@@ -309,120 +818,1593 @@ func runFetchByLabel(label string) error {
 
 	fmt.Printf("\n✓ Fetched %d issue(s) total (including dependencies)\n\n", len(jiraExport.Issues))
 
-	// Convert to beads format
-	outputDir, err := os.Getwd()
+	return convertAndRender(cfg, client, jiraExport, syncOptions{baseURL: client.BaseURL(), lease: lease, preview: preview, growth: growth})
+}
+
+// runJQLBuilder builds a sync scope interactively and saves it as
+// sync.jql
+func runJQLBuilder() error {
+	fmt.Println("jira-beads-sync jql-builder")
+	fmt.Println("===========================")
+	fmt.Println()
+
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
-	fmt.Println("Converting to beads format...")
-	protoConverter := converter.NewProtoConverter()
-	beadsExport, err := protoConverter.Convert(jiraExport)
+	builder := jqlbuilder.New(newJiraClient(cfg, cfg.Jira.BaseURL), os.Stdin, os.Stdout)
+	scope, err := builder.Build(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to convert: %w", err)
+		return fmt.Errorf("failed to build JQL query: %w", err)
 	}
+	jqlQuery := scope.JQL()
+	fmt.Printf("\nJQL: %s\n\n", jqlQuery)
 
-	// Render to JSONL
-	jsonlRenderer := beads.NewJSONLRenderer(outputDir)
-	if err := jsonlRenderer.RenderExport(beadsExport); err != nil {
-		return fmt.Errorf("failed to render: %w", err)
+	save, err := builder.Confirm("Save as the sync scope?")
+	if err != nil {
+		return err
 	}
-
-	fmt.Println("\n✓ Conversion complete!")
-	if len(beadsExport.Epics) > 0 {
-		fmt.Printf("  %d epic(s) written to %s/.beads/epics.jsonl\n", len(beadsExport.Epics), outputDir)
+	if !save {
+		return nil
+	}
+	if err := config.SaveSyncJQL(jqlQuery); err != nil {
+		return fmt.Errorf("failed to save sync scope: %w", err)
+	}
+	fmt.Println("✓ Sync scope saved; run 'jira-beads-sync fetch-jql' to sync it")
+	return nil
+}
+
+func runFetchByJQL(jqlQuery string, full bool, preview previewMode, growth growthPolicy) error {
+	fmt.Println("jira-beads-sync fetch-jql")
+	fmt.Println("=========================")
+	fmt.Println()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if jqlQuery == "" {
+		if cfg.Sync.JQL == "" {
+			return errors.New("no JQL query given and no sync scope configured; pass a query or run 'jira-beads-sync jql-builder' to build one")
+		}
+		jqlQuery = cfg.Sync.JQL
+		fmt.Printf("Syncing the configured scope: %s\n", jqlQuery)
+	}
+
+	dates, err := cfg.Display.Dates()
+	if err != nil {
+		return err
+	}
+
+	// Scheduled runs (e.g. from cron) skip outside the configured sync windows
+	sched, err := cfg.Schedule.Schedule()
+	if err != nil {
+		return err
+	}
+	if now := time.Now(); !sched.Allowed(now) {
+		if next, ok := sched.Next(now); ok {
+			fmt.Printf("⚠ Outside the configured sync window, skipping. Next window opens %s\n", dates.Format(next))
+		} else {
+			fmt.Println("⚠ The configured schedule never allows syncing, skipping")
+		}
+		return errOutsideWindow
+	}
+
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lease)
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	store, err := cfg.State.Store(outputDir)
+	if err != nil {
+		return err
+	}
+	state, err := store.Load(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// Create Jira client
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+
+	// Only fetch issues updated since this query last synced, unless forced
+	started := time.Now()
+	query := jqlQuery
+	lastSync, incremental := state.LastSync(jqlQuery)
+	incremental = incremental && !full
+	if incremental {
+		query = syncstate.IncrementalJQL(jqlQuery, lastSync, started)
+		fmt.Printf("Incremental sync: fetching issues updated since %s (use --full to resync everything)\n",
+			dates.Format(lastSync))
+
+		// An activity order only helps runs that are cut short to land the
+		// freshest changes first; full syncs keep the query's order
+		client.SetFetchOrder(jira.FetchOrder(cfg.Jira.FetchOrder))
+	}
+
+	// Fetch matching issues page by page, then their related issues in batches
+	jiraExport, err := client.FetchByJQL(query)
+	if incremental && errors.Is(err, jira.ErrNoIssuesFound) {
+		fmt.Println("\n✓ Already up to date")
+		if preview != previewOff {
+			return nil
+		}
+		state.MarkSynced(jqlQuery, started)
+		return store.Save(context.Background(), state)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues by JQL: %w", err)
+	}
+
+	fmt.Printf("\n✓ Fetched %d issue(s) total (including dependencies)\n\n", len(jiraExport.Issues))
+
+	// New records are appended in fetch order, which changes with activity
+	if incremental && cfg.Jira.FetchOrder != "" {
+		jira.SortByKey(jiraExport.Issues)
+	}
+
+	// Issues are often fetched again for changes that don't show in beads,
+	// such as a view or a new watcher; skip converting those whose payload
+	// matches the last sync, unless the records written then were changed
+	payload := payloadHashes(cfg, jiraExport)
+	if incremental && payload != nil {
+		if !outputIntact(cfg, outputDir) {
+			state.ForgetPayloads()
+		}
+		skipped := converter.DropUnchanged(jiraExport, func(issue *jirapb.Issue) bool {
+			return state.PayloadMatches(issue.Key, payload[issue.Key])
+		})
+		if len(jiraExport.Issues) == 0 {
+			fmt.Println("✓ Already up to date (no fetched issue changed since the last sync)")
+			if preview != previewOff {
+				return nil
+			}
+			state.MarkSynced(jqlQuery, started)
+			return store.Save(context.Background(), state)
+		}
+		if len(skipped) > 0 {
+			fmt.Printf("Skipping %d issue(s) unchanged since the last sync\n", len(skipped))
+		}
+	}
+
+	// Issues are only linked to epics converted alongside them, so parent
+	// epics left out of an incremental fetch are added to keep the epic
+	// links and labels that merging would otherwise drop
+	if incremental {
+		if err := addMissingEpics(cfg, client, jiraExport); err != nil {
+			return err
+		}
+	}
+
+	run := syncOptions{baseURL: client.BaseURL(), state: state, merge: incremental, lease: lease, preview: preview, growth: growth}
+	if cfg.Jira.StoreProperties {
+		run.properties = client
+	}
+	if err := convertAndRender(cfg, client, jiraExport, run); err != nil {
+		return err
+	}
+	if preview != previewOff {
+		return nil
+	}
+
+	state.RecordPayload(payload)
+	state.MarkSynced(jqlQuery, started)
+	return store.Save(context.Background(), state)
+}
+
+// addMissingEpics fetches the parent epics of the export's issues that are
+// not part of it and appends them to the export
+func addMissingEpics(cfg *config.Config, source jira.Searcher, jiraExport *jirapb.Export) error {
+	opts, err := converterOptions(cfg)
+	if err != nil {
+		return err
+	}
+	keys := converter.NewProtoConverterWithOptions(opts).MissingEpics(jiraExport)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fmt.Printf("Fetching %d parent epic(s) outside the updated issues...\n", len(keys))
+	epics, err := source.FetchIssuesByKey(context.Background(), keys)
+	if err != nil {
+		return fmt.Errorf("failed to fetch parent epics: %w", err)
+	}
+	jiraExport.Issues = append(jiraExport.Issues, epics...)
+	return nil
+}
+
+// outputIntact reports whether the beads files of every configured
+// repository still match their integrity manifests, so the records written
+// for unchanged payloads are still in place
+func outputIntact(cfg *config.Config, outputDir string) bool {
+	if cfg.Integrity.Disabled {
+		return false
+	}
+	for _, dir := range configuredRepos(cfg, outputDir) {
+		result, err := integrity.Verify(dir, nil)
+		if err != nil || len(result.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// payloadHashes hashes each fetched issue's payload together with the
+// settings conversion depends on. It returns nil when converting the same
+// payload can give a different result, so nothing is skipped.
+func payloadHashes(cfg *config.Config, jiraExport *jirapb.Export) map[string]string {
+	opts, err := converterOptions(cfg)
+	if err != nil || opts.TimeDependent() || cfg.Converter.ComponentOwners || cfg.Converter.Watchers {
+		return nil
+	}
+	settings, err := json.Marshal(struct {
+		Version   string
+		BaseURL   string
+		Converter config.ConverterConfig
+		Mapping   config.MappingConfig
+		Teams     config.TeamMapping
+		Routing   config.RoutingConfig
+		Output    config.OutputConfig
+	}{version, cfg.Jira.BaseURL, cfg.Converter, cfg.Mapping, cfg.Teams, cfg.Routing, cfg.Output})
+	if err != nil {
+		return nil
+	}
+
+	hashes := make(map[string]string, len(jiraExport.Issues))
+	for _, issue := range jiraExport.Issues {
+		hashes[issue.Key] = converter.PayloadHash(issue, string(settings))
+	}
+	return hashes
+}
+
+func runPreset(preset string, days int, preview previewMode, growth growthPolicy) error {
+	fmt.Println("jira-beads-sync sync --preset")
+	fmt.Println("============================")
+	fmt.Println()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lease)
+
+	jqlQuery, err := jira.PresetJQL(preset, jira.PresetOptions{
+		Days:      days,
+		Assignees: cfg.Team.Members,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+
+	jiraExport, err := client.FetchByJQL(jqlQuery)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s issues: %w", preset, err)
+	}
+
+	fmt.Printf("\n✓ Fetched %d issue(s) total (including dependencies)\n\n", len(jiraExport.Issues))
+
+	return convertAndRender(cfg, client, jiraExport, syncOptions{baseURL: client.BaseURL(), lease: lease, preview: preview, growth: growth})
+}
+
+func runAnnotate(issueID, repository string) error {
+	fmt.Println("jira-beads-sync annotate")
+	fmt.Println("========================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return fmt.Errorf("annotate only supports the jsonl output format")
+	}
+
+	jsonlRenderer := beads.NewJSONLRenderer(outputDir)
+
+	// Add repository annotation
+	if err := jsonlRenderer.AddRepositoryAnnotation(issueID, repository); err != nil {
+		return fmt.Errorf("failed to annotate issue: %w", err)
+	}
+	if err := writeManifests(cfg, outputDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added repository '%s' to issue %s\n", repository, issueID)
+	fmt.Printf("  Updated: %s/.beads/issues.jsonl\n", outputDir)
+
+	return nil
+}
+
+// runRelabel renames labels on the issues already written to the current
+// directory and the configured routing repositories, optionally renaming
+// them in Jira too, and records the pass in each repository's audit log.
+// Without explicit renames, the label mapping from mapping.yml is applied.
+func runRelabel(renames map[string]string, dryRun, push bool) error {
+	fmt.Println("jira-beads-sync relabel")
+	fmt.Println("=======================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if renames == nil {
+		renames = cfg.Mapping.Labels
+	}
+	if len(renames) == 0 {
+		return fmt.Errorf("no label renames: add them under labels in mapping.yml or pass <old-label> <new-label>")
+	}
+	if push {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+		if !cfg.Push.Allows("labels") {
+			return fmt.Errorf("--push needs labels in push.allowed_fields")
+		}
+	}
+
+	preview := previewOff
+	if dryRun {
+		preview = previewSummary
+	}
+	lease, err := lockRepo(cfg, preview)
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lease)
+	if err := lease.Check(context.Background()); err != nil {
+		return err
+	}
+
+	repos := configuredRepos(cfg, outputDir)
+
+	// Pushes are worked out from the labels in Jira rather than the local
+	// changes, so renames that failed to push are retried by the next run
+	var client *jira.Client
+	var syncedKeys map[string][]string
+	var pending map[string]map[string]string
+	if push && !dryRun {
+		client = newJiraClient(cfg, cfg.Jira.BaseURL)
+		if syncedKeys, err = syncedJiraKeys(cfg, repos); err != nil {
+			return err
+		}
+		if pending, err = jiraRenames(client, renames, syncedKeys); err != nil {
+			return err
+		}
+	}
+
+	total, pushes := 0, 0
+	var written []string
+	for _, dir := range repos {
+		renderer, err := beads.NewRendererWithOptions(dir, cfg.Output.BeadsFormat(), cfg.Output.RendererOptions())
+		if err != nil {
+			return err
+		}
+		changes, err := renderer.Relabel(renames, dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to relabel %s: %w", dir, err)
+		}
+		var pushKeys []string
+		for _, key := range syncedKeys[dir] {
+			if pending[key] != nil {
+				pushKeys = append(pushKeys, key)
+			}
+		}
+		if len(changes) == 0 && len(pushKeys) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s/.beads/: %d issue(s)\n", dir, len(changes))
+		entry := beads.AuditEntry{Time: time.Now().UTC(), Operation: "relabel", User: auditUser(cfg), Renames: renames}
+		for _, change := range changes {
+			fmt.Printf("  %s %s\n", change.ID, formatRenames(change.Renamed))
+			entry.Issues = append(entry.Issues, change.ID)
+		}
+		total += len(changes)
+		if dryRun {
+			continue
+		}
+
+		if client != nil {
+			entry.Pushed, entry.Failed = pushRenames(client, pending, pushKeys)
+			pushes += len(entry.Pushed) + len(entry.Failed)
+		}
+		if err := beads.AppendAudit(dir, entry); err != nil {
+			return err
+		}
+		written = append(written, dir)
+	}
+
+	if total == 0 && pushes == 0 {
+		fmt.Println("✓ No issues carry the renamed labels")
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("\n%d issue(s) would be relabelled\n", total)
+		return errPendingChanges
+	}
+	if err := writeManifests(cfg, written...); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Relabelled %d issue(s)\n", total)
+	return nil
+}
+
+// syncedJiraKeys returns the Jira keys of the issues synced to each
+// repository, leaving out records marked "sync": false. A key found in
+// several repositories is only listed for the first.
+func syncedJiraKeys(cfg *config.Config, repos []string) (map[string][]string, error) {
+	keys := make(map[string][]string, len(repos))
+	seen := make(map[string]bool)
+	for _, dir := range repos {
+		issues, _, err := beads.ReadRepo(dir, cfg.Output.BeadsFormat())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		for _, issue := range issues {
+			key := issue.Metadata["jiraKey"]
+			if key == "" || seen[key] || issue.SyncDisabled() {
+				continue
+			}
+			seen[key] = true
+			keys[dir] = append(keys[dir], key)
+		}
+	}
+	return keys, nil
+}
+
+// jiraRenames returns the renames still to apply in Jira, by Jira key,
+// from the labels the synced issues carry there
+func jiraRenames(client *jira.Client, renames map[string]string, syncedKeys map[string][]string) (map[string]map[string]string, error) {
+	synced := make(map[string]bool)
+	for _, keys := range syncedKeys {
+		for _, key := range keys {
+			synced[key] = true
+		}
+	}
+
+	ctx := context.Background()
+	found, err := client.SearchIssuesWithLabels(ctx, sortedKeys(renames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search Jira for the renamed labels: %w", err)
+	}
+	var keys []string
+	for _, key := range found {
+		if synced[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	issues, err := client.FetchIssuesByKey(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the labels of issues to rename: %w", err)
+	}
+	pending := make(map[string]map[string]string)
+	for _, issue := range issues {
+		if _, renamed := beads.RenameLabels(issue.GetFields().GetLabels(), renames); renamed != nil {
+			pending[issue.Key] = renamed
+		}
+	}
+	return pending, nil
+}
+
+func runPushCC(dryRun bool) error {
+	fmt.Println("jira-beads-sync push-cc")
+	fmt.Println("=======================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return fmt.Errorf("push-cc only supports the jsonl output format")
+	}
+	field := cfg.Mapping.ParticipantsField
+	if field == "" {
+		return fmt.Errorf("no participants field: set participants_field in mapping.yml")
+	}
+	if !dryRun && !cfg.Push.Allows("participants") {
+		return fmt.Errorf("push-cc needs participants in push.allowed_fields")
+	}
+
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+	ctx := context.Background()
+
+	total, failed := 0, 0
+	for _, dir := range configuredRepos(cfg, outputDir) {
+		issues, err := beads.ReadIssues(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read issues in %s: %w", dir, err)
+		}
+
+		entry := beads.AuditEntry{Time: time.Now().UTC(), Operation: "push-cc", User: auditUser(cfg)}
+		for _, issue := range issues {
+			jiraKey := issue.Metadata["jiraKey"]
+			ccs := converter.SplitCC(issue.Metadata[converter.CCMetadataKey])
+			if jiraKey == "" || len(ccs) == 0 || issue.SyncDisabled() {
+				continue
+			}
+
+			if dryRun {
+				participants, err := client.FetchParticipants(ctx, jiraKey, field)
+				if err != nil {
+					return err
+				}
+				if missing := jira.MissingParticipants(participants, ccs); len(missing) > 0 {
+					fmt.Printf("  %s (%s) +%s\n", issue.ID, jiraKey, strings.Join(missing, ", +"))
+					total += len(missing)
+				}
+				continue
+			}
+
+			added, unknown, err := client.AddParticipants(ctx, jiraKey, field, ccs)
+			if err != nil {
+				fmt.Printf("⚠ Warning: failed to update the participants of %s: %v\n", jiraKey, err)
+				entry.Issues = append(entry.Issues, issue.ID)
+				entry.Failed = append(entry.Failed, jiraKey)
+				failed++
+				continue
+			}
+			for _, cc := range unknown {
+				fmt.Printf("⚠ Warning: %s: no single Jira user matches cc %q\n", jiraKey, cc)
+			}
+			if len(added) > 0 {
+				fmt.Printf("  %s (%s) +%s\n", issue.ID, jiraKey, strings.Join(added, ", +"))
+				entry.Issues = append(entry.Issues, issue.ID)
+				entry.Pushed = append(entry.Pushed, jiraKey)
+				total += len(added)
+			}
+		}
+
+		if len(entry.Issues) > 0 {
+			if err := beads.AppendAudit(dir, entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to update the participants of %d issue(s)", failed)
+	}
+	if total == 0 {
+		fmt.Println("✓ Every cc is already a participant in Jira")
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("\n%d participant(s) would be added\n", total)
+		return errPendingChanges
+	}
+	fmt.Printf("\n✓ Added %d participant(s)\n", total)
+	return nil
+}
+
+// pushRenames applies the pending label renames of each Jira key and
+// returns the keys updated and the keys that failed
+func pushRenames(client *jira.Client, pending map[string]map[string]string, keys []string) (pushed, failed []string) {
+	for _, key := range keys {
+		renamed := pending[key]
+		var err error
+		for _, from := range sortedKeys(renamed) {
+			if err = client.RenameLabel(context.Background(), key, from, renamed[from]); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			fmt.Printf("⚠ Warning: failed to rename labels of %s in Jira: %v\n", key, err)
+			failed = append(failed, key)
+			continue
+		}
+		pushed = append(pushed, key)
+	}
+	if len(pushed) > 0 {
+		fmt.Printf("✓ Renamed labels on %d Jira issue(s)\n", len(pushed))
+	}
+	return pushed, failed
+}
+
+// configuredRepos returns the current directory and the repositories of the
+// routing config, without duplicates
+func configuredRepos(cfg *config.Config, outputDir string) []string {
+	repos := []string{outputDir}
+	add := func(repo string) {
+		if repo == "" {
+			return
+		}
+		dir := routing.ResolveRepo(outputDir, repo)
+		if !slices.Contains(repos, dir) {
+			repos = append(repos, dir)
+		}
+	}
+	add(cfg.Routing.DefaultRepo)
+	for _, route := range cfg.Routing.Routes {
+		add(route.Repo)
+	}
+	return repos
+}
+
+// auditUser names who ran a bulk edit: the Jira user, else the OS user
+func auditUser(cfg *config.Config) string {
+	if cfg.Jira.Username != "" {
+		return cfg.Jira.Username
+	}
+	return os.Getenv("USER")
+}
+
+// formatRenames formats renames as "old → new, …" in a stable order
+func formatRenames(renames map[string]string) string {
+	parts := make([]string, 0, len(renames))
+	for _, from := range sortedKeys(renames) {
+		parts = append(parts, from+" → "+renames[from])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// runServe keeps the current directory's beads files in sync with Jira by
+// applying webhook events until interrupted
+func runServe(addr string, debounce time.Duration, commitChanges bool, tenantsFile string) error {
+	fmt.Println("jira-beads-sync serve")
+	fmt.Println("=====================")
+	fmt.Println()
+
+	if tenantsFile != "" {
+		return runServeTenants(tenantsFile, addr, debounce, commitChanges)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	server, err := newServeServer(cfg, outputDir, addr, debounce, commitChanges)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return server.Run(ctx)
+}
+
+// runServeTenants serves every repository listed in a tenants file from a
+// single process
+func runServeTenants(tenantsFile, addr string, debounce time.Duration, commitChanges bool) error {
+	tenantsCfg, err := config.LoadTenants(tenantsFile)
+	if err != nil {
+		return err
+	}
+	if err := tenantsCfg.Validate(); err != nil {
+		return fmt.Errorf("invalid tenants file: %w", err)
+	}
+
+	tenants := make([]serve.Tenant, 0, len(tenantsCfg.Tenants))
+	for _, tenant := range tenantsCfg.Tenants {
+		cfg, err := config.LoadFile(tenant.Config)
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant.Name, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("tenant %s: invalid configuration: %w", tenant.Name, err)
+		}
+		// A relative lock file belongs to the tenant's repository, not to
+		// the daemon's working directory, which every tenant shares
+		cfg.Lock = cfg.Lock.InRepo(tenant.Repo)
+
+		server, err := newServeServer(cfg, tenant.Repo, addr, debounce, commitChanges)
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", tenant.Name, err)
+		}
+		tenants = append(tenants, serve.Tenant{Name: tenant.Name, Server: server})
+		fmt.Printf("✓ Tenant %s: %s\n", tenant.Name, tenant.Repo)
+	}
+
+	daemon, err := serve.NewDaemon(addr, tenantsCfg.AdminToken, tenants)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return daemon.Run(ctx)
+}
+
+// newServeServer creates the webhook server keeping outputDir in sync with
+// the settings in cfg
+func newServeServer(cfg *config.Config, outputDir, addr string, debounce time.Duration, commitChanges bool) (*serve.Server, error) {
+	if cfg.Serve.WebhookSecret == "" {
+		return nil, fmt.Errorf("a webhook secret is required: set serve.webhook_secret in the config file or JIRA_WEBHOOK_SECRET")
+	}
+	if len(cfg.Routing.Routes) > 0 {
+		fmt.Printf("⚠ Warning: routing is not supported by serve, writing all issues to %s\n", outputDir)
+	}
+
+	opts, err := converterOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := cfg.Schedule.Schedule()
+	if err != nil {
+		return nil, err
+	}
+
+	locker, err := cfg.Lock.Locker()
+	if err != nil {
+		return nil, err
+	}
+	lockWait, err := cfg.Lock.WaitTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+	syncer := serve.NewSyncer(client, opts, outputDir, commitChanges)
+	syncer.SetFormat(cfg.Output.BeadsFormat())
+	syncer.SetDurability(cfg.Output.RendererOptions().Durability)
+	syncer.SetSource(rendererOptions(cfg, client.BaseURL()).Source)
+	if !cfg.Integrity.Disabled {
+		key, err := cfg.Integrity.Signer()
+		if err != nil {
+			return nil, err
+		}
+		syncer.SetManifest(key)
+	}
+	return serve.NewServer(serve.Config{
+		Addr:     addr,
+		Secret:   cfg.Serve.WebhookSecret,
+		Debounce: debounce,
+		Schedule: sched,
+		Locker:   locker,
+		LockWait: lockWait,
+	}, syncer), nil
+}
+
+// runSimulate runs the conversion pipeline against synthetic issues and
+// prints timing and memory figures for sizing runners and daemons
+func runSimulate(opts simulate.Options, out string, force bool) error {
+	fmt.Println("jira-beads-sync simulate")
+	fmt.Println("========================")
+	fmt.Println()
+
+	// Use the configured conversion settings when there are any, since
+	// they change what each issue costs. No credentials are needed.
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠ Warning: %v; simulating with the default conversion settings\n\n", err)
+		cfg = &config.Config{}
+	}
+	if opts.Converter, err = converterOptions(cfg); err != nil {
+		return err
+	}
+	opts.Format = cfg.Output.BeadsFormat()
+	opts.Durability = cfg.Output.RendererOptions().Durability
+
+	// Synthetic issues replace whatever is in .beads/, so a real
+	// repository is only written over when asked to
+	if out != "" && !force {
+		entries, err := os.ReadDir(filepath.Join(out, ".beads"))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to check %s: %w", out, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("%s already has a non-empty .beads directory, which simulate would overwrite; use an empty directory or --force", out)
+		}
+	}
+
+	if out == "" {
+		tmpDir, err := os.MkdirTemp("", "jira-beads-simulate-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		out = tmpDir
+	}
+	opts.OutputDir = out
+
+	fmt.Printf("Generating %d synthetic issue(s)...\n\n", opts.Issues)
+	result, err := simulate.Run(opts)
+	if err != nil {
+		return err
+	}
+	result.Print(os.Stdout)
+	return nil
+}
+
+// runStress runs webhook servers, daemon cycles and manual syncs against one
+// repository at the same time and fails if any update was lost or a file
+// corrupted
+func runStress(opts serve.StressOptions, out string) error {
+	fmt.Println("jira-beads-sync stress")
+	fmt.Println("======================")
+	fmt.Println()
+
+	if out == "" {
+		tmpDir, err := os.MkdirTemp("", "jira-beads-stress-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		out = tmpDir
+	}
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", out, err)
+	}
+	opts.OutputDir = out
+
+	// Every actor takes the configured lock, so the run exercises the
+	// backend real syncs use; without one they share a file lock
+	lockCfg := config.LockConfig{Backend: "file", Path: filepath.Join(out, "stress.lock")}
+	if cfg, err := config.Load(); err != nil {
+		fmt.Printf("⚠ Warning: %v; using a file lock in the repository\n\n", err)
+	} else if cfg.Lock.Backend != "" {
+		lockCfg = cfg.Lock
+	}
+	if opts.Locker, err = lockCfg.Locker(); err != nil {
+		return err
+	}
+	fmt.Printf("Lock: %s backend\n", lockCfg.Backend)
+
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the jira-beads-sync binary: %w", err)
+	}
+	configHome, err := os.MkdirTemp("", "jira-beads-stress-config-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(configHome) }()
+	if err := writeStressConfig(configHome, lockCfg); err != nil {
+		return err
+	}
+	opts.ManualRun = func(ctx context.Context, jiraURL string) error {
+		return runStressSync(ctx, binary, configHome, jiraURL, out)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Running concurrent webhooks, daemon cycles and manual syncs for %s...\n\n", opts.Duration)
+	report, err := serve.Stress(ctx, opts)
+	if err != nil {
+		return err
+	}
+	report.Print(os.Stdout)
+	if !report.OK() {
+		return fmt.Errorf("stress test found %d problem(s)", len(report.Problems))
+	}
+	return nil
+}
+
+// writeStressConfig writes a config.yml under configHome for the manual
+// stress runs, holding only the lock settings, so the user's own Jira
+// settings and scope don't leak into them
+func writeStressConfig(configHome string, lockCfg config.LockConfig) error {
+	dir := filepath.Join(configHome, "jira-beads-sync")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := yaml.Marshal(&config.Config{Lock: lockCfg})
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yml"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// runStressSync runs "fetch-jql --full" in dir, against the simulated Jira at
+// jiraURL, as a user's manual sync would
+func runStressSync(ctx context.Context, binary, configHome, jiraURL, dir string) error {
+	cmd := exec.CommandContext(ctx, binary, "fetch-jql", "--full", serve.StressJQL)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"XDG_CONFIG_HOME="+configHome,
+		"JIRA_BASE_URL="+jiraURL,
+		"JIRA_USERNAME=stress",
+		"JIRA_API_TOKEN=stress",
+		"JIRA_AUTH_METHOD=basic",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("fetch-jql failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runCompareMappings converts the same Jira issues under two mapping files
+// and reports the issues whose kind, status, priority, disposition or labels
+// would change, so a mapping change can be reviewed before it is adopted
+func runCompareMappings(oldFile, newFile, in string) error {
+	fmt.Println("jira-beads-sync compare-mappings")
+	fmt.Println("================================")
+	fmt.Println()
+
+	// The other conversion settings apply to both sides. No credentials
+	// are needed, since nothing is fetched.
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠ Warning: %v; comparing with the default conversion settings\n\n", err)
+		cfg = &config.Config{}
+	}
+
+	jiraExport, err := readJiraExport(in)
+	if err != nil {
+		return err
+	}
+	source := in
+	if in == "-" {
+		source = "standard input"
+	}
+
+	convert := func(mappingFile string) (*beadspb.Export, error) {
+		mapping, err := config.LoadMapping(mappingFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mapping %s: %w", mappingFile, err)
+		}
+		if err := mapping.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid mapping %s: %w", mappingFile, err)
+		}
+		mapped := *cfg
+		mapped.Mapping = mapping
+		opts, err := converterOptions(&mapped)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping %s: %w", mappingFile, err)
+		}
+		beadsExport, _, err := converter.NewProtoConverterWithOptions(opts).ConvertWithWarnings(jiraExport)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert with %s: %w", mappingFile, err)
+		}
+		return beadsExport, nil
+	}
+
+	fmt.Printf("Converting %d issue(s) from %s with %s and %s...\n", len(jiraExport.Issues), source, oldFile, newFile)
+	before, err := convert(oldFile)
+	if err != nil {
+		return err
+	}
+	after, err := convert(newFile)
+	if err != nil {
+		return err
+	}
+
+	changes := converter.CompareExports(before, after)
+	if len(changes) == 0 {
+		fmt.Println("\n✓ Both mappings convert every issue the same way")
+		return nil
+	}
+
+	counts := make(map[string]int)
+	fmt.Printf("\n%d issue(s) would change:\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  %s %s\n", change.JiraKey, change.Title)
+		for _, field := range change.Fields {
+			fmt.Printf("    %-12s %s → %s\n", field.Field+":", orNone(field.Before), orNone(field.After))
+			counts[field.Field]++
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Changes by field:")
+	for _, field := range []string{"kind", "status", "priority", "disposition", "labels"} {
+		if counts[field] > 0 {
+			fmt.Printf("  %-12s %d\n", field, counts[field])
+		}
+	}
+	return errPendingChanges
+}
+
+// runExplain converts an issue the way a sync would and prints how each
+// beads field was derived
+func runExplain(urlOrKey, in string) error {
+	fmt.Println("jira-beads-sync explain")
+	fmt.Println("=======================")
+	fmt.Println()
+
+	var cfg *config.Config
+	var jiraExport *jirapb.Export
+	issueKey := urlOrKey
+	var err error
+	if isURL(urlOrKey) {
+		if issueKey, err = jira.ParseIssueKeyFromURL(urlOrKey); err != nil {
+			return err
+		}
+	}
+	if in != "" {
+		// Nothing is fetched, so no credentials are needed
+		if cfg, err = config.Load(); err != nil {
+			fmt.Printf("⚠ Warning: %v; explaining with the default conversion settings\n\n", err)
+			cfg = &config.Config{}
+		}
+		if jiraExport, err = readJiraExport(in); err != nil {
+			return err
+		}
+	} else {
+		if cfg, err = loadConfig(); err != nil {
+			return err
+		}
+		baseURL := cfg.Jira.BaseURL
+		if isURL(urlOrKey) {
+			if baseURL, err = jira.GetBaseURLFromIssueURL(urlOrKey); err != nil {
+				return err
+			}
+		}
+
+		// Epics and dependencies are resolved among the related issues, so
+		// fetch them as a sync would
+		client := newJiraClient(cfg, baseURL)
+		fmt.Printf("Fetching %s and its dependencies...\n", issueKey)
+		if jiraExport, err = client.FetchIssueWithDependencies(issueKey); err != nil {
+			return fmt.Errorf("failed to fetch issues: %w", err)
+		}
+		fmt.Printf("✓ Fetched %d issue(s)\n\n", len(jiraExport.Issues))
+	}
+
+	opts, err := converterOptions(cfg)
+	if err != nil {
+		return err
+	}
+	explanation, err := converter.NewProtoConverterWithOptions(opts).Explain(jiraExport, issueKey)
+	if err != nil {
+		return err
+	}
+
+	if explanation.ID == "" {
+		fmt.Printf("%s is skipped\n\n", explanation.JiraKey)
+	} else {
+		fmt.Printf("%s → %s (%s)\n\n", explanation.JiraKey, explanation.ID, explanation.Kind)
+	}
+	for _, field := range explanation.Fields {
+		fmt.Printf("  %-12s %s\n", field.Field, orNone(field.Value))
+		fmt.Printf("  %-12s ← %s\n", "", field.Source)
+		fmt.Printf("  %-12s rule: %s\n", "", field.Rule)
+	}
+
+	if len(explanation.Warnings) > 0 {
+		fmt.Println()
+		fmt.Println("Warnings:")
+		for _, warning := range explanation.Warnings {
+			fmt.Printf("  %s\n", warning)
+		}
+	}
+	return nil
+}
+
+// readJiraExport parses Jira JSON from a file, or standard input for "-"
+func readJiraExport(in string) (*jirapb.Export, error) {
+	if in != "-" {
+		jiraExport, err := jira.NewAdapter().ParseFile(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Jira JSON: %w", err)
+		}
+		return jiraExport, nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Jira JSON: %w", err)
+	}
+	jiraExport, err := jira.NewAdapter().Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira JSON: %w", err)
+	}
+	return jiraExport, nil
+}
+
+// orNone shows an empty field value as (none)
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+func runVerifyBD() error {
+	fmt.Println("jira-beads-sync verify-bd")
+	fmt.Println("=========================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	issues, _, err := beads.ReadRepo(outputDir, cfg.Output.BeadsFormat())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Checking %d issue(s) against the bd database...\n", len(issues))
+	discrepancies, err := bdgraph.NewChecker(outputDir).Check(issues)
+	if err != nil {
+		return err
+	}
+
+	if len(discrepancies) == 0 {
+		fmt.Println("\n✓ bd dependency graph matches the converted issues")
+		return nil
+	}
+
+	fmt.Printf("\n⚠ %d discrepancy(ies) between the converted issues and bd:\n", len(discrepancies))
+	for _, discrepancy := range discrepancies {
+		fmt.Printf("  - %s\n", discrepancy)
+	}
+	return fmt.Errorf("bd dependency graph does not match the converted issues")
+}
+
+// runVerifyIntegrity checks the beads files in the current directory
+// against the manifest written by the last sync
+func runVerifyIntegrity() error {
+	fmt.Println("jira-beads-sync verify-integrity")
+	fmt.Println("================================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	publicKey, err := cfg.Integrity.Verifier()
+	if err != nil {
+		return err
+	}
+
+	result, err := integrity.Verify(outputDir, publicKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Checking %d file(s) against .beads/%s...\n", result.Files, integrity.ManifestFile)
+	if result.Signed && !result.Checked {
+		fmt.Println("⚠ Warning: the manifest is signed but no public key is configured, signature not checked")
+	}
+
+	if len(result.Problems) > 0 {
+		fmt.Printf("\n⚠ %d integrity problem(s):\n", len(result.Problems))
+		for _, problem := range result.Problems {
+			fmt.Printf("  - %s\n", problem)
+		}
+		return fmt.Errorf("beads files do not match the integrity manifest")
+	}
+
+	fmt.Println("\n✓ All files match the manifest")
+	if result.Checked {
+		fmt.Println("✓ Manifest signature is valid")
+	}
+	return nil
+}
+
+// runCoordination reports the epics whose issues depend on many issues in
+// other epics or projects, flagging those above the configured thresholds
+func runCoordination(all bool) error {
+	fmt.Println("jira-beads-sync coordination")
+	fmt.Println("============================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return fmt.Errorf("coordination only supports the jsonl output format")
+	}
+
+	issues, err := beads.ReadIssues(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read issues: %w", err)
+	}
+	epics, err := beads.ReadEpics(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read epics: %w", err)
+	}
+
+	thresholds := cfg.Report.Thresholds()
+	report := beads.AnalyzeCoordination(issues, epics, thresholds)
+	heavy := 0
+	for _, epic := range report {
+		if epic.Heavy {
+			heavy++
+		}
+	}
+
+	fmt.Printf("Thresholds: %s issue(s) in other epics, %s in other projects\n\n",
+		formatThreshold(thresholds.CrossEpic, beads.DefaultCrossEpicThreshold),
+		formatThreshold(thresholds.CrossProject, beads.DefaultCrossProjectThreshold))
+	if len(report) == 0 {
+		fmt.Println("✓ No epic depends on issues in other epics or projects")
+		return nil
+	}
+	if heavy == 0 && !all {
+		fmt.Println("✓ No coordination-heavy epics")
+		return nil
+	}
+
+	fmt.Printf("%-14s %-36s %10s %13s\n", "EPIC", "NAME", "OTHER EPIC", "OTHER PROJECT")
+	for _, epic := range report {
+		if !epic.Heavy && !all {
+			continue
+		}
+		flag := ""
+		if epic.Heavy {
+			flag = "  ⚠ coordination-heavy"
+		}
+		fmt.Printf("%-14s %-36s %10d %13d%s\n", epic.Epic.ID, truncate(epic.Epic.Name, 36),
+			len(epic.CrossEpic), len(epic.CrossProject), flag)
+	}
+
+	if heavy > 0 {
+		fmt.Printf("\n⚠ %d coordination-heavy epic(s)\n", heavy)
+	}
+	return nil
+}
+
+// printCoordinationAlerts warns about coordination-heavy epics after a sync
+func printCoordinationAlerts(cfg *config.Config, dirs ...string) {
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return
+	}
+	for _, dir := range dirs {
+		issues, err := beads.ReadIssues(dir)
+		if err != nil {
+			continue
+		}
+		epics, err := beads.ReadEpics(dir)
+		if err != nil {
+			continue
+		}
+
+		var heavy []string
+		for _, epic := range beads.AnalyzeCoordination(issues, epics, cfg.Report.Thresholds()) {
+			if epic.Heavy {
+				heavy = append(heavy, epic.Epic.ID)
+			}
+		}
+		if len(heavy) > 0 {
+			fmt.Printf("\n⚠ %d coordination-heavy epic(s) in %s: %s\n", len(heavy), dir, strings.Join(heavy, ", "))
+			fmt.Println("  Run 'jira-beads-sync coordination' there for details")
+		}
+	}
+}
+
+// checkBDGraph compares the bd database with the files just imported in the
+// bd-import format and warns about discrepancies, so bd versions that drop
+// or re-type relationships are noticed when they do, not at the next
+// verify-bd
+func checkBDGraph(cfg *config.Config, dirs ...string) {
+	if cfg.Output.BeadsFormat() != beads.FormatBDImport {
+		return
+	}
+	for _, dir := range dirs {
+		issues, _, err := beads.ReadRepo(dir, beads.FormatBDImport)
+		if err != nil {
+			fmt.Printf("\n⚠ Warning: failed to check the bd dependency graph in %s: %v\n", dir, err)
+			continue
+		}
+		discrepancies, err := bdgraph.NewChecker(dir).Check(issues)
+		if err != nil {
+			fmt.Printf("\n⚠ Warning: failed to check the bd dependency graph in %s: %v\n", dir, err)
+			continue
+		}
+		if len(discrepancies) > 0 {
+			fmt.Printf("\n⚠ %d discrepancy(ies) between the converted issues and bd in %s:\n", len(discrepancies), dir)
+			for _, discrepancy := range discrepancies {
+				fmt.Printf("  - %s\n", discrepancy)
+			}
+		}
+	}
+}
+
+// formatThreshold describes a coordination threshold for the report
+func formatThreshold(threshold, defaultThreshold int) string {
+	switch {
+	case threshold < 0:
+		return "unlimited"
+	case threshold == 0:
+		return fmt.Sprintf("more than %d", defaultThreshold)
+	default:
+		return fmt.Sprintf("more than %d", threshold)
+	}
+}
+
+// truncate shortens s to n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func runImpact(issueID string) error {
+	fmt.Println("jira-beads-sync impact")
+	fmt.Println("======================")
+	fmt.Println()
+
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	issues, epics, err := beads.ReadRepo(outputDir, cfg.Output.BeadsFormat())
+	if err != nil {
+		return err
+	}
+
+	impact, err := beads.AnalyzeImpact(issues, issueID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n\n", impact.Root.ID, impact.Root.Title)
+
+	if len(impact.Blocked) == 0 {
+		fmt.Println("Nothing depends on this issue.")
+		return nil
+	}
+
+	fmt.Printf("Transitively blocks %d issue(s):\n", len(impact.Blocked))
+	for _, blocked := range impact.Blocked {
+		fmt.Printf("  %s%-12s [%s] %s\n",
+			strings.Repeat("  ", blocked.Depth-1), blocked.Issue.ID, blocked.Issue.Status, blocked.Issue.Title)
+	}
+
+	epicNames := make(map[string]string)
+	for _, epic := range epics {
+		epicNames[epic.ID] = epic.Name
+	}
+
+	fmt.Println()
+	fmt.Println("Blocked issues by epic:")
+	for _, epicID := range impact.Epics() {
+		label := "(no epic)"
+		if epicID != "" {
+			label = epicID
+			if name := epicNames[epicID]; name != "" {
+				label = fmt.Sprintf("%s (%s)", epicID, name)
+			}
+		}
+		fmt.Printf("  %-40s %d\n", label, impact.ByEpic[epicID])
 	}
-	fmt.Printf("  %d issue(s) written to %s/.beads/issues.jsonl\n", len(beadsExport.Issues), outputDir)
 
 	return nil
 }
 
-func runFetchByJQL(jqlQuery string) error {
-	fmt.Println("jira-beads-sync fetch-jql")
-	fmt.Println("=========================")
-	fmt.Println()
+// runFetchAttachment downloads an attachment recorded on a local issue from
+// Jira. Issues only carry attachment metadata, so files are fetched on demand.
+func runFetchAttachment(issueID, ref, output string, force bool) error {
+	outputDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
 
-	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Println("⚠ No configuration found. Let's set it up!")
-		fmt.Println()
-		cfg, err = config.PromptForConfig()
-		if err != nil {
-			return fmt.Errorf("failed to configure: %w", err)
-		}
-		if err := cfg.Save(); err != nil {
-			fmt.Printf("⚠ Warning: failed to save config: %v\n", err)
-		} else {
-			fmt.Println("✓ Configuration saved")
-			fmt.Println()
+		return err
+	}
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return fmt.Errorf("fetch-attachment only supports the jsonl output format")
+	}
+
+	issues, err := beads.ReadIssues(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read issues: %w", err)
+	}
+	issue, err := beads.FindIssue(issues, issueID)
+	if err != nil {
+		return err
+	}
+	attachment, err := issue.FindAttachment(ref)
+	if err != nil {
+		return err
+	}
+
+	client := newJiraClient(cfg, cfg.Jira.BaseURL)
+	contentURL := attachment.URL
+	if contentURL == "" {
+		if attachment.ID == "" {
+			return fmt.Errorf("attachment %s has no download URL; re-sync the issue to record one", attachment.Filename)
 		}
+		contentURL = client.AttachmentURL(attachment.ID, attachment.Filename)
 	}
 
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w. Run 'jira-beads-sync configure' to set up", err)
+	if output == "-" {
+		_, err := client.DownloadAttachment(context.Background(), contentURL, os.Stdout)
+		return err
+	}
+	if output == "" {
+		output = filepath.Base(attachment.Filename)
+		if output == "." || output == ".." || output == string(filepath.Separator) {
+			return fmt.Errorf("attachment filename %q is not a valid file name; use -o to choose one", attachment.Filename)
+		}
+	}
+	if _, err := os.Stat(output); err == nil && !force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", output)
 	}
 
-	// Create Jira client
-	client := jira.NewClient(cfg.Jira.BaseURL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.AuthMethod)
+	// Download next to the destination and rename, so an interrupted
+	// download never leaves a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.part")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
 
-	// Fetch issues by JQL
-	jiraExport, err := client.FetchIssuesByJQL(jqlQuery)
+	n, err := client.DownloadAttachment(context.Background(), contentURL, tmp)
+	if cerr := tmp.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
 	if err != nil {
-		return fmt.Errorf("failed to fetch issues by JQL: %w", err)
+		return err
+	}
+	if err := os.Rename(tmp.Name(), output); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
 	}
 
-	fmt.Printf("\n✓ Fetched %d issue(s) total (including dependencies)\n\n", len(jiraExport.Issues))
+	if attachment.Size > 0 && n != attachment.Size {
+		fmt.Printf("⚠ Warning: expected %d bytes but downloaded %d; the attachment may have changed since the last sync\n", attachment.Size, n)
+	}
+	fmt.Printf("✓ Downloaded %s from %s (%d bytes) to %s\n", attachment.Filename, issue.ID, n, output)
+	return nil
+}
+
+func runRoadmap(format, output string) error {
+	var write func(*beads.Roadmap, io.Writer) error
+	switch format {
+	case "yaml":
+		write = (*beads.Roadmap).WriteYAML
+		if output == "" {
+			output = "roadmap.yaml"
+		}
+	case "mermaid":
+		write = func(r *beads.Roadmap, w io.Writer) error { return r.WriteMermaid(w, "Roadmap") }
+		if output == "" {
+			output = "roadmap.mmd"
+		}
+	default:
+		return fmt.Errorf("invalid format %q, must be yaml or mermaid", format)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Output.BeadsFormat() != beads.FormatJSONL {
+		return fmt.Errorf("roadmap only supports the jsonl output format")
+	}
 
-	// Convert to beads format
 	outputDir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
-
-	fmt.Println("Converting to beads format...")
-	protoConverter := converter.NewProtoConverter()
-	beadsExport, err := protoConverter.Convert(jiraExport)
+	issues, err := beads.ReadIssues(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to convert: %w", err)
+		return fmt.Errorf("failed to read issues: %w", err)
+	}
+	epics, err := beads.ReadEpics(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read epics: %w", err)
 	}
+	roadmap := beads.BuildRoadmap(issues, epics)
 
-	// Render to JSONL
-	jsonlRenderer := beads.NewJSONLRenderer(outputDir)
-	if err := jsonlRenderer.RenderExport(beadsExport); err != nil {
-		return fmt.Errorf("failed to render: %w", err)
+	if output == "-" {
+		return write(roadmap, os.Stdout)
 	}
 
-	fmt.Println("\n✓ Conversion complete!")
-	if len(beadsExport.Epics) > 0 {
-		fmt.Printf("  %d epic(s) written to %s/.beads/epics.jsonl\n", len(beadsExport.Epics), outputDir)
+	fmt.Println("jira-beads-sync roadmap")
+	fmt.Println("=======================")
+	fmt.Println()
+
+	var buf bytes.Buffer
+	if err := write(roadmap, &buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
 	}
-	fmt.Printf("  %d issue(s) written to %s/.beads/issues.jsonl\n", len(beadsExport.Issues), outputDir)
 
+	undated := 0
+	for _, epic := range roadmap.Epics {
+		if epic.Start == "" || epic.Due == "" {
+			undated++
+		}
+	}
+	if undated > 0 {
+		fmt.Printf("⚠ Warning: %d epic(s) have no start or due date; set target dates on them or their issues\n", undated)
+	}
+	fmt.Printf("✓ Wrote %d epic(s) to %s\n", len(roadmap.Epics), output)
 	return nil
 }
 
-func runAnnotate(issueID, repository string) error {
-	fmt.Println("jira-beads-sync annotate")
-	fmt.Println("========================")
-	fmt.Println()
-
+func runList(filter beads.ListFilter, color bool) error {
 	outputDir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	jsonlRenderer := beads.NewJSONLRenderer(outputDir)
-
-	// Add repository annotation
-	if err := jsonlRenderer.AddRepositoryAnnotation(issueID, repository); err != nil {
-		return fmt.Errorf("failed to annotate issue: %w", err)
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	issues, epics, err := beads.ReadRepo(outputDir, cfg.Output.BeadsFormat())
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("✓ Added repository '%s' to issue %s\n", repository, issueID)
-	fmt.Printf("  Updated: %s/.beads/issues.jsonl\n", outputDir)
+	groups := beads.GroupByEpic(issues, epics, filter)
+	if len(groups) == 0 {
+		fmt.Println("No matching issues.")
+		return nil
+	}
 
-	return nil
+	return beads.RenderTable(os.Stdout, groups, color)
 }
 
 func printUsage() {
@@ -430,10 +2412,34 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  jira-beads-sync quickstart <jira-url>         Fetch issue from Jira and convert to beads")
+	fmt.Println("  jira-beads-sync quickstart --scope branch     Fetch issues referenced by the current git branch")
+	fmt.Println("  jira-beads-sync sync --preset due-soon        Fetch team issues due within --days (default 7)")
 	fmt.Println("  jira-beads-sync fetch-by-label <label>        Fetch all issues with label from Jira")
 	fmt.Println("  jira-beads-sync fetch-jql <jql-query>         Fetch issues matching JQL query from Jira")
+	fmt.Println("  jira-beads-sync fetch-jql                     Fetch issues in the configured sync scope (sync.jql)")
+	fmt.Println("  jira-beads-sync jql-builder                   Build a sync scope interactively and save it")
+	fmt.Println("  jira-beads-sync --jql <jql-query>             Same as fetch-jql")
+	fmt.Println("  jira-beads-sync fetch-jql --full <jql-query>  Ignore sync state and refetch everything")
+	fmt.Println("  jira-beads-sync fetch-jql --dry-run [--diff]  Show what a sync would change; exits 2 if anything would")
+	fmt.Println("  jira-beads-sync fetch-jql --max-repo-growth   Fail instead of warning when a sync would bloat the beads repo")
 	fmt.Println("  jira-beads-sync annotate <issue-id> <repo>    Annotate issue with repository info")
+	fmt.Println("  jira-beads-sync impact <beads-id>             Show everything transitively blocked by an issue")
+	fmt.Println("  jira-beads-sync coordination [--all]          Flag epics depending on many issues in other epics or projects")
+	fmt.Println("  jira-beads-sync roadmap [--format f] [-o file] Export epic start and due dates as YAML or a Mermaid Gantt chart")
+	fmt.Println("  jira-beads-sync list [--status open]          Show local issues grouped by epic, most urgent first")
+	fmt.Println("  jira-beads-sync fetch-attachment <id> <file>  Download an attachment of a local issue from Jira")
+	fmt.Println("  jira-beads-sync relabel [--push]              Apply the label renames in mapping.yml to existing issues")
+	fmt.Println("  jira-beads-sync push-cc [--dry-run]           Add local cc entries to the Jira participants of each issue")
+	fmt.Println("  jira-beads-sync serve [--addr :8080]          Apply Jira webhook events continuously")
+	fmt.Println("  jira-beads-sync serve --tenants <file>        Keep several beads repositories in sync from one process")
+	fmt.Println("  jira-beads-sync simulate [--issues 50000]     Time the pipeline on synthetic issues to size runners")
+	fmt.Println("  jira-beads-sync stress [--duration 1m]        Check concurrent webhooks and syncs lose no updates")
+	fmt.Println("  jira-beads-sync compare-mappings <old> <new>  Show which issues two mapping files convert differently")
+	fmt.Println("  jira-beads-sync explain <issue-key>           Show how each beads field of an issue was derived")
+	fmt.Println("  jira-beads-sync verify-bd                     Check the bd dependency graph matches the converted issues")
+	fmt.Println("  jira-beads-sync verify-integrity              Check .beads/ files against the manifest written by the last sync")
 	fmt.Println("  jira-beads-sync convert <jira-export-file>    Convert Jira export to beads format")
+	fmt.Println("  jira-beads-sync convert --in - --out <dir>    Convert Jira JSON from standard input into <dir>/.beads/")
 	fmt.Println("  jira-beads-sync configure                     Configure Jira credentials")
 	fmt.Println("  jira-beads-sync whoami                        Test Jira authentication and show user info")
 	fmt.Println("  jira-beads-sync version                       Show version information")
@@ -442,14 +2448,488 @@ func printUsage() {
 	fmt.Println("Examples:")
 	fmt.Println("  jira-beads-sync quickstart https://jira.example.com/browse/PROJ-123")
 	fmt.Println("  jira-beads-sync quickstart PROJ-123")
+	fmt.Println("  jira-beads-sync quickstart --scope branch --commits 50")
+	fmt.Println("  jira-beads-sync sync --preset due-soon --days 3")
 	fmt.Println("  jira-beads-sync fetch-by-label sprint-23")
 	fmt.Println("  jira-beads-sync fetch-jql 'project = MYPROJ AND assignee = currentUser() AND status IN (\"READY TO START\", \"In Progress\")'")
 	fmt.Println("  jira-beads-sync fetch-jql 'project = MYPROJ AND sprint = 42'")
+	fmt.Println("  jira-beads-sync --jql 'project = MYPROJ AND updated >= -30d'")
+	fmt.Println("  jira-beads-sync fetch-jql --diff 'project = MYPROJ'")
 	fmt.Println("  jira-beads-sync annotate proj-123 https://github.com/org/repo")
+	fmt.Println("  jira-beads-sync impact proj-123")
+	fmt.Println("  jira-beads-sync list --priority 0,1 --assignee alice")
+	fmt.Println("  jira-beads-sync fetch-attachment proj-123 trace.log")
+	fmt.Println("  jira-beads-sync relabel --dry-run frontend ui")
+	fmt.Println("  jira-beads-sync simulate --fixture dataset/ --issues 50000")
+	fmt.Println("  jira-beads-sync compare-mappings --in jira-export.json mapping.yml mapping.new.yml")
+	fmt.Println("  jira-beads-sync explain PROJ-123")
 	fmt.Println("  jira-beads-sync convert jira-export.json")
+	fmt.Println("  curl -s -u $JIRA_USERNAME:$JIRA_API_TOKEN \"$JIRA_BASE_URL/rest/api/2/search?jql=project=PROJ\" | jira-beads-sync convert --in - --out ./beads")
 	fmt.Println("  jira-beads-sync configure")
 }
 
+// removeOptedOut removes the records of issues that were labelled to opt
+// out since they were synced. Full syncs leave them out anyway; merges
+// have to remove them.
+func removeOptedOut(cfg *config.Config, keys []string, dirs ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	for _, dir := range dirs {
+		renderer, err := beads.NewRendererWithOptions(dir, cfg.Output.BeadsFormat(), cfg.Output.RendererOptions())
+		if err != nil {
+			return err
+		}
+		if _, err := renderer.RemoveJiraKeys(keys); err != nil {
+			return fmt.Errorf("failed to remove opted-out issues: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeManifests records the integrity manifest of each repository a sync
+// wrote to, signing it when a key is configured
+func writeManifests(cfg *config.Config, dirs ...string) error {
+	if cfg.Integrity.Disabled {
+		return nil
+	}
+	key, err := cfg.Integrity.Signer()
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := integrity.Write(dir, key); err != nil {
+			return fmt.Errorf("failed to write integrity manifest: %w", err)
+		}
+	}
+	return nil
+}
+
+// rendererOptions returns the configured write settings, with a source
+// block naming the Jira instance at baseURL and this build
+func rendererOptions(cfg *config.Config, baseURL string) beads.RendererOptions {
+	opts := cfg.Output.RendererOptions()
+	opts.Source = &beads.SourceOptions{Instance: baseURL, ToolVersion: version}
+	return opts
+}
+
+// lockRepo takes the configured sync lock so that only one runner mutates
+// the repository at a time. Without a lock backend it returns a nil lease,
+// as it does for dry runs, which don't write.
+func lockRepo(cfg *config.Config, preview previewMode) (*lock.Lease, error) {
+	if preview != previewOff {
+		return nil, nil
+	}
+	locker, err := cfg.Lock.Locker()
+	if err != nil || locker == nil {
+		return nil, err
+	}
+	wait, err := cfg.Lock.WaitTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	var lease *lock.Lease
+	if wait == 0 {
+		lease, err = locker.TryAcquire(context.Background())
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), wait)
+		defer cancel()
+		lease, err = locker.Acquire(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("another sync is running: %w", err)
+	}
+
+	fmt.Printf("✓ Acquired sync lock (fencing token %d)\n\n", lease.Token())
+	return lease, nil
+}
+
+// releaseLock releases a lease taken by lockRepo
+func releaseLock(lease *lock.Lease) {
+	if err := lease.Release(context.Background()); err != nil {
+		fmt.Printf("⚠ Warning: %v\n", err)
+	}
+}
+
+// previewMode selects a dry run, which prints what a sync would change
+// instead of writing it
+type previewMode int
+
+const (
+	previewOff     previewMode = iota
+	previewSummary             // List created, updated and removed records
+	previewDiff                // Also show a unified diff per record
+)
+
+// errPendingChanges is returned by dry runs that found changes to write
+var errPendingChanges = errors.New("dry run found pending changes")
+
+// errOutsideWindow is returned by syncs skipped because the schedule
+// doesn't allow syncing now
+var errOutsideWindow = errors.New("outside the configured sync window")
+
+// previewFlags are the --dry-run, --diff and --max-repo-growth flags of
+// the syncing commands
+type previewFlags struct {
+	dryRun    *bool
+	diff      *bool
+	maxGrowth *bool
+}
+
+func newPreviewFlags(fs *flag.FlagSet) previewFlags {
+	return previewFlags{
+		dryRun:    fs.Bool("dry-run", false, "Show what would change without writing; exits 2 if anything would"),
+		diff:      fs.Bool("diff", false, "Like --dry-run, with a unified diff of each changed record"),
+		maxGrowth: fs.Bool("max-repo-growth", false, "Fail instead of warning when the sync exceeds output.max_new_records or output.max_growth_mb"),
+	}
+}
+
+func (f previewFlags) growth() growthPolicy {
+	if *f.maxGrowth {
+		return growthFail
+	}
+	return growthWarn
+}
+
+func (f previewFlags) mode() previewMode {
+	switch {
+	case *f.diff:
+		return previewDiff
+	case *f.dryRun:
+		return previewSummary
+	default:
+		return previewOff
+	}
+}
+
+// printPlan prints the changes a dry run found and returns
+// errPendingChanges if there are any
+func printPlan(plan *beads.Plan, preview previewMode) error {
+	fmt.Print("\nDry run: ")
+	if err := plan.Render(os.Stdout, preview == previewDiff); err != nil {
+		return err
+	}
+	if plan.HasChanges() {
+		fmt.Println("\nRun without --dry-run to apply these changes.")
+		return errPendingChanges
+	}
+	fmt.Println("\n✓ Already up to date")
+	return nil
+}
+
+// growthPolicy selects what happens when a sync would grow the beads
+// repository past the configured limits
+type growthPolicy int
+
+const (
+	growthWarn growthPolicy = iota // Print a warning and sync anyway
+	growthFail                     // Fail before writing anything
+)
+
+// checkGrowth returns a check of how much a sync would grow the beads
+// repository against the configured limits
+func checkGrowth(cfg *config.Config, policy growthPolicy) func(beads.Growth) error {
+	limits := cfg.Output.GrowthLimits()
+	return func(growth beads.Growth) error {
+		exceeded := limits.Exceeded(growth)
+		if len(exceeded) == 0 {
+			return nil
+		}
+		if policy == growthFail {
+			return fmt.Errorf("sync would add %s to the beads repository (--max-repo-growth); narrow the query or raise the limits",
+				strings.Join(exceeded, ", "))
+		}
+		fmt.Printf("⚠ Warning: this sync adds %s to the beads repository, which slows git down; "+
+			"check the query, or raise output.max_new_records and output.max_growth_mb\n", strings.Join(exceeded, ", "))
+		return nil
+	}
+}
+
+// extraArgsError describes arguments left over after a command's
+// positional arguments. Flags stop being parsed at the first positional
+// argument, so flags given after it would otherwise be ignored silently.
+func extraArgsError(command string, extra []string) error {
+	for _, arg := range extra {
+		if strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("%s would ignore %s: flags must come before the other arguments", command, strings.Join(extra, " "))
+		}
+	}
+	return fmt.Errorf("%s got unexpected arguments: %s", command, strings.Join(extra, " "))
+}
+
+// exitOnError exits if a command failed. Dry runs that found changes exit
+// with status 2 and syncs skipped outside the sync window with status 3, so
+// CI and cron can tell them from failures (status 1).
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, errPendingChanges) {
+		os.Exit(2)
+	}
+	if errors.Is(err, errOutsideWindow) {
+		os.Exit(3)
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+// converterOptions translates the converter config into converter options
+func converterOptions(cfg *config.Config) (converter.Options, error) {
+	opts := converter.Options{
+		PriorityAging:          cfg.Converter.PriorityAging.Thresholds(),
+		MaxDescriptionLength:   cfg.Converter.MaxDescriptionLength,
+		ComputedFields:         cfg.Converter.ComputedFields,
+		Workers:                cfg.Converter.Workers,
+		MaxComments:            cfg.Converter.MaxComments,
+		SkipAttachments:        cfg.Converter.SkipAttachments,
+		PreserveRawDescription: cfg.Converter.PreserveRawDescription,
+		MaxChainDepth:          cfg.Converter.MaxChainDepth,
+		CopyCloneLabels:        cfg.Converter.Clones.CopyLabels,
+		CopyCloneEpic:          cfg.Converter.Clones.CopyEpic,
+		SprintLabels:           cfg.Converter.PlanningLabels.Sprint,
+		FixVersionLabels:       cfg.Converter.PlanningLabels.FixVersion,
+		ComponentLabels:        cfg.Converter.PlanningLabels.Component,
+		ComponentSeparator:     cfg.Converter.ComponentSeparator,
+		EpicNameField:          cfg.Mapping.EpicNameField,
+		EpicNameFromField:      cfg.Converter.EpicName.Source == "field",
+		EpicOtherInDescription: cfg.Converter.EpicName.Other == "description",
+		OptOutLabel:            cfg.Converter.OptOutLabel,
+		EpicLabels:             cfg.Converter.EpicLabels,
+		ReleaseEpicProjects:    cfg.Converter.ReleaseEpics,
+	}
+
+	for _, rule := range cfg.Converter.TitleRules {
+		titleRule, err := converter.NewTitleRule(rule.Pattern, rule.Replace)
+		if err != nil {
+			return converter.Options{}, err
+		}
+		opts.TitleRules = append(opts.TitleRules, titleRule)
+	}
+
+	if len(cfg.Mapping.Statuses) > 0 {
+		opts.StatusMap = make(map[string]beadspb.Status, len(cfg.Mapping.Statuses))
+		for name, value := range cfg.Mapping.Statuses {
+			status, err := converter.ParseStatus(value)
+			if err != nil {
+				return converter.Options{}, fmt.Errorf("invalid status mapping for %q: %w", name, err)
+			}
+			opts.StatusMap[name] = status
+		}
+	}
+	if len(cfg.Mapping.Priorities) > 0 {
+		opts.PriorityMap = make(map[string]beadspb.Priority, len(cfg.Mapping.Priorities))
+		for name, value := range cfg.Mapping.Priorities {
+			priority, err := converter.ParsePriority(value)
+			if err != nil {
+				return converter.Options{}, fmt.Errorf("invalid priority mapping for %q: %w", name, err)
+			}
+			opts.PriorityMap[name] = priority
+		}
+	}
+	if len(cfg.Mapping.Resolutions) > 0 {
+		opts.DispositionMap = make(map[string]beadspb.Disposition, len(cfg.Mapping.Resolutions))
+		for name, value := range cfg.Mapping.Resolutions {
+			disposition, err := converter.ParseDisposition(value)
+			if err != nil {
+				return converter.Options{}, fmt.Errorf("invalid resolution mapping for %q: %w", name, err)
+			}
+			opts.DispositionMap[name] = disposition
+		}
+	}
+	if len(cfg.Mapping.IssueTypes) > 0 {
+		opts.IssueTypes = make(map[string]converter.IssueClass, len(cfg.Mapping.IssueTypes))
+		for name, value := range cfg.Mapping.IssueTypes {
+			class, err := converter.ParseIssueClass(value)
+			if err != nil {
+				return converter.Options{}, fmt.Errorf("invalid issue type mapping for %q: %w", name, err)
+			}
+			opts.IssueTypes[name] = class
+		}
+	}
+	opts.CustomFields = cfg.Mapping.CustomFields
+	opts.ParticipantsField = cfg.Mapping.ParticipantsField
+	opts.TargetStartField = cfg.Mapping.TargetStartField
+	opts.TargetEndField = cfg.Mapping.TargetEndField
+	opts.LabelRenames = cfg.Mapping.Labels
+	opts.Teams = cfg.Teams.Users
+
+	return opts, nil
+}
+
+// newJiraClient creates a Jira client for baseURL using the configured
+// credentials, requesting any mapped custom fields in searches
+func newJiraClient(cfg *config.Config, baseURL string) *jira.Client {
+	client := jira.NewClientWithOptions(baseURL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.AuthMethod, jira.ClientOptions{
+		MaxConcurrency: cfg.Jira.MaxConcurrency,
+		MaxRetries:     cfg.Jira.MaxRetries,
+		APIVersion:     cfg.Jira.APIVersion,
+	})
+	client.RequestCustomFields(cfg.Mapping.CustomFieldIDs()...)
+	return client
+}
+
+// printWarnings prints a per-kind summary of conversion warnings followed by the details
+func printWarnings(warnings converter.Warnings) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	counts := warnings.CountByKind()
+	fmt.Printf("\n⚠ %d conversion warning(s):\n", len(warnings))
+	for _, kind := range warnings.Kinds() {
+		fmt.Printf("  %s: %d\n", kind, counts[kind])
+		for _, warning := range warnings.OfKind(kind) {
+			fmt.Printf("    - %s\n", warning)
+		}
+	}
+}
+
+// recordSyncState stores the synced version of every converted issue and
+// epic, reports how many changed since the last sync and returns the Jira
+// keys of the changed ones
+func recordSyncState(state *syncstate.State, beadsExport *beadspb.Export) []string {
+	var changed []string
+	unchanged := 0
+	record := func(jiraKey string, issue syncstate.IssueState) {
+		if state.RecordIssue(jiraKey, issue) {
+			changed = append(changed, jiraKey)
+		} else {
+			unchanged++
+		}
+	}
+
+	for _, epic := range beadsExport.Epics {
+		record(epic.Metadata.GetJiraKey(), syncstate.IssueState{
+			Updated: epic.Updated.AsTime(), Hash: beads.EpicContentHash(epic), BeadsID: epic.Id,
+		})
+	}
+	for _, issue := range beadsExport.Issues {
+		record(issue.Metadata.GetJiraKey(), syncstate.IssueState{
+			Updated: issue.Updated.AsTime(), Hash: beads.IssueContentHash(issue), BeadsID: issue.Id,
+		})
+	}
+
+	fmt.Printf("  %d changed, %d unchanged since last sync\n", len(changed), unchanged)
+	return changed
+}
+
+// restoreSyncState fills in the versions of issues missing from the local
+// sync state from their Jira issue properties, so losing the state file
+// doesn't make every issue look changed
+func restoreSyncState(store jira.PropertyStore, state *syncstate.State, beadsExport *beadspb.Export) {
+	var keys []string
+	for _, epic := range beadsExport.Epics {
+		keys = append(keys, epic.Metadata.GetJiraKey())
+	}
+	for _, issue := range beadsExport.Issues {
+		keys = append(keys, issue.Metadata.GetJiraKey())
+	}
+	keys = state.Unknown(keys)
+	if len(keys) == 0 {
+		return
+	}
+
+	properties, err := store.FetchSyncProperties(context.Background(), keys)
+	if err != nil {
+		fmt.Printf("⚠ Warning: failed to read sync state from Jira issue properties: %v\n", err)
+	}
+	restored := 0
+	for key, property := range properties {
+		if state.Restore(key, syncstate.IssueState{Updated: property.Updated, Hash: property.Hash, BeadsID: property.BeadsID}) {
+			restored++
+		}
+	}
+	if restored > 0 {
+		fmt.Printf("  Restored the sync state of %d issue(s) from Jira issue properties\n", restored)
+	}
+}
+
+// storeSyncProperties writes the sync state of the changed issues to their
+// Jira issue properties. Failures only warn, since the local state is
+// already up to date.
+func storeSyncProperties(store jira.PropertyStore, state *syncstate.State, jiraKeys []string) {
+	if state == nil || len(jiraKeys) == 0 {
+		return
+	}
+
+	properties := make(map[string]jira.SyncProperty, len(jiraKeys))
+	for _, key := range jiraKeys {
+		issue := state.Issues[key]
+		properties[key] = jira.SyncProperty{BeadsID: issue.BeadsID, Hash: issue.Hash, Updated: issue.Updated}
+	}
+
+	stored, err := store.StoreSyncProperties(context.Background(), properties)
+	if err != nil {
+		fmt.Printf("⚠ Warning: failed to store sync state in Jira issue properties: %v\n", err)
+		fmt.Println("  Unset jira.store_properties if the account may not edit issues")
+	}
+	if stored > 0 {
+		fmt.Printf("✓ Stored the sync state of %d issue(s) in Jira issue properties\n", stored)
+	}
+}
+
+// printKeyChanges reports issues whose Jira key changed since the last sync
+func printKeyChanges(changes []beads.KeyChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Printf("\n↪ %d moved issue(s):\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  %s → %s (%s → %s)\n", change.OldKey, change.NewKey, change.OldID, change.NewID)
+	}
+}
+
+// newRouter creates a component router from the routing config, or nil if no routes are configured
+func newRouter(cfg *config.Config) *routing.Router {
+	if len(cfg.Routing.Routes) == 0 {
+		return nil
+	}
+
+	routes := make([]routing.Route, 0, len(cfg.Routing.Routes))
+	for _, route := range cfg.Routing.Routes {
+		routes = append(routes, routing.Route{Component: route.Component, Repo: route.Repo})
+	}
+	return routing.NewRouter(routes, cfg.Routing.DefaultRepo)
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parsePriorities parses a comma-separated list of priorities such as
+// "0,1" or "P0,P1"
+func parsePriorities(value string) ([]int, error) {
+	var priorities []int
+	for _, item := range splitList(value) {
+		priority, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(item), "P"))
+		if err != nil || priority < 0 || priority > 4 {
+			return nil, fmt.Errorf("invalid priority %q (expected 0-4)", item)
+		}
+		priorities = append(priorities, priority)
+	}
+	return priorities, nil
+}
+
+// colorTerminal reports whether stdout is a terminal that should get
+// colored output, honouring the NO_COLOR convention
+func colorTerminal() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // isURL checks if a string is a URL (starts with http:// or https://)
 func isURL(s string) bool {
 	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
@@ -2,8 +2,17 @@ package main
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/config"
+	"github.com/conallob/jira-beads-sync/internal/syncstate"
+	"github.com/conallob/jira-beads-sync/jira/jiratest"
 )
 
 func TestIsURL(t *testing.T) {
@@ -279,7 +288,7 @@ func TestRunFetchByJQLWithMockConfig(t *testing.T) {
 
 	// Test will fail at network call (which is expected without a real Jira server)
 	// But it will exercise the config loading and client creation code paths
-	err := runFetchByJQL("project = TEST")
+	err := runFetchByJQL("project = TEST", false, previewOff, growthWarn)
 
 	// We expect an error because there's no real Jira server
 	// But the error should be from network/API call, not from config loading
@@ -331,7 +340,7 @@ func TestRunFetchByLabelWithMockConfig(t *testing.T) {
 	}
 
 	// Test runFetchByLabel - will fail at network call
-	err := runFetchByLabel("test-label")
+	err := runFetchByLabel("test-label", previewOff, growthWarn)
 
 	// We expect an error because there's no real Jira server
 	if err != nil {
@@ -381,7 +390,7 @@ func TestRunQuickstartWithMockConfig(t *testing.T) {
 	}
 
 	// Test runQuickstart with an issue key - will fail at network call
-	err := runQuickstart("TEST-123")
+	err := runQuickstart("TEST-123", previewOff, growthWarn)
 
 	// We expect an error because there's no real Jira server
 	if err != nil {
@@ -393,3 +402,166 @@ func TestRunQuickstartWithMockConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestRunPushCCRejectsBDFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tmpDir)
+	configDir := filepath.Join(tmpDir, "jira-beads-sync")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	configContent := `jira:
+  base_url: https://jira.example.com
+  username: test@example.com
+  api_token: test-token
+output:
+  format: bd
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	// Issues in bd's database can't be read back, so every issue would be
+	// skipped and push-cc would report success without pushing anything
+	err := runPushCC(true)
+	if err == nil || !strings.Contains(err.Error(), "jsonl output format") {
+		t.Errorf("Expected push-cc to reject the bd format, got %v", err)
+	}
+}
+
+func TestSyncPropertiesRoundTrip(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := syncstate.New()
+	state.RecordIssue("PROJ-1", syncstate.IssueState{Updated: updated, Hash: "abc", BeadsID: "proj-1"})
+
+	store := jiratest.NewPropertyStore(nil)
+	storeSyncProperties(store, state, []string{"PROJ-1"})
+	if property := store.Properties()["PROJ-1"]; property.Hash != "abc" || property.BeadsID != "proj-1" {
+		t.Fatalf("Expected the sync state to be stored, got %+v", property)
+	}
+
+	// A lost state file is filled in from the issue properties
+	restored := syncstate.New()
+	restoreSyncState(store, restored, &beadspb.Export{Issues: []*beadspb.Issue{
+		{Id: "proj-1", Metadata: &beadspb.Metadata{JiraKey: "PROJ-1"}},
+	}})
+	if issue := restored.Issues["PROJ-1"]; issue.Hash != "abc" || !issue.Updated.Equal(updated) {
+		t.Errorf("Expected the sync state to be restored, got %+v", issue)
+	}
+}
+
+func TestParsePriorities(t *testing.T) {
+	got, err := parsePriorities("0, P1,p2,")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("Expected [0 1 2], got %v", got)
+	}
+
+	for _, invalid := range []string{"5", "high", "-1"} {
+		if _, err := parsePriorities(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestLockRepo(t *testing.T) {
+	lease, err := lockRepo(&config.Config{}, previewOff)
+	if err != nil || lease != nil {
+		t.Fatalf("Expected no lease without a lock backend, got %v, %v", lease, err)
+	}
+
+	cfg := &config.Config{Lock: config.LockConfig{
+		Backend: "file",
+		Path:    filepath.Join(t.TempDir(), "sync.lock"),
+		Wait:    "0",
+	}}
+	lease, err = lockRepo(cfg, previewOff)
+	if err != nil {
+		t.Fatalf("lockRepo failed: %v", err)
+	}
+	defer releaseLock(lease)
+
+	if _, err := lockRepo(cfg, previewOff); err == nil || !strings.Contains(err.Error(), "another sync is running") {
+		t.Errorf("Expected a second sync to fail while locked, got %v", err)
+	}
+}
+
+func TestCheckGrowth(t *testing.T) {
+	cfg := &config.Config{Output: config.OutputConfig{MaxNewRecords: 10, MaxGrowthMB: -1}}
+	small := beads.Growth{Records: 10, Bytes: 1 << 30}
+	large := beads.Growth{Records: 11}
+
+	if err := checkGrowth(cfg, growthFail)(small); err != nil {
+		t.Errorf("Expected growth within the limits to pass, got %v", err)
+	}
+	if err := checkGrowth(cfg, growthWarn)(large); err != nil {
+		t.Errorf("Expected only a warning without --max-repo-growth, got %v", err)
+	}
+	if err := checkGrowth(cfg, growthFail)(large); err == nil || !strings.Contains(err.Error(), "11 new records (limit 10)") {
+		t.Errorf("Expected --max-repo-growth to fail, got %v", err)
+	}
+}
+
+func TestPayloadHashes(t *testing.T) {
+	export := &jirapb.Export{Issues: []*jirapb.Issue{
+		{Key: "PROJ-1", Fields: &jirapb.Fields{Summary: "First"}},
+		{Key: "PROJ-2", Fields: &jirapb.Fields{Summary: "Second"}},
+	}}
+
+	cfg := &config.Config{}
+	hashes := payloadHashes(cfg, export)
+	if len(hashes) != 2 || hashes["PROJ-1"] == "" || hashes["PROJ-1"] == hashes["PROJ-2"] {
+		t.Fatalf("Expected a distinct hash per issue, got %v", hashes)
+	}
+
+	cfg.Converter.PlanningLabels.Sprint = true
+	if got := payloadHashes(cfg, export); got["PROJ-1"] == hashes["PROJ-1"] {
+		t.Error("Expected a config change to change the hashes")
+	}
+
+	cfg.Converter.ComputedFields = true
+	if got := payloadHashes(cfg, export); got != nil {
+		t.Errorf("Expected no hashes when conversion depends on the time, got %v", got)
+	}
+}
+
+func TestOutputIntact(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{}
+	if outputIntact(cfg, tmpDir) {
+		t.Error("Expected a repository without a manifest not to count as intact")
+	}
+
+	issuesFile := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(issuesFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(issuesFile, []byte(`{"id":"proj-1"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifests(cfg, tmpDir); err != nil {
+		t.Fatalf("writeManifests failed: %v", err)
+	}
+	if !outputIntact(cfg, tmpDir) {
+		t.Error("Expected files matching the manifest to count as intact")
+	}
+
+	if err := os.WriteFile(issuesFile, []byte(`{"id":"proj-1","title":"Edited"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if outputIntact(cfg, tmpDir) {
+		t.Error("Expected an edited issues file not to count as intact")
+	}
+}
+
+func TestExtraArgsError(t *testing.T) {
+	err := extraArgsError("convert", []string{"--out", "./beads"})
+	if !strings.Contains(err.Error(), "ignore --out ./beads") || !strings.Contains(err.Error(), "flags must come before") {
+		t.Errorf("Expected the ignored flags to be named, got %v", err)
+	}
+	if err := extraArgsError("convert", []string{"second.json"}); !strings.Contains(err.Error(), "unexpected arguments: second.json") {
+		t.Errorf("Expected the extra argument to be named, got %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !notzdata
+
+package main
+
+// The IANA time zone database is embedded so that the zones of sync
+// windows and date display load on minimal containers and hosts without
+// /usr/share/zoneinfo; the system database is still preferred when present.
+// Build with -tags notzdata to leave it out and save about 450 KB.
+import _ "time/tzdata"
@@ -137,6 +137,63 @@ func (Priority) EnumDescriptor() ([]byte, []int) {
 	return file_beads_proto_rawDescGZIP(), []int{1}
 }
 
+// Disposition distinguishes delivered work from abandoned work among
+// closed issues, derived from the Jira resolution
+type Disposition int32
+
+const (
+	Disposition_DISPOSITION_UNSPECIFIED      Disposition = 0 // Not closed
+	Disposition_DISPOSITION_DONE             Disposition = 1
+	Disposition_DISPOSITION_WONT_DO          Disposition = 2
+	Disposition_DISPOSITION_DUPLICATE        Disposition = 3
+	Disposition_DISPOSITION_CANNOT_REPRODUCE Disposition = 4
+)
+
+// Enum value maps for Disposition.
+var (
+	Disposition_name = map[int32]string{
+		0: "DISPOSITION_UNSPECIFIED",
+		1: "DISPOSITION_DONE",
+		2: "DISPOSITION_WONT_DO",
+		3: "DISPOSITION_DUPLICATE",
+		4: "DISPOSITION_CANNOT_REPRODUCE",
+	}
+	Disposition_value = map[string]int32{
+		"DISPOSITION_UNSPECIFIED":      0,
+		"DISPOSITION_DONE":             1,
+		"DISPOSITION_WONT_DO":          2,
+		"DISPOSITION_DUPLICATE":        3,
+		"DISPOSITION_CANNOT_REPRODUCE": 4,
+	}
+)
+
+func (x Disposition) Enum() *Disposition {
+	p := new(Disposition)
+	*p = x
+	return p
+}
+
+func (x Disposition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Disposition) Descriptor() protoreflect.EnumDescriptor {
+	return file_beads_proto_enumTypes[2].Descriptor()
+}
+
+func (Disposition) Type() protoreflect.EnumType {
+	return &file_beads_proto_enumTypes[2]
+}
+
+func (x Disposition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Disposition.Descriptor instead.
+func (Disposition) EnumDescriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{2}
+}
+
 // Issue represents a beads issue stored as YAML in .beads/issues/
 type Issue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -152,6 +209,12 @@ type Issue struct {
 	Created       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
 	Updated       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated,proto3" json:"updated,omitempty"`
 	Metadata      *Metadata              `protobuf:"bytes,12,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Comments      []*Comment             `protobuf:"bytes,13,rep,name=comments,proto3" json:"comments,omitempty"`
+	Attachments   []*Attachment          `protobuf:"bytes,14,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Sprint        string                 `protobuf:"bytes,15,opt,name=sprint,proto3" json:"sprint,omitempty"` // Current sprint
+	FixVersions   []string               `protobuf:"bytes,16,rep,name=fix_versions,json=fixVersions,proto3" json:"fix_versions,omitempty"`
+	Components    []string               `protobuf:"bytes,17,rep,name=components,proto3" json:"components,omitempty"`
+	Disposition   Disposition            `protobuf:"varint,18,opt,name=disposition,proto3,enum=beads.Disposition" json:"disposition,omitempty"` // Why a closed issue was closed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -270,6 +333,202 @@ func (x *Issue) GetMetadata() *Metadata {
 	return nil
 }
 
+func (x *Issue) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *Issue) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Issue) GetSprint() string {
+	if x != nil {
+		return x.Sprint
+	}
+	return ""
+}
+
+func (x *Issue) GetFixVersions() []string {
+	if x != nil {
+		return x.FixVersions
+	}
+	return nil
+}
+
+func (x *Issue) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Issue) GetDisposition() Disposition {
+	if x != nil {
+		return x.Disposition
+	}
+	return Disposition_DISPOSITION_UNSPECIFIED
+}
+
+// Comment represents a comment carried over from the source issue
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        string                 `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_beads_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+// Attachment references a file attached to the source issue
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"` // Jira attachment ID, used to fetch the file on demand
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_beads_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_beads_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_beads_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Attachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 // Metadata stores additional information about the issue
 type Metadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -284,7 +543,7 @@ type Metadata struct {
 
 func (x *Metadata) Reset() {
 	*x = Metadata{}
-	mi := &file_beads_proto_msgTypes[1]
+	mi := &file_beads_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -296,7 +555,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_proto_msgTypes[1]
+	mi := &file_beads_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +568,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_beads_proto_rawDescGZIP(), []int{1}
+	return file_beads_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Metadata) GetJiraKey() string {
@@ -357,13 +616,14 @@ type Epic struct {
 	Created       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
 	Updated       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated,proto3" json:"updated,omitempty"`
 	Metadata      *Metadata              `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Disposition   Disposition            `protobuf:"varint,8,opt,name=disposition,proto3,enum=beads.Disposition" json:"disposition,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Epic) Reset() {
 	*x = Epic{}
-	mi := &file_beads_proto_msgTypes[2]
+	mi := &file_beads_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +635,7 @@ func (x *Epic) String() string {
 func (*Epic) ProtoMessage() {}
 
 func (x *Epic) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_proto_msgTypes[2]
+	mi := &file_beads_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +648,7 @@ func (x *Epic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Epic.ProtoReflect.Descriptor instead.
 func (*Epic) Descriptor() ([]byte, []int) {
-	return file_beads_proto_rawDescGZIP(), []int{2}
+	return file_beads_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Epic) GetId() string {
@@ -440,6 +700,13 @@ func (x *Epic) GetMetadata() *Metadata {
 	return nil
 }
 
+func (x *Epic) GetDisposition() Disposition {
+	if x != nil {
+		return x.Disposition
+	}
+	return Disposition_DISPOSITION_UNSPECIFIED
+}
+
 // Export represents a collection of beads issues and epics for export
 type Export struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -451,7 +718,7 @@ type Export struct {
 
 func (x *Export) Reset() {
 	*x = Export{}
-	mi := &file_beads_proto_msgTypes[3]
+	mi := &file_beads_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +730,7 @@ func (x *Export) String() string {
 func (*Export) ProtoMessage() {}
 
 func (x *Export) ProtoReflect() protoreflect.Message {
-	mi := &file_beads_proto_msgTypes[3]
+	mi := &file_beads_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +743,7 @@ func (x *Export) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Export.ProtoReflect.Descriptor instead.
 func (*Export) Descriptor() ([]byte, []int) {
-	return file_beads_proto_rawDescGZIP(), []int{3}
+	return file_beads_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Export) GetIssues() []*Issue {
@@ -497,7 +764,7 @@ var File_beads_proto protoreflect.FileDescriptor
 
 const file_beads_proto_rawDesc = "" +
 	"\n" +
-	"\vbeads.proto\x12\x05beads\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x03\n" +
+	"\vbeads.proto\x12\x05beads\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x05\n" +
 	"\x05Issue\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -512,7 +779,28 @@ const file_beads_proto_rawDesc = "" +
 	"\acreated\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
 	"\aupdated\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12+\n" +
-	"\bmetadata\x18\f \x01(\v2\x0f.beads.MetadataR\bmetadata\"\xfa\x01\n" +
+	"\bmetadata\x18\f \x01(\v2\x0f.beads.MetadataR\bmetadata\x12*\n" +
+	"\bcomments\x18\r \x03(\v2\x0e.beads.CommentR\bcomments\x123\n" +
+	"\vattachments\x18\x0e \x03(\v2\x11.beads.AttachmentR\vattachments\x12\x16\n" +
+	"\x06sprint\x18\x0f \x01(\tR\x06sprint\x12!\n" +
+	"\ffix_versions\x18\x10 \x03(\tR\vfixVersions\x12\x1e\n" +
+	"\n" +
+	"components\x18\x11 \x03(\tR\n" +
+	"components\x124\n" +
+	"\vdisposition\x18\x12 \x01(\x0e2\x12.beads.DispositionR\vdisposition\"k\n" +
+	"\aComment\x12\x16\n" +
+	"\x06author\x18\x01 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x124\n" +
+	"\acreated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\"\xc9\x01\n" +
+	"\n" +
+	"Attachment\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x124\n" +
+	"\acreated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\"\xfa\x01\n" +
 	"\bMetadata\x12\x19\n" +
 	"\bjira_key\x18\x01 \x01(\tR\ajiraKey\x12\x17\n" +
 	"\ajira_id\x18\x02 \x01(\tR\x06jiraId\x12&\n" +
@@ -521,7 +809,7 @@ const file_beads_proto_rawDesc = "" +
 	"\frepositories\x18\x05 \x03(\tR\frepositories\x1a9\n" +
 	"\vCustomEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8c\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc2\x02\n" +
 	"\x04Epic\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -529,7 +817,8 @@ const file_beads_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2\r.beads.StatusR\x06status\x124\n" +
 	"\acreated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
 	"\aupdated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12+\n" +
-	"\bmetadata\x18\a \x01(\v2\x0f.beads.MetadataR\bmetadata\"Q\n" +
+	"\bmetadata\x18\a \x01(\v2\x0f.beads.MetadataR\bmetadata\x124\n" +
+	"\vdisposition\x18\b \x01(\x0e2\x12.beads.DispositionR\vdisposition\"Q\n" +
 	"\x06Export\x12$\n" +
 	"\x06issues\x18\x01 \x03(\v2\f.beads.IssueR\x06issues\x12!\n" +
 	"\x05epics\x18\x02 \x03(\v2\v.beads.EpicR\x05epics*p\n" +
@@ -545,7 +834,13 @@ const file_beads_proto_rawDesc = "" +
 	"\vPRIORITY_P1\x10\x02\x12\x0f\n" +
 	"\vPRIORITY_P2\x10\x03\x12\x0f\n" +
 	"\vPRIORITY_P3\x10\x04\x12\x0f\n" +
-	"\vPRIORITY_P4\x10\x05B/Z-github.com/conallob/jira-beads-sync/gen/beadsb\x06proto3"
+	"\vPRIORITY_P4\x10\x05*\x96\x01\n" +
+	"\vDisposition\x12\x1b\n" +
+	"\x17DISPOSITION_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10DISPOSITION_DONE\x10\x01\x12\x17\n" +
+	"\x13DISPOSITION_WONT_DO\x10\x02\x12\x19\n" +
+	"\x15DISPOSITION_DUPLICATE\x10\x03\x12 \n" +
+	"\x1cDISPOSITION_CANNOT_REPRODUCE\x10\x04B/Z-github.com/conallob/jira-beads-sync/gen/beadsb\x06proto3"
 
 var (
 	file_beads_proto_rawDescOnce sync.Once
@@ -559,36 +854,45 @@ func file_beads_proto_rawDescGZIP() []byte {
 	return file_beads_proto_rawDescData
 }
 
-var file_beads_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_beads_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_beads_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_beads_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_beads_proto_goTypes = []any{
 	(Status)(0),                   // 0: beads.Status
 	(Priority)(0),                 // 1: beads.Priority
-	(*Issue)(nil),                 // 2: beads.Issue
-	(*Metadata)(nil),              // 3: beads.Metadata
-	(*Epic)(nil),                  // 4: beads.Epic
-	(*Export)(nil),                // 5: beads.Export
-	nil,                           // 6: beads.Metadata.CustomEntry
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(Disposition)(0),              // 2: beads.Disposition
+	(*Issue)(nil),                 // 3: beads.Issue
+	(*Comment)(nil),               // 4: beads.Comment
+	(*Attachment)(nil),            // 5: beads.Attachment
+	(*Metadata)(nil),              // 6: beads.Metadata
+	(*Epic)(nil),                  // 7: beads.Epic
+	(*Export)(nil),                // 8: beads.Export
+	nil,                           // 9: beads.Metadata.CustomEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 }
 var file_beads_proto_depIdxs = []int32{
 	0,  // 0: beads.Issue.status:type_name -> beads.Status
 	1,  // 1: beads.Issue.priority:type_name -> beads.Priority
-	7,  // 2: beads.Issue.created:type_name -> google.protobuf.Timestamp
-	7,  // 3: beads.Issue.updated:type_name -> google.protobuf.Timestamp
-	3,  // 4: beads.Issue.metadata:type_name -> beads.Metadata
-	6,  // 5: beads.Metadata.custom:type_name -> beads.Metadata.CustomEntry
-	0,  // 6: beads.Epic.status:type_name -> beads.Status
-	7,  // 7: beads.Epic.created:type_name -> google.protobuf.Timestamp
-	7,  // 8: beads.Epic.updated:type_name -> google.protobuf.Timestamp
-	3,  // 9: beads.Epic.metadata:type_name -> beads.Metadata
-	2,  // 10: beads.Export.issues:type_name -> beads.Issue
-	4,  // 11: beads.Export.epics:type_name -> beads.Epic
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	10, // 2: beads.Issue.created:type_name -> google.protobuf.Timestamp
+	10, // 3: beads.Issue.updated:type_name -> google.protobuf.Timestamp
+	6,  // 4: beads.Issue.metadata:type_name -> beads.Metadata
+	4,  // 5: beads.Issue.comments:type_name -> beads.Comment
+	5,  // 6: beads.Issue.attachments:type_name -> beads.Attachment
+	2,  // 7: beads.Issue.disposition:type_name -> beads.Disposition
+	10, // 8: beads.Comment.created:type_name -> google.protobuf.Timestamp
+	10, // 9: beads.Attachment.created:type_name -> google.protobuf.Timestamp
+	9,  // 10: beads.Metadata.custom:type_name -> beads.Metadata.CustomEntry
+	0,  // 11: beads.Epic.status:type_name -> beads.Status
+	10, // 12: beads.Epic.created:type_name -> google.protobuf.Timestamp
+	10, // 13: beads.Epic.updated:type_name -> google.protobuf.Timestamp
+	6,  // 14: beads.Epic.metadata:type_name -> beads.Metadata
+	2,  // 15: beads.Epic.disposition:type_name -> beads.Disposition
+	3,  // 16: beads.Export.issues:type_name -> beads.Issue
+	7,  // 17: beads.Export.epics:type_name -> beads.Epic
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_beads_proto_init() }
@@ -601,8 +905,8 @@ func file_beads_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_beads_proto_rawDesc), len(file_beads_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -138,23 +138,33 @@ func (x *Issue) GetFields() *Fields {
 
 // Fields contains the detailed information about a Jira issue
 type Fields struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	IssueType     *IssueType             `protobuf:"bytes,3,opt,name=issue_type,json=issueType,proto3" json:"issue_type,omitempty"`
-	Status        *Status                `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      *Priority              `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Assignee      *User                  `protobuf:"bytes,6,opt,name=assignee,proto3" json:"assignee,omitempty"`
-	Reporter      *User                  `protobuf:"bytes,7,opt,name=reporter,proto3" json:"reporter,omitempty"`
-	Created       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
-	Updated       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
-	Labels        []string               `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty"`
-	IssueLinks    []*IssueLink           `protobuf:"bytes,11,rep,name=issue_links,json=issueLinks,proto3" json:"issue_links,omitempty"`
-	Parent        *Parent                `protobuf:"bytes,12,opt,name=parent,proto3" json:"parent,omitempty"`
-	Epic          *Epic                  `protobuf:"bytes,13,opt,name=epic,proto3" json:"epic,omitempty"`
-	Subtasks      []*Subtask             `protobuf:"bytes,14,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Summary        string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	IssueType      *IssueType             `protobuf:"bytes,3,opt,name=issue_type,json=issueType,proto3" json:"issue_type,omitempty"`
+	Status         *Status                `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority       *Priority              `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee       *User                  `protobuf:"bytes,6,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Reporter       *User                  `protobuf:"bytes,7,opt,name=reporter,proto3" json:"reporter,omitempty"`
+	Created        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	Updated        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
+	Labels         []string               `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty"`
+	IssueLinks     []*IssueLink           `protobuf:"bytes,11,rep,name=issue_links,json=issueLinks,proto3" json:"issue_links,omitempty"`
+	Parent         *Parent                `protobuf:"bytes,12,opt,name=parent,proto3" json:"parent,omitempty"`
+	Epic           *Epic                  `protobuf:"bytes,13,opt,name=epic,proto3" json:"epic,omitempty"`
+	Subtasks       []*Subtask             `protobuf:"bytes,14,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	Components     []*Component           `protobuf:"bytes,15,rep,name=components,proto3" json:"components,omitempty"`
+	Resolved       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Comments       []*Comment             `protobuf:"bytes,17,rep,name=comments,proto3" json:"comments,omitempty"`
+	Attachments    []*Attachment          `protobuf:"bytes,18,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	CustomFields   map[string]string      `protobuf:"bytes,19,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // customfield_XXXXX → display value
+	Checklist      []*ChecklistItem       `protobuf:"bytes,20,rep,name=checklist,proto3" json:"checklist,omitempty"`                                                                                                     // Items from checklist plugin fields
+	DescriptionAdf string                 `protobuf:"bytes,21,opt,name=description_adf,json=descriptionAdf,proto3" json:"description_adf,omitempty"`                                                                     // Atlassian Document Format JSON (v3 API); description is empty when set
+	FixVersions    []*Version             `protobuf:"bytes,22,rep,name=fix_versions,json=fixVersions,proto3" json:"fix_versions,omitempty"`
+	Sprints        []*Sprint              `protobuf:"bytes,23,rep,name=sprints,proto3" json:"sprints,omitempty"`       // Every sprint the issue has been in, as listed by Jira
+	Resolution     *Resolution            `protobuf:"bytes,24,opt,name=resolution,proto3" json:"resolution,omitempty"` // Unset while the issue is unresolved
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Fields) Reset() {
@@ -285,6 +295,76 @@ func (x *Fields) GetSubtasks() []*Subtask {
 	return nil
 }
 
+func (x *Fields) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *Fields) GetResolved() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Resolved
+	}
+	return nil
+}
+
+func (x *Fields) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *Fields) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Fields) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Fields) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Fields) GetDescriptionAdf() string {
+	if x != nil {
+		return x.DescriptionAdf
+	}
+	return ""
+}
+
+func (x *Fields) GetFixVersions() []*Version {
+	if x != nil {
+		return x.FixVersions
+	}
+	return nil
+}
+
+func (x *Fields) GetSprints() []*Sprint {
+	if x != nil {
+		return x.Sprints
+	}
+	return nil
+}
+
+func (x *Fields) GetResolution() *Resolution {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
 // IssueType represents the type of a Jira issue
 type IssueType struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -980,6 +1060,482 @@ func (x *Epic) GetDone() bool {
 	return false
 }
 
+// Component represents a project component assigned to an issue
+type Component struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	mi := &file_jira_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Component) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Resolution records how a resolved issue was resolved, e.g. "Done" or
+// "Won't Do"
+type Resolution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resolution) Reset() {
+	*x = Resolution{}
+	mi := &file_jira_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resolution) ProtoMessage() {}
+
+func (x *Resolution) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resolution.ProtoReflect.Descriptor instead.
+func (*Resolution) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Resolution) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Resolution) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Version represents a project version (release) an issue is fixed in
+type Version struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Released      bool                   `protobuf:"varint,3,opt,name=released,proto3" json:"released,omitempty"`
+	ReleaseDate   string                 `protobuf:"bytes,4,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"` // YYYY-MM-DD
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_jira_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Version) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Version) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Version) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+func (x *Version) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+// Sprint represents an agile sprint an issue belongs to
+type Sprint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // "active", "future" or "closed"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sprint) Reset() {
+	*x = Sprint{}
+	mi := &file_jira_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sprint) ProtoMessage() {}
+
+func (x *Sprint) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sprint.ProtoReflect.Descriptor instead.
+func (*Sprint) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Sprint) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Sprint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sprint) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// Comment represents a comment on a Jira issue
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        *User                  `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated,proto3" json:"updated,omitempty"`
+	BodyAdf       string                 `protobuf:"bytes,6,opt,name=body_adf,json=bodyAdf,proto3" json:"body_adf,omitempty"` // Atlassian Document Format JSON (v3 API); body is empty when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_jira_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() *User {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Comment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Comment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Comment) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Comment) GetBodyAdf() string {
+	if x != nil {
+		return x.BodyAdf
+	}
+	return ""
+}
+
+// Attachment represents the metadata of a file attached to a Jira issue
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Author        *User                  `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	MimeType      string                 `protobuf:"bytes,6,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Content       string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"` // Download URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_jira_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetAuthor() *User {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Attachment) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Attachment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// ChecklistItem represents an item of a checklist plugin field
+type ChecklistItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Checked       bool                   `protobuf:"varint,2,opt,name=checked,proto3" json:"checked,omitempty"`
+	Header        bool                   `protobuf:"varint,3,opt,name=header,proto3" json:"header,omitempty"` // Section heading rather than a checkable item
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_jira_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistItem) ProtoMessage() {}
+
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_jira_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_jira_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChecklistItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+func (x *ChecklistItem) GetHeader() bool {
+	if x != nil {
+		return x.Header
+	}
+	return false
+}
+
 // Subtask represents a subtask reference
 type Subtask struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -993,7 +1549,7 @@ type Subtask struct {
 
 func (x *Subtask) Reset() {
 	*x = Subtask{}
-	mi := &file_jira_proto_msgTypes[14]
+	mi := &file_jira_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1005,7 +1561,7 @@ func (x *Subtask) String() string {
 func (*Subtask) ProtoMessage() {}
 
 func (x *Subtask) ProtoReflect() protoreflect.Message {
-	mi := &file_jira_proto_msgTypes[14]
+	mi := &file_jira_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,7 +1574,7 @@ func (x *Subtask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subtask.ProtoReflect.Descriptor instead.
 func (*Subtask) Descriptor() ([]byte, []int) {
-	return file_jira_proto_rawDescGZIP(), []int{14}
+	return file_jira_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Subtask) GetId() string {
@@ -1061,7 +1617,7 @@ const file_jira_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
 	"\x04self\x18\x03 \x01(\tR\x04self\x12$\n" +
-	"\x06fields\x18\x04 \x01(\v2\f.jira.FieldsR\x06fields\"\xbd\x04\n" +
+	"\x06fields\x18\x04 \x01(\v2\f.jira.FieldsR\x06fields\"\xf3\b\n" +
 	"\x06Fields\x12\x18\n" +
 	"\asummary\x18\x01 \x01(\tR\asummary\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12.\n" +
@@ -1082,7 +1638,24 @@ const file_jira_proto_rawDesc = "" +
 	"\x06parent\x18\f \x01(\v2\f.jira.ParentR\x06parent\x12\x1e\n" +
 	"\x04epic\x18\r \x01(\v2\n" +
 	".jira.EpicR\x04epic\x12)\n" +
-	"\bsubtasks\x18\x0e \x03(\v2\r.jira.SubtaskR\bsubtasks\"[\n" +
+	"\bsubtasks\x18\x0e \x03(\v2\r.jira.SubtaskR\bsubtasks\x12/\n" +
+	"\n" +
+	"components\x18\x0f \x03(\v2\x0f.jira.ComponentR\n" +
+	"components\x126\n" +
+	"\bresolved\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\bresolved\x12)\n" +
+	"\bcomments\x18\x11 \x03(\v2\r.jira.CommentR\bcomments\x122\n" +
+	"\vattachments\x18\x12 \x03(\v2\x10.jira.AttachmentR\vattachments\x12C\n" +
+	"\rcustom_fields\x18\x13 \x03(\v2\x1e.jira.Fields.CustomFieldsEntryR\fcustomFields\x121\n" +
+	"\tchecklist\x18\x14 \x03(\v2\x13.jira.ChecklistItemR\tchecklist\x12'\n" +
+	"\x0fdescription_adf\x18\x15 \x01(\tR\x0edescriptionAdf\x120\n" +
+	"\ffix_versions\x18\x16 \x03(\v2\r.jira.VersionR\vfixVersions\x12&\n" +
+	"\asprints\x18\x17 \x03(\v2\f.jira.SprintR\asprints\x120\n" +
+	"\n" +
+	"resolution\x18\x18 \x01(\v2\x10.jira.ResolutionR\n" +
+	"resolution\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"[\n" +
 	"\tIssueType\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
@@ -1131,7 +1704,45 @@ const file_jira_proto_rawDesc = "" +
 	"\x04self\x18\x03 \x01(\tR\x04self\x12\x12\n" +
 	"\x04name\x18\x04 \x01(\tR\x04name\x12\x18\n" +
 	"\asummary\x18\x05 \x01(\tR\asummary\x12\x12\n" +
-	"\x04done\x18\x06 \x01(\bR\x04done\"k\n" +
+	"\x04done\x18\x06 \x01(\bR\x04done\"/\n" +
+	"\tComponent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"0\n" +
+	"\n" +
+	"Resolution\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"l\n" +
+	"\aVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\breleased\x18\x03 \x01(\bR\breleased\x12!\n" +
+	"\frelease_date\x18\x04 \x01(\tR\vreleaseDate\"B\n" +
+	"\x06Sprint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\"\xd8\x01\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\x06author\x18\x02 \x01(\v2\n" +
+	".jira.UserR\x06author\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x124\n" +
+	"\acreated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x124\n" +
+	"\aupdated\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aupdated\x12\x19\n" +
+	"\bbody_adf\x18\x06 \x01(\tR\abodyAdf\"\xdd\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\"\n" +
+	"\x06author\x18\x03 \x01(\v2\n" +
+	".jira.UserR\x06author\x124\n" +
+	"\acreated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1b\n" +
+	"\tmime_type\x18\x06 \x01(\tR\bmimeType\x12\x18\n" +
+	"\acontent\x18\a \x01(\tR\acontent\"U\n" +
+	"\rChecklistItem\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\achecked\x18\x02 \x01(\bR\achecked\x12\x16\n" +
+	"\x06header\x18\x03 \x01(\bR\x06header\"k\n" +
 	"\aSubtask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12\x12\n" +
@@ -1150,7 +1761,7 @@ func file_jira_proto_rawDescGZIP() []byte {
 	return file_jira_proto_rawDescData
 }
 
-var file_jira_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_jira_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_jira_proto_goTypes = []any{
 	(*Export)(nil),                // 0: jira.Export
 	(*Issue)(nil),                 // 1: jira.Issue
@@ -1166,8 +1777,16 @@ var file_jira_proto_goTypes = []any{
 	(*LinkedFields)(nil),          // 11: jira.LinkedFields
 	(*Parent)(nil),                // 12: jira.Parent
 	(*Epic)(nil),                  // 13: jira.Epic
-	(*Subtask)(nil),               // 14: jira.Subtask
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*Component)(nil),             // 14: jira.Component
+	(*Resolution)(nil),            // 15: jira.Resolution
+	(*Version)(nil),               // 16: jira.Version
+	(*Sprint)(nil),                // 17: jira.Sprint
+	(*Comment)(nil),               // 18: jira.Comment
+	(*Attachment)(nil),            // 19: jira.Attachment
+	(*ChecklistItem)(nil),         // 20: jira.ChecklistItem
+	(*Subtask)(nil),               // 21: jira.Subtask
+	nil,                           // 22: jira.Fields.CustomFieldsEntry
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
 }
 var file_jira_proto_depIdxs = []int32{
 	1,  // 0: jira.Export.issues:type_name -> jira.Issue
@@ -1177,26 +1796,40 @@ var file_jira_proto_depIdxs = []int32{
 	6,  // 4: jira.Fields.priority:type_name -> jira.Priority
 	7,  // 5: jira.Fields.assignee:type_name -> jira.User
 	7,  // 6: jira.Fields.reporter:type_name -> jira.User
-	15, // 7: jira.Fields.created:type_name -> google.protobuf.Timestamp
-	15, // 8: jira.Fields.updated:type_name -> google.protobuf.Timestamp
+	23, // 7: jira.Fields.created:type_name -> google.protobuf.Timestamp
+	23, // 8: jira.Fields.updated:type_name -> google.protobuf.Timestamp
 	8,  // 9: jira.Fields.issue_links:type_name -> jira.IssueLink
 	12, // 10: jira.Fields.parent:type_name -> jira.Parent
 	13, // 11: jira.Fields.epic:type_name -> jira.Epic
-	14, // 12: jira.Fields.subtasks:type_name -> jira.Subtask
-	5,  // 13: jira.Status.status_category:type_name -> jira.StatusCategory
-	9,  // 14: jira.IssueLink.type:type_name -> jira.IssueLinkType
-	10, // 15: jira.IssueLink.inward_issue:type_name -> jira.LinkedIssue
-	10, // 16: jira.IssueLink.outward_issue:type_name -> jira.LinkedIssue
-	11, // 17: jira.LinkedIssue.fields:type_name -> jira.LinkedFields
-	4,  // 18: jira.LinkedFields.status:type_name -> jira.Status
-	3,  // 19: jira.LinkedFields.issue_type:type_name -> jira.IssueType
-	11, // 20: jira.Parent.fields:type_name -> jira.LinkedFields
-	11, // 21: jira.Subtask.fields:type_name -> jira.LinkedFields
-	22, // [22:22] is the sub-list for method output_type
-	22, // [22:22] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	21, // 12: jira.Fields.subtasks:type_name -> jira.Subtask
+	14, // 13: jira.Fields.components:type_name -> jira.Component
+	23, // 14: jira.Fields.resolved:type_name -> google.protobuf.Timestamp
+	18, // 15: jira.Fields.comments:type_name -> jira.Comment
+	19, // 16: jira.Fields.attachments:type_name -> jira.Attachment
+	22, // 17: jira.Fields.custom_fields:type_name -> jira.Fields.CustomFieldsEntry
+	20, // 18: jira.Fields.checklist:type_name -> jira.ChecklistItem
+	16, // 19: jira.Fields.fix_versions:type_name -> jira.Version
+	17, // 20: jira.Fields.sprints:type_name -> jira.Sprint
+	15, // 21: jira.Fields.resolution:type_name -> jira.Resolution
+	5,  // 22: jira.Status.status_category:type_name -> jira.StatusCategory
+	9,  // 23: jira.IssueLink.type:type_name -> jira.IssueLinkType
+	10, // 24: jira.IssueLink.inward_issue:type_name -> jira.LinkedIssue
+	10, // 25: jira.IssueLink.outward_issue:type_name -> jira.LinkedIssue
+	11, // 26: jira.LinkedIssue.fields:type_name -> jira.LinkedFields
+	4,  // 27: jira.LinkedFields.status:type_name -> jira.Status
+	3,  // 28: jira.LinkedFields.issue_type:type_name -> jira.IssueType
+	11, // 29: jira.Parent.fields:type_name -> jira.LinkedFields
+	7,  // 30: jira.Comment.author:type_name -> jira.User
+	23, // 31: jira.Comment.created:type_name -> google.protobuf.Timestamp
+	23, // 32: jira.Comment.updated:type_name -> google.protobuf.Timestamp
+	7,  // 33: jira.Attachment.author:type_name -> jira.User
+	23, // 34: jira.Attachment.created:type_name -> google.protobuf.Timestamp
+	11, // 35: jira.Subtask.fields:type_name -> jira.LinkedFields
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_jira_proto_init() }
@@ -1210,7 +1843,7 @@ func file_jira_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jira_proto_rawDesc), len(file_jira_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,236 @@
+// Package bdgraph checks that the dependency graph stored by the bd CLI
+// matches the converted beads export, catching behaviour drift between bd
+// versions (dropped or re-typed dependencies, missing epic links).
+package bdgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+// Dependency types used by bd
+const (
+	DependencyBlocks      = "blocks"
+	DependencyParentChild = "parent-child"
+)
+
+// DiscrepancyKind classifies a graph mismatch
+type DiscrepancyKind string
+
+const (
+	// MissingIssue means bd has no issue with the expected ID
+	MissingIssue DiscrepancyKind = "missing_issue"
+	// MissingDependency means a converted dependency is absent in bd
+	MissingDependency DiscrepancyKind = "missing_dependency"
+	// UnexpectedDependency means bd has a blocking dependency the export lacks
+	UnexpectedDependency DiscrepancyKind = "unexpected_dependency"
+	// MissingEpicLink means an issue is not a child of its epic in bd
+	MissingEpicLink DiscrepancyKind = "missing_epic_link"
+)
+
+// errNotFound is returned by show when bd has no issue with the ID
+var errNotFound = errors.New("not found in bd")
+
+// Discrepancy is a difference between the export and the bd database
+type Discrepancy struct {
+	Kind    DiscrepancyKind
+	IssueID string
+	Detail  string
+}
+
+// String formats a discrepancy for display
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.IssueID, d.Detail, d.Kind)
+}
+
+// Issue is the subset of `bd show --json` output the checker reads
+type Issue struct {
+	ID           string       `json:"id"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency is an edge reported by bd. Depending on the bd version the
+// target is reported as depends_on_id or as an embedded issue id.
+type Dependency struct {
+	ID          string `json:"id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+	DepType     string `json:"dependency_type"`
+}
+
+// target returns the issue this dependency points at
+func (d Dependency) target() string {
+	if d.DependsOnID != "" {
+		return d.DependsOnID
+	}
+	return d.ID
+}
+
+// kind returns the dependency type, defaulting to blocks
+func (d Dependency) kind() string {
+	switch {
+	case d.DepType != "":
+		return d.DepType
+	case d.Type != "":
+		return d.Type
+	default:
+		return DependencyBlocks
+	}
+}
+
+// Checker compares converted issues with the bd database in a directory
+type Checker struct {
+	dir   string
+	runBD func(dir string, args ...string) ([]byte, error)
+}
+
+// NewChecker creates a checker that runs bd in dir
+func NewChecker(dir string) *Checker {
+	return &Checker{
+		dir:   dir,
+		runBD: runBD,
+	}
+}
+
+// Check looks up every converted issue in bd and reports missing issues,
+// missing or unexpected blocking dependencies and missing epic links. Only
+// issues bd says it doesn't have count as missing; any other bd failure,
+// such as a locked database or output that doesn't parse, stops the check.
+func (c *Checker) Check(issues []*beads.BeadsIssue) ([]Discrepancy, error) {
+	// Fail early if bd is missing rather than reporting every issue as missing
+	if _, err := c.runBD(c.dir, "version"); err != nil {
+		return nil, fmt.Errorf("bd CLI is not available: %w", err)
+	}
+
+	var discrepancies []Discrepancy
+	for _, issue := range issues {
+		bdIssue, err := c.show(issue.ID)
+		if errors.Is(err, errNotFound) {
+			discrepancies = append(discrepancies, Discrepancy{
+				Kind:    MissingIssue,
+				IssueID: issue.ID,
+				Detail:  "not found in bd",
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s in bd: %w", issue.ID, err)
+		}
+
+		discrepancies = append(discrepancies, compare(issue, bdIssue)...)
+	}
+
+	return discrepancies, nil
+}
+
+// show runs `bd show <id> --json`, returning errNotFound if bd has no
+// such issue
+func (c *Checker) show(id string) (*Issue, error) {
+	out, err := c.runBD(c.dir, "show", id, "--json")
+	if err != nil {
+		if notFound(err) {
+			return nil, fmt.Errorf("%w: %v", errNotFound, err)
+		}
+		return nil, err
+	}
+
+	out = bytes.TrimSpace(out)
+	if bytes.HasPrefix(out, []byte("[")) {
+		var issues []Issue
+		if err := json.Unmarshal(out, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse bd output: %w", err)
+		}
+		for i := range issues {
+			if issues[i].ID == id {
+				return &issues[i], nil
+			}
+		}
+		return nil, fmt.Errorf("%w: issue %s not in bd output", errNotFound, id)
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(out, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse bd output: %w", err)
+	}
+	return &issue, nil
+}
+
+// notFound reports whether a failed bd show says the issue doesn't exist.
+// bd has no distinct exit status for it, so its message is matched.
+func notFound(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "not found") || strings.Contains(message, "no issue found")
+}
+
+// compare diffs the expected relationships of one issue against bd
+func compare(expected *beads.BeadsIssue, actual *Issue) []Discrepancy {
+	blocking := make(map[string]bool)
+	parents := make(map[string]bool)
+	for _, dep := range actual.Dependencies {
+		switch dep.kind() {
+		case DependencyBlocks:
+			blocking[dep.target()] = true
+		case DependencyParentChild:
+			parents[dep.target()] = true
+		}
+	}
+
+	var discrepancies []Discrepancy
+	wanted := make(map[string]bool, len(expected.DependsOn))
+	for _, dep := range expected.DependsOn {
+		wanted[dep] = true
+		if !blocking[dep] {
+			discrepancies = append(discrepancies, Discrepancy{
+				Kind:    MissingDependency,
+				IssueID: expected.ID,
+				Detail:  "expected to depend on " + dep,
+			})
+		}
+	}
+
+	var unexpected []string
+	for dep := range blocking {
+		if !wanted[dep] {
+			unexpected = append(unexpected, dep)
+		}
+	}
+	sort.Strings(unexpected)
+	for _, dep := range unexpected {
+		discrepancies = append(discrepancies, Discrepancy{
+			Kind:    UnexpectedDependency,
+			IssueID: expected.ID,
+			Detail:  "bd has an extra dependency on " + dep,
+		})
+	}
+
+	if expected.Epic != "" && !parents[expected.Epic] {
+		discrepancies = append(discrepancies, Discrepancy{
+			Kind:    MissingEpicLink,
+			IssueID: expected.ID,
+			Detail:  "expected to be a child of epic " + expected.Epic,
+		})
+	}
+
+	return discrepancies
+}
+
+// runBD runs a bd command in dir and returns its output
+func runBD(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("bd", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("bd %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package bdgraph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+// fakeBD returns canned `bd show --json` output per issue ID
+func fakeBD(responses map[string]string) func(dir string, args ...string) ([]byte, error) {
+	return func(dir string, args ...string) ([]byte, error) {
+		if args[0] == "version" {
+			return []byte("bd version 0.20.0"), nil
+		}
+		if out, ok := responses[args[1]]; ok {
+			return []byte(out), nil
+		}
+		return nil, fmt.Errorf("issue %s not found", args[1])
+	}
+}
+
+func TestCheckMatchingGraph(t *testing.T) {
+	checker := &Checker{runBD: fakeBD(map[string]string{
+		"proj-2": `{"id": "proj-2", "dependencies": [
+			{"depends_on_id": "proj-4", "type": "blocks"},
+			{"depends_on_id": "proj-1", "type": "parent-child"}
+		]}`,
+		"proj-4": `[{"id": "proj-4", "dependencies": []}]`,
+	})}
+
+	issues := []*beads.BeadsIssue{
+		{ID: "proj-2", Epic: "proj-1", DependsOn: []string{"proj-4"}},
+		{ID: "proj-4"},
+	}
+
+	discrepancies, err := checker.Check(issues)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(discrepancies) != 0 {
+		t.Errorf("Expected no discrepancies, got %v", discrepancies)
+	}
+}
+
+func TestCheckReportsDiscrepancies(t *testing.T) {
+	checker := &Checker{runBD: fakeBD(map[string]string{
+		"proj-2": `{"id": "proj-2", "dependencies": [
+			{"id": "proj-9", "dependency_type": "blocks"},
+			{"id": "proj-7", "dependency_type": "related"}
+		]}`,
+	})}
+
+	issues := []*beads.BeadsIssue{
+		{ID: "proj-2", Epic: "proj-1", DependsOn: []string{"proj-4"}},
+		{ID: "proj-3"},
+	}
+
+	discrepancies, err := checker.Check(issues)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	want := []DiscrepancyKind{MissingDependency, UnexpectedDependency, MissingEpicLink, MissingIssue}
+	if len(discrepancies) != len(want) {
+		t.Fatalf("Expected %d discrepancies, got %v", len(want), discrepancies)
+	}
+	for i, kind := range want {
+		if discrepancies[i].Kind != kind {
+			t.Errorf("Expected discrepancy %d to be %s, got %s", i, kind, discrepancies[i].Kind)
+		}
+	}
+	if discrepancies[3].IssueID != "proj-3" {
+		t.Errorf("Expected missing issue proj-3, got %s", discrepancies[3].IssueID)
+	}
+}
+
+func TestCheckWithoutBD(t *testing.T) {
+	checker := &Checker{runBD: func(dir string, args ...string) ([]byte, error) {
+		return nil, fmt.Errorf("executable file not found")
+	}}
+
+	if _, err := checker.Check([]*beads.BeadsIssue{{ID: "proj-1"}}); err == nil {
+		t.Error("Expected error when bd is unavailable")
+	}
+}
+
+func TestCheckFailsOnBDErrors(t *testing.T) {
+	tests := map[string]func(dir string, args ...string) ([]byte, error){
+		"database locked": func(dir string, args ...string) ([]byte, error) {
+			if args[0] == "version" {
+				return []byte("bd version 0.20.0"), nil
+			}
+			return nil, fmt.Errorf("bd show proj-1 --json failed: exit status 1: database is locked")
+		},
+		"unparseable output": fakeBD(map[string]string{"proj-1": "Error: something went wrong"}),
+	}
+
+	for name, runBD := range tests {
+		t.Run(name, func(t *testing.T) {
+			checker := &Checker{runBD: runBD}
+			discrepancies, err := checker.Check([]*beads.BeadsIssue{{ID: "proj-1"}})
+			if err == nil {
+				t.Errorf("Expected an error rather than discrepancies, got %v", discrepancies)
+			}
+		})
+	}
+}
+
+func TestDiscrepancyString(t *testing.T) {
+	d := Discrepancy{Kind: MissingDependency, IssueID: "proj-2", Detail: "expected to depend on proj-4"}
+	if got := d.String(); got != "proj-2: expected to depend on proj-4 (missing_dependency)" {
+		t.Errorf("Unexpected string: %s", got)
+	}
+}
@@ -0,0 +1,61 @@
+package beads
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindIssue returns the issue with the given beads ID or Jira key
+func FindIssue(issues []*BeadsIssue, ref string) (*BeadsIssue, error) {
+	for _, issue := range issues {
+		if issue.ID == ref || strings.EqualFold(issue.Metadata["jiraKey"], ref) {
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("issue %s not found", ref)
+}
+
+// FindAttachment returns the attachment of an issue with the given Jira
+// attachment ID or filename. A filename shared by several attachments is
+// rejected, since only the ID tells them apart.
+func (i *BeadsIssue) FindAttachment(ref string) (*BeadsAttachment, error) {
+	var matches []*BeadsAttachment
+	for n := range i.Attachments {
+		attachment := &i.Attachments[n]
+		if attachment.ID != "" && attachment.ID == ref {
+			return attachment, nil
+		}
+		if attachment.Filename == ref {
+			matches = append(matches, attachment)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if len(i.Attachments) == 0 {
+			return nil, fmt.Errorf("issue %s has no attachments", i.ID)
+		}
+		return nil, fmt.Errorf("issue %s has no attachment %q (available: %s)", i.ID, ref, i.attachmentNames())
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.ID)
+		}
+		return nil, fmt.Errorf("issue %s has %d attachments named %q; use one of the IDs: %s", i.ID, len(matches), ref, strings.Join(ids, ", "))
+	}
+}
+
+// attachmentNames lists an issue's attachments for error messages
+func (i *BeadsIssue) attachmentNames() string {
+	names := make([]string, 0, len(i.Attachments))
+	for _, attachment := range i.Attachments {
+		if attachment.ID != "" {
+			names = append(names, fmt.Sprintf("%s (%s)", attachment.Filename, attachment.ID))
+		} else {
+			names = append(names, attachment.Filename)
+		}
+	}
+	return strings.Join(names, ", ")
+}
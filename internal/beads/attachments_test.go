@@ -0,0 +1,66 @@
+package beads
+
+import (
+	"strings"
+	"testing"
+)
+
+func attachmentTestIssue() *BeadsIssue {
+	return &BeadsIssue{
+		ID:       "proj-1",
+		Metadata: map[string]string{"jiraKey": "PROJ-1"},
+		Attachments: []BeadsAttachment{
+			{ID: "100", Filename: "trace.log", URL: "https://jira.example.com/secure/attachment/100/trace.log"},
+			{ID: "101", Filename: "screenshot.png", URL: "https://jira.example.com/secure/attachment/101/screenshot.png"},
+			{ID: "102", Filename: "screenshot.png", URL: "https://jira.example.com/secure/attachment/102/screenshot.png"},
+		},
+	}
+}
+
+func TestFindIssue(t *testing.T) {
+	issues := []*BeadsIssue{{ID: "proj-2"}, attachmentTestIssue()}
+
+	for _, ref := range []string{"proj-1", "PROJ-1"} {
+		issue, err := FindIssue(issues, ref)
+		if err != nil {
+			t.Fatalf("FindIssue(%q) failed: %v", ref, err)
+		}
+		if issue.ID != "proj-1" {
+			t.Errorf("Expected proj-1 for %q, got %s", ref, issue.ID)
+		}
+	}
+
+	if _, err := FindIssue(issues, "PROJ-9"); err == nil {
+		t.Error("Expected an error for an unknown issue")
+	}
+}
+
+func TestFindAttachment(t *testing.T) {
+	issue := attachmentTestIssue()
+
+	attachment, err := issue.FindAttachment("trace.log")
+	if err != nil {
+		t.Fatalf("FindAttachment by filename failed: %v", err)
+	}
+	if attachment.ID != "100" {
+		t.Errorf("Expected attachment 100, got %s", attachment.ID)
+	}
+
+	attachment, err = issue.FindAttachment("102")
+	if err != nil {
+		t.Fatalf("FindAttachment by ID failed: %v", err)
+	}
+	if !strings.HasSuffix(attachment.URL, "/102/screenshot.png") {
+		t.Errorf("Expected attachment 102, got %s", attachment.URL)
+	}
+
+	if _, err := issue.FindAttachment("screenshot.png"); err == nil || !strings.Contains(err.Error(), "101, 102") {
+		t.Errorf("Expected an ambiguity error listing the IDs, got %v", err)
+	}
+	if _, err := issue.FindAttachment("missing.txt"); err == nil || !strings.Contains(err.Error(), "trace.log (100)") {
+		t.Errorf("Expected a not found error listing the attachments, got %v", err)
+	}
+	if _, err := (&BeadsIssue{ID: "proj-3"}).FindAttachment("trace.log"); err == nil || !strings.Contains(err.Error(), "no attachments") {
+		t.Errorf("Expected a no attachments error, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AuditFileName is the log of bulk edits made to the .beads files outside
+// of a sync, kept in the .beads directory
+const AuditFileName = "audit.jsonl"
+
+// AuditEntry records one bulk edit
+type AuditEntry struct {
+	Time      time.Time         `json:"time"`
+	Operation string            `json:"operation"`
+	User      string            `json:"user,omitempty"`
+	Renames   map[string]string `json:"renames,omitempty"` // Label renames applied
+	Issues    []string          `json:"issues,omitempty"`  // Beads IDs of the changed records
+	Pushed    []string          `json:"pushed,omitempty"`  // Jira keys updated in Jira
+	Failed    []string          `json:"failed,omitempty"`  // Jira keys that couldn't be updated
+}
+
+// AppendAudit appends an entry to the audit log under outputDir
+func AppendAudit(outputDir string, entry AuditEntry) (err error) {
+	dir := filepath.Join(outputDir, ".beads")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, AuditFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// Dependency types written for bd
+const (
+	bdBlocks      = "blocks"
+	bdParentChild = "parent-child"
+)
+
+// BDIssue is a record in the JSONL format read by `bd import` and kept in
+// sync by bd itself. Epics are issues with issue type "epic", and their
+// stories are linked with parent-child dependencies.
+type BDIssue struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description,omitempty"`
+	Status       string         `json:"status"`
+	Priority     int            `json:"priority"`
+	IssueType    string         `json:"issue_type"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Labels       []string       `json:"labels,omitempty"`
+	ExternalRef  string         `json:"external_ref,omitempty"` // Jira key
+	CreatedAt    string         `json:"created_at,omitempty"`
+	UpdatedAt    string         `json:"updated_at,omitempty"`
+	ClosedAt     string         `json:"closed_at,omitempty"`
+	Dependencies []BDDependency `json:"dependencies,omitempty"`
+	Comments     []BDComment    `json:"comments,omitempty"`
+	Sync         *bool          `json:"sync,omitempty"` // false opts the record out of syncing
+
+	raw json.RawMessage // Record as read, kept for records opted out of syncing
+}
+
+// BDDependency is an edge from IssueID to DependsOnID
+type BDDependency struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+}
+
+// BDComment is a comment on a bd issue
+type BDComment struct {
+	Author    string `json:"author,omitempty"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// BDRenderer renders a beads export to a single .beads/issues.jsonl in the
+// format bd imports, so the file can be consumed by a SQLite-backed bd
+// database directly. Fields bd has no place for, such as custom metadata,
+// attachments and the source block, are left out. Moved issues are not
+// detected, since bd records don't carry the Jira ID.
+type BDRenderer struct {
+	outputDir  string
+	durability Durability
+	runImport  bool
+	runBD      func(dir string, args ...string) error
+}
+
+// NewBDRenderer creates a renderer writing bd's JSONL format. With runImport
+// set, each write is followed by `bd import` so the bd database picks up
+// the changes immediately.
+func NewBDRenderer(outputDir string, runImport bool) *BDRenderer {
+	return &BDRenderer{
+		outputDir: outputDir,
+		runImport: runImport,
+		runBD:     runBD,
+	}
+}
+
+// RenderExport replaces the issues file with the export. Existing epics are
+// kept when the export has none, as JSONLRenderer leaves epics.jsonl alone,
+// and so are records marked "sync": false.
+func (r *BDRenderer) RenderExport(export *pb.Export) error {
+	existing, err := r.read()
+	if err != nil {
+		return err
+	}
+	records := keepOptedOut(existing, r.records(export), bdRecordID)
+	if len(export.Epics) == 0 {
+		var epics []*BDIssue
+		for _, record := range existing {
+			if record.IssueType == "epic" && !record.SyncDisabled() {
+				epics = append(epics, record)
+			}
+		}
+		records = append(epics, records...)
+	}
+	return r.write(records)
+}
+
+// MergeExport merges a partial export into the issues file: records with the
+// same ID are replaced, new ones are appended and everything else is kept.
+// Records marked "sync": false are never replaced.
+func (r *BDRenderer) MergeExport(export *pb.Export) error {
+	existing, err := r.read()
+	if err != nil {
+		return err
+	}
+	records := keepOptedOut(existing, r.records(export), bdRecordID)
+	return r.write(mergeByID(existing, records, bdRecordID, nil))
+}
+
+// bdRecordID returns the ID of a bd record
+func bdRecordID(record *BDIssue) string {
+	return record.ID
+}
+
+// RemoveJiraKeys removes the records created from the given Jira keys,
+// except those marked "sync": false, and returns how many were removed
+func (r *BDRenderer) RemoveJiraKeys(keys []string) (int, error) {
+	remove := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		remove[key] = true
+	}
+
+	existing, err := r.read()
+	if err != nil {
+		return 0, err
+	}
+	kept := existing[:0]
+	for _, record := range existing {
+		if !remove[record.ExternalRef] || record.SyncDisabled() {
+			kept = append(kept, record)
+		}
+	}
+	removed := len(existing) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, r.write(kept)
+}
+
+// PlanExport compares an export with the issues file and returns the
+// changes RenderExport (or MergeExport, with merge set) would make
+func (r *BDRenderer) PlanExport(export *pb.Export, merge bool) (*Plan, error) {
+	existing, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	identify := func(issue *BDIssue) (string, map[string]string) {
+		return issue.ID, map[string]string{"jiraKey": issue.ExternalRef}
+	}
+	split := func(records []*BDIssue) (epics, issues []*BDIssue) {
+		for _, record := range records {
+			if record.IssueType == "epic" {
+				epics = append(epics, record)
+			} else {
+				issues = append(issues, record)
+			}
+		}
+		return epics, issues
+	}
+	existingEpics, existingIssues := split(existing)
+	epics, issues := split(keepOptedOut(existing, r.records(export), bdRecordID))
+
+	plan := &Plan{}
+	plan.Changes = append(plan.Changes, planRecords("epic", existingEpics, epics, nil, merge || len(epics) == 0, identify)...)
+	plan.Changes = append(plan.Changes, planRecords("issue", existingIssues, issues, nil, merge, identify)...)
+	return plan, nil
+}
+
+// KeyChanges always returns nil, since moved issues are not detected
+func (r *BDRenderer) KeyChanges() []KeyChange {
+	return nil
+}
+
+// records converts an export to bd records, epics first
+func (r *BDRenderer) records(export *pb.Export) []*BDIssue {
+	jsonl := &JSONLRenderer{}
+	records := make([]*BDIssue, 0, len(export.Epics)+len(export.Issues))
+
+	for _, epic := range export.Epics {
+		e := jsonl.epicToJSON(epic)
+		records = append(records, &BDIssue{
+			ID:          e.ID,
+			Title:       e.Name,
+			Description: e.Description,
+			Status:      e.Status,
+			Priority:    2,
+			IssueType:   "epic",
+			ExternalRef: e.Metadata["jiraKey"],
+			CreatedAt:   e.Created,
+			UpdatedAt:   e.Updated,
+			ClosedAt:    closedAt(e.Status, e.Updated),
+		})
+	}
+
+	for _, issue := range export.Issues {
+		i := jsonl.issueToJSON(issue)
+		record := &BDIssue{
+			ID:          i.ID,
+			Title:       i.Title,
+			Description: i.Description,
+			Status:      i.Status,
+			Priority:    i.Priority,
+			IssueType:   bdIssueType(i.Metadata["jiraIssueType"]),
+			Assignee:    i.Assignee,
+			Labels:      i.Labels,
+			ExternalRef: i.Metadata["jiraKey"],
+			CreatedAt:   i.Created,
+			UpdatedAt:   i.Updated,
+			ClosedAt:    closedAt(i.Status, i.Updated),
+		}
+		if i.Epic != "" {
+			record.Dependencies = append(record.Dependencies, BDDependency{IssueID: i.ID, DependsOnID: i.Epic, Type: bdParentChild})
+		}
+		for _, dep := range i.DependsOn {
+			record.Dependencies = append(record.Dependencies, BDDependency{IssueID: i.ID, DependsOnID: dep, Type: bdBlocks})
+		}
+		for _, comment := range i.Comments {
+			record.Comments = append(record.Comments, BDComment{Author: comment.Author, Text: comment.Body, CreatedAt: comment.Created})
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// bdIssueType maps a Jira issue type to one of bd's issue types
+func bdIssueType(jiraType string) string {
+	switch strings.ToLower(jiraType) {
+	case "bug", "defect":
+		return "bug"
+	case "story", "feature", "new feature", "improvement":
+		return "feature"
+	case "epic":
+		return "epic"
+	default:
+		return "task"
+	}
+}
+
+// closedAt returns when a closed record was closed, which bd requires; the
+// last update is the closest Jira timestamp available
+func closedAt(status, updated string) string {
+	if status != "closed" {
+		return ""
+	}
+	return updated
+}
+
+func (r *BDRenderer) issuesFile() string {
+	return filepath.Join(r.outputDir, ".beads", "issues.jsonl")
+}
+
+// read returns the records in the issues file, or none if it doesn't exist
+func (r *BDRenderer) read() ([]*BDIssue, error) {
+	if _, err := os.Stat(r.issuesFile()); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var records []*BDIssue
+	err := readJSONL(r.issuesFile(), func(line []byte) error {
+		var record BDIssue
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("failed to parse bd issue: %w", err)
+		}
+		if record.SyncDisabled() {
+			record.raw = bytes.Clone(line)
+		}
+		records = append(records, &record)
+		return nil
+	})
+	return records, err
+}
+
+// ReadBD reads a bd-format .beads/issues.jsonl under outputDir back as
+// beads epics and issues, so commands reading the beads repository work
+// with either format. Records only hold the fields BDRenderer writes:
+// parent-child dependencies become the issue's epic, blocks dependencies its
+// DependsOn and the external reference its jiraKey metadata.
+func ReadBD(outputDir string) ([]*BeadsIssue, []*BeadsEpic, error) {
+	var issues []*BeadsIssue
+	var epics []*BeadsEpic
+	err := readJSONL(NewBDRenderer(outputDir, false).issuesFile(), func(line []byte) error {
+		var record BDIssue
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("failed to parse bd issue: %w", err)
+		}
+
+		var metadata map[string]string
+		if record.ExternalRef != "" {
+			metadata = map[string]string{"jiraKey": record.ExternalRef}
+		}
+		if record.IssueType == "epic" {
+			epics = append(epics, &BeadsEpic{
+				ID:          record.ID,
+				Name:        record.Title,
+				Description: record.Description,
+				Status:      record.Status,
+				Created:     record.CreatedAt,
+				Updated:     record.UpdatedAt,
+				Metadata:    metadata,
+				Sync:        record.Sync,
+			})
+			return nil
+		}
+
+		issue := &BeadsIssue{
+			ID:          record.ID,
+			Title:       record.Title,
+			Description: record.Description,
+			Status:      record.Status,
+			Priority:    record.Priority,
+			Assignee:    record.Assignee,
+			Labels:      record.Labels,
+			Created:     record.CreatedAt,
+			Updated:     record.UpdatedAt,
+			Metadata:    metadata,
+			Sync:        record.Sync,
+		}
+		for _, dep := range record.Dependencies {
+			switch dep.Type {
+			case bdParentChild:
+				issue.Epic = dep.DependsOnID
+			case bdBlocks:
+				issue.DependsOn = append(issue.DependsOn, dep.DependsOnID)
+			}
+		}
+		for _, comment := range record.Comments {
+			issue.Comments = append(issue.Comments, BeadsComment{Author: comment.Author, Body: comment.Text, Created: comment.CreatedAt})
+		}
+		issues = append(issues, issue)
+		return nil
+	})
+	return issues, epics, err
+}
+
+// write writes the issues file and, if enabled, imports it into bd
+func (r *BDRenderer) write(records []*BDIssue) error {
+	if err := os.MkdirAll(filepath.Join(r.outputDir, ".beads"), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	files := newFileBatch(r.durability)
+	if err := files.commit(writeJSONL(files, r.issuesFile(), records)); err != nil {
+		return fmt.Errorf("failed to render issues: %w", err)
+	}
+
+	if r.runImport {
+		if err := r.runBD(r.outputDir, "import", "-i", r.issuesFile()); err != nil {
+			return fmt.Errorf("failed to import issues into bd: %w", err)
+		}
+	}
+	return nil
+}
+
+// runBD runs a bd command in dir
+func runBD(dir string, args ...string) error {
+	cmd := exec.Command("bd", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("bd %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
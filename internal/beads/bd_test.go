@@ -0,0 +1,236 @@
+package beads
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func bdTestExport() *pb.Export {
+	updated := timestamppb.Now()
+	return &pb.Export{
+		Epics: []*pb.Epic{{
+			Id:       "proj-1",
+			Name:     "Login",
+			Status:   pb.Status_STATUS_OPEN,
+			Metadata: &pb.Metadata{JiraKey: "PROJ-1", JiraIssueType: "Epic"},
+		}},
+		Issues: []*pb.Issue{
+			{
+				Id:        "proj-2",
+				Title:     "Login API",
+				Status:    pb.Status_STATUS_IN_PROGRESS,
+				Priority:  pb.Priority_PRIORITY_P1,
+				Epic:      "proj-1",
+				DependsOn: []string{"proj-3"},
+				Comments:  []*pb.Comment{{Author: "alice", Body: "Started"}},
+				Metadata:  &pb.Metadata{JiraKey: "PROJ-2", JiraIssueType: "Story", Custom: map[string]string{"sprint": "4"}},
+			},
+			{
+				Id:       "proj-3",
+				Title:    "Fix session bug",
+				Status:   pb.Status_STATUS_CLOSED,
+				Priority: pb.Priority_PRIORITY_P2,
+				Updated:  updated,
+				Metadata: &pb.Metadata{JiraKey: "PROJ-3", JiraIssueType: "Bug"},
+			},
+		},
+	}
+}
+
+func readBDRecords(t *testing.T, dir string) []*BDIssue {
+	t.Helper()
+	records, err := NewBDRenderer(dir, false).read()
+	if err != nil {
+		t.Fatalf("Failed to read bd records: %v", err)
+	}
+	return records
+}
+
+func TestBDRendererRenderExport(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := NewBDRenderer(tmpDir, false).RenderExport(bdTestExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, ".beads", "epics.jsonl")); !os.IsNotExist(err) {
+		t.Error("Expected no epics.jsonl in bd format")
+	}
+
+	records := readBDRecords(t, tmpDir)
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	epic, story, bug := records[0], records[1], records[2]
+	if epic.ID != "proj-1" || epic.IssueType != "epic" || epic.Title != "Login" || epic.ExternalRef != "PROJ-1" {
+		t.Errorf("Unexpected epic record: %+v", epic)
+	}
+	if story.IssueType != "feature" || story.Status != "in_progress" || story.Priority != 1 {
+		t.Errorf("Unexpected story record: %+v", story)
+	}
+	expectedDeps := []BDDependency{
+		{IssueID: "proj-2", DependsOnID: "proj-1", Type: "parent-child"},
+		{IssueID: "proj-2", DependsOnID: "proj-3", Type: "blocks"},
+	}
+	if len(story.Dependencies) != 2 || story.Dependencies[0] != expectedDeps[0] || story.Dependencies[1] != expectedDeps[1] {
+		t.Errorf("Expected dependencies %v, got %v", expectedDeps, story.Dependencies)
+	}
+	if len(story.Comments) != 1 || story.Comments[0].Text != "Started" {
+		t.Errorf("Expected comment text 'Started', got %v", story.Comments)
+	}
+	if bug.IssueType != "bug" || bug.ClosedAt == "" || bug.ClosedAt != bug.UpdatedAt {
+		t.Errorf("Expected closed bug with closed_at, got %+v", bug)
+	}
+
+	// Records use bd's field names
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read issues.jsonl: %v", err)
+	}
+	line := strings.SplitN(string(data), "\n", 3)[1]
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	for _, name := range []string{"issue_type", "external_ref", "dependencies"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("Expected field %s in %s", name, line)
+		}
+	}
+	if _, ok := fields["metadata"]; ok {
+		t.Error("Expected no metadata field in bd format")
+	}
+}
+
+func TestBDRendererMergeAndRemove(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewBDRenderer(tmpDir, false)
+	if err := renderer.RenderExport(bdTestExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	partial := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "proj-2",
+		Title:    "Login API (edited)",
+		Metadata: &pb.Metadata{JiraKey: "PROJ-2"},
+	}}}
+	if err := renderer.MergeExport(partial); err != nil {
+		t.Fatalf("MergeExport failed: %v", err)
+	}
+	records := readBDRecords(t, tmpDir)
+	if len(records) != 3 || records[1].Title != "Login API (edited)" {
+		t.Errorf("Expected proj-2 replaced in place, got %d records", len(records))
+	}
+
+	removed, err := renderer.RemoveJiraKeys([]string{"PROJ-3", "PROJ-99"})
+	if err != nil {
+		t.Fatalf("RemoveJiraKeys failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 record removed, got %d", removed)
+	}
+
+	// A full render without epics keeps the existing ones
+	if err := renderer.RenderExport(partial); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	records = readBDRecords(t, tmpDir)
+	if len(records) != 2 || records[0].ID != "proj-1" || records[1].ID != "proj-2" {
+		t.Errorf("Expected epic kept and proj-2 rendered, got %d records", len(records))
+	}
+}
+
+func TestBDRendererPlanExport(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewBDRenderer(tmpDir, false)
+	if err := renderer.RenderExport(bdTestExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	export := bdTestExport()
+	export.Issues[0].Title = "Login API v2"
+	export.Issues = export.Issues[:1]
+
+	plan, err := renderer.PlanExport(export, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if plan.Count(ChangeUnchanged) != 1 || plan.Count(ChangeUpdated) != 1 || plan.Count(ChangeRemoved) != 1 {
+		t.Errorf("Expected 1 unchanged epic, 1 updated and 1 removed issue, got %+v", plan.Changes)
+	}
+	if change := planChange(t, plan, "proj-2"); strings.Join(change.Fields, ",") != "title" {
+		t.Errorf("Expected title changed, got %v", change.Fields)
+	}
+	if change := planChange(t, plan, "proj-3"); change.JiraKey != "PROJ-3" {
+		t.Errorf("Expected removed record to report its Jira key, got %q", change.JiraKey)
+	}
+}
+
+func TestBDRendererRunsImport(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewBDRenderer(tmpDir, true)
+
+	var calls []string
+	renderer.runBD = func(dir string, args ...string) error {
+		calls = append(calls, dir+": bd "+strings.Join(args, " "))
+		return nil
+	}
+
+	if err := renderer.RenderExport(bdTestExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	expected := tmpDir + ": bd import -i " + filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	if len(calls) != 1 || calls[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, calls)
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	for _, format := range []Format{"", FormatJSONL, FormatBD, FormatBDImport} {
+		if _, err := NewRenderer(t.TempDir(), format); err != nil {
+			t.Errorf("NewRenderer(%q) failed: %v", format, err)
+		}
+	}
+	if _, err := NewRenderer(t.TempDir(), "yaml"); err == nil {
+		t.Error("Expected error for unknown format, got nil")
+	}
+}
+
+func TestReadBD(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := NewBDRenderer(tmpDir, false).RenderExport(bdTestExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	issues, epics, err := ReadRepo(tmpDir, FormatBD)
+	if err != nil {
+		t.Fatalf("ReadRepo failed: %v", err)
+	}
+
+	if len(epics) != 1 || epics[0].ID != "proj-1" || epics[0].Name != "Login" || epics[0].Metadata["jiraKey"] != "PROJ-1" {
+		t.Errorf("Unexpected epics: %+v", epics)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	story := issues[0]
+	if story.Epic != "proj-1" || len(story.DependsOn) != 1 || story.DependsOn[0] != "proj-3" {
+		t.Errorf("Expected epic and blocker from dependencies, got epic %q, dependsOn %v", story.Epic, story.DependsOn)
+	}
+	if story.Priority != 1 || story.Status != "in_progress" || story.Metadata["jiraKey"] != "PROJ-2" {
+		t.Errorf("Unexpected story: %+v", story)
+	}
+	if len(story.Comments) != 1 || story.Comments[0].Body != "Started" {
+		t.Errorf("Expected comment body 'Started', got %v", story.Comments)
+	}
+
+	if _, _, err := ReadRepo(t.TempDir(), FormatBD); err == nil {
+		t.Error("Expected an error for a missing issues file")
+	}
+}
@@ -0,0 +1,131 @@
+package beads
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultCrossEpicThreshold is how many issues in other epics an epic
+	// may depend on before it is flagged as coordination-heavy
+	DefaultCrossEpicThreshold = 5
+
+	// DefaultCrossProjectThreshold is how many issues in other projects an
+	// epic may depend on before it is flagged as coordination-heavy
+	DefaultCrossProjectThreshold = 3
+)
+
+// CoordinationThresholds are the dependency counts above which an epic is
+// coordination-heavy. Zero uses the default; a negative threshold disables
+// the check.
+type CoordinationThresholds struct {
+	CrossEpic    int
+	CrossProject int
+}
+
+// EpicCoordination counts the issues outside an epic that its issues depend on
+type EpicCoordination struct {
+	Epic         *BeadsEpic
+	CrossEpic    []string // IDs of depended-on issues in other epics
+	CrossProject []string // IDs of depended-on issues in other projects
+	Heavy        bool     // A count exceeds its threshold
+}
+
+// AnalyzeCoordination counts, for every epic, the distinct issues in other
+// epics and in other projects that its issues depend on. Dependencies on
+// issues without an epic only count when they are in another project.
+// Epics are returned with the heaviest first, then by ID; epics without
+// outside dependencies are left out.
+func AnalyzeCoordination(issues []*BeadsIssue, epics []*BeadsEpic, thresholds CoordinationThresholds) []EpicCoordination {
+	crossEpicLimit := thresholds.CrossEpic
+	if crossEpicLimit == 0 {
+		crossEpicLimit = DefaultCrossEpicThreshold
+	}
+	crossProjectLimit := thresholds.CrossProject
+	if crossProjectLimit == 0 {
+		crossProjectLimit = DefaultCrossProjectThreshold
+	}
+
+	epicOf := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		epicOf[issue.ID] = issue.Epic
+	}
+
+	type targets struct{ crossEpic, crossProject map[string]bool }
+	byEpic := make(map[string]*targets)
+	for _, issue := range issues {
+		if issue.Epic == "" {
+			continue
+		}
+		for _, dep := range issue.DependsOn {
+			depEpic := epicOf[dep]
+			crossEpic := depEpic != "" && depEpic != issue.Epic
+			crossProject := projectOf(dep) != projectOf(issue.Epic)
+			if !crossEpic && !crossProject {
+				continue
+			}
+
+			t := byEpic[issue.Epic]
+			if t == nil {
+				t = &targets{crossEpic: make(map[string]bool), crossProject: make(map[string]bool)}
+				byEpic[issue.Epic] = t
+			}
+			if crossEpic {
+				t.crossEpic[dep] = true
+			}
+			if crossProject {
+				t.crossProject[dep] = true
+			}
+		}
+	}
+
+	epicsByID := make(map[string]*BeadsEpic, len(epics))
+	for _, epic := range epics {
+		epicsByID[epic.ID] = epic
+	}
+
+	result := make([]EpicCoordination, 0, len(byEpic))
+	for epicID, t := range byEpic {
+		epic := epicsByID[epicID]
+		if epic == nil {
+			epic = &BeadsEpic{ID: epicID}
+		}
+		coordination := EpicCoordination{
+			Epic:         epic,
+			CrossEpic:    sortedSet(t.crossEpic),
+			CrossProject: sortedSet(t.crossProject),
+		}
+		coordination.Heavy = (crossEpicLimit > 0 && len(coordination.CrossEpic) > crossEpicLimit) ||
+			(crossProjectLimit > 0 && len(coordination.CrossProject) > crossProjectLimit)
+		result = append(result, coordination)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Heavy != b.Heavy {
+			return a.Heavy
+		}
+		if total := len(a.CrossEpic) + len(a.CrossProject); total != len(b.CrossEpic)+len(b.CrossProject) {
+			return total > len(b.CrossEpic)+len(b.CrossProject)
+		}
+		return a.Epic.ID < b.Epic.ID
+	})
+	return result
+}
+
+// projectOf returns the project part of a beads ID, e.g. proj for proj-12
+func projectOf(id string) string {
+	if i := strings.LastIndex(id, "-"); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
+func sortedSet(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,50 @@
+package beads
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeCoordination(t *testing.T) {
+	epics := []*BeadsEpic{{ID: "app-1", Name: "Checkout"}, {ID: "app-2", Name: "Search"}, {ID: "app-3", Name: "Billing"}}
+	issues := []*BeadsIssue{
+		{ID: "app-10", Epic: "app-1", DependsOn: []string{"app-20", "app-21", "app-11"}},
+		{ID: "app-11", Epic: "app-1", DependsOn: []string{"app-20", "ops-5", "ops-6"}},
+		{ID: "app-12", Epic: "app-1", DependsOn: []string{"app-40"}}, // No epic, same project
+		{ID: "app-20", Epic: "app-2"},
+		{ID: "app-21", Epic: "app-2", DependsOn: []string{"app-22"}},
+		{ID: "app-22", Epic: "app-2"},
+		{ID: "app-30", Epic: "app-3", DependsOn: []string{"app-20"}},
+		{ID: "app-40"},
+	}
+
+	got := AnalyzeCoordination(issues, epics, CoordinationThresholds{CrossEpic: 1, CrossProject: -1})
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 epics with outside dependencies, got %d", len(got))
+	}
+
+	checkout := got[0]
+	if checkout.Epic.Name != "Checkout" || !checkout.Heavy {
+		t.Errorf("Expected Checkout first and coordination-heavy, got %s (heavy %v)", checkout.Epic.ID, checkout.Heavy)
+	}
+	if !slices.Equal(checkout.CrossEpic, []string{"app-20", "app-21"}) {
+		t.Errorf("Unexpected cross-epic dependencies %v", checkout.CrossEpic)
+	}
+	if !slices.Equal(checkout.CrossProject, []string{"ops-5", "ops-6"}) {
+		t.Errorf("Unexpected cross-project dependencies %v", checkout.CrossProject)
+	}
+
+	billing := got[1]
+	if billing.Epic.ID != "app-3" || billing.Heavy || len(billing.CrossEpic) != 1 {
+		t.Errorf("Expected Billing with 1 cross-epic dependency below the threshold, got %+v", billing)
+	}
+}
+
+func TestAnalyzeCoordinationDefaults(t *testing.T) {
+	issues := []*BeadsIssue{{ID: "app-10", Epic: "app-1", DependsOn: []string{"ops-1", "ops-2", "ops-3", "ops-4"}}}
+
+	got := AnalyzeCoordination(issues, nil, CoordinationThresholds{})
+	if len(got) != 1 || !got[0].Heavy || got[0].Epic.ID != "app-1" {
+		t.Errorf("Expected 4 cross-project dependencies to exceed the default threshold, got %+v", got)
+	}
+}
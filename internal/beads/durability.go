@@ -0,0 +1,172 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+)
+
+// Durability selects when rendered files are flushed to stable storage
+type Durability string
+
+const (
+	// DurabilityAlways flushes every file, and the directory entry of its
+	// rename, as it is written. Slowest, but every completed write survives
+	// a crash or power loss.
+	DurabilityAlways Durability = "always"
+	// DurabilityBatch writes a render's files to temporary files, flushes
+	// them together and then renames them into place. A crash leaves either
+	// the old or the new version of each file. This is the default.
+	DurabilityBatch Durability = "batch"
+	// DurabilityNone leaves flushing to the operating system. Fastest, but
+	// a crash may leave files empty or truncated.
+	DurabilityNone Durability = "none"
+)
+
+// Durabilities lists the supported durability modes
+var Durabilities = []Durability{DurabilityAlways, DurabilityBatch, DurabilityNone}
+
+// fileBatch holds the writes of one renderer operation. Files are replaced
+// by renaming temporary files into place, so readers never see a
+// half-written file, and flushed according to the durability. Each
+// operation uses its own batch, so concurrent operations don't commit or
+// discard each other's files.
+type fileBatch struct {
+	durability Durability
+	pending    []pendingFile // Writes staged until commit, in batch mode
+}
+
+// pendingFile is a temporary file waiting to be renamed over filename
+type pendingFile struct {
+	tmp, filename string
+}
+
+func newFileBatch(durability Durability) *fileBatch {
+	if durability == "" {
+		durability = DurabilityBatch
+	}
+	return &fileBatch{durability: durability}
+}
+
+// write replaces filename with data. In batch mode the file is only staged
+// until commit.
+func (b *fileBatch) write(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	staged := false
+	defer func() {
+		if !staged {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if b.durability == DurabilityAlways {
+		if err := tmp.Sync(); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("failed to flush %s: %w", filepath.Base(filename), err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if b.durability == DurabilityBatch {
+		b.pending = append(b.pending, pendingFile{tmp: tmp.Name(), filename: filename})
+		staged = true
+		return nil
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+	if b.durability == DurabilityAlways {
+		return syncDir(filepath.Dir(filename))
+	}
+	return nil
+}
+
+// commit finishes the operation: if err is nil the staged files are
+// flushed and renamed into place, otherwise they are discarded so a failed
+// operation leaves the old files. It returns err, or the error of committing.
+func (b *fileBatch) commit(err error) error {
+	pending := b.pending
+	b.pending = nil
+
+	if err == nil {
+		err = flushAndRename(pending)
+	}
+	if err != nil {
+		for _, file := range pending {
+			_ = os.Remove(file.tmp)
+		}
+	}
+	return err
+}
+
+// flushAndRename flushes the staged files, renames them into place and
+// flushes their directories, each once
+func flushAndRename(pending []pendingFile) error {
+	for _, file := range pending {
+		if err := syncFile(file.tmp); err != nil {
+			return fmt.Errorf("failed to flush %s: %w", filepath.Base(file.filename), err)
+		}
+	}
+
+	var dirs []string
+	for _, file := range pending {
+		if err := os.Rename(file.tmp, file.filename); err != nil {
+			return err
+		}
+		if dir := filepath.Dir(file.filename); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	var errs []error
+	for _, dir := range dirs {
+		errs = append(errs, syncDir(dir))
+	}
+	return errors.Join(errs...)
+}
+
+func syncFile(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// syncDir flushes a directory, making the renames in it durable. Windows
+// can't open directories for flushing; NTFS journals renames itself.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to flush directory %s: %w", dir, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,135 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func readFileString(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Expected no temporary files, found %s", entry.Name())
+		}
+	}
+}
+
+func TestFileBatchModes(t *testing.T) {
+	for _, durability := range Durabilities {
+		t.Run(string(durability), func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "issues.jsonl")
+			if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			files := newFileBatch(durability)
+			if err := files.write(path, []byte("new\n")); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+			if durability == DurabilityBatch && readFileString(t, path) != "old\n" {
+				t.Error("Expected a batch to keep the old file until commit")
+			}
+			if err := files.commit(nil); err != nil {
+				t.Fatalf("commit failed: %v", err)
+			}
+
+			if got := readFileString(t, path); got != "new\n" {
+				t.Errorf("Expected the new content, got %q", got)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0644 {
+				t.Errorf("Expected mode 0644, got %v", info.Mode().Perm())
+			}
+			assertNoTempFiles(t, dir)
+		})
+	}
+}
+
+func TestFileBatchDiscardsOnError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := newFileBatch(DurabilityBatch)
+	if err := files.write(path, []byte("new\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	renderErr := errors.New("render failed")
+	if err := files.commit(renderErr); !errors.Is(err, renderErr) {
+		t.Errorf("Expected the render error to be returned, got %v", err)
+	}
+
+	if got := readFileString(t, path); got != "old\n" {
+		t.Errorf("Expected a failed batch to leave the old file, got %q", got)
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestFileBatchConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			files := newFileBatch(DurabilityBatch)
+			content := strings.Repeat(fmt.Sprintf("writer %d\n", i), 100)
+			if err := files.commit(files.write(path, []byte(content))); err != nil {
+				t.Errorf("writer %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Whichever writer won, the file holds one writer's complete content
+	lines := strings.Split(strings.TrimSpace(readFileString(t, path)), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("Expected 100 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if line != lines[0] {
+			t.Fatalf("Expected the content of a single writer, got %q and %q", lines[0], line)
+		}
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestNewRendererWithOptionsRejectsUnknownDurability(t *testing.T) {
+	if _, err := NewRendererWithOptions(t.TempDir(), FormatJSONL, RendererOptions{Durability: "sometimes"}); err == nil {
+		t.Error("Expected an error for an unknown durability")
+	}
+
+	renderer, err := NewRendererWithOptions(t.TempDir(), FormatBD, RendererOptions{Durability: DurabilityAlways})
+	if err != nil {
+		t.Fatalf("NewRendererWithOptions failed: %v", err)
+	}
+	if renderer.(*BDRenderer).durability != DurabilityAlways {
+		t.Error("Expected the durability to be passed to the renderer")
+	}
+}
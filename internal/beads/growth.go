@@ -0,0 +1,51 @@
+package beads
+
+import "fmt"
+
+// Default repository growth limits, see GrowthLimits
+const (
+	DefaultMaxNewRecords = 2000
+	DefaultMaxGrowthMB   = 20
+)
+
+// Growth is how much a sync adds to a beads repository: records created
+// less records removed, and the change in the size of the beads files
+type Growth struct {
+	Records int
+	Bytes   int64
+}
+
+// Growth returns how much applying the plan would grow the repositories
+func (p *Plan) Growth() Growth {
+	var growth Growth
+	for _, change := range p.Changes {
+		switch change.Kind {
+		case ChangeCreated:
+			growth.Records++
+		case ChangeRemoved:
+			growth.Records--
+		}
+		growth.Bytes += change.Bytes
+	}
+	return growth
+}
+
+// GrowthLimits is the most a single sync may add to the beads repository
+// before it is flagged, so an accidental whole-instance sync doesn't bloat
+// the git history. A zero limit is not checked.
+type GrowthLimits struct {
+	Records int
+	Bytes   int64
+}
+
+// Exceeded describes each limit the growth exceeds, or returns nil
+func (l GrowthLimits) Exceeded(g Growth) []string {
+	var exceeded []string
+	if l.Records > 0 && g.Records > l.Records {
+		exceeded = append(exceeded, fmt.Sprintf("%d new records (limit %d)", g.Records, l.Records))
+	}
+	if l.Bytes > 0 && g.Bytes > l.Bytes {
+		exceeded = append(exceeded, fmt.Sprintf("%.1f MB (limit %.1f MB)", float64(g.Bytes)/(1<<20), float64(l.Bytes)/(1<<20)))
+	}
+	return exceeded
+}
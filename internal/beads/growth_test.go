@@ -0,0 +1,58 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+func TestPlanGrowth(t *testing.T) {
+	tmpDir := t.TempDir()
+	var issues []*pb.Issue
+	for i := 1; i <= 5; i++ {
+		issues = append(issues, planTestIssue(fmt.Sprintf("proj-%d", i), "Issue"))
+	}
+
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(&pb.Export{Issues: issues}, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if err := NewJSONLRenderer(tmpDir).RenderExport(&pb.Export{Issues: issues}); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(tmpDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := plan.Growth(); got.Records != 5 || got.Bytes != info.Size() {
+		t.Errorf("Expected 5 records and %d bytes, got %+v", info.Size(), got)
+	}
+
+	// Removing records shrinks the repository
+	plan, err = NewJSONLRenderer(tmpDir).PlanExport(&pb.Export{Issues: issues[:2]}, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if got := plan.Growth(); got.Records != -3 || got.Bytes >= 0 {
+		t.Errorf("Expected the repository to shrink by 3 records, got %+v", got)
+	}
+}
+
+func TestGrowthLimitsExceeded(t *testing.T) {
+	limits := GrowthLimits{Records: 100, Bytes: 1 << 20}
+
+	if got := limits.Exceeded(Growth{Records: 100, Bytes: 1 << 20}); got != nil {
+		t.Errorf("Expected growth at the limits to pass, got %v", got)
+	}
+	got := strings.Join(limits.Exceeded(Growth{Records: 250, Bytes: 3 << 20}), ", ")
+	if got != "250 new records (limit 100), 3.0 MB (limit 1.0 MB)" {
+		t.Errorf("Unexpected exceeded limits %q", got)
+	}
+	if got := (GrowthLimits{}).Exceeded(Growth{Records: 1e6, Bytes: 1 << 40}); got != nil {
+		t.Errorf("Expected zero limits not to be checked, got %v", got)
+	}
+}
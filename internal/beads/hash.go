@@ -0,0 +1,30 @@
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// IssueContentHash returns a hash of an issue's rendered JSONL record, so
+// callers can tell whether an issue changed without comparing files
+func IssueContentHash(issue *pb.Issue) string {
+	return contentHash((&JSONLRenderer{}).issueToJSON(issue))
+}
+
+// EpicContentHash returns a hash of an epic's rendered JSONL record
+func EpicContentHash(epic *pb.Epic) string {
+	return contentHash((&JSONLRenderer{}).epicToJSON(epic))
+}
+
+// contentHash hashes the JSON encoding of a record
+func contentHash(record interface{}) string {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ImpactedIssue is an issue transitively blocked by the analysed issue
+type ImpactedIssue struct {
+	Issue *BeadsIssue
+	Depth int // 1 for direct dependents, 2 for their dependents, and so on
+}
+
+// Impact describes everything transitively blocked by one issue
+type Impact struct {
+	Root    *BeadsIssue
+	Blocked []ImpactedIssue
+	ByEpic  map[string]int // Blocked issue counts keyed by epic ID ("" for no epic)
+}
+
+// AnalyzeImpact walks the dependency graph downward from rootID and returns
+// every issue that directly or transitively depends on it, in breadth-first
+// order
+func AnalyzeImpact(issues []*BeadsIssue, rootID string) (*Impact, error) {
+	byID := make(map[string]*BeadsIssue, len(issues))
+	dependents := make(map[string][]string)
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		for _, dep := range issue.DependsOn {
+			dependents[dep] = append(dependents[dep], issue.ID)
+		}
+	}
+
+	root, ok := byID[rootID]
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found", rootID)
+	}
+
+	impact := &Impact{
+		Root:    root,
+		Blocked: []ImpactedIssue{},
+		ByEpic:  make(map[string]int),
+	}
+
+	visited := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	depth := map[string]int{rootID: 0}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		next := dependents[current]
+		sort.Strings(next)
+		for _, id := range next {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			depth[id] = depth[current] + 1
+			queue = append(queue, id)
+
+			issue := byID[id]
+			impact.Blocked = append(impact.Blocked, ImpactedIssue{Issue: issue, Depth: depth[id]})
+			impact.ByEpic[issue.Epic]++
+		}
+	}
+
+	return impact, nil
+}
+
+// Epics returns the epic IDs with blocked issues, sorted by descending count
+// then ID, with issues without an epic last
+func (i *Impact) Epics() []string {
+	epics := make([]string, 0, len(i.ByEpic))
+	for epic := range i.ByEpic {
+		epics = append(epics, epic)
+	}
+	sort.Slice(epics, func(a, b int) bool {
+		if (epics[a] == "") != (epics[b] == "") {
+			return epics[b] == ""
+		}
+		if i.ByEpic[epics[a]] != i.ByEpic[epics[b]] {
+			return i.ByEpic[epics[a]] > i.ByEpic[epics[b]]
+		}
+		return epics[a] < epics[b]
+	})
+	return epics
+}
@@ -0,0 +1,66 @@
+package beads
+
+import (
+	"testing"
+)
+
+func TestAnalyzeImpact(t *testing.T) {
+	issues := []*BeadsIssue{
+		{ID: "proj-1", Epic: "epic-a"},
+		{ID: "proj-2", Epic: "epic-a", DependsOn: []string{"proj-1"}},
+		{ID: "proj-3", Epic: "epic-b", DependsOn: []string{"proj-1"}},
+		{ID: "proj-4", Epic: "epic-a", DependsOn: []string{"proj-2", "proj-3"}},
+		{ID: "proj-5", DependsOn: []string{"proj-4"}},
+		{ID: "proj-6", DependsOn: []string{"proj-9"}},
+	}
+
+	impact, err := AnalyzeImpact(issues, "proj-1")
+	if err != nil {
+		t.Fatalf("AnalyzeImpact failed: %v", err)
+	}
+
+	wantOrder := []string{"proj-2", "proj-3", "proj-4", "proj-5"}
+	wantDepth := []int{1, 1, 2, 3}
+	if len(impact.Blocked) != len(wantOrder) {
+		t.Fatalf("Expected %d blocked issues, got %d", len(wantOrder), len(impact.Blocked))
+	}
+	for i, blocked := range impact.Blocked {
+		if blocked.Issue.ID != wantOrder[i] {
+			t.Errorf("Expected %s at position %d, got %s", wantOrder[i], i, blocked.Issue.ID)
+		}
+		if blocked.Depth != wantDepth[i] {
+			t.Errorf("Expected depth %d for %s, got %d", wantDepth[i], blocked.Issue.ID, blocked.Depth)
+		}
+	}
+
+	if impact.ByEpic["epic-a"] != 2 || impact.ByEpic["epic-b"] != 1 || impact.ByEpic[""] != 1 {
+		t.Errorf("Unexpected per-epic counts: %v", impact.ByEpic)
+	}
+
+	epics := impact.Epics()
+	if len(epics) != 3 || epics[0] != "epic-a" || epics[1] != "epic-b" || epics[2] != "" {
+		t.Errorf("Unexpected epic order: %v", epics)
+	}
+}
+
+func TestAnalyzeImpactCycle(t *testing.T) {
+	issues := []*BeadsIssue{
+		{ID: "proj-1", DependsOn: []string{"proj-2"}},
+		{ID: "proj-2", DependsOn: []string{"proj-1"}},
+	}
+
+	impact, err := AnalyzeImpact(issues, "proj-1")
+	if err != nil {
+		t.Fatalf("AnalyzeImpact failed: %v", err)
+	}
+
+	if len(impact.Blocked) != 1 || impact.Blocked[0].Issue.ID != "proj-2" {
+		t.Errorf("Expected only proj-2 to be blocked, got %v", impact.Blocked)
+	}
+}
+
+func TestAnalyzeImpactUnknownIssue(t *testing.T) {
+	if _, err := AnalyzeImpact([]*BeadsIssue{{ID: "proj-1"}}, "proj-9"); err == nil {
+		t.Error("Expected error for unknown issue")
+	}
+}
@@ -2,6 +2,7 @@ package beads
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -14,7 +15,10 @@ import (
 
 // JSONLRenderer handles rendering protobuf beads to JSONL files
 type JSONLRenderer struct {
-	outputDir string
+	outputDir  string
+	durability Durability
+	source     *SourceOptions
+	keyChanges []KeyChange
 }
 
 // NewJSONLRenderer creates a new JSONL renderer
@@ -24,22 +28,39 @@ func NewJSONLRenderer(outputDir string) *JSONLRenderer {
 	}
 }
 
-// RenderExport renders a beads export to JSONL files
-func (r *JSONLRenderer) RenderExport(export *pb.Export) error {
+// RenderExport renders a beads export to JSONL files.
+// Issues whose Jira key changed since the previous render (matched by Jira ID)
+// keep their key history in metadata; see KeyChanges. Records marked
+// "sync": false are kept as they are.
+func (r *JSONLRenderer) RenderExport(export *pb.Export) (err error) {
+	files := newFileBatch(r.durability)
+	defer func() { err = files.commit(err) }()
+
 	if err := r.ensureDirectory(); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	if err := r.reconcileKeyChanges(export); err != nil {
+		return fmt.Errorf("failed to reconcile moved issues: %w", err)
+	}
+
+	existingIssues, existingEpics, err := r.readExisting()
+	if err != nil {
+		return err
+	}
+
 	// Render all issues to a single JSONL file
 	issuesFile := filepath.Join(r.outputDir, ".beads", "issues.jsonl")
-	if err := r.renderIssuesToJSONL(issuesFile, export.Issues); err != nil {
+	issues := keepOptedOut(existingIssues, r.issueRecords(existingIssues, export.Issues), issueRecordID)
+	if err := writeJSONL(files, issuesFile, issues); err != nil {
 		return fmt.Errorf("failed to render issues: %w", err)
 	}
 
 	// Render all epics to a single JSONL file
 	if len(export.Epics) > 0 {
 		epicsFile := filepath.Join(r.outputDir, ".beads", "epics.jsonl")
-		if err := r.renderEpicsToJSONL(epicsFile, export.Epics); err != nil {
+		epics := keepOptedOut(existingEpics, r.epicRecords(existingEpics, export.Epics), epicRecordID)
+		if err := writeJSONL(files, epicsFile, epics); err != nil {
 			return fmt.Errorf("failed to render epics: %w", err)
 		}
 	}
@@ -47,56 +68,235 @@ func (r *JSONLRenderer) RenderExport(export *pb.Export) error {
 	return nil
 }
 
-// ensureDirectory creates the necessary beads directory
-func (r *JSONLRenderer) ensureDirectory() error {
-	beadsDir := filepath.Join(r.outputDir, ".beads")
-	return os.MkdirAll(beadsDir, 0755)
-}
+// MergeExport merges a partial export (e.g. from an incremental sync) into
+// the JSONL files already on disk: records with the same ID are replaced,
+// new ones are appended and everything else is kept as is. Records of moved
+// issues are replaced under their new ID. Records marked "sync": false are
+// never replaced.
+func (r *JSONLRenderer) MergeExport(export *pb.Export) (err error) {
+	files := newFileBatch(r.durability)
+	defer func() { err = files.commit(err) }()
+
+	if err := r.ensureDirectory(); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := r.reconcileKeyChanges(export); err != nil {
+		return fmt.Errorf("failed to reconcile moved issues: %w", err)
+	}
+
+	moved := make(map[string]bool, len(r.keyChanges))
+	for _, change := range r.keyChanges {
+		moved[change.OldID] = true
+	}
 
-// renderIssuesToJSONL renders issues to a JSONL file
-func (r *JSONLRenderer) renderIssuesToJSONL(filename string, issues []*pb.Issue) (err error) {
-	file, err := os.Create(filename)
+	existingIssues, existingEpics, err := r.readExisting()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if cerr := file.Close(); cerr != nil && err == nil {
-			err = cerr
-		}
-	}()
 
-	encoder := json.NewEncoder(file)
-	for _, issue := range issues {
-		jsonIssue := r.issueToJSON(issue)
-		if err := encoder.Encode(jsonIssue); err != nil {
-			return fmt.Errorf("failed to encode issue %s: %w", issue.Id, err)
+	issuesFile := filepath.Join(r.outputDir, ".beads", "issues.jsonl")
+	issues := keepOptedOut(existingIssues, r.issueRecords(existingIssues, export.Issues), issueRecordID)
+	issues = mergeByID(existingIssues, issues, issueRecordID, moved)
+	if err := writeJSONL(files, issuesFile, issues); err != nil {
+		return fmt.Errorf("failed to render issues: %w", err)
+	}
+
+	epics := keepOptedOut(existingEpics, r.epicRecords(existingEpics, export.Epics), epicRecordID)
+	epics = mergeByID(existingEpics, epics, epicRecordID, moved)
+	if len(epics) > 0 {
+		epicsFile := filepath.Join(r.outputDir, ".beads", "epics.jsonl")
+		if err := writeJSONL(files, epicsFile, epics); err != nil {
+			return fmt.Errorf("failed to render epics: %w", err)
 		}
 	}
 
 	return nil
 }
 
-// renderEpicsToJSONL renders epics to a JSONL file
-func (r *JSONLRenderer) renderEpicsToJSONL(filename string, epics []*pb.Epic) (err error) {
-	file, err := os.Create(filename)
+// RemoveJiraKeys removes the issues and epics created from the given Jira
+// keys from the JSONL files and returns how many records were removed.
+// Dependencies on the removed issues are left in place, and so are records
+// marked "sync": false.
+func (r *JSONLRenderer) RemoveJiraKeys(keys []string) (_ int, err error) {
+	files := newFileBatch(r.durability)
+	defer func() { err = files.commit(err) }()
+
+	remove := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		remove[key] = true
+	}
+
+	removed := 0
+	keep := func(metadata map[string]string, syncDisabled bool) bool {
+		if remove[metadata["jiraKey"]] && !syncDisabled {
+			removed++
+			return false
+		}
+		return true
+	}
+
+	issuesFile := filepath.Join(r.outputDir, ".beads", "issues.jsonl")
+	if _, err := os.Stat(issuesFile); err == nil {
+		issues, err := ReadIssues(r.outputDir)
+		if err != nil {
+			return 0, err
+		}
+		kept := issues[:0]
+		for _, issue := range issues {
+			if keep(issue.Metadata, issue.SyncDisabled()) {
+				kept = append(kept, issue)
+			}
+		}
+		if err := writeJSONL(files, issuesFile, kept); err != nil {
+			return 0, fmt.Errorf("failed to render issues: %w", err)
+		}
+	}
+
+	epicsFile := filepath.Join(r.outputDir, ".beads", "epics.jsonl")
+	if _, err := os.Stat(epicsFile); err == nil {
+		epics, err := ReadEpics(r.outputDir)
+		if err != nil {
+			return 0, err
+		}
+		kept := epics[:0]
+		for _, epic := range epics {
+			if keep(epic.Metadata, epic.SyncDisabled()) {
+				kept = append(kept, epic)
+			}
+		}
+		if err := writeJSONL(files, epicsFile, kept); err != nil {
+			return 0, fmt.Errorf("failed to render epics: %w", err)
+		}
+	}
+
+	return removed, nil
+}
+
+// mergeByID replaces existing records with updated ones sharing their ID,
+// keeping the existing order, and appends the remaining updated records.
+// Existing records whose ID is in drop are removed.
+func mergeByID[T any](existing, updated []T, id func(T) string, drop map[string]bool) []T {
+	byID := make(map[string]T, len(updated))
+	for _, record := range updated {
+		byID[id(record)] = record
+	}
+
+	merged := make([]T, 0, len(existing)+len(updated))
+	placed := make(map[string]bool, len(updated))
+	for _, record := range existing {
+		recordID := id(record)
+		if replacement, ok := byID[recordID]; ok {
+			merged = append(merged, replacement)
+			placed[recordID] = true
+			continue
+		}
+		if !drop[recordID] {
+			merged = append(merged, record)
+		}
+	}
+	for _, record := range updated {
+		if !placed[id(record)] {
+			merged = append(merged, record)
+		}
+	}
+
+	return merged
+}
+
+// KeyChanges returns the issues detected as moved by the last RenderExport
+func (r *JSONLRenderer) KeyChanges() []KeyChange {
+	return r.keyChanges
+}
+
+// reconcileKeyChanges compares the export with the files already on disk
+// and records Jira key changes before they are overwritten
+func (r *JSONLRenderer) reconcileKeyChanges(export *pb.Export) error {
+	r.keyChanges = nil
+
+	issuesFile := filepath.Join(r.outputDir, ".beads", "issues.jsonl")
+	if _, err := os.Stat(issuesFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	existingIssues, err := ReadIssues(r.outputDir)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if cerr := file.Close(); cerr != nil && err == nil {
-			err = cerr
+	existingEpics, err := ReadEpics(r.outputDir)
+	if err != nil {
+		return err
+	}
+
+	r.keyChanges = DetectKeyChanges(existingIssues, existingEpics, export)
+	ApplyKeyChanges(export, r.keyChanges)
+	return nil
+}
+
+// ensureDirectory creates the necessary beads directory
+func (r *JSONLRenderer) ensureDirectory() error {
+	beadsDir := filepath.Join(r.outputDir, ".beads")
+	return os.MkdirAll(beadsDir, 0755)
+}
+
+// readExisting reads the issues and epics already on disk, if any
+func (r *JSONLRenderer) readExisting() ([]*BeadsIssue, []*BeadsEpic, error) {
+	var issues []*BeadsIssue
+	if _, err := os.Stat(filepath.Join(r.outputDir, ".beads", "issues.jsonl")); err == nil {
+		if issues, err = ReadIssues(r.outputDir); err != nil {
+			return nil, nil, err
 		}
-	}()
+	}
+	epics, err := ReadEpics(r.outputDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, epics, nil
+}
+
+// issueRecords converts issues to their JSON records, keeping the source
+// block of existing records they don't change
+func (r *JSONLRenderer) issueRecords(existing []*BeadsIssue, issues []*pb.Issue) []*BeadsIssue {
+	records := make([]*BeadsIssue, 0, len(issues))
+	for _, issue := range issues {
+		records = append(records, r.issueToJSON(issue))
+	}
+	carrySource(existing, records, issueRecordID)
+	return records
+}
 
-	encoder := json.NewEncoder(file)
+// epicRecords converts epics to their JSON records, keeping the source
+// block of existing records they don't change
+func (r *JSONLRenderer) epicRecords(existing []*BeadsEpic, epics []*pb.Epic) []*BeadsEpic {
+	records := make([]*BeadsEpic, 0, len(epics))
 	for _, epic := range epics {
-		jsonEpic := r.epicToJSON(epic)
-		if err := encoder.Encode(jsonEpic); err != nil {
-			return fmt.Errorf("failed to encode epic %s: %w", epic.Id, err)
+		records = append(records, r.epicToJSON(epic))
+	}
+	carrySource(existing, records, epicRecordID)
+	return records
+}
+
+func issueRecordID(issue *BeadsIssue) string { return issue.ID }
+
+func epicRecordID(epic *BeadsEpic) string { return epic.ID }
+
+// writeJSONL encodes records one per line and writes them as part of a
+// batch. The file is only rewritten when its content changes, so unchanged
+// syncs leave no diff or mtime churn.
+func writeJSONL[T any](files *fileBatch, filename string, records []T) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
 		}
 	}
 
-	return nil
+	if existing, err := os.ReadFile(filename); err == nil && bytes.Equal(existing, buf.Bytes()) {
+		return nil
+	}
+
+	return files.write(filename, buf.Bytes())
 }
 
 // BeadsIssue represents a beads issue in JSON format
@@ -110,9 +310,37 @@ type BeadsIssue struct {
 	Assignee    string            `json:"assignee,omitempty"`
 	Labels      []string          `json:"labels,omitempty"`
 	DependsOn   []string          `json:"dependsOn,omitempty"`
+	Sprint      string            `json:"sprint,omitempty"`
+	FixVersions []string          `json:"fixVersions,omitempty"`
+	Components  []string          `json:"components,omitempty"`
+	Disposition string            `json:"disposition,omitempty"`
 	Created     string            `json:"created,omitempty"`
 	Updated     string            `json:"updated,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Source      *Source           `json:"source,omitempty"`
+	Comments    []BeadsComment    `json:"comments,omitempty"`
+	Attachments []BeadsAttachment `json:"attachments,omitempty"`
+	Sync        *bool             `json:"sync,omitempty"` // false opts the record out of syncing
+
+	raw json.RawMessage // Record as read, kept for records opted out of syncing
+}
+
+// BeadsComment represents a comment on a beads issue in JSON format
+type BeadsComment struct {
+	Author  string `json:"author,omitempty"`
+	Body    string `json:"body"`
+	Created string `json:"created,omitempty"`
+}
+
+// BeadsAttachment represents attachment metadata on a beads issue in JSON format
+type BeadsAttachment struct {
+	ID       string `json:"id,omitempty"`
+	Filename string `json:"filename"`
+	URL      string `json:"url,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Created  string `json:"created,omitempty"`
 }
 
 // BeadsEpic represents a beads epic in JSON format
@@ -121,9 +349,14 @@ type BeadsEpic struct {
 	Name        string            `json:"name"`
 	Description string            `json:"description,omitempty"`
 	Status      string            `json:"status"`
+	Disposition string            `json:"disposition,omitempty"`
 	Created     string            `json:"created,omitempty"`
 	Updated     string            `json:"updated,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Source      *Source           `json:"source,omitempty"`
+	Sync        *bool             `json:"sync,omitempty"` // false opts the record out of syncing
+
+	raw json.RawMessage // Record as read, kept for records opted out of syncing
 }
 
 // issueToJSON converts a protobuf issue to JSON format
@@ -138,6 +371,10 @@ func (r *JSONLRenderer) issueToJSON(issue *pb.Issue) *BeadsIssue {
 		Assignee:    issue.Assignee,
 		Labels:      issue.Labels,
 		DependsOn:   issue.DependsOn,
+		Sprint:      issue.Sprint,
+		FixVersions: issue.FixVersions,
+		Components:  issue.Components,
+		Disposition: r.dispositionToString(issue.Disposition),
 	}
 
 	if issue.Created != nil {
@@ -163,6 +400,28 @@ func (r *JSONLRenderer) issueToJSON(issue *pb.Issue) *BeadsIssue {
 		}
 	}
 
+	for _, comment := range issue.Comments {
+		jsonIssue.Comments = append(jsonIssue.Comments, BeadsComment{
+			Author:  comment.Author,
+			Body:    comment.Body,
+			Created: r.timestampToString(comment.Created),
+		})
+	}
+
+	for _, attachment := range issue.Attachments {
+		jsonIssue.Attachments = append(jsonIssue.Attachments, BeadsAttachment{
+			ID:       attachment.Id,
+			Filename: attachment.Filename,
+			URL:      attachment.Url,
+			MimeType: attachment.MimeType,
+			Size:     attachment.Size,
+			Author:   attachment.Author,
+			Created:  r.timestampToString(attachment.Created),
+		})
+	}
+
+	jsonIssue.Source = r.source.block(jsonIssue.Metadata)
+	jsonIssue.normalize()
 	return jsonIssue
 }
 
@@ -173,6 +432,7 @@ func (r *JSONLRenderer) epicToJSON(epic *pb.Epic) *BeadsEpic {
 		Name:        epic.Name,
 		Description: epic.Description,
 		Status:      r.statusToString(epic.Status),
+		Disposition: r.dispositionToString(epic.Disposition),
 	}
 
 	if epic.Created != nil {
@@ -193,8 +453,13 @@ func (r *JSONLRenderer) epicToJSON(epic *pb.Epic) *BeadsEpic {
 		if epic.Metadata.JiraIssueType != "" {
 			jsonEpic.Metadata["jiraIssueType"] = epic.Metadata.JiraIssueType
 		}
+		for k, v := range epic.Metadata.Custom {
+			jsonEpic.Metadata[k] = v
+		}
 	}
 
+	jsonEpic.Source = r.source.block(jsonEpic.Metadata)
+	jsonEpic.normalize()
 	return jsonEpic
 }
 
@@ -214,6 +479,23 @@ func (r *JSONLRenderer) statusToString(status pb.Status) string {
 	}
 }
 
+// dispositionToString converts a disposition enum to its name, or "" for
+// issues that aren't closed
+func (r *JSONLRenderer) dispositionToString(disposition pb.Disposition) string {
+	switch disposition {
+	case pb.Disposition_DISPOSITION_DONE:
+		return "done"
+	case pb.Disposition_DISPOSITION_WONT_DO:
+		return "wont-do"
+	case pb.Disposition_DISPOSITION_DUPLICATE:
+		return "duplicate"
+	case pb.Disposition_DISPOSITION_CANNOT_REPRODUCE:
+		return "cannot-reproduce"
+	default:
+		return ""
+	}
+}
+
 // priorityToInt converts priority enum to integer (0-4)
 func (r *JSONLRenderer) priorityToInt(priority pb.Priority) int {
 	switch priority {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/conallob/jira-beads-sync/gen/beads"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -182,6 +183,29 @@ func TestIssueToJSON(t *testing.T) {
 	}
 }
 
+func TestEpicToJSONIncludesCustomMetadata(t *testing.T) {
+	renderer := NewJSONLRenderer("/tmp/test")
+
+	epic := &pb.Epic{
+		Id:     "proj-1",
+		Name:   "Test Epic",
+		Status: pb.Status_STATUS_OPEN,
+		Metadata: &pb.Metadata{
+			JiraKey: "PROJ-1",
+			Custom:  map[string]string{"ageDays": "12"},
+		},
+	}
+
+	jsonEpic := renderer.epicToJSON(epic)
+
+	if jsonEpic.Metadata["ageDays"] != "12" {
+		t.Errorf("Expected ageDays '12', got '%s'", jsonEpic.Metadata["ageDays"])
+	}
+	if jsonEpic.Metadata["jiraKey"] != "PROJ-1" {
+		t.Errorf("Expected jiraKey 'PROJ-1', got '%s'", jsonEpic.Metadata["jiraKey"])
+	}
+}
+
 func TestStatusConversion(t *testing.T) {
 	renderer := NewJSONLRenderer("/tmp/test")
 
@@ -205,6 +229,30 @@ func TestStatusConversion(t *testing.T) {
 	}
 }
 
+func TestDispositionConversion(t *testing.T) {
+	renderer := NewJSONLRenderer("/tmp/test")
+
+	tests := []struct {
+		disposition pb.Disposition
+		want        string
+	}{
+		{pb.Disposition_DISPOSITION_UNSPECIFIED, ""},
+		{pb.Disposition_DISPOSITION_DONE, "done"},
+		{pb.Disposition_DISPOSITION_WONT_DO, "wont-do"},
+		{pb.Disposition_DISPOSITION_DUPLICATE, "duplicate"},
+		{pb.Disposition_DISPOSITION_CANNOT_REPRODUCE, "cannot-reproduce"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.disposition.String(), func(t *testing.T) {
+			got := renderer.dispositionToString(tt.disposition)
+			if got != tt.want {
+				t.Errorf("dispositionToString(%v) = %s, want %s", tt.disposition, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPriorityConversion(t *testing.T) {
 	renderer := NewJSONLRenderer("/tmp/test")
 
@@ -279,3 +327,245 @@ func TestJSONLFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeExport(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	issue := func(id, title string) *pb.Issue {
+		return &pb.Issue{
+			Id:       id,
+			Title:    title,
+			Status:   pb.Status_STATUS_OPEN,
+			Priority: pb.Priority_PRIORITY_P2,
+			Metadata: &pb.Metadata{JiraKey: strings.ToUpper(id), JiraId: id + "-id"},
+		}
+	}
+
+	initial := &pb.Export{
+		Issues: []*pb.Issue{issue("proj-1", "First"), issue("proj-2", "Second")},
+		Epics: []*pb.Epic{{
+			Id:       "proj-10",
+			Name:     "Epic",
+			Status:   pb.Status_STATUS_OPEN,
+			Metadata: &pb.Metadata{JiraKey: "PROJ-10", JiraId: "proj-10-id"},
+		}},
+	}
+	if err := renderer.RenderExport(initial); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	// An incremental sync only returns the changed and new issues
+	partial := &pb.Export{
+		Issues: []*pb.Issue{issue("proj-2", "Second (edited)"), issue("proj-3", "Third")},
+	}
+	if err := renderer.MergeExport(partial); err != nil {
+		t.Fatalf("MergeExport failed: %v", err)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+
+	want := []string{"proj-1:First", "proj-2:Second (edited)", "proj-3:Third"}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d", len(want), len(issues))
+	}
+	for i, w := range want {
+		if got := issues[i].ID + ":" + issues[i].Title; got != w {
+			t.Errorf("Expected issue %d to be %s, got %s", i, w, got)
+		}
+	}
+
+	epics, err := ReadEpics(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadEpics failed: %v", err)
+	}
+	if len(epics) != 1 {
+		t.Errorf("Expected existing epic to be kept, got %d epics", len(epics))
+	}
+}
+
+func TestMergeExportReplacesMovedIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	original := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "proj-2",
+		Title:    "Moved",
+		Metadata: &pb.Metadata{JiraKey: "PROJ-2", JiraId: "10002"},
+	}}}
+	if err := renderer.RenderExport(original); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	moved := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "new-5",
+		Title:    "Moved",
+		Metadata: &pb.Metadata{JiraKey: "NEW-5", JiraId: "10002"},
+	}}}
+	if err := renderer.MergeExport(moved); err != nil {
+		t.Fatalf("MergeExport failed: %v", err)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "new-5" {
+		t.Errorf("Expected only new-5 after merge, got %d issue(s)", len(issues))
+	}
+}
+
+func TestRemoveJiraKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	export := &pb.Export{
+		Issues: []*pb.Issue{
+			{Id: "proj-1", Title: "First", Metadata: &pb.Metadata{JiraKey: "PROJ-1"}},
+			{Id: "proj-2", Title: "Second", Metadata: &pb.Metadata{JiraKey: "PROJ-2"}},
+		},
+		Epics: []*pb.Epic{
+			{Id: "proj-10", Name: "Epic", Metadata: &pb.Metadata{JiraKey: "PROJ-10"}},
+		},
+	}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	removed, err := renderer.RemoveJiraKeys([]string{"PROJ-2", "PROJ-10", "PROJ-404"})
+	if err != nil {
+		t.Fatalf("RemoveJiraKeys failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 records removed, got %d", removed)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "proj-1" {
+		t.Errorf("Expected only proj-1 to remain, got %d issue(s)", len(issues))
+	}
+
+	epics, err := ReadEpics(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadEpics failed: %v", err)
+	}
+	if len(epics) != 0 {
+		t.Errorf("Expected epic to be removed, got %d epic(s)", len(epics))
+	}
+}
+
+func TestRenderExportSkipsUnchangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	export := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "proj-1",
+		Title:    "Stable",
+		Metadata: &pb.Metadata{JiraKey: "PROJ-1", JiraId: "10001"},
+	}}}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	issuesFile := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(issuesFile, past, past); err != nil {
+		t.Fatalf("Chtimes failed: %v", err)
+	}
+
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	info, err := os.Stat(issuesFile)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if !info.ModTime().Equal(past) {
+		t.Error("Expected unchanged issues file not to be rewritten")
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	issue := &pb.Issue{Id: "proj-1", Title: "Title", Metadata: &pb.Metadata{JiraKey: "PROJ-1"}}
+	hash := IssueContentHash(issue)
+	if hash == "" {
+		t.Fatal("Expected non-empty hash")
+	}
+	if IssueContentHash(issue) != hash {
+		t.Error("Expected hash to be stable")
+	}
+
+	issue.Title = "Changed"
+	if IssueContentHash(issue) == hash {
+		t.Error("Expected hash to change with content")
+	}
+
+	epic := &pb.Epic{Id: "proj-10", Name: "Epic"}
+	if EpicContentHash(epic) == "" {
+		t.Error("Expected non-empty epic hash")
+	}
+}
+
+func TestRenderCommentsAndAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	created := timestamppb.New(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+	export := &pb.Export{
+		Issues: []*pb.Issue{
+			{
+				Id:     "proj-1",
+				Title:  "Discussed issue",
+				Status: pb.Status_STATUS_OPEN,
+				Comments: []*pb.Comment{
+					{Author: "jane@example.com", Body: "Looks good", Created: created},
+				},
+				Attachments: []*pb.Attachment{
+					{Filename: "trace.log", Url: "https://jira.example.com/a/1", MimeType: "text/plain", Size: 2048, Author: "John Smith", Created: created},
+				},
+			},
+			{Id: "proj-2", Title: "Quiet issue", Status: pb.Status_STATUS_OPEN},
+		},
+	}
+
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+
+	if len(issues[0].Comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(issues[0].Comments))
+	}
+	comment := issues[0].Comments[0]
+	if comment.Author != "jane@example.com" || comment.Body != "Looks good" || comment.Created != "2024-01-02T10:00:00Z" {
+		t.Errorf("Unexpected comment: %+v", comment)
+	}
+
+	if len(issues[0].Attachments) != 1 {
+		t.Fatalf("Expected 1 attachment, got %d", len(issues[0].Attachments))
+	}
+	attachment := issues[0].Attachments[0]
+	if attachment.Filename != "trace.log" || attachment.URL != "https://jira.example.com/a/1" || attachment.Size != 2048 {
+		t.Errorf("Unexpected attachment: %+v", attachment)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read issues.jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if strings.Contains(lines[1], "comments") || strings.Contains(lines[1], "attachments") {
+		t.Errorf("Expected issue without comments to omit the fields, got %s", lines[1])
+	}
+}
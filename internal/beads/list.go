@@ -0,0 +1,221 @@
+package beads
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI escape sequences used by the table view
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+	ansiCyan   = "\033[36m"
+)
+
+// maxTitleWidth truncates long titles so rows fit a typical terminal
+const maxTitleWidth = 60
+
+// ListFilter selects the issues shown by the list view. Empty fields match
+// everything.
+type ListFilter struct {
+	Statuses   []string // Issue statuses, e.g. "open" or "in_progress"
+	Epic       string   // Epic ID, or "none" for issues without an epic
+	Assignee   string
+	Label      string
+	Priorities []int // Priorities 0-4
+	HideClosed bool  // Skip closed issues unless Statuses asks for them
+}
+
+// Matches reports whether issue passes the filter
+func (f ListFilter) Matches(issue *BeadsIssue) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, issue.Status) {
+		return false
+	}
+	if f.HideClosed && len(f.Statuses) == 0 && issue.Status == "closed" {
+		return false
+	}
+	switch f.Epic {
+	case "":
+	case "none":
+		if issue.Epic != "" {
+			return false
+		}
+	default:
+		if issue.Epic != f.Epic {
+			return false
+		}
+	}
+	if f.Assignee != "" && !strings.EqualFold(issue.Assignee, f.Assignee) {
+		return false
+	}
+	if f.Label != "" && !slices.Contains(issue.Labels, f.Label) {
+		return false
+	}
+	if len(f.Priorities) > 0 && !slices.Contains(f.Priorities, issue.Priority) {
+		return false
+	}
+	return true
+}
+
+// IssueGroup is the issues of one epic in the list view
+type IssueGroup struct {
+	Epic   *BeadsEpic // nil for issues without an epic
+	Issues []*BeadsIssue
+}
+
+// GroupByEpic filters issues and groups them by epic. Groups are ordered by
+// epic ID with issues without an epic last; issues within a group are
+// sorted by priority, then ID.
+func GroupByEpic(issues []*BeadsIssue, epics []*BeadsEpic, filter ListFilter) []IssueGroup {
+	epicsByID := make(map[string]*BeadsEpic, len(epics))
+	for _, epic := range epics {
+		epicsByID[epic.ID] = epic
+	}
+
+	byEpic := make(map[string][]*BeadsIssue)
+	for _, issue := range issues {
+		if filter.Matches(issue) {
+			byEpic[issue.Epic] = append(byEpic[issue.Epic], issue)
+		}
+	}
+
+	epicIDs := make([]string, 0, len(byEpic))
+	for id := range byEpic {
+		epicIDs = append(epicIDs, id)
+	}
+	sort.Slice(epicIDs, func(a, b int) bool {
+		if (epicIDs[a] == "") != (epicIDs[b] == "") {
+			return epicIDs[b] == ""
+		}
+		return epicIDs[a] < epicIDs[b]
+	})
+
+	groups := make([]IssueGroup, 0, len(epicIDs))
+	for _, id := range epicIDs {
+		group := byEpic[id]
+		sort.SliceStable(group, func(a, b int) bool {
+			if group[a].Priority != group[b].Priority {
+				return group[a].Priority < group[b].Priority
+			}
+			return group[a].ID < group[b].ID
+		})
+
+		epic := epicsByID[id]
+		if epic == nil && id != "" {
+			// Issues can reference an epic that wasn't exported
+			epic = &BeadsEpic{ID: id}
+		}
+		groups = append(groups, IssueGroup{Epic: epic, Issues: group})
+	}
+	return groups
+}
+
+// RenderTable writes groups as a table, one section per epic. With color
+// set, priorities and statuses are colour-coded with ANSI escapes.
+func RenderTable(w io.Writer, groups []IssueGroup, color bool) error {
+	paint := func(code, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + ansiReset
+	}
+
+	idWidth, statusWidth := len("ID"), len("STATUS")
+	for _, group := range groups {
+		for _, issue := range group.Issues {
+			idWidth = max(idWidth, len(issue.ID))
+			statusWidth = max(statusWidth, len(issue.Status))
+		}
+	}
+
+	var b strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(paint(ansiBold, groupHeading(group)))
+		b.WriteString("\n")
+		b.WriteString(paint(ansiDim, fmt.Sprintf("  PRI  %-*s  %-*s  TITLE", idWidth, "ID", statusWidth, "STATUS")))
+		b.WriteString("\n")
+
+		for _, issue := range group.Issues {
+			// Pad before painting so escapes don't skew the column widths
+			fmt.Fprintf(&b, "  %s  %-*s  %s  %s%s\n",
+				paint(priorityColor(issue.Priority), fmt.Sprintf("P%-2d", issue.Priority)),
+				idWidth, issue.ID,
+				paint(statusColor(issue.Status), fmt.Sprintf("%-*s", statusWidth, issue.Status)),
+				truncateTitle(issue.Title),
+				paint(ansiDim, assigneeSuffix(issue.Assignee)))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// groupHeading names the epic a group belongs to
+func groupHeading(group IssueGroup) string {
+	count := fmt.Sprintf("(%d)", len(group.Issues))
+	switch {
+	case group.Epic == nil:
+		return "No epic " + count
+	case group.Epic.Name == "":
+		return group.Epic.ID + " " + count
+	default:
+		return fmt.Sprintf("%s: %s %s", group.Epic.ID, group.Epic.Name, count)
+	}
+}
+
+// priorityColor highlights urgent priorities
+func priorityColor(priority int) string {
+	switch priority {
+	case 0:
+		return ansiRed + ansiBold
+	case 1:
+		return ansiRed
+	case 2:
+		return ansiYellow
+	case 3:
+		return ansiBlue
+	default:
+		return ansiDim
+	}
+}
+
+// statusColor highlights work in flight, blocked and done
+func statusColor(status string) string {
+	switch status {
+	case "in_progress":
+		return ansiCyan
+	case "blocked":
+		return ansiRed
+	case "closed":
+		return ansiGreen
+	default:
+		return ""
+	}
+}
+
+func truncateTitle(title string) string {
+	if utf8.RuneCountInString(title) <= maxTitleWidth {
+		return title
+	}
+	runes := []rune(title)
+	return string(runes[:maxTitleWidth-1]) + "…"
+}
+
+func assigneeSuffix(assignee string) string {
+	if assignee == "" {
+		return ""
+	}
+	return " @" + assignee
+}
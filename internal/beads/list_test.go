@@ -0,0 +1,122 @@
+package beads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func listTestIssues() []*BeadsIssue {
+	return []*BeadsIssue{
+		{ID: "proj-2", Title: "Login API", Status: "in_progress", Priority: 1, Epic: "proj-1", Assignee: "alice"},
+		{ID: "proj-3", Title: "Docs", Status: "open", Priority: 3, Epic: "proj-1", Labels: []string{"docs"}},
+		{ID: "proj-4", Title: "Schema", Status: "blocked", Priority: 0, Epic: "proj-1"},
+		{ID: "proj-5", Title: "Old", Status: "closed", Priority: 2},
+		{ID: "proj-6", Title: "Stray", Status: "open", Priority: 2},
+		{ID: "proj-7", Title: "Orphan", Status: "open", Priority: 2, Epic: "proj-9"},
+	}
+}
+
+func groupIDs(groups []IssueGroup) []string {
+	var ids []string
+	for _, group := range groups {
+		for _, issue := range group.Issues {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
+
+func TestGroupByEpic(t *testing.T) {
+	epics := []*BeadsEpic{{ID: "proj-1", Name: "Auth"}}
+	groups := GroupByEpic(listTestIssues(), epics, ListFilter{})
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+	if groups[0].Epic.Name != "Auth" {
+		t.Errorf("Expected first group to be the Auth epic, got %+v", groups[0].Epic)
+	}
+	if groups[1].Epic == nil || groups[1].Epic.ID != "proj-9" {
+		t.Errorf("Expected unexported epic to get its own group, got %+v", groups[1].Epic)
+	}
+	if groups[2].Epic != nil {
+		t.Errorf("Expected issues without an epic last, got %+v", groups[2].Epic)
+	}
+
+	want := "proj-4,proj-2,proj-3,proj-7,proj-5,proj-6"
+	if got := strings.Join(groupIDs(groups), ","); got != want {
+		t.Errorf("Expected order %s, got %s", want, got)
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   string
+	}{
+		{"hide closed", ListFilter{HideClosed: true}, "proj-4,proj-2,proj-3,proj-7,proj-6"},
+		{"explicit status overrides hide closed", ListFilter{Statuses: []string{"closed"}, HideClosed: true}, "proj-5"},
+		{"epic", ListFilter{Epic: "proj-1"}, "proj-4,proj-2,proj-3"},
+		{"no epic", ListFilter{Epic: "none"}, "proj-5,proj-6"},
+		{"assignee is case-insensitive", ListFilter{Assignee: "Alice"}, "proj-2"},
+		{"label", ListFilter{Label: "docs"}, "proj-3"},
+		{"priorities", ListFilter{Priorities: []int{0, 1}}, "proj-4,proj-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupByEpic(listTestIssues(), nil, tt.filter)
+			if got := strings.Join(groupIDs(groups), ","); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	epics := []*BeadsEpic{{ID: "proj-1", Name: "Auth"}}
+	groups := GroupByEpic(listTestIssues(), epics, ListFilter{Epic: "proj-1"})
+
+	var plain bytes.Buffer
+	if err := RenderTable(&plain, groups, false); err != nil {
+		t.Fatalf("RenderTable failed: %v", err)
+	}
+	output := plain.String()
+	if strings.Contains(output, "\033[") {
+		t.Error("Expected no ANSI escapes without color")
+	}
+	for _, want := range []string{
+		"proj-1: Auth (3)",
+		"  PRI  ID      STATUS       TITLE",
+		"  P0   proj-4  blocked      Schema",
+		"  P1   proj-2  in_progress  Login API @alice",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	var colored bytes.Buffer
+	if err := RenderTable(&colored, groups, true); err != nil {
+		t.Fatalf("RenderTable failed: %v", err)
+	}
+	if !strings.Contains(colored.String(), ansiRed+ansiBold+"P0 "+ansiReset) {
+		t.Errorf("Expected P0 to be highlighted, got:\n%q", colored.String())
+	}
+}
+
+func TestTruncateTitle(t *testing.T) {
+	long := strings.Repeat("é", maxTitleWidth+5)
+	got := truncateTitle(long)
+	if n := len([]rune(got)); n != maxTitleWidth {
+		t.Errorf("Expected %d runes, got %d", maxTitleWidth, n)
+	}
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("Expected ellipsis, got %q", got)
+	}
+	if truncateTitle("short") != "short" {
+		t.Error("Expected short titles to be unchanged")
+	}
+}
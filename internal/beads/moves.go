@@ -0,0 +1,130 @@
+package beads
+
+import (
+	"strings"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// previousKeysField is the metadata key holding earlier Jira keys of a moved issue
+const previousKeysField = "previousJiraKeys"
+
+// KeyChange records a Jira issue whose key changed (e.g. after moving
+// projects) while its numeric Jira ID stayed the same
+type KeyChange struct {
+	JiraID string
+	OldKey string
+	NewKey string
+	OldID  string // Previous beads ID
+	NewID  string // Current beads ID
+}
+
+// DetectKeyChanges compares an export against previously rendered issues
+// and epics, matching them by Jira ID. Earlier keys are recorded in the
+// previousJiraKeys metadata of the new records (carrying forward any history
+// already on disk) and the changes are returned.
+func DetectKeyChanges(existingIssues []*BeadsIssue, existingEpics []*BeadsEpic, export *pb.Export) []KeyChange {
+	type known struct {
+		id       string
+		metadata map[string]string
+	}
+
+	byJiraID := make(map[string]known)
+	for _, issue := range existingIssues {
+		if jiraID := issue.Metadata["jiraId"]; jiraID != "" {
+			byJiraID[jiraID] = known{id: issue.ID, metadata: issue.Metadata}
+		}
+	}
+	for _, epic := range existingEpics {
+		if jiraID := epic.Metadata["jiraId"]; jiraID != "" {
+			byJiraID[jiraID] = known{id: epic.ID, metadata: epic.Metadata}
+		}
+	}
+
+	var changes []KeyChange
+	reconcile := func(id string, metadata *pb.Metadata) {
+		if metadata == nil || metadata.JiraId == "" {
+			return
+		}
+		previous, ok := byJiraID[metadata.JiraId]
+		if !ok {
+			return
+		}
+
+		history := splitKeys(previous.metadata[previousKeysField])
+		oldKey := previous.metadata["jiraKey"]
+		if oldKey != "" && oldKey != metadata.JiraKey {
+			history = appendUnique(history, oldKey)
+			changes = append(changes, KeyChange{
+				JiraID: metadata.JiraId,
+				OldKey: oldKey,
+				NewKey: metadata.JiraKey,
+				OldID:  previous.id,
+				NewID:  id,
+			})
+		}
+
+		if len(history) > 0 {
+			if metadata.Custom == nil {
+				metadata.Custom = make(map[string]string)
+			}
+			metadata.Custom[previousKeysField] = strings.Join(history, ",")
+		}
+	}
+
+	for _, epic := range export.Epics {
+		reconcile(epic.Id, epic.Metadata)
+	}
+	for _, issue := range export.Issues {
+		reconcile(issue.Id, issue.Metadata)
+	}
+
+	return changes
+}
+
+// ApplyKeyChanges rewrites epic and dependency references to old beads IDs
+// so they point at the renamed issues
+func ApplyKeyChanges(export *pb.Export, changes []KeyChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	renamed := make(map[string]string, len(changes))
+	for _, change := range changes {
+		if change.OldID != change.NewID {
+			renamed[change.OldID] = change.NewID
+		}
+	}
+
+	for _, issue := range export.Issues {
+		if newID, ok := renamed[issue.Epic]; ok {
+			issue.Epic = newID
+		}
+		for i, dep := range issue.DependsOn {
+			if newID, ok := renamed[dep]; ok {
+				issue.DependsOn[i] = newID
+			}
+		}
+	}
+}
+
+// splitKeys splits a comma-separated key list, ignoring empty entries
+func splitKeys(value string) []string {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// appendUnique appends value to slice unless it is already present
+func appendUnique(slice []string, value string) []string {
+	for _, item := range slice {
+		if item == value {
+			return slice
+		}
+	}
+	return append(slice, value)
+}
@@ -0,0 +1,161 @@
+package beads
+
+import (
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func movedIssueExport() *pb.Export {
+	return &pb.Export{
+		Issues: []*pb.Issue{
+			{
+				Id:       "new-5",
+				Title:    "Moved issue",
+				Status:   pb.Status_STATUS_OPEN,
+				Priority: pb.Priority_PRIORITY_P2,
+				Epic:     "proj-1",
+				Created:  timestamppb.Now(),
+				Updated:  timestamppb.Now(),
+				Metadata: &pb.Metadata{JiraKey: "NEW-5", JiraId: "10002"},
+			},
+			{
+				Id:        "proj-3",
+				Title:     "Dependent issue",
+				Status:    pb.Status_STATUS_OPEN,
+				Priority:  pb.Priority_PRIORITY_P2,
+				DependsOn: []string{"proj-2"},
+				Created:   timestamppb.Now(),
+				Updated:   timestamppb.Now(),
+				Metadata:  &pb.Metadata{JiraKey: "PROJ-3", JiraId: "10003"},
+			},
+		},
+	}
+}
+
+func TestDetectKeyChanges(t *testing.T) {
+	existing := []*BeadsIssue{
+		{ID: "proj-2", Metadata: map[string]string{"jiraKey": "PROJ-2", "jiraId": "10002"}},
+		{ID: "proj-3", Metadata: map[string]string{"jiraKey": "PROJ-3", "jiraId": "10003"}},
+	}
+	export := movedIssueExport()
+
+	changes := DetectKeyChanges(existing, nil, export)
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 key change, got %d", len(changes))
+	}
+
+	change := changes[0]
+	if change.OldKey != "PROJ-2" || change.NewKey != "NEW-5" {
+		t.Errorf("Expected PROJ-2 → NEW-5, got %s → %s", change.OldKey, change.NewKey)
+	}
+	if change.OldID != "proj-2" || change.NewID != "new-5" {
+		t.Errorf("Expected proj-2 → new-5, got %s → %s", change.OldID, change.NewID)
+	}
+	if got := export.Issues[0].Metadata.Custom["previousJiraKeys"]; got != "PROJ-2" {
+		t.Errorf("Expected previousJiraKeys 'PROJ-2', got %q", got)
+	}
+	if export.Issues[1].Metadata.Custom != nil {
+		t.Errorf("Expected unmoved issue to have no custom metadata, got %v", export.Issues[1].Metadata.Custom)
+	}
+}
+
+func TestDetectKeyChangesCarriesHistory(t *testing.T) {
+	existing := []*BeadsIssue{
+		{ID: "mid-9", Metadata: map[string]string{
+			"jiraKey":          "MID-9",
+			"jiraId":           "10002",
+			"previousJiraKeys": "PROJ-2",
+		}},
+	}
+	export := movedIssueExport()
+
+	DetectKeyChanges(existing, nil, export)
+
+	if got := export.Issues[0].Metadata.Custom["previousJiraKeys"]; got != "PROJ-2,MID-9" {
+		t.Errorf("Expected previousJiraKeys 'PROJ-2,MID-9', got %q", got)
+	}
+
+	// A later sync with an unchanged key must keep the history
+	rendered := []*BeadsIssue{
+		{ID: "new-5", Metadata: map[string]string{
+			"jiraKey":          "NEW-5",
+			"jiraId":           "10002",
+			"previousJiraKeys": "PROJ-2,MID-9",
+		}},
+	}
+	next := movedIssueExport()
+	if changes := DetectKeyChanges(rendered, nil, next); len(changes) != 0 {
+		t.Errorf("Expected no key changes, got %d", len(changes))
+	}
+	if got := next.Issues[0].Metadata.Custom["previousJiraKeys"]; got != "PROJ-2,MID-9" {
+		t.Errorf("Expected previousJiraKeys to be kept, got %q", got)
+	}
+}
+
+func TestApplyKeyChanges(t *testing.T) {
+	export := movedIssueExport()
+	changes := []KeyChange{
+		{OldID: "proj-2", NewID: "new-5"},
+		{OldID: "proj-1", NewID: "new-1"},
+	}
+
+	ApplyKeyChanges(export, changes)
+
+	if export.Issues[0].Epic != "new-1" {
+		t.Errorf("Expected epic reference new-1, got %s", export.Issues[0].Epic)
+	}
+	if export.Issues[1].DependsOn[0] != "new-5" {
+		t.Errorf("Expected dependency new-5, got %s", export.Issues[1].DependsOn[0])
+	}
+}
+
+func TestRenderExportDetectsMovedIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	first := &pb.Export{
+		Issues: []*pb.Issue{
+			{
+				Id:       "proj-2",
+				Title:    "Moved issue",
+				Status:   pb.Status_STATUS_OPEN,
+				Priority: pb.Priority_PRIORITY_P2,
+				Created:  timestamppb.Now(),
+				Updated:  timestamppb.Now(),
+				Metadata: &pb.Metadata{JiraKey: "PROJ-2", JiraId: "10002"},
+			},
+		},
+	}
+	if err := renderer.RenderExport(first); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	if len(renderer.KeyChanges()) != 0 {
+		t.Errorf("Expected no key changes on first render, got %d", len(renderer.KeyChanges()))
+	}
+
+	if err := renderer.RenderExport(movedIssueExport()); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	if len(renderer.KeyChanges()) != 1 {
+		t.Fatalf("Expected 1 key change, got %d", len(renderer.KeyChanges()))
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].ID != "new-5" {
+		t.Errorf("Expected renamed issue new-5, got %s", issues[0].ID)
+	}
+	if issues[0].Metadata["previousJiraKeys"] != "PROJ-2" {
+		t.Errorf("Expected previousJiraKeys 'PROJ-2', got %q", issues[0].Metadata["previousJiraKeys"])
+	}
+	if issues[1].DependsOn[0] != "new-5" {
+		t.Errorf("Expected dependency rewritten to new-5, got %s", issues[1].DependsOn[0])
+	}
+}
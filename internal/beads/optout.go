@@ -0,0 +1,82 @@
+package beads
+
+import "encoding/json"
+
+// SyncDisabled reports whether the issue is marked "sync": false. Syncs
+// never overwrite or remove such records, so they can hold local notes or
+// diverge from Jira on purpose.
+func (i *BeadsIssue) SyncDisabled() bool {
+	return i.Sync != nil && !*i.Sync
+}
+
+// SyncDisabled reports whether the epic is marked "sync": false
+func (e *BeadsEpic) SyncDisabled() bool {
+	return e.Sync != nil && !*e.Sync
+}
+
+// SyncDisabled reports whether the bd record is marked "sync": false
+func (i *BDIssue) SyncDisabled() bool {
+	return i.Sync != nil && !*i.Sync
+}
+
+// MarshalJSON writes records opted out of syncing exactly as they were
+// read, so fields this tool doesn't know about survive a sync
+func (i *BeadsIssue) MarshalJSON() ([]byte, error) {
+	if i.raw != nil {
+		return i.raw, nil
+	}
+	type plain BeadsIssue
+	return json.Marshal((*plain)(i))
+}
+
+// MarshalJSON writes records opted out of syncing exactly as they were read
+func (e *BeadsEpic) MarshalJSON() ([]byte, error) {
+	if e.raw != nil {
+		return e.raw, nil
+	}
+	type plain BeadsEpic
+	return json.Marshal((*plain)(e))
+}
+
+// keepOptedOut returns the rendered records with the existing records
+// marked "sync": false put back: they replace rendered records with the
+// same ID, and are appended when the export doesn't include them.
+func keepOptedOut[T interface{ SyncDisabled() bool }](existing, rendered []T, id func(T) string) []T {
+	optedOut := make(map[string]T)
+	var order []string
+	for _, record := range existing {
+		if record.SyncDisabled() {
+			optedOut[id(record)] = record
+			order = append(order, id(record))
+		}
+	}
+	if len(optedOut) == 0 {
+		return rendered
+	}
+
+	kept := make([]T, 0, len(rendered)+len(order))
+	placed := make(map[string]bool, len(order))
+	for _, record := range rendered {
+		if local, ok := optedOut[id(record)]; ok {
+			kept = append(kept, local)
+			placed[id(record)] = true
+			continue
+		}
+		kept = append(kept, record)
+	}
+	for _, recordID := range order {
+		if !placed[recordID] {
+			kept = append(kept, optedOut[recordID])
+		}
+	}
+	return kept
+}
+
+// MarshalJSON writes records opted out of syncing exactly as they were read
+func (i *BDIssue) MarshalJSON() ([]byte, error) {
+	if i.raw != nil {
+		return i.raw, nil
+	}
+	type plain BDIssue
+	return json.Marshal((*plain)(i))
+}
@@ -0,0 +1,181 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// optedOutRecord is an issue the user took over locally, with a field this
+// tool doesn't know about
+const optedOutRecord = `{"id":"proj-1","title":"Local title","status":"open","priority":1,"sync":false,"notes":"kept by hand","metadata":{"jiraKey":"PROJ-1"}}`
+
+func writeOptOutIssues(t *testing.T, dir string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func optOutExport(ids ...string) *pb.Export {
+	export := &pb.Export{}
+	for _, id := range ids {
+		export.Issues = append(export.Issues, &pb.Issue{
+			Id:       id,
+			Title:    "From Jira " + id,
+			Status:   pb.Status_STATUS_IN_PROGRESS,
+			Priority: pb.Priority_PRIORITY_P2,
+			Created:  timestamppb.Now(),
+			Updated:  timestamppb.Now(),
+			Metadata: &pb.Metadata{JiraKey: strings.ToUpper(id)},
+		})
+	}
+	return export
+}
+
+func TestRenderExportKeepsOptedOutRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		export *pb.Export
+		merge  bool
+	}{
+		{name: "render with the same ID", export: optOutExport("proj-1", "proj-2")},
+		{name: "render without the ID", export: optOutExport("proj-2")},
+		{name: "merge with the same ID", export: optOutExport("proj-1", "proj-2"), merge: true},
+		{name: "merge without the ID", export: optOutExport("proj-2"), merge: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			path := writeOptOutIssues(t, tmpDir, optedOutRecord)
+			renderer := NewJSONLRenderer(tmpDir)
+
+			var err error
+			if tt.merge {
+				err = renderer.MergeExport(tt.export)
+			} else {
+				err = renderer.RenderExport(tt.export)
+			}
+			if err != nil {
+				t.Fatalf("Failed to render: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("Expected 2 records, got %d:\n%s", len(lines), data)
+			}
+			found := false
+			for _, line := range lines {
+				if line == optedOutRecord {
+					found = true
+				}
+				if strings.Contains(line, "From Jira proj-1") {
+					t.Errorf("Expected the opted-out record not to be overwritten, got %s", line)
+				}
+			}
+			if !found {
+				t.Errorf("Expected the opted-out record to be kept verbatim, got:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestBDRendererKeepsOptedOutRecords(t *testing.T) {
+	const optedOutBD = `{"id":"proj-1","title":"Local title","status":"open","priority":1,"issue_type":"task","external_ref":"PROJ-1","sync":false,"design":"kept by hand"}`
+
+	for _, merge := range []bool{false, true} {
+		tmpDir := t.TempDir()
+		path := writeOptOutIssues(t, tmpDir, optedOutBD)
+		renderer := NewBDRenderer(tmpDir, false)
+
+		render := renderer.RenderExport
+		if merge {
+			render = renderer.MergeExport
+		}
+		if err := render(optOutExport("proj-1", "proj-2")); err != nil {
+			t.Fatalf("Failed to render: %v", err)
+		}
+		if removed, err := renderer.RemoveJiraKeys([]string{"PROJ-1"}); err != nil || removed != 0 {
+			t.Errorf("Expected the opted-out record not to be removed, got %d, %v", removed, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 || lines[0] != optedOutBD || !strings.Contains(lines[1], "From Jira proj-2") {
+			t.Errorf("Expected the opted-out record to be kept verbatim (merge %v), got:\n%s", merge, data)
+		}
+	}
+}
+
+func TestRemoveJiraKeysKeepsOptedOutRecords(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeOptOutIssues(t, tmpDir, optedOutRecord,
+		`{"id":"proj-2","title":"Synced","status":"open","priority":2,"metadata":{"jiraKey":"PROJ-2"}}`)
+
+	removed, err := NewJSONLRenderer(tmpDir).RemoveJiraKeys([]string{"PROJ-1", "PROJ-2"})
+	if err != nil {
+		t.Fatalf("RemoveJiraKeys failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 record removed, got %d", removed)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].ID != "proj-1" {
+		t.Errorf("Expected only the opted-out record to remain, got %+v", issues)
+	}
+}
+
+func TestPlanExportLeavesOptedOutRecordsUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeOptOutIssues(t, tmpDir, optedOutRecord)
+
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(optOutExport("proj-1"), false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if len(plan.Changes) != 1 {
+		t.Fatalf("Expected 1 change, got %d", len(plan.Changes))
+	}
+	if plan.Changes[0].Kind != ChangeUnchanged {
+		t.Errorf("Expected the opted-out record to be unchanged, got %s", plan.Changes[0].Kind)
+	}
+}
+
+func TestSyncDisabled(t *testing.T) {
+	disabled, enabled := false, true
+	tests := []struct {
+		sync *bool
+		want bool
+	}{
+		{nil, false},
+		{&enabled, false},
+		{&disabled, true},
+	}
+	for _, tt := range tests {
+		issue := &BeadsIssue{Sync: tt.sync}
+		if got := issue.SyncDisabled(); got != tt.want {
+			t.Errorf("Expected SyncDisabled %v, got %v", tt.want, got)
+		}
+	}
+}
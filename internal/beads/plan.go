@@ -0,0 +1,300 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// ChangeKind classifies what a sync would do to a record
+type ChangeKind string
+
+const (
+	// ChangeCreated means the record doesn't exist locally yet
+	ChangeCreated ChangeKind = "created"
+	// ChangeUpdated means the record exists locally with different content
+	ChangeUpdated ChangeKind = "updated"
+	// ChangeUnchanged means the record exists locally with the same content
+	ChangeUnchanged ChangeKind = "unchanged"
+	// ChangeRemoved means the record exists locally but not in the export
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// RecordChange describes what a sync would do to one issue or epic
+type RecordChange struct {
+	Kind    ChangeKind
+	Record  string // "issue" or "epic"
+	ID      string // Beads ID
+	OldID   string // Previous beads ID of a moved issue
+	JiraKey string
+	Repo    string   // Beads repository directory, set when routing
+	Fields  []string // Changed fields of an update, e.g. "status" or "metadata.sprint"
+	Diff    string   // Unified diff of the record's JSON; empty when unchanged
+	Bytes   int64    // Change in the size of the beads files, negative when shrinking
+}
+
+// Plan lists what rendering an export would change, without writing
+// anything. See JSONLRenderer.PlanExport.
+type Plan struct {
+	Changes []RecordChange
+}
+
+// Count returns the number of records with the given kind of change
+func (p *Plan) Count(kind ChangeKind) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// HasChanges reports whether the sync would modify any record
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > p.Count(ChangeUnchanged)
+}
+
+// Append adds the changes of another plan, e.g. for another repository
+func (p *Plan) Append(other *Plan) {
+	p.Changes = append(p.Changes, other.Changes...)
+}
+
+// PlanExport compares an export with the JSONL files on disk and returns
+// the changes RenderExport (or MergeExport, with merge set) would make.
+// Nothing is written. Like rendering, moved issues are detected by Jira ID
+// and their references in the export updated.
+func (r *JSONLRenderer) PlanExport(export *pb.Export, merge bool) (*Plan, error) {
+	if err := r.reconcileKeyChanges(export); err != nil {
+		return nil, fmt.Errorf("failed to reconcile moved issues: %w", err)
+	}
+	moved := make(map[string]string, len(r.keyChanges))
+	for _, change := range r.keyChanges {
+		moved[change.NewID] = change.OldID
+	}
+
+	existingIssues, existingEpics, err := r.readExisting()
+	if err != nil {
+		return nil, err
+	}
+
+	issues := keepOptedOut(existingIssues, r.issueRecords(existingIssues, export.Issues), issueRecordID)
+	epics := keepOptedOut(existingEpics, r.epicRecords(existingEpics, export.Epics), epicRecordID)
+
+	plan := &Plan{}
+	// RenderExport leaves the epics file alone when there are no epics
+	keepEpics := merge || len(export.Epics) == 0
+	plan.Changes = append(plan.Changes, planRecords("epic", existingEpics, epics, moved, keepEpics,
+		func(epic *BeadsEpic) (string, map[string]string) { return epic.ID, epic.Metadata })...)
+	plan.Changes = append(plan.Changes, planRecords("issue", existingIssues, issues, moved, merge,
+		func(issue *BeadsIssue) (string, map[string]string) { return issue.ID, issue.Metadata })...)
+	return plan, nil
+}
+
+// planRecords compares existing records with the rendered ones. Records
+// missing from the export are removed unless keep is set; records of moved
+// issues are compared with their old record.
+func planRecords[T any](record string, existing, updated []T, moved map[string]string, keep bool,
+	identify func(T) (string, map[string]string)) []RecordChange {
+	byID := make(map[string]T, len(existing))
+	for _, e := range existing {
+		id, _ := identify(e)
+		byID[id] = e
+	}
+
+	seen := make(map[string]bool, len(updated))
+	var changes []RecordChange
+	for _, u := range updated {
+		id, metadata := identify(u)
+		change := RecordChange{Record: record, ID: id, JiraKey: metadata["jiraKey"]}
+
+		old, found := byID[id]
+		if oldID, ok := moved[id]; ok && !found {
+			old, found = byID[oldID]
+			change.OldID = oldID
+			seen[oldID] = true
+		}
+		seen[id] = true
+
+		if !found {
+			change.Kind = ChangeCreated
+			change.Bytes = recordSize(u)
+			change.Diff = unifiedDiff("/dev/null", recordPath(record, id), "", recordJSON(u))
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Fields = changedFields(old, u)
+		if len(change.Fields) == 0 {
+			change.Kind = ChangeUnchanged
+		} else {
+			change.Kind = ChangeUpdated
+			change.Bytes = recordSize(u) - recordSize(old)
+			fromID := id
+			if change.OldID != "" {
+				fromID = change.OldID
+			}
+			change.Diff = unifiedDiff(recordPath(record, fromID), recordPath(record, id), recordJSON(old), recordJSON(u))
+		}
+		changes = append(changes, change)
+	}
+
+	for _, e := range existing {
+		id, metadata := identify(e)
+		if seen[id] || keep {
+			continue
+		}
+		changes = append(changes, RecordChange{
+			Kind:    ChangeRemoved,
+			Record:  record,
+			ID:      id,
+			JiraKey: metadata["jiraKey"],
+			Diff:    unifiedDiff(recordPath(record, id), "/dev/null", recordJSON(e), ""),
+			Bytes:   -recordSize(e),
+		})
+	}
+
+	sort.SliceStable(changes, func(a, b int) bool { return changes[a].ID < changes[b].ID })
+	return changes
+}
+
+// changedFields lists the top-level JSON fields that differ between two
+// records, with metadata compared key by key
+func changedFields(old, updated any) []string {
+	oldFields, newFields := jsonFields(old), jsonFields(updated)
+
+	var fields []string
+	for _, name := range unionKeys(oldFields, newFields) {
+		if name == "metadata" {
+			oldMeta, _ := oldFields[name].(map[string]any)
+			newMeta, _ := newFields[name].(map[string]any)
+			for _, key := range unionKeys(oldMeta, newMeta) {
+				if !reflect.DeepEqual(oldMeta[key], newMeta[key]) {
+					fields = append(fields, "metadata."+key)
+				}
+			}
+			continue
+		}
+		if !reflect.DeepEqual(oldFields[name], newFields[name]) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// jsonFields decodes a record's JSON into a map, so records compare the way
+// they are written
+func jsonFields(record any) map[string]any {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recordJSON formats a record for diffing, one field per line
+func recordJSON(record any) string {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data) + "\n"
+}
+
+// recordSize returns the size of a record's line in a JSONL file
+func recordSize(record any) int64 {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data)) + 1
+}
+
+func recordPath(record, id string) string {
+	return record + "/" + id
+}
+
+// Render writes the plan as a summary line followed by one line per created,
+// updated or removed record. With diffs set each change is followed by a
+// unified diff of the record.
+func (p *Plan) Render(w io.Writer, diffs bool) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d created, %d updated, %d unchanged, %d removed\n",
+		p.Count(ChangeCreated), p.Count(ChangeUpdated), p.Count(ChangeUnchanged), p.Count(ChangeRemoved))
+
+	repo := ""
+	for _, change := range p.Changes {
+		if change.Kind == ChangeUnchanged {
+			continue
+		}
+		if change.Repo != repo {
+			repo = change.Repo
+			fmt.Fprintf(&b, "\n%s:\n", repo)
+		}
+
+		fmt.Fprintf(&b, "  %s %s %s", changeSymbol(change.Kind), change.Record, change.ID)
+		if change.JiraKey != "" {
+			fmt.Fprintf(&b, " (%s)", change.JiraKey)
+		}
+		switch change.Kind {
+		case ChangeUpdated:
+			if change.OldID != "" {
+				fmt.Fprintf(&b, " moved from %s", change.OldID)
+			}
+			fmt.Fprintf(&b, ": %s", strings.Join(change.Fields, ", "))
+		case ChangeRemoved:
+			b.WriteString(": no longer in Jira or outside this sync")
+		}
+		b.WriteString("\n")
+
+		if diffs && change.Diff != "" {
+			b.WriteString(prefixDiff(change.Diff, "    "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func changeSymbol(kind ChangeKind) string {
+	switch kind {
+	case ChangeCreated:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func prefixDiff(diff, prefix string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString(prefix + line)
+		}
+	}
+	return b.String()
+}
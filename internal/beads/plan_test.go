@@ -0,0 +1,218 @@
+package beads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+func planTestIssue(id, title string) *pb.Issue {
+	return &pb.Issue{
+		Id:       id,
+		Title:    title,
+		Status:   pb.Status_STATUS_OPEN,
+		Priority: pb.Priority_PRIORITY_P2,
+		Metadata: &pb.Metadata{JiraKey: strings.ToUpper(id), JiraId: id + "-id"},
+	}
+}
+
+func planChange(t *testing.T, plan *Plan, id string) RecordChange {
+	t.Helper()
+	for _, change := range plan.Changes {
+		if change.ID == id {
+			return change
+		}
+	}
+	t.Fatalf("Expected a change for %s", id)
+	return RecordChange{}
+}
+
+func TestPlanExport(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	initial := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First"), planTestIssue("proj-2", "Second"), planTestIssue("proj-3", "Third")},
+	}
+	if err := renderer.RenderExport(initial); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	edited := planTestIssue("proj-2", "Second (edited)")
+	edited.Metadata.Custom = map[string]string{"sprint": "Sprint 4"}
+	export := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First"), edited, planTestIssue("proj-4", "Fourth")},
+	}
+
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(export, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+
+	if got := planChange(t, plan, "proj-1").Kind; got != ChangeUnchanged {
+		t.Errorf("Expected proj-1 unchanged, got %s", got)
+	}
+	updated := planChange(t, plan, "proj-2")
+	if updated.Kind != ChangeUpdated {
+		t.Errorf("Expected proj-2 updated, got %s", updated.Kind)
+	}
+	if got := strings.Join(updated.Fields, ","); got != "metadata.sprint,title" {
+		t.Errorf("Expected changed fields metadata.sprint,title, got %s", got)
+	}
+	if !strings.Contains(updated.Diff, `-  "title": "Second",`) || !strings.Contains(updated.Diff, `+  "title": "Second (edited)",`) {
+		t.Errorf("Expected diff of the title, got:\n%s", updated.Diff)
+	}
+	if got := planChange(t, plan, "proj-3").Kind; got != ChangeRemoved {
+		t.Errorf("Expected proj-3 removed, got %s", got)
+	}
+	if got := planChange(t, plan, "proj-4").Kind; got != ChangeCreated {
+		t.Errorf("Expected proj-4 created, got %s", got)
+	}
+	if !plan.HasChanges() {
+		t.Error("Expected plan to have changes")
+	}
+
+	// Planning must not write anything
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 3 || issues[1].Title != "Second" {
+		t.Errorf("Expected issues on disk to be untouched")
+	}
+}
+
+func TestPlanExportUpToDate(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	export := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First")},
+		Epics: []*pb.Epic{{
+			Id:       "proj-10",
+			Name:     "Epic",
+			Status:   pb.Status_STATUS_OPEN,
+			Metadata: &pb.Metadata{JiraKey: "PROJ-10", JiraId: "proj-10-id"},
+		}},
+	}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(export, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("Expected no changes, got %+v", plan.Changes)
+	}
+	if plan.Count(ChangeUnchanged) != 2 {
+		t.Errorf("Expected 2 unchanged records, got %d", plan.Count(ChangeUnchanged))
+	}
+}
+
+func TestPlanExportMergeKeepsRecords(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	initial := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First"), planTestIssue("proj-2", "Second")},
+		Epics: []*pb.Epic{{
+			Id:       "proj-10",
+			Name:     "Epic",
+			Status:   pb.Status_STATUS_OPEN,
+			Metadata: &pb.Metadata{JiraKey: "PROJ-10", JiraId: "proj-10-id"},
+		}},
+	}
+	if err := renderer.RenderExport(initial); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	partial := &pb.Export{Issues: []*pb.Issue{planTestIssue("proj-2", "Second")}}
+
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(partial, true)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if plan.Count(ChangeRemoved) != 0 {
+		t.Errorf("Expected merge to remove nothing, got %d removed", plan.Count(ChangeRemoved))
+	}
+
+	// A full render without epics leaves the epics file alone too
+	plan, err = NewJSONLRenderer(tmpDir).PlanExport(partial, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if got := planChange(t, plan, "proj-1").Kind; got != ChangeRemoved {
+		t.Errorf("Expected proj-1 removed, got %s", got)
+	}
+	if plan.Count(ChangeRemoved) != 1 {
+		t.Errorf("Expected only proj-1 removed, got %d removed", plan.Count(ChangeRemoved))
+	}
+}
+
+func TestPlanExportMovedIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	original := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "proj-2",
+		Title:    "Moved",
+		Metadata: &pb.Metadata{JiraKey: "PROJ-2", JiraId: "10002"},
+	}}}
+	if err := renderer.RenderExport(original); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	moved := &pb.Export{Issues: []*pb.Issue{{
+		Id:       "new-5",
+		Title:    "Moved",
+		Metadata: &pb.Metadata{JiraKey: "NEW-5", JiraId: "10002"},
+	}}}
+	plan, err := NewJSONLRenderer(tmpDir).PlanExport(moved, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+
+	if len(plan.Changes) != 1 {
+		t.Fatalf("Expected 1 change, got %d", len(plan.Changes))
+	}
+	change := plan.Changes[0]
+	if change.Kind != ChangeUpdated || change.OldID != "proj-2" {
+		t.Errorf("Expected new-5 updated from proj-2, got %s from %q", change.Kind, change.OldID)
+	}
+	if !strings.Contains(change.Diff, "--- issue/proj-2") {
+		t.Errorf("Expected diff against the old record, got:\n%s", change.Diff)
+	}
+}
+
+func TestPlanRender(t *testing.T) {
+	plan := &Plan{Changes: []RecordChange{
+		{Kind: ChangeCreated, Record: "issue", ID: "proj-4", JiraKey: "PROJ-4", Repo: "backend", Diff: "--- /dev/null\n+++ issue/proj-4\n"},
+		{Kind: ChangeUnchanged, Record: "issue", ID: "proj-1", JiraKey: "PROJ-1", Repo: "backend"},
+		{Kind: ChangeUpdated, Record: "issue", ID: "proj-2", JiraKey: "PROJ-2", Repo: "frontend", Fields: []string{"status", "title"}},
+		{Kind: ChangeRemoved, Record: "epic", ID: "proj-10", Repo: "frontend"},
+	}}
+
+	var buf bytes.Buffer
+	if err := plan.Render(&buf, true); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := `1 created, 1 updated, 1 unchanged, 1 removed
+
+backend:
+  + issue proj-4 (PROJ-4)
+    --- /dev/null
+    +++ issue/proj-4
+
+frontend:
+  ~ issue proj-2 (PROJ-2): status, title
+  - epic proj-10: no longer in Jira or outside this sync
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -0,0 +1,102 @@
+package beads
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ReadIssues reads all issues from .beads/issues.jsonl under outputDir
+func ReadIssues(outputDir string) ([]*BeadsIssue, error) {
+	var issues []*BeadsIssue
+	err := readJSONL(filepath.Join(outputDir, ".beads", "issues.jsonl"), func(line []byte) error {
+		var issue BeadsIssue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return fmt.Errorf("failed to parse issue: %w", err)
+		}
+		if issue.SyncDisabled() {
+			issue.raw = bytes.Clone(line)
+		}
+		issues = append(issues, &issue)
+		return nil
+	})
+	return issues, err
+}
+
+// ReadEpics reads all epics from .beads/epics.jsonl under outputDir.
+// A missing epics file is not an error since exports without epics don't write one.
+func ReadEpics(outputDir string) ([]*BeadsEpic, error) {
+	epicsFile := filepath.Join(outputDir, ".beads", "epics.jsonl")
+	if _, err := os.Stat(epicsFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var epics []*BeadsEpic
+	err := readJSONL(epicsFile, func(line []byte) error {
+		var epic BeadsEpic
+		if err := json.Unmarshal(line, &epic); err != nil {
+			return fmt.Errorf("failed to parse epic: %w", err)
+		}
+		if epic.SyncDisabled() {
+			epic.raw = bytes.Clone(line)
+		}
+		epics = append(epics, &epic)
+		return nil
+	})
+	return epics, err
+}
+
+// ReadRepo reads the issues and epics under outputDir written in the given
+// format. bd's format holds fewer fields; see ReadBD.
+func ReadRepo(outputDir string, format Format) ([]*BeadsIssue, []*BeadsEpic, error) {
+	if format == FormatBD || format == FormatBDImport {
+		issues, epics, err := ReadBD(outputDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read issues: %w", err)
+		}
+		return issues, epics, nil
+	}
+
+	issues, err := ReadIssues(outputDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read issues: %w", err)
+	}
+	epics, err := ReadEpics(outputDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read epics: %w", err)
+	}
+	return issues, epics, nil
+}
+
+// readJSONL calls fn for every non-empty line of a JSONL file
+func readJSONL(filename string, fn func(line []byte) error) (err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filepath.Base(filename), err)
+	}
+	defer func() {
+		if cerr := file.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", filepath.Base(filename), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+func TestReadIssuesAndEpics(t *testing.T) {
+	tmpDir := t.TempDir()
+	renderer := NewJSONLRenderer(tmpDir)
+
+	export := &pb.Export{
+		Issues: []*pb.Issue{
+			{Id: "proj-2", Title: "Issue", Epic: "proj-1", DependsOn: []string{"proj-3"}},
+			{Id: "proj-3", Title: "Blocker"},
+		},
+		Epics: []*pb.Epic{
+			{Id: "proj-1", Name: "Epic"},
+		},
+	}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Epic != "proj-1" || len(issues[0].DependsOn) != 1 {
+		t.Errorf("Unexpected first issue: %+v", issues[0])
+	}
+
+	epics, err := ReadEpics(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadEpics failed: %v", err)
+	}
+	if len(epics) != 1 || epics[0].Name != "Epic" {
+		t.Errorf("Unexpected epics: %+v", epics)
+	}
+}
+
+func TestReadEpicsMissingFile(t *testing.T) {
+	epics, err := ReadEpics(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error for missing epics file, got: %v", err)
+	}
+	if len(epics) != 0 {
+		t.Errorf("Expected no epics, got %d", len(epics))
+	}
+}
+
+func TestReadIssuesInvalidJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".beads"), 0755); err != nil {
+		t.Fatalf("Failed to create .beads: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"), []byte("{not json\n"), 0644); err != nil {
+		t.Fatalf("Failed to write issues.jsonl: %v", err)
+	}
+
+	if _, err := ReadIssues(tmpDir); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
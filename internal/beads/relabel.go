@@ -0,0 +1,126 @@
+package beads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LabelChange is an issue whose labels a relabel rewrote
+type LabelChange struct {
+	ID      string
+	JiraKey string
+	Renamed map[string]string // Old label, as found on the issue → new label
+}
+
+// RenameLabels applies renames, keyed by lower-cased old label, to labels.
+// Labels that end up duplicated are dropped. It returns the new labels and
+// the renames that applied, keyed by the label as found.
+func RenameLabels(labels []string, renames map[string]string) ([]string, map[string]string) {
+	if len(renames) == 0 || len(labels) == 0 {
+		return labels, nil
+	}
+
+	var renamed map[string]string
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if to, ok := renames[strings.ToLower(label)]; ok && to != label {
+			if renamed == nil {
+				renamed = make(map[string]string)
+			}
+			renamed[label] = to
+			label = to
+		}
+		if !slices.Contains(result, label) {
+			result = append(result, label)
+		}
+	}
+	if renamed == nil {
+		return labels, nil
+	}
+	return result, renamed
+}
+
+// Relabel renames labels on every issue in the issues file, keeping records
+// marked "sync": false as they are. The file is replaced in one step, so a
+// failed relabel leaves it untouched. With dryRun set nothing is written.
+func (r *JSONLRenderer) Relabel(renames map[string]string, dryRun bool) (_ []LabelChange, err error) {
+	files := newFileBatch(r.durability)
+	defer func() { err = files.commit(err) }()
+
+	issuesFile := filepath.Join(r.outputDir, ".beads", "issues.jsonl")
+	if _, err := os.Stat(issuesFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+	issues, err := ReadIssues(r.outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	renames = lowerLabelKeys(renames)
+	var changes []LabelChange
+	for _, issue := range issues {
+		if issue.SyncDisabled() {
+			continue
+		}
+		labels, renamed := RenameLabels(issue.Labels, renames)
+		if renamed == nil {
+			continue
+		}
+		issue.Labels = labels
+		changes = append(changes, LabelChange{ID: issue.ID, JiraKey: issue.Metadata["jiraKey"], Renamed: renamed})
+	}
+
+	if len(changes) == 0 || dryRun {
+		return sortLabelChanges(changes), nil
+	}
+	if err := writeJSONL(files, issuesFile, issues); err != nil {
+		return nil, fmt.Errorf("failed to write issues: %w", err)
+	}
+	return sortLabelChanges(changes), nil
+}
+
+// Relabel renames labels on every record in the issues file, importing the
+// result into bd in the bd-import format. With dryRun set nothing is written.
+func (r *BDRenderer) Relabel(renames map[string]string, dryRun bool) ([]LabelChange, error) {
+	records, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	renames = lowerLabelKeys(renames)
+	var changes []LabelChange
+	for _, record := range records {
+		labels, renamed := RenameLabels(record.Labels, renames)
+		if renamed == nil {
+			continue
+		}
+		record.Labels = labels
+		changes = append(changes, LabelChange{ID: record.ID, JiraKey: record.ExternalRef, Renamed: renamed})
+	}
+
+	if len(changes) == 0 || dryRun {
+		return sortLabelChanges(changes), nil
+	}
+	if err := r.write(records); err != nil {
+		return nil, err
+	}
+	return sortLabelChanges(changes), nil
+}
+
+// lowerLabelKeys returns a copy of renames keyed by lower-cased label
+func lowerLabelKeys(renames map[string]string) map[string]string {
+	lowered := make(map[string]string, len(renames))
+	for from, to := range renames {
+		lowered[strings.ToLower(from)] = to
+	}
+	return lowered
+}
+
+func sortLabelChanges(changes []LabelChange) []LabelChange {
+	sort.Slice(changes, func(a, b int) bool { return changes[a].ID < changes[b].ID })
+	return changes
+}
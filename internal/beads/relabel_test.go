@@ -0,0 +1,123 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRenameLabels(t *testing.T) {
+	labels, renamed := RenameLabels([]string{"Frontend", "ui", "backend"}, map[string]string{"frontend": "ui"})
+	if !slices.Equal(labels, []string{"ui", "backend"}) {
+		t.Errorf("Expected [ui backend], got %v", labels)
+	}
+	if len(renamed) != 1 || renamed["Frontend"] != "ui" {
+		t.Errorf("Expected Frontend → ui, got %v", renamed)
+	}
+
+	original := []string{"backend"}
+	labels, renamed = RenameLabels(original, map[string]string{"frontend": "ui"})
+	if renamed != nil || &labels[0] != &original[0] {
+		t.Errorf("Expected labels without renames to be returned as is, got %v", labels)
+	}
+}
+
+func TestJSONLRelabel(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := writeOptOutIssues(t, tmpDir,
+		`{"id":"proj-1","title":"One","status":"open","labels":["frontend","perf"],"metadata":{"jiraKey":"PROJ-1"}}`,
+		`{"id":"proj-2","title":"Two","status":"open","labels":["backend"]}`,
+		`{"id":"proj-3","title":"Local","status":"open","labels":["frontend"],"sync":false}`,
+	)
+	renderer := NewJSONLRenderer(tmpDir)
+	renames := map[string]string{"Frontend": "ui"}
+
+	before, _ := os.ReadFile(path)
+	changes, err := renderer.Relabel(renames, true)
+	if err != nil {
+		t.Fatalf("Relabel failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change in a dry run, got %d", len(changes))
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("Expected a dry run not to write")
+	}
+
+	changes, err = renderer.Relabel(renames, false)
+	if err != nil {
+		t.Fatalf("Relabel failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].ID != "proj-1" || changes[0].JiraKey != "PROJ-1" || changes[0].Renamed["frontend"] != "ui" {
+		t.Fatalf("Unexpected changes: %+v", changes)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(issues[0].Labels, []string{"ui", "perf"}) {
+		t.Errorf("Expected proj-1 labels [ui perf], got %v", issues[0].Labels)
+	}
+	if !slices.Equal(issues[2].Labels, []string{"frontend"}) {
+		t.Errorf("Expected the opted-out record to keep its labels, got %v", issues[2].Labels)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Expected no temporary files to be left, found %s", entry.Name())
+		}
+	}
+}
+
+func TestJSONLRelabelWithoutIssues(t *testing.T) {
+	changes, err := NewJSONLRenderer(t.TempDir()).Relabel(map[string]string{"a": "b"}, false)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("Expected nothing to relabel, got %v, %v", changes, err)
+	}
+}
+
+func TestBDRelabel(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeOptOutIssues(t, tmpDir,
+		`{"id":"proj-1","title":"One","status":"open","labels":["frontend"],"external_ref":"PROJ-1"}`,
+	)
+	renderer := NewBDRenderer(tmpDir, false)
+
+	changes, err := renderer.Relabel(map[string]string{"frontend": "ui"}, false)
+	if err != nil {
+		t.Fatalf("Relabel failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].JiraKey != "PROJ-1" {
+		t.Fatalf("Unexpected changes: %+v", changes)
+	}
+
+	records, err := renderer.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(records[0].Labels, []string{"ui"}) {
+		t.Errorf("Expected labels [ui], got %v", records[0].Labels)
+	}
+}
+
+func TestAppendAudit(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, id := range []string{"proj-1", "proj-2"} {
+		if err := AppendAudit(tmpDir, AuditEntry{Operation: "relabel", Issues: []string{id}}); err != nil {
+			t.Fatalf("AppendAudit failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".beads", AuditFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"proj-2"`) {
+		t.Errorf("Expected 2 appended entries, got:\n%s", data)
+	}
+}
@@ -0,0 +1,80 @@
+package beads
+
+import (
+	"fmt"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// Format selects how exports are written to a repository's .beads directory
+type Format string
+
+const (
+	// FormatJSONL writes issues.jsonl and epics.jsonl (JSONLRenderer)
+	FormatJSONL Format = "jsonl"
+	// FormatBD writes a single issues.jsonl in bd's import format (BDRenderer)
+	FormatBD Format = "bd"
+	// FormatBDImport writes like FormatBD and then runs `bd import`
+	FormatBDImport Format = "bd-import"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{FormatJSONL, FormatBD, FormatBDImport}
+
+// Renderer writes beads exports to a repository
+type Renderer interface {
+	// RenderExport replaces the repository's records with the export
+	RenderExport(export *pb.Export) error
+	// MergeExport merges a partial export into the existing records
+	MergeExport(export *pb.Export) error
+	// RemoveJiraKeys removes the records of the given Jira keys
+	RemoveJiraKeys(keys []string) (int, error)
+	// PlanExport returns the changes rendering would make, without writing
+	PlanExport(export *pb.Export, merge bool) (*Plan, error)
+	// KeyChanges returns the moved issues detected by the last render
+	KeyChanges() []KeyChange
+	// Relabel renames labels on the existing records
+	Relabel(renames map[string]string, dryRun bool) ([]LabelChange, error)
+}
+
+// RendererOptions tunes how a renderer writes its files
+type RendererOptions struct {
+	// Durability selects when written files are flushed to stable storage.
+	// Empty means DurabilityBatch.
+	Durability Durability
+
+	// Source adds a source block to every record, see Source. Nil leaves
+	// it out. Only the JSONL format writes it: bd records have no field for
+	// it, so the bd formats ignore it and keep just the Jira key.
+	Source *SourceOptions
+}
+
+// NewRenderer creates a renderer for the given format writing to the .beads
+// directory in outputDir. An empty format means FormatJSONL.
+func NewRenderer(outputDir string, format Format) (Renderer, error) {
+	return NewRendererWithOptions(outputDir, format, RendererOptions{})
+}
+
+// NewRendererWithOptions creates a renderer for the given format with the
+// given write settings
+func NewRendererWithOptions(outputDir string, format Format, opts RendererOptions) (Renderer, error) {
+	switch opts.Durability {
+	case "", DurabilityAlways, DurabilityBatch, DurabilityNone:
+	default:
+		return nil, fmt.Errorf("unknown durability %q", opts.Durability)
+	}
+
+	switch format {
+	case "", FormatJSONL:
+		renderer := NewJSONLRenderer(outputDir)
+		renderer.durability = opts.Durability
+		renderer.source = opts.Source
+		return renderer, nil
+	case FormatBD, FormatBDImport:
+		renderer := NewBDRenderer(outputDir, format == FormatBDImport)
+		renderer.durability = opts.Durability
+		return renderer, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,169 @@
+package beads
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata keys of the planned dates of epics and issues, as YYYY-MM-DD
+const (
+	TargetStartKey = "targetStart"
+	TargetEndKey   = "targetEnd"
+)
+
+// Where a roadmap date comes from
+const (
+	DateFromTarget   = "target"   // The epic's own target date
+	DateFromChildren = "children" // Computed from the epic's issues
+)
+
+// Roadmap lists the planned dates of epics, for roadmap and Gantt tools
+type Roadmap struct {
+	Epics []RoadmapEpic `yaml:"epics"`
+}
+
+// RoadmapEpic is an epic with its planned start and due dates. Dates the
+// epic doesn't set are computed from its issues: the earliest target start
+// (or creation date) and the latest target end.
+type RoadmapEpic struct {
+	ID        string `yaml:"id"`
+	JiraKey   string `yaml:"jiraKey,omitempty"`
+	Name      string `yaml:"name"`
+	Status    string `yaml:"status"`
+	Start     string `yaml:"start,omitempty"`
+	StartFrom string `yaml:"startFrom,omitempty"`
+	Due       string `yaml:"due,omitempty"`
+	DueFrom   string `yaml:"dueFrom,omitempty"`
+	Issues    int    `yaml:"issues"`
+	Closed    int    `yaml:"closed"`
+}
+
+// BuildRoadmap works out the dates of every epic, ordered by start date;
+// epics without a start date come last, by ID
+func BuildRoadmap(issues []*BeadsIssue, epics []*BeadsEpic) *Roadmap {
+	children := make(map[string][]*BeadsIssue)
+	for _, issue := range issues {
+		if issue.Epic != "" {
+			children[issue.Epic] = append(children[issue.Epic], issue)
+		}
+	}
+
+	roadmap := &Roadmap{Epics: make([]RoadmapEpic, 0, len(epics))}
+	for _, epic := range epics {
+		entry := RoadmapEpic{
+			ID:      epic.ID,
+			JiraKey: epic.Metadata["jiraKey"],
+			Name:    epic.Name,
+			Status:  epic.Status,
+			Issues:  len(children[epic.ID]),
+		}
+
+		var childStart, childDue string
+		for _, issue := range children[epic.ID] {
+			if issue.Status == "closed" {
+				entry.Closed++
+			}
+			start := issue.Metadata[TargetStartKey]
+			if start == "" && len(issue.Created) >= 10 {
+				start = issue.Created[:10]
+			}
+			if start != "" && (childStart == "" || start < childStart) {
+				childStart = start
+			}
+			if due := issue.Metadata[TargetEndKey]; due > childDue {
+				childDue = due
+			}
+		}
+
+		entry.Start, entry.StartFrom = pickDate(epic.Metadata[TargetStartKey], childStart)
+		entry.Due, entry.DueFrom = pickDate(epic.Metadata[TargetEndKey], childDue)
+		if entry.Start != "" && entry.Due != "" && entry.Due < entry.Start {
+			// E.g. an issue created after the epic's target end. A computed
+			// date gives way to a target; otherwise the bar shrinks to a day.
+			if entry.StartFrom == DateFromChildren && entry.DueFrom == DateFromTarget {
+				entry.Start = entry.Due
+			} else {
+				entry.Due = entry.Start
+			}
+		}
+		roadmap.Epics = append(roadmap.Epics, entry)
+	}
+
+	sort.Slice(roadmap.Epics, func(i, j int) bool {
+		a, b := roadmap.Epics[i], roadmap.Epics[j]
+		if (a.Start == "") != (b.Start == "") {
+			return a.Start != ""
+		}
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.ID < b.ID
+	})
+	return roadmap
+}
+
+// pickDate prefers the epic's own date over the one computed from its issues
+func pickDate(target, children string) (date, from string) {
+	switch {
+	case target != "":
+		return target, DateFromTarget
+	case children != "":
+		return children, DateFromChildren
+	default:
+		return "", ""
+	}
+}
+
+// WriteYAML writes the roadmap as YAML
+func (r *Roadmap) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode roadmap: %w", err)
+	}
+	return encoder.Close()
+}
+
+// WriteMermaid writes the roadmap as a Mermaid Gantt chart. Epics missing
+// a start or due date can't be drawn and are listed in comments.
+func (r *Roadmap) WriteMermaid(w io.Writer, title string) error {
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	if title != "" {
+		fmt.Fprintf(&b, "    title %s\n", mermaidText(title))
+	}
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+	b.WriteString("    section Epics\n")
+
+	var undated []RoadmapEpic
+	for _, epic := range r.Epics {
+		if epic.Start == "" || epic.Due == "" {
+			undated = append(undated, epic)
+			continue
+		}
+		state := ""
+		switch epic.Status {
+		case "closed":
+			state = "done, "
+		case "in_progress":
+			state = "active, "
+		}
+		fmt.Fprintf(&b, "    %s :%s%s, %s\n", mermaidText(epic.Name), state, epic.Start, epic.Due)
+	}
+	for _, epic := range undated {
+		fmt.Fprintf(&b, "    %%%% %s %s: no start or due date\n", epic.ID, mermaidText(epic.Name))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mermaidText removes the characters Mermaid treats as syntax in task names
+func mermaidText(s string) string {
+	s = strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ").Replace(s)
+	return strings.TrimSpace(s)
+}
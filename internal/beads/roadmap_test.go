@@ -0,0 +1,102 @@
+package beads
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func roadmapTestData() ([]*BeadsIssue, []*BeadsEpic) {
+	epics := []*BeadsEpic{
+		{ID: "app-1", Name: "Checkout: v2", Status: "in_progress", Metadata: map[string]string{"jiraKey": "APP-1", TargetStartKey: "2024-05-01", TargetEndKey: "2024-06-30"}},
+		{ID: "app-2", Name: "Search", Status: "open"},
+		{ID: "app-3", Name: "Billing", Status: "open"},
+	}
+	issues := []*BeadsIssue{
+		{ID: "app-10", Epic: "app-1", Status: "closed"},
+		{ID: "app-20", Epic: "app-2", Status: "open", Created: "2024-03-10T09:00:00Z", Metadata: map[string]string{TargetEndKey: "2024-04-15"}},
+		{ID: "app-21", Epic: "app-2", Status: "closed", Metadata: map[string]string{TargetStartKey: "2024-03-01", TargetEndKey: "2024-04-30"}},
+	}
+	return issues, epics
+}
+
+func TestBuildRoadmap(t *testing.T) {
+	roadmap := BuildRoadmap(roadmapTestData())
+	if len(roadmap.Epics) != 3 {
+		t.Fatalf("Expected 3 epics, got %d", len(roadmap.Epics))
+	}
+
+	search := roadmap.Epics[0]
+	if search.ID != "app-2" || search.Start != "2024-03-01" || search.Due != "2024-04-30" || search.StartFrom != DateFromChildren {
+		t.Errorf("Expected Search first with dates computed from its issues, got %+v", search)
+	}
+	if search.Issues != 2 || search.Closed != 1 {
+		t.Errorf("Expected 2 issues with 1 closed, got %d and %d", search.Issues, search.Closed)
+	}
+
+	checkout := roadmap.Epics[1]
+	if checkout.Start != "2024-05-01" || checkout.Due != "2024-06-30" || checkout.DueFrom != DateFromTarget {
+		t.Errorf("Expected Checkout to keep its target dates, got %+v", checkout)
+	}
+
+	if billing := roadmap.Epics[2]; billing.ID != "app-3" || billing.Start != "" || billing.Due != "" {
+		t.Errorf("Expected undated Billing last, got %+v", billing)
+	}
+}
+
+func TestBuildRoadmapKeepsDueAfterStart(t *testing.T) {
+	epics := []*BeadsEpic{
+		{ID: "app-1", Name: "Target end", Metadata: map[string]string{TargetEndKey: "2024-04-30"}},
+		{ID: "app-2", Name: "Target start", Metadata: map[string]string{TargetStartKey: "2024-06-01"}},
+	}
+	issues := []*BeadsIssue{
+		{ID: "app-10", Epic: "app-1", Created: "2024-05-20T09:00:00Z"},
+		{ID: "app-20", Epic: "app-2", Metadata: map[string]string{TargetEndKey: "2024-05-15"}},
+	}
+
+	for _, epic := range BuildRoadmap(issues, epics).Epics {
+		if epic.Due < epic.Start {
+			t.Errorf("Expected %s to be due no earlier than it starts, got %s to %s", epic.ID, epic.Start, epic.Due)
+		}
+		switch epic.ID {
+		case "app-1":
+			if epic.Due != "2024-04-30" || epic.Start != "2024-04-30" {
+				t.Errorf("Expected the computed start to give way to the target end, got %+v", epic)
+			}
+		case "app-2":
+			if epic.Start != "2024-06-01" || epic.Due != "2024-06-01" {
+				t.Errorf("Expected the computed due date to give way to the target start, got %+v", epic)
+			}
+		}
+	}
+}
+
+func TestRoadmapWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildRoadmap(roadmapTestData()).WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+	for _, want := range []string{"epics:\n  - id: app-2\n", "    start: \"2024-03-01\"\n", "    startFrom: children\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in YAML, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRoadmapWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildRoadmap(roadmapTestData()).WriteMermaid(&buf, "Roadmap"); err != nil {
+		t.Fatalf("WriteMermaid failed: %v", err)
+	}
+	want := `gantt
+    title Roadmap
+    dateFormat YYYY-MM-DD
+    section Epics
+    Search :2024-03-01, 2024-04-30
+    Checkout - v2 :active, 2024-05-01, 2024-06-30
+    %% app-3 Billing: no start or due date
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected chart:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
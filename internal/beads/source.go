@@ -0,0 +1,86 @@
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// SourceSystemJira is the source system of records synced from Jira
+const SourceSystemJira = "jira"
+
+// Source records where a record came from and how fresh it is, so
+// repositories mixing several sources, and later migrations, can tell
+// records apart. LastSyncedAt and SyncToolVersion describe the last sync
+// that changed the record: syncs that change nothing else keep the block
+// as it was, so they leave the files untouched.
+type Source struct {
+	System          string `json:"system"`
+	Instance        string `json:"instance,omitempty"` // Base URL of the Jira instance
+	Key             string `json:"key,omitempty"`      // Jira issue key
+	LastSyncedAt    string `json:"lastSyncedAt,omitempty"`
+	SyncToolVersion string `json:"syncToolVersion,omitempty"`
+}
+
+// SourceOptions fills in the source block of every rendered record
+type SourceOptions struct {
+	Instance    string           // Base URL of the Jira instance synced from
+	ToolVersion string           // Version of jira-beads-sync
+	Now         func() time.Time // Clock for LastSyncedAt, time.Now if nil
+}
+
+// block returns the source block of a record created from a Jira issue
+func (o *SourceOptions) block(metadata map[string]string) *Source {
+	if o == nil {
+		return nil
+	}
+	now := time.Now
+	if o.Now != nil {
+		now = o.Now
+	}
+	return &Source{
+		System:          SourceSystemJira,
+		Instance:        strings.TrimSuffix(o.Instance, "/"),
+		Key:             metadata["jiraKey"],
+		LastSyncedAt:    now().UTC().Format(time.RFC3339),
+		SyncToolVersion: o.ToolVersion,
+	}
+}
+
+// sourced is a record with a source block
+type sourced interface {
+	sourceBlock() **Source
+}
+
+func (i *BeadsIssue) sourceBlock() **Source { return &i.Source }
+
+func (e *BeadsEpic) sourceBlock() **Source { return &e.Source }
+
+// carrySource keeps the existing source block of updated records that are
+// otherwise unchanged and still come from the same issue
+func carrySource[T sourced](existing, updated []T, id func(T) string) {
+	byID := make(map[string]T, len(existing))
+	for _, record := range existing {
+		byID[id(record)] = record
+	}
+
+	for _, record := range updated {
+		old, ok := byID[id(record)]
+		if !ok {
+			continue
+		}
+		oldSource, newSource := *old.sourceBlock(), *record.sourceBlock()
+		if oldSource == nil || newSource == nil || oldSource.System != newSource.System ||
+			oldSource.Instance != newSource.Instance || oldSource.Key != newSource.Key {
+			continue
+		}
+
+		*record.sourceBlock() = oldSource
+		oldJSON, err1 := json.Marshal(old)
+		newJSON, err2 := json.Marshal(record)
+		if err1 != nil || err2 != nil || !bytes.Equal(oldJSON, newJSON) {
+			*record.sourceBlock() = newSource
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+func sourceTestRenderer(t *testing.T, dir string, now time.Time) Renderer {
+	t.Helper()
+	renderer, err := NewRendererWithOptions(dir, FormatJSONL, RendererOptions{Source: &SourceOptions{
+		Instance:    "https://example.atlassian.net/",
+		ToolVersion: "1.2.0",
+		Now:         func() time.Time { return now },
+	}})
+	if err != nil {
+		t.Fatalf("NewRendererWithOptions failed: %v", err)
+	}
+	return renderer
+}
+
+func TestRenderSourceBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	first := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	export := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First"), planTestIssue("proj-2", "Second")},
+		Epics:  []*pb.Epic{{Id: "proj-10", Name: "Epic", Metadata: &pb.Metadata{JiraKey: "PROJ-10"}}},
+	}
+	if err := sourceTestRenderer(t, tmpDir, first).RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	want := Source{
+		System:          SourceSystemJira,
+		Instance:        "https://example.atlassian.net",
+		Key:             "PROJ-1",
+		LastSyncedAt:    "2024-05-01T09:30:00Z",
+		SyncToolVersion: "1.2.0",
+	}
+	if issues[0].Source == nil || *issues[0].Source != want {
+		t.Errorf("Expected source %+v, got %+v", want, issues[0].Source)
+	}
+	epics, err := ReadEpics(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadEpics failed: %v", err)
+	}
+	if epics[0].Source == nil || epics[0].Source.Key != "PROJ-10" {
+		t.Errorf("Expected the epic to carry a source block, got %+v", epics[0].Source)
+	}
+
+	// A later sync only refreshes the records it changes
+	issuesFile := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	before, err := os.ReadFile(issuesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := first.Add(time.Hour)
+	renderer := sourceTestRenderer(t, tmpDir, later)
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	if after, _ := os.ReadFile(issuesFile); string(after) != string(before) {
+		t.Errorf("Expected an unchanged sync to leave the file alone, got:\n%s", after)
+	}
+
+	export.Issues[1].Title = "Second, renamed"
+	plan, err := renderer.PlanExport(export, false)
+	if err != nil {
+		t.Fatalf("PlanExport failed: %v", err)
+	}
+	if change := planChange(t, plan, "proj-1"); change.Kind != ChangeUnchanged {
+		t.Errorf("Expected proj-1 unchanged, got %s %v", change.Kind, change.Fields)
+	}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	issues, err = ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if got := issues[0].Source.LastSyncedAt; got != "2024-05-01T09:30:00Z" {
+		t.Errorf("Expected proj-1 to keep its sync time, got %s", got)
+	}
+	if got := issues[1].Source.LastSyncedAt; got != "2024-05-01T10:30:00Z" {
+		t.Errorf("Expected the renamed proj-2 to get the new sync time, got %s", got)
+	}
+}
+
+func TestRenderWithoutSourceBlock(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := NewJSONLRenderer(tmpDir).RenderExport(&pb.Export{Issues: []*pb.Issue{planTestIssue("proj-1", "First")}}); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	issues, err := ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if issues[0].Source != nil {
+		t.Errorf("Expected no source block without SourceOptions, got %+v", issues[0].Source)
+	}
+}
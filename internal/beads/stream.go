@@ -0,0 +1,147 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// DocumentKind tells the records of a stream apart
+type DocumentKind string
+
+const (
+	// DocumentEpic is an epic record
+	DocumentEpic DocumentKind = "epic"
+	// DocumentIssue is an issue record
+	DocumentIssue DocumentKind = "issue"
+)
+
+// Document describes a record a StreamRenderer is about to emit
+type Document struct {
+	Kind    DocumentKind
+	ID      string // Beads ID
+	JiraKey string // Empty for records not created from a Jira issue
+}
+
+// OpenFunc returns the writer a document is emitted to. Writers that also
+// implement io.Closer are closed once the document is written, so each
+// document can become its own message or HTTP request body.
+type OpenFunc func(doc Document) (io.Writer, error)
+
+// StreamRenderer emits each epic and issue of an export as a marshaled JSON
+// document to writers provided by the caller instead of files, so
+// embedders can ship synced data to message queues or HTTP sinks without
+// touching disk. Documents are the records the file renderers write, each
+// followed by a newline. Nothing is read back, so records opted out of
+// syncing and moved issues are not detected.
+type StreamRenderer struct {
+	open   OpenFunc
+	format Format
+	jsonl  *JSONLRenderer
+}
+
+// NewStreamRenderer creates a renderer emitting JSONL records through open
+func NewStreamRenderer(open OpenFunc) *StreamRenderer {
+	renderer, _ := NewStreamRendererWithOptions(open, FormatJSONL, RendererOptions{})
+	return renderer
+}
+
+// NewStreamRendererWithOptions creates a renderer emitting records of the
+// given format through open. FormatBDImport is rejected, since there is no
+// file to import, and the durability option is ignored.
+func NewStreamRendererWithOptions(open OpenFunc, format Format, opts RendererOptions) (*StreamRenderer, error) {
+	switch format {
+	case "":
+		format = FormatJSONL
+	case FormatJSONL, FormatBD:
+	default:
+		return nil, fmt.Errorf("unsupported stream format %q", format)
+	}
+
+	renderer := &StreamRenderer{open: open, format: format, jsonl: &JSONLRenderer{}}
+	if format == FormatJSONL {
+		renderer.jsonl.source = opts.Source
+	}
+	return renderer, nil
+}
+
+// WriterStream emits issues to one writer and epics to another, e.g. two
+// files or the same pipe for both. The writers are never closed.
+func WriterStream(issues, epics io.Writer) OpenFunc {
+	return func(doc Document) (io.Writer, error) {
+		if doc.Kind == DocumentEpic {
+			return nopCloser{epics}, nil
+		}
+		return nopCloser{issues}, nil
+	}
+}
+
+// nopCloser hides the Close method of a writer shared by documents
+type nopCloser struct {
+	io.Writer
+}
+
+// RenderExport emits the export's epics, then its issues
+func (r *StreamRenderer) RenderExport(export *pb.Export) error {
+	for _, epic := range export.Epics {
+		if err := r.WriteEpic(epic); err != nil {
+			return err
+		}
+	}
+	for _, issue := range export.Issues {
+		if err := r.WriteIssue(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteEpic emits a single epic
+func (r *StreamRenderer) WriteEpic(epic *pb.Epic) error {
+	doc := Document{Kind: DocumentEpic, ID: epic.Id, JiraKey: epic.GetMetadata().GetJiraKey()}
+	if r.format == FormatBD {
+		return r.emit(doc, r.bdRecord(&pb.Export{Epics: []*pb.Epic{epic}}))
+	}
+	return r.emit(doc, r.jsonl.epicToJSON(epic))
+}
+
+// WriteIssue emits a single issue
+func (r *StreamRenderer) WriteIssue(issue *pb.Issue) error {
+	doc := Document{Kind: DocumentIssue, ID: issue.Id, JiraKey: issue.GetMetadata().GetJiraKey()}
+	if r.format == FormatBD {
+		return r.emit(doc, r.bdRecord(&pb.Export{Issues: []*pb.Issue{issue}}))
+	}
+	return r.emit(doc, r.jsonl.issueToJSON(issue))
+}
+
+// bdRecord converts an export holding a single record to bd's format
+func (r *StreamRenderer) bdRecord(export *pb.Export) *BDIssue {
+	return (&BDRenderer{}).records(export)[0]
+}
+
+// emit marshals a record and writes it to the document's writer
+func (r *StreamRenderer) emit(doc Document, record any) (err error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", doc.Kind, doc.ID, err)
+	}
+
+	w, err := r.open(doc)
+	if err != nil {
+		return fmt.Errorf("failed to open stream for %s %s: %w", doc.Kind, doc.ID, err)
+	}
+	if closer, ok := w.(io.Closer); ok {
+		defer func() {
+			if closeErr := closer.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close stream for %s %s: %w", doc.Kind, doc.ID, closeErr)
+			}
+		}()
+	}
+
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s %s: %w", doc.Kind, doc.ID, err)
+	}
+	return nil
+}
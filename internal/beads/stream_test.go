@@ -0,0 +1,132 @@
+package beads
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// recordingSink collects one buffer per document and tracks closes
+type recordingSink struct {
+	docs   []Document
+	bodies []*bytes.Buffer
+	closed int
+}
+
+func (s *recordingSink) open(doc Document) (io.Writer, error) {
+	s.docs = append(s.docs, doc)
+	body := &bytes.Buffer{}
+	s.bodies = append(s.bodies, body)
+	return &closingWriter{Writer: body, closed: &s.closed}, nil
+}
+
+type closingWriter struct {
+	io.Writer
+	closed *int
+}
+
+func (w *closingWriter) Close() error {
+	*w.closed++
+	return nil
+}
+
+func TestStreamRendererMatchesFiles(t *testing.T) {
+	export := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First"), planTestIssue("proj-2", "Second")},
+		Epics:  []*pb.Epic{{Id: "proj-10", Name: "Epic", Status: pb.Status_STATUS_OPEN, Metadata: &pb.Metadata{JiraKey: "PROJ-10"}}},
+	}
+
+	var issues, epics bytes.Buffer
+	if err := NewStreamRenderer(WriterStream(&issues, &epics)).RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	if err := NewJSONLRenderer(tmpDir).RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+	for name, got := range map[string]string{"issues.jsonl": issues.String(), "epics.jsonl": epics.String()} {
+		want, err := os.ReadFile(tmpDir + "/.beads/" + name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if got != string(want) {
+			t.Errorf("Expected the stream to match %s:\n%s\ngot:\n%s", name, want, got)
+		}
+	}
+}
+
+func TestStreamRendererDocuments(t *testing.T) {
+	sink := &recordingSink{}
+	renderer := NewStreamRenderer(sink.open)
+	export := &pb.Export{
+		Issues: []*pb.Issue{planTestIssue("proj-1", "First")},
+		Epics:  []*pb.Epic{{Id: "proj-10", Name: "Epic", Metadata: &pb.Metadata{JiraKey: "PROJ-10"}}},
+	}
+	if err := renderer.RenderExport(export); err != nil {
+		t.Fatalf("RenderExport failed: %v", err)
+	}
+
+	want := []Document{
+		{Kind: DocumentEpic, ID: "proj-10", JiraKey: "PROJ-10"},
+		{Kind: DocumentIssue, ID: "proj-1", JiraKey: "PROJ-1"},
+	}
+	if len(sink.docs) != len(want) {
+		t.Fatalf("Expected %d documents, got %v", len(want), sink.docs)
+	}
+	for i := range want {
+		if sink.docs[i] != want[i] {
+			t.Errorf("Expected document %v, got %v", want[i], sink.docs[i])
+		}
+	}
+	if sink.closed != 2 {
+		t.Errorf("Expected each document's writer to be closed, got %d closes", sink.closed)
+	}
+
+	var issue BeadsIssue
+	if err := json.Unmarshal(sink.bodies[1].Bytes(), &issue); err != nil {
+		t.Fatalf("Expected an issue document, got %q: %v", sink.bodies[1], err)
+	}
+	if issue.ID != "proj-1" || issue.Title != "First" {
+		t.Errorf("Unexpected issue document %+v", issue)
+	}
+}
+
+func TestStreamRendererBDFormat(t *testing.T) {
+	sink := &recordingSink{}
+	renderer, err := NewStreamRendererWithOptions(sink.open, FormatBD, RendererOptions{})
+	if err != nil {
+		t.Fatalf("NewStreamRendererWithOptions failed: %v", err)
+	}
+	if err := renderer.WriteEpic(&pb.Epic{Id: "proj-10", Name: "Epic"}); err != nil {
+		t.Fatalf("WriteEpic failed: %v", err)
+	}
+
+	var record BDIssue
+	if err := json.Unmarshal(sink.bodies[0].Bytes(), &record); err != nil {
+		t.Fatalf("Expected a bd record, got %q: %v", sink.bodies[0], err)
+	}
+	if record.ID != "proj-10" || record.IssueType != "epic" {
+		t.Errorf("Unexpected bd record %+v", record)
+	}
+
+	if _, err := NewStreamRendererWithOptions(sink.open, FormatBDImport, RendererOptions{}); err == nil {
+		t.Error("Expected bd-import to be rejected for streams")
+	}
+}
+
+func TestStreamRendererErrors(t *testing.T) {
+	failing := func(doc Document) (io.Writer, error) {
+		return nil, errors.New("queue unavailable")
+	}
+	err := NewStreamRenderer(failing).WriteIssue(planTestIssue("proj-1", "First"))
+	if err == nil || !strings.Contains(err.Error(), "queue unavailable") {
+		t.Errorf("Expected the open error, got %v", err)
+	}
+}
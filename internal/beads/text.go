@@ -0,0 +1,70 @@
+package beads
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// textNormalizer converts CRLF and lone CR line endings to LF and drops
+// byte order marks
+var textNormalizer = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\uFEFF", "")
+
+// normalizeText makes Jira text safe to commit: valid UTF-8 (invalid
+// sequences become U+FFFD), LF line endings and no byte order marks, so
+// content written on Windows doesn't mix line endings in the repository
+func normalizeText(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "\uFFFD")
+	}
+	if strings.ContainsAny(s, "\r\uFEFF") {
+		s = textNormalizer.Replace(s)
+	}
+	return s
+}
+
+// normalizeStrings returns a normalized copy of values, leaving the source
+// slice untouched
+func normalizeStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	normalized := make([]string, len(values))
+	for i, value := range values {
+		normalized[i] = normalizeText(value)
+	}
+	return normalized
+}
+
+// normalizeMetadata normalizes metadata values in place
+func normalizeMetadata(metadata map[string]string) {
+	for key, value := range metadata {
+		metadata[key] = normalizeText(value)
+	}
+}
+
+// normalize normalizes every text field of a rendered issue
+func (i *BeadsIssue) normalize() {
+	i.Title = normalizeText(i.Title)
+	i.Description = normalizeText(i.Description)
+	i.Assignee = normalizeText(i.Assignee)
+	i.Labels = normalizeStrings(i.Labels)
+	i.Sprint = normalizeText(i.Sprint)
+	i.FixVersions = normalizeStrings(i.FixVersions)
+	i.Components = normalizeStrings(i.Components)
+	normalizeMetadata(i.Metadata)
+	for n := range i.Comments {
+		i.Comments[n].Author = normalizeText(i.Comments[n].Author)
+		i.Comments[n].Body = normalizeText(i.Comments[n].Body)
+	}
+	for n := range i.Attachments {
+		i.Attachments[n].Filename = normalizeText(i.Attachments[n].Filename)
+		i.Attachments[n].Author = normalizeText(i.Attachments[n].Author)
+	}
+}
+
+// normalize normalizes every text field of a rendered epic
+func (e *BeadsEpic) normalize() {
+	e.Name = normalizeText(e.Name)
+	e.Description = normalizeText(e.Description)
+	normalizeMetadata(e.Metadata)
+}
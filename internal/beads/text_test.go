@@ -0,0 +1,62 @@
+package beads
+
+import (
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "line one\nline two", "line one\nline two"},
+		{"crlf", "line one\r\nline two\r\n", "line one\nline two\n"},
+		{"lone cr", "line one\rline two", "line one\nline two"},
+		{"mixed", "a\r\nb\nc\rd", "a\nb\nc\nd"},
+		{"bom", "\uFEFFTitle", "Title"},
+		{"invalid utf-8", "caf\xe9 \xff\xfe", "caf\uFFFD \uFFFD"},
+		{"multibyte kept", "naïve – 日本", "naïve – 日本"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeText(tt.in); got != tt.want {
+				t.Errorf("normalizeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueToJSONNormalizesText(t *testing.T) {
+	labels := []string{"win\r\nlabel"}
+	issue := &pb.Issue{
+		Id:          "proj-1",
+		Title:       "\uFEFFImported title",
+		Description: "Step 1\r\nStep 2\r\n",
+		Labels:      labels,
+		Metadata:    &pb.Metadata{JiraKey: "PROJ-1", Custom: map[string]string{"team": "Ops\r\n"}},
+		Comments:    []*pb.Comment{{Author: "jane", Body: "Looks good\r\nShip it"}},
+	}
+
+	got := NewJSONLRenderer("").issueToJSON(issue)
+	if got.Title != "Imported title" {
+		t.Errorf("Expected BOM stripped from title, got %q", got.Title)
+	}
+	if got.Description != "Step 1\nStep 2\n" {
+		t.Errorf("Expected LF line endings in description, got %q", got.Description)
+	}
+	if got.Labels[0] != "win\nlabel" || got.Metadata["team"] != "Ops\n" || got.Comments[0].Body != "Looks good\nShip it" {
+		t.Errorf("Expected labels, metadata and comments normalized, got %+v", got)
+	}
+	if labels[0] != "win\r\nlabel" || issue.Metadata.Custom["team"] != "Ops\r\n" {
+		t.Error("Expected the source issue to be left unchanged")
+	}
+
+	epic := NewJSONLRenderer("").epicToJSON(&pb.Epic{Id: "proj-2", Name: "Epic\r\n", Description: "\uFEFFGoals\r\n"})
+	if epic.Name != "Epic\n" || epic.Description != "Goals\n" {
+		t.Errorf("Expected epic text normalized, got %q and %q", epic.Name, epic.Description)
+	}
+}
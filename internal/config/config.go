@@ -3,14 +3,49 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/jira"
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds the configuration for jira-beads-sync
 type Config struct {
-	Jira JiraConfig `yaml:"jira"`
+	Jira      JiraConfig      `yaml:"jira"`
+	Converter ConverterConfig `yaml:"converter,omitempty"`
+	Routing   RoutingConfig   `yaml:"routing,omitempty"`
+	Push      PushConfig      `yaml:"push,omitempty"`
+	Team      TeamConfig      `yaml:"team,omitempty"`
+	Schedule  ScheduleConfig  `yaml:"schedule,omitempty"`
+	Serve     ServeConfig     `yaml:"serve,omitempty"`
+	Lock      LockConfig      `yaml:"lock,omitempty"`
+	State     StateConfig     `yaml:"state,omitempty"`
+	Integrity IntegrityConfig `yaml:"integrity,omitempty"`
+	Output    OutputConfig    `yaml:"output,omitempty"`
+	Display   DisplayConfig   `yaml:"display,omitempty"`
+	Report    ReportConfig    `yaml:"report,omitempty"`
+	Sync      SyncConfig      `yaml:"sync,omitempty"`
+
+	MappingFile string        `yaml:"mapping_file,omitempty"` // Defaults to mapping.yml next to this file
+	Mapping     MappingConfig `yaml:"-"`                      // Loaded from MappingFile
+	TeamsFile   string        `yaml:"teams_file,omitempty"`   // Defaults to teams.yml next to this file
+	Teams       TeamMapping   `yaml:"-"`                      // Loaded from TeamsFile
+}
+
+// TeamConfig identifies the team whose work presets focus on
+type TeamConfig struct {
+	Members []string `yaml:"members,omitempty"` // Jira usernames, emails or account IDs
+}
+
+// ServeConfig holds settings for the webhook server
+type ServeConfig struct {
+	WebhookSecret string `yaml:"webhook_secret,omitempty"` // Shared secret configured on the Jira webhook
 }
 
 // JiraConfig holds Jira-specific configuration
@@ -19,6 +54,170 @@ type JiraConfig struct {
 	Username   string `yaml:"username"`
 	APIToken   string `yaml:"api_token"`
 	AuthMethod string `yaml:"auth_method"` // "basic" or "bearer"
+
+	MaxConcurrency int `yaml:"max_concurrency,omitempty"` // Parallel issue fetches, 0 means the client default
+	MaxRetries     int `yaml:"max_retries,omitempty"`     // Retries on 429/5xx, 0 means the client default, -1 disables
+
+	FetchOrder string `yaml:"fetch_order,omitempty"` // updated or last_viewed fetches the most active issues first in incremental syncs, the query's order if empty
+	APIVersion string `yaml:"api_version,omitempty"` // REST API version, 2 if empty; falls back to 2 where 3 is missing
+
+	StoreProperties bool `yaml:"store_properties,omitempty"` // Keep sync state in Jira issue properties, which edits the issues
+}
+
+// ConverterConfig holds optional settings for the Jira to beads conversion
+type ConverterConfig struct {
+	PriorityAging          PriorityAgingConfig `yaml:"priority_aging,omitempty"`
+	MaxDescriptionLength   int                 `yaml:"max_description_length,omitempty"` // 0 means unlimited
+	ComputedFields         bool                `yaml:"computed_fields,omitempty"`        // Add ageDays/cycleTimeDays metadata
+	ComponentOwners        bool                `yaml:"component_owners,omitempty"`       // Set owner metadata from component leads
+	Watchers               bool                `yaml:"watchers,omitempty"`               // Set watchers metadata from issue watchers
+	TitleRules             []TitleRuleConfig   `yaml:"title_rules,omitempty"`
+	Workers                int                 `yaml:"workers,omitempty"`                  // Conversion goroutines, 0 means one per CPU
+	MaxComments            int                 `yaml:"max_comments,omitempty"`             // Most recent comments kept per issue, 0 means all
+	SkipAttachments        bool                `yaml:"skip_attachments,omitempty"`         // Leave attachment metadata out of issues
+	PreserveRawDescription bool                `yaml:"preserve_raw_description,omitempty"` // Keep unconverted wiki/ADF descriptions in metadata
+	MaxChainDepth          int                 `yaml:"max_chain_depth,omitempty"`          // Deepest parent chain accepted, 0 means the converter default
+	Clones                 CloneConfig         `yaml:"clones,omitempty"`
+	PlanningLabels         PlanningLabelConfig `yaml:"planning_labels,omitempty"`
+	ComponentSeparator     string              `yaml:"component_separator,omitempty"` // Splits "Parent/Child" component names into a hierarchy, e.g. "/"
+	EpicName               EpicNameConfig      `yaml:"epic_name,omitempty"`
+	OptOutLabel            string              `yaml:"opt_out_label,omitempty"` // Jira label that keeps an issue out of beads, no-beads-sync by default
+	EpicLabels             []string            `yaml:"epic_labels,omitempty"`   // Epic labels copied to the epic's issues, * matches any text
+	ReleaseEpics           []string            `yaml:"release_epics,omitempty"` // Projects whose fix versions become epics, * for all
+}
+
+// EpicNameConfig chooses whether an epic's name comes from its summary or
+// from the custom field set as epic_name_field in the mapping (e.g. the
+// "Epic Name" field of classic projects), and where the other value goes.
+type EpicNameConfig struct {
+	Source string `yaml:"source,omitempty"` // summary (default) or field
+	Other  string `yaml:"other,omitempty"`  // metadata (default) or description
+}
+
+// EpicNameSources lists the supported epic name sources
+var EpicNameSources = []string{"summary", "field"}
+
+// EpicNameTargets lists where the value not used as the epic name can go
+var EpicNameTargets = []string{"metadata", "description"}
+
+// PlanningLabelConfig adds labels for an issue's sprint, fix versions and
+// components, e.g. "sprint:Sprint 3", so bd can filter on them. The values
+// are always kept in the issue's sprint, fixVersions and components fields.
+type PlanningLabelConfig struct {
+	Sprint     bool `yaml:"sprint,omitempty"`      // Label the current sprint as sprint:<name>
+	FixVersion bool `yaml:"fix_version,omitempty"` // Label each fix version as release:<name>
+	Component  bool `yaml:"component,omitempty"`   // Label each component as component:<name>
+}
+
+// CloneConfig controls what cloned issues inherit from the issue they were
+// cloned from. The clone source is always recorded in clonedFrom metadata.
+type CloneConfig struct {
+	CopyLabels bool `yaml:"copy_labels,omitempty"` // Add the source's labels to the clone
+	CopyEpic   bool `yaml:"copy_epic,omitempty"`   // Put clones without an epic into the source's epic
+}
+
+// TitleRuleConfig is a regex replacement applied to Jira summaries.
+// An empty replacement strips the match.
+type TitleRuleConfig struct {
+	Pattern string `yaml:"pattern"`
+	Replace string `yaml:"replace,omitempty"`
+}
+
+// PriorityAgingConfig bumps the local priority of long-open issues.
+// Each threshold an issue's age exceeds raises its priority by one level.
+type PriorityAgingConfig struct {
+	ThresholdsDays []int `yaml:"thresholds_days,omitempty"`
+}
+
+// Thresholds returns the aging thresholds as durations
+func (p PriorityAgingConfig) Thresholds() []time.Duration {
+	thresholds := make([]time.Duration, 0, len(p.ThresholdsDays))
+	for _, days := range p.ThresholdsDays {
+		thresholds = append(thresholds, time.Duration(days)*24*time.Hour)
+	}
+	return thresholds
+}
+
+// RoutingConfig routes converted issues to different beads repositories
+// based on their Jira component
+type RoutingConfig struct {
+	DefaultRepo string        `yaml:"default_repo,omitempty"` // Repo for unrouted issues (defaults to current directory)
+	Routes      []RouteConfig `yaml:"routes,omitempty"`
+}
+
+// RouteConfig maps a Jira component to a beads repository path
+type RouteConfig struct {
+	Component string `yaml:"component"`
+	Repo      string `yaml:"repo"`
+}
+
+// PushFields lists the Jira fields push mode knows how to write back
+var PushFields = []string{"summary", "description", "status", "priority", "assignee", "labels", "issuelinks", "participants"}
+
+// OutputConfig selects how issues are written to .beads/
+type OutputConfig struct {
+	Format string `yaml:"format,omitempty"` // jsonl (default), bd or bd-import
+	Fsync  string `yaml:"fsync,omitempty"`  // always, batch (default) or none
+
+	// Warn when a sync adds more records or megabytes than this; zero uses
+	// the default and a negative value disables the check
+	MaxNewRecords int `yaml:"max_new_records,omitempty"`
+	MaxGrowthMB   int `yaml:"max_growth_mb,omitempty"`
+}
+
+// BeadsFormat returns the configured output format
+func (o OutputConfig) BeadsFormat() beads.Format {
+	if o.Format == "" {
+		return beads.FormatJSONL
+	}
+	return beads.Format(o.Format)
+}
+
+// RendererOptions returns the configured write settings
+func (o OutputConfig) RendererOptions() beads.RendererOptions {
+	return beads.RendererOptions{Durability: beads.Durability(o.Fsync)}
+}
+
+// GrowthLimits returns the configured repository growth limits
+func (o OutputConfig) GrowthLimits() beads.GrowthLimits {
+	limits := beads.GrowthLimits{Records: beads.DefaultMaxNewRecords, Bytes: beads.DefaultMaxGrowthMB << 20}
+	if o.MaxNewRecords != 0 {
+		limits.Records = max(o.MaxNewRecords, 0)
+	}
+	if o.MaxGrowthMB != 0 {
+		limits.Bytes = int64(max(o.MaxGrowthMB, 0)) << 20
+	}
+	return limits
+}
+
+// ReportConfig sets when the coordination report flags an epic as
+// coordination-heavy: when its issues depend on more issues in other
+// epics, or in other projects, than the threshold. Zero uses the default
+// and a negative value disables the check.
+type ReportConfig struct {
+	CrossEpicThreshold    int `yaml:"cross_epic_threshold,omitempty"`
+	CrossProjectThreshold int `yaml:"cross_project_threshold,omitempty"`
+}
+
+// Thresholds returns the configured coordination thresholds
+func (r ReportConfig) Thresholds() beads.CoordinationThresholds {
+	return beads.CoordinationThresholds{CrossEpic: r.CrossEpicThreshold, CrossProject: r.CrossProjectThreshold}
+}
+
+// PushConfig controls which Jira fields push mode may modify.
+// Nothing is writable by default; each field must be opted in explicitly.
+type PushConfig struct {
+	AllowedFields []string `yaml:"allowed_fields,omitempty"`
+}
+
+// Allows reports whether push mode may modify the given Jira field
+func (p PushConfig) Allows(field string) bool {
+	for _, allowed := range p.AllowedFields {
+		if strings.EqualFold(allowed, field) {
+			return true
+		}
+	}
+	return false
 }
 
 // configPathFunc is a variable that can be overridden in tests
@@ -49,13 +248,77 @@ func Load() (*Config, error) {
 	if authMethod := os.Getenv("JIRA_AUTH_METHOD"); authMethod != "" {
 		config.Jira.AuthMethod = authMethod
 	}
+	if secret := os.Getenv("JIRA_WEBHOOK_SECRET"); secret != "" {
+		config.Serve.WebhookSecret = secret
+	}
+	if token := os.Getenv("JIRA_BEADS_LOCK_TOKEN"); token != "" {
+		config.Lock.Token = token
+	}
+	if token := os.Getenv("JIRA_BEADS_STATE_TOKEN"); token != "" {
+		config.State.Token = token
+	}
+	if keyID := os.Getenv("AWS_ACCESS_KEY_ID"); keyID != "" {
+		config.State.AccessKeyID = keyID
+	}
+	if secret := os.Getenv("AWS_SECRET_ACCESS_KEY"); secret != "" {
+		config.State.SecretAccessKey = secret
+	}
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		config.State.SessionToken = token
+	}
+	if key := os.Getenv("JIRA_BEADS_SIGNING_KEY"); key != "" {
+		config.Integrity.SigningKey = key
+	}
+
+	if err := finishLoad(configPath, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadFile loads the configuration in path, ignoring environment variables.
+// The multi-tenant daemon uses it so each tenant keeps its own credentials.
+func LoadFile(path string) (*Config, error) {
+	config := &Config{}
+	if err := loadFromFile(path, config); err != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", err)
+	}
+	if err := finishLoad(path, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
 
+// finishLoad applies defaults and loads the mapping and teams files of the
+// config in configPath
+func finishLoad(configPath string, config *Config) error {
 	// Default to basic auth if not specified
 	if config.Jira.AuthMethod == "" {
 		config.Jira.AuthMethod = "basic"
 	}
 
-	return config, nil
+	// Load field and status mappings; without a mapping file the built-in
+	// defaults apply
+	mappingFile, explicit := mappingPath(configPath, config.MappingFile)
+	if _, err := os.Stat(mappingFile); err == nil || explicit {
+		mapping, err := LoadMapping(mappingFile)
+		if err != nil {
+			return fmt.Errorf("failed to load mapping file: %w", err)
+		}
+		config.Mapping = mapping
+	}
+
+	// Load the user → team mapping used for team labels
+	teamsFile, explicit := siblingPath(configPath, config.TeamsFile, TeamsFileName)
+	if _, err := os.Stat(teamsFile); err == nil || explicit {
+		teams, err := LoadTeams(teamsFile)
+		if err != nil {
+			return fmt.Errorf("failed to load teams file: %w", err)
+		}
+		config.Teams = teams
+	}
+
+	return nil
 }
 
 // Validate checks if the configuration is valid
@@ -87,9 +350,135 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Jira.MaxConcurrency < 0 {
+		return fmt.Errorf("jira max concurrency must not be negative, got: %d", c.Jira.MaxConcurrency)
+	}
+	if c.Jira.MaxRetries < -1 {
+		return fmt.Errorf("jira max retries must be -1 (disabled) or more, got: %d", c.Jira.MaxRetries)
+	}
+	if c.Jira.FetchOrder != "" && !slices.Contains(jira.FetchOrders, jira.FetchOrder(c.Jira.FetchOrder)) {
+		return fmt.Errorf("jira fetch order %q is not supported, must be updated or last_viewed", c.Jira.FetchOrder)
+	}
+	if c.Jira.APIVersion != "" && !slices.Contains(jira.APIVersions, c.Jira.APIVersion) {
+		return fmt.Errorf("jira API version %q is not supported, must be one of: %s", c.Jira.APIVersion, strings.Join(jira.APIVersions, ", "))
+	}
+
+	for _, days := range c.Converter.PriorityAging.ThresholdsDays {
+		if days <= 0 {
+			return fmt.Errorf("priority aging thresholds must be positive, got: %d", days)
+		}
+	}
+
+	if c.Converter.MaxDescriptionLength < 0 {
+		return fmt.Errorf("max description length must not be negative, got: %d", c.Converter.MaxDescriptionLength)
+	}
+
+	if c.Converter.Workers < 0 {
+		return fmt.Errorf("converter workers must not be negative, got: %d", c.Converter.Workers)
+	}
+
+	if c.Converter.MaxComments < 0 {
+		return fmt.Errorf("max comments must not be negative, got: %d", c.Converter.MaxComments)
+	}
+
+	if c.Converter.MaxChainDepth < 0 {
+		return fmt.Errorf("max chain depth must not be negative, got: %d", c.Converter.MaxChainDepth)
+	}
+
+	for i, rule := range c.Converter.TitleRules {
+		if rule.Pattern == "" {
+			return fmt.Errorf("title rule %d must set a pattern", i+1)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("title rule %d has an invalid pattern: %w", i+1, err)
+		}
+	}
+
+	if _, err := c.Schedule.Schedule(); err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	if _, err := c.Lock.Locker(); err != nil {
+		return fmt.Errorf("invalid lock: %w", err)
+	}
+	if _, err := c.Lock.WaitTimeout(); err != nil {
+		return fmt.Errorf("invalid lock: %w", err)
+	}
+	if _, err := c.State.Store(""); err != nil {
+		return fmt.Errorf("invalid state store: %w", err)
+	}
+	if _, err := c.Display.Dates(); err != nil {
+		return fmt.Errorf("invalid display settings: %w", err)
+	}
+
+	if !slices.Contains(beads.Formats, c.Output.BeadsFormat()) {
+		return fmt.Errorf("output format %q is not supported, must be jsonl, bd or bd-import", c.Output.Format)
+	}
+	if c.Output.Fsync != "" && !slices.Contains(beads.Durabilities, beads.Durability(c.Output.Fsync)) {
+		return fmt.Errorf("output fsync %q is not supported, must be always, batch or none", c.Output.Fsync)
+	}
+
+	if _, err := c.Integrity.Signer(); err != nil {
+		return fmt.Errorf("invalid integrity signing key: %w", err)
+	}
+
+	if err := c.Mapping.Validate(); err != nil {
+		return fmt.Errorf("invalid mapping: %w", err)
+	}
+	if c.Converter.EpicName.Source != "" && !slices.Contains(EpicNameSources, c.Converter.EpicName.Source) {
+		return fmt.Errorf("epic name source must be one of: %s, got: %s", strings.Join(EpicNameSources, ", "), c.Converter.EpicName.Source)
+	}
+	if c.Converter.EpicName.Other != "" && !slices.Contains(EpicNameTargets, c.Converter.EpicName.Other) {
+		return fmt.Errorf("epic name other must be one of: %s, got: %s", strings.Join(EpicNameTargets, ", "), c.Converter.EpicName.Other)
+	}
+	if c.Converter.EpicName.Source == "field" && c.Mapping.EpicNameField == "" {
+		return fmt.Errorf("epic name source field requires epic_name_field in the mapping file")
+	}
+	if c.Converter.ComponentSeparator != "" && strings.TrimSpace(c.Converter.ComponentSeparator) == "" {
+		return fmt.Errorf("component separator must not be blank")
+	}
+	for _, pattern := range c.Converter.EpicLabels {
+		if pattern == "" {
+			return fmt.Errorf("epic labels must not be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid epic label pattern %q: %w", pattern, err)
+		}
+	}
+	for _, project := range c.Converter.ReleaseEpics {
+		if strings.TrimSpace(project) == "" {
+			return fmt.Errorf("release epic projects must not be empty")
+		}
+	}
+	if err := c.Teams.Validate(); err != nil {
+		return fmt.Errorf("invalid teams file: %w", err)
+	}
+
+	for i, route := range c.Routing.Routes {
+		if route.Component == "" || route.Repo == "" {
+			return fmt.Errorf("routing rule %d must set both component and repo", i+1)
+		}
+	}
+
+	for _, field := range c.Push.AllowedFields {
+		if !isPushField(field) {
+			return fmt.Errorf("push allowed field %q is not supported, must be one of: %s", field, strings.Join(PushFields, ", "))
+		}
+	}
+
 	return nil
 }
 
+// isPushField reports whether field is one of the known PushFields
+func isPushField(field string) bool {
+	for _, known := range PushFields {
+		if strings.EqualFold(known, field) {
+			return true
+		}
+	}
+	return false
+}
+
 // Save saves the configuration to a file
 func (c *Config) Save() error {
 	configPath := configPathFunc()
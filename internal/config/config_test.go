@@ -3,7 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
 )
 
 func TestConfigValidate(t *testing.T) {
@@ -243,6 +247,41 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	}
 }
 
+func TestLoadConfigWebhookSecretFromEnv(t *testing.T) {
+	t.Setenv("JIRA_WEBHOOK_SECRET", "envsecret")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `jira:
+  base_url: https://file.jira.com
+  username: file@example.com
+  api_token: filetoken
+serve:
+  webhook_secret: filesecret
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+
+	configPathFunc = func() string {
+		return configPath
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Serve.WebhookSecret != "envsecret" {
+		t.Errorf("Expected env webhook secret 'envsecret', got '%s'", config.Serve.WebhookSecret)
+	}
+}
+
 func TestLoadConfigNoFile(t *testing.T) {
 	// Override configPathFunc to return non-existent file
 	originalConfigPathFunc := configPathFunc
@@ -547,3 +586,426 @@ func TestLoadFromFileNonExistent(t *testing.T) {
 		t.Error("Expected error for non-existent file, got nil")
 	}
 }
+
+func TestLoadConfigWithPriorityAging(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `jira:
+  base_url: https://jira.example.com
+  username: user@example.com
+  api_token: token123
+converter:
+  priority_aging:
+    thresholds_days: [30, 90]
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+
+	configPathFunc = func() string {
+		return configPath
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	thresholds := config.Converter.PriorityAging.Thresholds()
+	if len(thresholds) != 2 {
+		t.Fatalf("Expected 2 thresholds, got %d", len(thresholds))
+	}
+	if thresholds[0] != 30*24*time.Hour {
+		t.Errorf("Expected first threshold of 30 days, got %v", thresholds[0])
+	}
+	if thresholds[1] != 90*24*time.Hour {
+		t.Errorf("Expected second threshold of 90 days, got %v", thresholds[1])
+	}
+
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected config to be valid, got: %v", err)
+	}
+}
+
+func TestConfigValidateRejectsNonPositiveAgingThreshold(t *testing.T) {
+	config := &Config{
+		Jira: JiraConfig{
+			BaseURL:  "https://jira.example.com",
+			Username: "user@example.com",
+			APIToken: "token123",
+		},
+		Converter: ConverterConfig{
+			PriorityAging: PriorityAgingConfig{ThresholdsDays: []int{30, 0}},
+		},
+	}
+
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for non-positive aging threshold")
+	}
+}
+
+func TestConfigValidateRouting(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{
+		Jira: base,
+		Routing: RoutingConfig{
+			Routes: []RouteConfig{{Component: "Frontend", Repo: "../web"}},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid routing config, got: %v", err)
+	}
+
+	missingRepo := &Config{
+		Jira: base,
+		Routing: RoutingConfig{
+			Routes: []RouteConfig{{Component: "Frontend"}},
+		},
+	}
+	if err := missingRepo.Validate(); err == nil {
+		t.Error("Expected error for routing rule without repo")
+	}
+}
+
+func TestPushConfigAllows(t *testing.T) {
+	var empty PushConfig
+	if empty.Allows("description") {
+		t.Error("Expected no fields to be writable by default")
+	}
+
+	push := PushConfig{AllowedFields: []string{"status", "Labels"}}
+	if !push.Allows("status") {
+		t.Error("Expected status to be writable")
+	}
+	if !push.Allows("labels") {
+		t.Error("Expected field names to be case-insensitive")
+	}
+	if push.Allows("assignee") {
+		t.Error("Expected assignee not to be writable")
+	}
+}
+
+func TestConfigValidatePushAllowedFields(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{Jira: base, Push: PushConfig{AllowedFields: []string{"status", "priority"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid push config, got: %v", err)
+	}
+
+	unknown := &Config{Jira: base, Push: PushConfig{AllowedFields: []string{"reporter"}}}
+	if err := unknown.Validate(); err == nil {
+		t.Error("Expected error for unsupported push field")
+	}
+}
+
+func TestConfigValidateTitleRules(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{Jira: base, Converter: ConverterConfig{
+		TitleRules: []TitleRuleConfig{{Pattern: `^\[WIP\]\s*`}},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid title rules, got: %v", err)
+	}
+
+	invalid := &Config{Jira: base, Converter: ConverterConfig{
+		TitleRules: []TitleRuleConfig{{Pattern: `[unclosed`}},
+	}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected error for invalid title pattern")
+	}
+
+	empty := &Config{Jira: base, Converter: ConverterConfig{
+		TitleRules: []TitleRuleConfig{{Replace: "x"}},
+	}}
+	if err := empty.Validate(); err == nil {
+		t.Error("Expected error for title rule without pattern")
+	}
+}
+
+func TestConfigValidateEpicName(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{
+		Jira:      base,
+		Converter: ConverterConfig{EpicName: EpicNameConfig{Source: "field", Other: "description"}},
+		Mapping:   MappingConfig{EpicNameField: "customfield_10011"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid epic name settings, got: %v", err)
+	}
+
+	withoutField := &Config{Jira: base, Converter: ConverterConfig{EpicName: EpicNameConfig{Source: "field"}}}
+	if err := withoutField.Validate(); err == nil || !strings.Contains(err.Error(), "epic_name_field") {
+		t.Errorf("Expected error for field source without epic_name_field, got: %v", err)
+	}
+
+	unknownSource := &Config{Jira: base, Converter: ConverterConfig{EpicName: EpicNameConfig{Source: "title"}}}
+	if err := unknownSource.Validate(); err == nil {
+		t.Error("Expected error for unknown epic name source")
+	}
+
+	unknownOther := &Config{Jira: base, Converter: ConverterConfig{EpicName: EpicNameConfig{Other: "labels"}}}
+	if err := unknownOther.Validate(); err == nil {
+		t.Error("Expected error for unknown epic name target")
+	}
+}
+
+func TestConfigValidateEpicLabels(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{Jira: base, Converter: ConverterConfig{EpicLabels: []string{"quarter:*", "initiative"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid epic labels, got: %v", err)
+	}
+
+	for _, pattern := range []string{"", "quarter:["} {
+		invalid := &Config{Jira: base, Converter: ConverterConfig{EpicLabels: []string{pattern}}}
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Expected error for epic label pattern %q", pattern)
+		}
+	}
+}
+
+func TestConfigValidateReleaseEpics(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	valid := &Config{Jira: base, Converter: ConverterConfig{ReleaseEpics: []string{"MOBILE", "*"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid release epic projects, got: %v", err)
+	}
+	invalid := &Config{Jira: base, Converter: ConverterConfig{ReleaseEpics: []string{" "}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("Expected error for a blank release epic project")
+	}
+}
+
+func TestConfigValidateRejectsNegativeWorkers(t *testing.T) {
+	config := &Config{
+		Jira: JiraConfig{
+			BaseURL:  "https://jira.example.com",
+			Username: "user@example.com",
+			APIToken: "token123",
+		},
+		Converter: ConverterConfig{Workers: -1},
+	}
+
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for negative worker count")
+	}
+}
+
+func TestConfigValidateRejectsNegativeMaxComments(t *testing.T) {
+	config := &Config{
+		Jira: JiraConfig{
+			BaseURL:  "https://jira.example.com",
+			Username: "user@example.com",
+			APIToken: "token123",
+		},
+		Converter: ConverterConfig{MaxComments: -1},
+	}
+
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for negative max comments")
+	}
+}
+
+func TestConfigValidateClientLimits(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	tests := []struct {
+		name           string
+		maxConcurrency int
+		maxRetries     int
+		wantErr        bool
+	}{
+		{"defaults", 0, 0, false},
+		{"explicit limits", 8, 3, false},
+		{"retries disabled", 1, -1, false},
+		{"negative concurrency", -1, 0, true},
+		{"invalid retries", 0, -2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jiraConfig := base
+			jiraConfig.MaxConcurrency = tt.maxConcurrency
+			jiraConfig.MaxRetries = tt.maxRetries
+
+			err := (&Config{Jira: jiraConfig}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigValidateFetchOrder(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	for _, order := range []string{"", "updated", "last_viewed"} {
+		jiraConfig := base
+		jiraConfig.FetchOrder = order
+		if err := (&Config{Jira: jiraConfig}).Validate(); err != nil {
+			t.Errorf("Expected fetch order %q to be valid, got: %v", order, err)
+		}
+	}
+
+	jiraConfig := base
+	jiraConfig.FetchOrder = "priority"
+	if err := (&Config{Jira: jiraConfig}).Validate(); err == nil {
+		t.Error("Expected error for unknown fetch order")
+	}
+}
+
+func TestConfigValidateAPIVersion(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	for _, version := range []string{"", "2", "3"} {
+		jiraConfig := base
+		jiraConfig.APIVersion = version
+		if err := (&Config{Jira: jiraConfig}).Validate(); err != nil {
+			t.Errorf("Expected API version %q to be valid, got: %v", version, err)
+		}
+	}
+
+	jiraConfig := base
+	jiraConfig.APIVersion = "latest"
+	if err := (&Config{Jira: jiraConfig}).Validate(); err == nil {
+		t.Error("Expected error for unknown API version")
+	}
+}
+
+func TestConfigValidateOutputFormat(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	for _, format := range []string{"", "jsonl", "bd", "bd-import"} {
+		config := &Config{Jira: base, Output: OutputConfig{Format: format}}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected format %q to be valid, got: %v", format, err)
+		}
+	}
+
+	config := &Config{Jira: base, Output: OutputConfig{Format: "yaml"}}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unsupported output format")
+	}
+}
+
+func TestConfigValidateOutputFsync(t *testing.T) {
+	base := JiraConfig{
+		BaseURL:  "https://jira.example.com",
+		Username: "user@example.com",
+		APIToken: "token123",
+	}
+
+	for _, fsync := range []string{"", "always", "batch", "none"} {
+		config := &Config{Jira: base, Output: OutputConfig{Fsync: fsync}}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected fsync %q to be valid, got: %v", fsync, err)
+		}
+	}
+
+	config := &Config{Jira: base, Output: OutputConfig{Fsync: "sometimes"}}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unsupported fsync mode")
+	}
+}
+
+func TestLoadFileIgnoresEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "tenant.yml")
+	configContent := `jira:
+  base_url: https://tenant.jira.com
+  username: tenant@example.com
+  api_token: tenanttoken
+serve:
+  webhook_secret: tenant-secret
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	mappingContent := "custom_fields:\n  customfield_10016: storyPoints\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, MappingFileName), []byte(mappingContent), 0600); err != nil {
+		t.Fatalf("Failed to create test mapping file: %v", err)
+	}
+
+	t.Setenv("JIRA_BASE_URL", "https://env.jira.com")
+	t.Setenv("JIRA_WEBHOOK_SECRET", "env-secret")
+
+	config, err := LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.Jira.BaseURL != "https://tenant.jira.com" {
+		t.Errorf("Expected base URL from the file, got '%s'", config.Jira.BaseURL)
+	}
+	if config.Serve.WebhookSecret != "tenant-secret" {
+		t.Errorf("Expected webhook secret from the file, got '%s'", config.Serve.WebhookSecret)
+	}
+	if config.Jira.AuthMethod != "basic" {
+		t.Errorf("Expected default auth method 'basic', got '%s'", config.Jira.AuthMethod)
+	}
+	if config.Mapping.CustomFields["customfield_10016"] != "storyPoints" {
+		t.Errorf("Expected mapping file next to the config to be loaded, got %v", config.Mapping.CustomFields)
+	}
+}
+
+func TestOutputGrowthLimits(t *testing.T) {
+	defaults := OutputConfig{}.GrowthLimits()
+	if defaults.Records != beads.DefaultMaxNewRecords || defaults.Bytes != beads.DefaultMaxGrowthMB<<20 {
+		t.Errorf("Expected the default limits, got %+v", defaults)
+	}
+
+	limits := OutputConfig{MaxNewRecords: 500, MaxGrowthMB: -1}.GrowthLimits()
+	if limits.Records != 500 || limits.Bytes != 0 {
+		t.Errorf("Expected 500 records and no size limit, got %+v", limits)
+	}
+}
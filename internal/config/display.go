@@ -0,0 +1,15 @@
+package config
+
+import "github.com/conallob/jira-beads-sync/internal/datefmt"
+
+// DisplayConfig controls how dates are shown in reports and summaries.
+// Files written for tools, such as the beads files, keep RFC 3339.
+type DisplayConfig struct {
+	Locale     string `yaml:"locale,omitempty"`      // e.g. de-DE; taken from LC_ALL, LC_TIME or LANG if empty
+	DateFormat string `yaml:"date_format,omitempty"` // iso, rfc3339 or a strftime pattern; the locale's default if empty
+}
+
+// Dates builds the date formatter for human-facing output
+func (d DisplayConfig) Dates() (*datefmt.Formatter, error) {
+	return datefmt.New(d.Locale, d.DateFormat)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDisplayConfigDates(t *testing.T) {
+	dates, err := DisplayConfig{Locale: "de-DE", DateFormat: "%-d. %B %Y"}.Dates()
+	if err != nil {
+		t.Fatalf("Dates failed: %v", err)
+	}
+	if got := dates.Format(time.Date(2024, 3, 5, 12, 0, 0, 0, time.Local)); got != "5. März 2024" {
+		t.Errorf("Expected a German date, got %q", got)
+	}
+
+	config := &Config{
+		Jira:    JiraConfig{BaseURL: "https://jira.example.com", Username: "user", APIToken: "token"},
+		Display: DisplayConfig{Locale: "klingon"},
+	}
+	if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "invalid display settings") {
+		t.Errorf("Expected an unsupported locale to fail validation, got: %v", err)
+	}
+}
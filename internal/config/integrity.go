@@ -0,0 +1,39 @@
+package config
+
+import (
+	"crypto/ed25519"
+
+	"github.com/conallob/jira-beads-sync/internal/integrity"
+)
+
+// IntegrityConfig controls the manifest of file hashes written to .beads/
+// after each sync. The manifest is written unless disabled and is signed
+// when a signing key is set.
+type IntegrityConfig struct {
+	Disabled   bool   `yaml:"disabled,omitempty"`    // Don't write .beads/manifest.json
+	SigningKey string `yaml:"signing_key,omitempty"` // PEM Ed25519 private key that signs the manifest
+	PublicKey  string `yaml:"public_key,omitempty"`  // PEM Ed25519 public key verify-integrity checks the signature with
+}
+
+// Signer loads the manifest signing key, or returns nil when the manifest
+// isn't signed
+func (i IntegrityConfig) Signer() (ed25519.PrivateKey, error) {
+	if i.SigningKey == "" {
+		return nil, nil
+	}
+	return integrity.LoadPrivateKey(i.SigningKey)
+}
+
+// Verifier loads the public key signatures are checked with, deriving it
+// from the signing key when only that is set. It returns nil when neither
+// is configured.
+func (i IntegrityConfig) Verifier() (ed25519.PublicKey, error) {
+	if i.PublicKey != "" {
+		return integrity.LoadPublicKey(i.PublicKey)
+	}
+	key, err := i.Signer()
+	if err != nil || key == nil {
+		return nil, err
+	}
+	return key.Public().(ed25519.PublicKey), nil
+}
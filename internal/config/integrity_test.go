@@ -0,0 +1,48 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIntegrityConfigKeys(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "signing.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	unsigned := IntegrityConfig{}
+	if key, err := unsigned.Signer(); err != nil || key != nil {
+		t.Errorf("Expected no signer without a key, got %v, %v", key, err)
+	}
+	if key, err := unsigned.Verifier(); err != nil || key != nil {
+		t.Errorf("Expected no verifier without a key, got %v, %v", key, err)
+	}
+
+	// The public key is derived from the signing key when not set
+	signed := IntegrityConfig{SigningKey: keyFile}
+	verifier, err := signed.Verifier()
+	if err != nil {
+		t.Fatalf("Verifier failed: %v", err)
+	}
+	if !verifier.Equal(publicKey) {
+		t.Error("Expected the verifier to match the signing key")
+	}
+
+	missing := IntegrityConfig{SigningKey: filepath.Join(t.TempDir(), "missing.pem")}
+	if _, err := missing.Signer(); err == nil {
+		t.Error("Expected error for a missing key file, got nil")
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/lock"
+)
+
+// DefaultLockWait is how long a sync waits for another runner's lock
+const DefaultLockWait = 10 * time.Minute
+
+// LockConfig selects a distributed lock so that only one runner syncs a
+// repository at a time. Without a backend no lock is taken.
+type LockConfig struct {
+	Backend string `yaml:"backend,omitempty"` // "file" or "http"
+	Path    string `yaml:"path,omitempty"`    // Lock file on shared storage, e.g. an NFS mount (file)
+	URL     string `yaml:"url,omitempty"`     // Lock service endpoint (http)
+	Token   string `yaml:"token,omitempty"`   // Bearer token for the lock service (http)
+	TTL     string `yaml:"ttl,omitempty"`     // Lease duration, e.g. 2m
+	Wait    string `yaml:"wait,omitempty"`    // How long to wait for the lock, e.g. 10m; 0 fails at once
+}
+
+// Locker builds the configured locker, or returns nil when locking is
+// disabled
+func (l LockConfig) Locker() (*lock.Locker, error) {
+	opts := lock.Options{}
+	if l.TTL != "" {
+		ttl, err := time.ParseDuration(l.TTL)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid lock ttl %q: must be a positive duration such as 2m", l.TTL)
+		}
+		opts.TTL = ttl
+	}
+
+	switch l.Backend {
+	case "":
+		return nil, nil
+	case "file":
+		if l.Path == "" {
+			return nil, fmt.Errorf("the file lock backend requires a path")
+		}
+		return lock.NewFileLocker(l.Path, opts), nil
+	case "http":
+		u, err := url.Parse(l.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("the http lock backend requires an http(s) url, got %q", l.URL)
+		}
+		return lock.NewHTTPLocker(l.URL, l.Token, opts), nil
+	default:
+		return nil, fmt.Errorf("unknown lock backend %q, must be file or http", l.Backend)
+	}
+}
+
+// InRepo returns the settings with a relative lock file path resolved
+// against the repository in dir, as it is for a sync run in dir. Processes
+// syncing several repositories use it so their locks stay apart.
+func (l LockConfig) InRepo(dir string) LockConfig {
+	if l.Path != "" && !filepath.IsAbs(l.Path) {
+		l.Path = filepath.Join(dir, l.Path)
+	}
+	return l
+}
+
+// WaitTimeout returns how long to wait for a lock held by another runner
+func (l LockConfig) WaitTimeout() (time.Duration, error) {
+	if l.Wait == "" {
+		return DefaultLockWait, nil
+	}
+	wait, err := time.ParseDuration(l.Wait)
+	if err != nil || wait < 0 {
+		return 0, fmt.Errorf("invalid lock wait %q: must be a duration such as 10m", l.Wait)
+	}
+	return wait, nil
+}
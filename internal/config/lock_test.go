@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockConfigLocker(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  LockConfig
+		enabled bool
+		wantErr bool
+	}{
+		{name: "disabled", config: LockConfig{}},
+		{name: "file", config: LockConfig{Backend: "file", Path: "/mnt/shared/sync.lock", TTL: "90s"}, enabled: true},
+		{name: "http", config: LockConfig{Backend: "http", URL: "https://locks.example.com/repo"}, enabled: true},
+		{name: "file without path", config: LockConfig{Backend: "file"}, wantErr: true},
+		{name: "http without url", config: LockConfig{Backend: "http", URL: "locks.example.com"}, wantErr: true},
+		{name: "unknown backend", config: LockConfig{Backend: "redis"}, wantErr: true},
+		{name: "invalid ttl", config: LockConfig{Backend: "file", Path: "x.lock", TTL: "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locker, err := tt.config.Locker()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if (locker != nil) != tt.enabled {
+				t.Errorf("Expected locker enabled %v, got %v", tt.enabled, locker != nil)
+			}
+		})
+	}
+}
+
+func TestLockConfigWaitTimeout(t *testing.T) {
+	tests := []struct {
+		wait     string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"", DefaultLockWait, false},
+		{"0", 0, false},
+		{"30s", 30 * time.Second, false},
+		{"-1m", 0, true},
+		{"forever", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := LockConfig{Wait: tt.wait}.WaitTimeout()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("WaitTimeout(%q): expected error %v, got %v", tt.wait, tt.wantErr, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("WaitTimeout(%q): expected %v, got %v", tt.wait, tt.expected, got)
+		}
+	}
+}
+
+func TestLockConfigInRepo(t *testing.T) {
+	relative := LockConfig{Backend: "file", Path: ".beads/sync.lock"}
+	if got := relative.InRepo("/srv/repos/web").Path; got != "/srv/repos/web/.beads/sync.lock" {
+		t.Errorf("Expected the lock file in the repository, got %s", got)
+	}
+
+	absolute := LockConfig{Backend: "file", Path: "/mnt/shared/web.lock"}
+	if got := absolute.InRepo("/srv/repos/web").Path; got != "/mnt/shared/web.lock" {
+		t.Errorf("Expected an absolute lock path to be kept, got %s", got)
+	}
+}
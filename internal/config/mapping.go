@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MappingFileName is the mapping file loaded from the config directory when
+// mapping_file is not set
+const MappingFileName = "mapping.yml"
+
+// StatusNames lists the beads statuses a status mapping may target
+var StatusNames = []string{"open", "in_progress", "blocked", "closed"}
+
+// PriorityNames lists the beads priorities a priority mapping may target
+var PriorityNames = []string{"p0", "p1", "p2", "p3", "p4"}
+
+// DispositionNames lists the beads dispositions a resolution mapping may target
+var DispositionNames = []string{"done", "wont-do", "duplicate", "cannot-reproduce"}
+
+// IssueClassNames lists how an issue type mapping may classify a type
+var IssueClassNames = []string{"epic", "issue", "skip"}
+
+// MappingConfig overrides the built-in Jira to beads mappings for instances
+// with custom workflows, priorities and fields. Names are matched
+// case-insensitively; anything not listed keeps the default mapping.
+type MappingConfig struct {
+	Statuses     map[string]string `yaml:"statuses,omitempty"`      // Jira status name → beads status
+	Priorities   map[string]string `yaml:"priorities,omitempty"`    // Jira priority name → beads priority
+	CustomFields map[string]string `yaml:"custom_fields,omitempty"` // customfield_XXXXX → metadata key
+	Resolutions  map[string]string `yaml:"resolutions,omitempty"`   // Jira resolution name → beads disposition
+	Labels       map[string]string `yaml:"labels,omitempty"`        // Jira label → beads label, e.g. after a rename
+	IssueTypes   map[string]string `yaml:"issue_types,omitempty"`   // Jira issue type → epic, issue or skip
+
+	// ChecklistFields lists checklist plugin fields to request in searches.
+	// Checklists are detected automatically in single issue fetches.
+	ChecklistFields []string `yaml:"checklist_fields,omitempty"`
+
+	// SprintField is the sprint custom field to request in searches. The
+	// sprint field is detected automatically in single issue fetches.
+	SprintField string `yaml:"sprint_field,omitempty"`
+
+	// EpicNameField is the custom field holding epic names, such as the
+	// "Epic Name" field of classic projects (customfield_10011 on Jira
+	// Cloud). See converter.epic_name for how it is used.
+	EpicNameField string `yaml:"epic_name_field,omitempty"`
+
+	// ParticipantsField is the user list field whose users are copied to
+	// the cc metadata of issues, such as the "Request participants" field
+	// of Jira Service Management
+	ParticipantsField string `yaml:"participants_field,omitempty"`
+
+	// TargetStartField and TargetEndField are the planned date fields of
+	// epics and issues, such as the "Target start" and "Target end" fields
+	// of Advanced Roadmaps
+	TargetStartField string `yaml:"target_start_field,omitempty"`
+	TargetEndField   string `yaml:"target_end_field,omitempty"`
+}
+
+// CustomFieldIDs returns the Jira custom field IDs the mapping reads, sorted
+func (m MappingConfig) CustomFieldIDs() []string {
+	ids := make([]string, 0, len(m.CustomFields)+len(m.ChecklistFields))
+	for id := range m.CustomFields {
+		ids = append(ids, id)
+	}
+	ids = append(ids, m.ChecklistFields...)
+	if m.SprintField != "" {
+		ids = append(ids, m.SprintField)
+	}
+	if m.EpicNameField != "" {
+		ids = append(ids, m.EpicNameField)
+	}
+	if m.ParticipantsField != "" {
+		ids = append(ids, m.ParticipantsField)
+	}
+	for _, id := range []string{m.TargetStartField, m.TargetEndField} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Validate checks that every mapping targets a known beads value
+func (m MappingConfig) Validate() error {
+	for name, status := range m.Statuses {
+		if !containsFold(StatusNames, status) {
+			return fmt.Errorf("status mapping for %q must be one of: %s, got: %s", name, strings.Join(StatusNames, ", "), status)
+		}
+	}
+	for name, priority := range m.Priorities {
+		if !containsFold(PriorityNames, priority) {
+			return fmt.Errorf("priority mapping for %q must be one of: %s, got: %s", name, strings.Join(PriorityNames, ", "), priority)
+		}
+	}
+	for name, disposition := range m.Resolutions {
+		if !containsFold(DispositionNames, disposition) {
+			return fmt.Errorf("resolution mapping for %q must be one of: %s, got: %s", name, strings.Join(DispositionNames, ", "), disposition)
+		}
+	}
+	for name, class := range m.IssueTypes {
+		if !containsFold(IssueClassNames, class) {
+			return fmt.Errorf("issue type mapping for %q must be one of: %s, got: %s", name, strings.Join(IssueClassNames, ", "), class)
+		}
+	}
+	for from, to := range m.Labels {
+		if to == "" || strings.ContainsAny(to, " \t\n") {
+			return fmt.Errorf("label mapping for %q must be a label without spaces, got: %q", from, to)
+		}
+	}
+	for id, key := range m.CustomFields {
+		if !strings.HasPrefix(id, "customfield_") {
+			return fmt.Errorf("custom field mapping %q must use a Jira custom field ID (customfield_XXXXX)", id)
+		}
+		if key == "" {
+			return fmt.Errorf("custom field mapping for %s must set a metadata key", id)
+		}
+	}
+	for _, id := range m.ChecklistFields {
+		if !strings.HasPrefix(id, "customfield_") {
+			return fmt.Errorf("checklist field %q must use a Jira custom field ID (customfield_XXXXX)", id)
+		}
+	}
+	if m.SprintField != "" && !strings.HasPrefix(m.SprintField, "customfield_") {
+		return fmt.Errorf("sprint field %q must use a Jira custom field ID (customfield_XXXXX)", m.SprintField)
+	}
+	if m.EpicNameField != "" && !strings.HasPrefix(m.EpicNameField, "customfield_") {
+		return fmt.Errorf("epic name field %q must use a Jira custom field ID (customfield_XXXXX)", m.EpicNameField)
+	}
+	if m.ParticipantsField != "" && !strings.HasPrefix(m.ParticipantsField, "customfield_") {
+		return fmt.Errorf("participants field %q must use a Jira custom field ID (customfield_XXXXX)", m.ParticipantsField)
+	}
+	for _, id := range []string{m.TargetStartField, m.TargetEndField} {
+		if id != "" && !strings.HasPrefix(id, "customfield_") {
+			return fmt.Errorf("target date field %q must use a Jira custom field ID (customfield_XXXXX)", id)
+		}
+	}
+	return nil
+}
+
+// LoadMapping loads a mapping file
+func LoadMapping(path string) (MappingConfig, error) {
+	var mapping MappingConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, err
+	}
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return mapping, err
+	}
+
+	return mapping, nil
+}
+
+// mappingPath resolves the mapping file for a config file. A relative
+// mapping_file is resolved against the config directory. The second return
+// value reports whether the file was set explicitly.
+func mappingPath(configPath, mappingFile string) (string, bool) {
+	return siblingPath(configPath, mappingFile, MappingFileName)
+}
+
+// siblingPath resolves a file referenced by a config file, defaulting to
+// defaultName in the config directory. The second return value reports
+// whether the file was set explicitly.
+func siblingPath(configPath, file, defaultName string) (string, bool) {
+	if file == "" {
+		return filepath.Join(filepath.Dir(configPath), defaultName), false
+	}
+	if !filepath.IsAbs(file) {
+		return filepath.Join(filepath.Dir(configPath), file), true
+	}
+	return file, true
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
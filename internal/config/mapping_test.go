@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigWithDefaultMappingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `jira:
+  base_url: https://jira.example.com
+  username: user@example.com
+  api_token: token123
+`
+	mappingContent := `statuses:
+  Awaiting Review: in_progress
+  Won't Do: closed
+priorities:
+  Blocker: p0
+  Trivial: p4
+custom_fields:
+  customfield_10016: storyPoints
+  customfield_10010: sprint
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, MappingFileName), []byte(mappingContent), 0600); err != nil {
+		t.Fatalf("Failed to create test mapping file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+	configPathFunc = func() string { return configPath }
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.Mapping.Statuses["Won't Do"] != "closed" {
+		t.Errorf("Expected \"Won't Do\" to map to closed, got %q", config.Mapping.Statuses["Won't Do"])
+	}
+	if config.Mapping.Priorities["Blocker"] != "p0" {
+		t.Errorf("Expected Blocker to map to p0, got %q", config.Mapping.Priorities["Blocker"])
+	}
+	if got := strings.Join(config.Mapping.CustomFieldIDs(), ","); got != "customfield_10010,customfield_10016" {
+		t.Errorf("Expected sorted custom field IDs, got %s", got)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected config to be valid, got: %v", err)
+	}
+}
+
+func TestLoadConfigWithoutMappingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	if err := os.WriteFile(configPath, []byte("jira:\n  base_url: https://jira.example.com\n"), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+	configPathFunc = func() string { return configPath }
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(config.Mapping.Statuses) != 0 || len(config.Mapping.Priorities) != 0 || len(config.Mapping.CustomFields) != 0 {
+		t.Errorf("Expected empty mapping, got %+v", config.Mapping)
+	}
+}
+
+func TestLoadConfigWithMissingExplicitMappingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := "jira:\n  base_url: https://jira.example.com\nmapping_file: custom-mapping.yml\n"
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+	configPathFunc = func() string { return configPath }
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for missing mapping file, got nil")
+	}
+}
+
+func TestMappingPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		mappingFile  string
+		wantPath     string
+		wantExplicit bool
+	}{
+		{"default", "", "/etc/jbs/mapping.yml", false},
+		{"relative", "team.yml", "/etc/jbs/team.yml", true},
+		{"absolute", "/srv/mapping.yml", "/srv/mapping.yml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, explicit := mappingPath("/etc/jbs/config.yml", tt.mappingFile)
+			if path != tt.wantPath {
+				t.Errorf("Expected path %s, got %s", tt.wantPath, path)
+			}
+			if explicit != tt.wantExplicit {
+				t.Errorf("Expected explicit %v, got %v", tt.wantExplicit, explicit)
+			}
+		})
+	}
+}
+
+func TestMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping MappingConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			mapping: MappingConfig{
+				Statuses:     map[string]string{"Awaiting Review": "IN_PROGRESS"},
+				Priorities:   map[string]string{"Blocker": "p0"},
+				CustomFields: map[string]string{"customfield_10016": "storyPoints"},
+				Resolutions:  map[string]string{"Out of Scope": "WONT-DO"},
+				Labels:       map[string]string{"frontend": "ui"},
+				IssueTypes:   map[string]string{"Initiative": "Epic", "Incident": "issue", "Risk": "skip"},
+			},
+		},
+		{
+			name:    "unknown issue class",
+			mapping: MappingConfig{IssueTypes: map[string]string{"Risk": "ignore"}},
+			wantErr: true,
+		},
+		{
+			name:    "label renamed to one with spaces",
+			mapping: MappingConfig{Labels: map[string]string{"frontend": "user interface"}},
+			wantErr: true,
+		},
+		{
+			name:    "label renamed to nothing",
+			mapping: MappingConfig{Labels: map[string]string{"frontend": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown disposition",
+			mapping: MappingConfig{Resolutions: map[string]string{"Out of Scope": "abandoned"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown status",
+			mapping: MappingConfig{Statuses: map[string]string{"Review": "reviewing"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown priority",
+			mapping: MappingConfig{Priorities: map[string]string{"Blocker": "urgent"}},
+			wantErr: true,
+		},
+		{
+			name:    "field name instead of ID",
+			mapping: MappingConfig{CustomFields: map[string]string{"Story Points": "storyPoints"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty metadata key",
+			mapping: MappingConfig{CustomFields: map[string]string{"customfield_10016": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "sprint field name instead of ID",
+			mapping: MappingConfig{SprintField: "Sprint"},
+			wantErr: true,
+		},
+		{
+			name:    "epic name field name instead of ID",
+			mapping: MappingConfig{EpicNameField: "Epic Name"},
+			wantErr: true,
+		},
+		{
+			name:    "participants field name instead of ID",
+			mapping: MappingConfig{ParticipantsField: "Request participants"},
+			wantErr: true,
+		},
+		{
+			name:    "target date field name instead of ID",
+			mapping: MappingConfig{TargetStartField: "customfield_10022", TargetEndField: "Target end"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/schedule"
+)
+
+// ScheduleConfig restricts when syncs may run. Without windows syncing is
+// allowed at any time outside the blackouts.
+type ScheduleConfig struct {
+	Timezone  string           `yaml:"timezone,omitempty"` // IANA name, defaults to local time
+	Windows   []WindowConfig   `yaml:"windows,omitempty"`
+	Blackouts []BlackoutConfig `yaml:"blackouts,omitempty"`
+}
+
+// WindowConfig is a recurring daily period, e.g. business hours.
+// An end at or before the start wraps past midnight.
+type WindowConfig struct {
+	Days  []string `yaml:"days,omitempty"` // mon, tue, …; empty means every day
+	Start string   `yaml:"start"`          // HH:MM
+	End   string   `yaml:"end"`            // HH:MM
+}
+
+// BlackoutConfig is a period when syncing must not run: either recurring
+// (days/start/end) or one-off (from/until)
+type BlackoutConfig struct {
+	WindowConfig `yaml:",inline"`
+	From         time.Time `yaml:"from,omitempty"`
+	Until        time.Time `yaml:"until,omitempty"`
+}
+
+// Schedule builds the sync schedule, or returns nil when none is configured
+func (s ScheduleConfig) Schedule() (*schedule.Schedule, error) {
+	if s.Timezone == "" && len(s.Windows) == 0 && len(s.Blackouts) == 0 {
+		return nil, nil
+	}
+
+	sched := &schedule.Schedule{Location: time.Local}
+	if s.Timezone != "" {
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+		sched.Location = loc
+	}
+
+	for i, window := range s.Windows {
+		w, err := window.window()
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %w", i+1, err)
+		}
+		sched.Windows = append(sched.Windows, w)
+	}
+
+	for i, blackout := range s.Blackouts {
+		if !blackout.From.IsZero() || !blackout.Until.IsZero() {
+			if !blackout.Until.After(blackout.From) {
+				return nil, fmt.Errorf("blackout %d must end after it starts", i+1)
+			}
+			sched.Maintenance = append(sched.Maintenance, schedule.Period{From: blackout.From, Until: blackout.Until})
+			continue
+		}
+		w, err := blackout.window()
+		if err != nil {
+			return nil, fmt.Errorf("blackout %d: %w", i+1, err)
+		}
+		sched.Blackouts = append(sched.Blackouts, w)
+	}
+
+	return sched, nil
+}
+
+// window parses a window config
+func (w WindowConfig) window() (schedule.Window, error) {
+	var window schedule.Window
+	var err error
+
+	if w.Start == "" || w.End == "" {
+		return window, fmt.Errorf("must set both start and end")
+	}
+	if window.Start, err = schedule.ParseClock(w.Start); err != nil {
+		return window, err
+	}
+	if window.End, err = schedule.ParseClock(w.End); err != nil {
+		return window, err
+	}
+	for _, name := range w.Days {
+		day, err := schedule.ParseWeekday(name)
+		if err != nil {
+			return window, err
+		}
+		window.Days = append(window.Days, day)
+	}
+
+	return window, nil
+}
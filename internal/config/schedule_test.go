@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestScheduleConfig(t *testing.T) {
+	var cfg ScheduleConfig
+	err := yaml.Unmarshal([]byte(`timezone: Europe/Dublin
+windows:
+  - days: [mon, tue, wed, thu, fri]
+    start: "08:00"
+    end: "18:00"
+blackouts:
+  - days: [sun]
+    start: "02:00"
+    end: "04:00"
+  - from: 2026-11-01T00:00:00Z
+    until: 2026-11-01T06:00:00Z
+`), &cfg)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal schedule: %v", err)
+	}
+
+	sched, err := cfg.Schedule()
+	if err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+
+	if sched.Location.String() != "Europe/Dublin" {
+		t.Errorf("Expected Europe/Dublin, got %s", sched.Location)
+	}
+	if len(sched.Windows) != 1 || len(sched.Windows[0].Days) != 5 {
+		t.Errorf("Expected one weekday window, got %+v", sched.Windows)
+	}
+	if sched.Windows[0].Start != 8*time.Hour || sched.Windows[0].End != 18*time.Hour {
+		t.Errorf("Expected 08:00–18:00, got %v–%v", sched.Windows[0].Start, sched.Windows[0].End)
+	}
+	if len(sched.Blackouts) != 1 || sched.Blackouts[0].Days[0] != time.Sunday {
+		t.Errorf("Expected one Sunday blackout, got %+v", sched.Blackouts)
+	}
+	if len(sched.Maintenance) != 1 || sched.Maintenance[0].Until.Sub(sched.Maintenance[0].From) != 6*time.Hour {
+		t.Errorf("Expected one 6h maintenance period, got %+v", sched.Maintenance)
+	}
+}
+
+func TestScheduleConfigEmpty(t *testing.T) {
+	sched, err := ScheduleConfig{}.Schedule()
+	if err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+	if sched != nil {
+		t.Errorf("Expected no schedule, got %+v", sched)
+	}
+}
+
+func TestConfigValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule ScheduleConfig
+	}{
+		{"unknown timezone", ScheduleConfig{Timezone: "Mars/Olympus"}},
+		{"missing end", ScheduleConfig{Windows: []WindowConfig{{Start: "08:00"}}}},
+		{"invalid time", ScheduleConfig{Windows: []WindowConfig{{Start: "8am", End: "18:00"}}}},
+		{"invalid day", ScheduleConfig{Windows: []WindowConfig{{Days: []string{"someday"}, Start: "08:00", End: "18:00"}}}},
+		{"backwards maintenance", ScheduleConfig{Blackouts: []BlackoutConfig{{
+			From:  time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC),
+			Until: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Jira: JiraConfig{
+					BaseURL:  "https://jira.example.com",
+					Username: "user@example.com",
+					APIToken: "token123",
+				},
+				Schedule: tt.schedule,
+			}
+			if err := config.Validate(); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
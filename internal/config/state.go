@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/conallob/jira-beads-sync/internal/syncstate"
+)
+
+// StateConfig selects where incremental sync state is kept. Without a
+// backend it is kept in .beads/ next to the beads files; the remote
+// backends let stateless runners, such as CI jobs, share it.
+type StateConfig struct {
+	Backend  string `yaml:"backend,omitempty"`  // "file" (default), "sqlite", "http" or "s3"
+	Path     string `yaml:"path,omitempty"`     // Database file (sqlite)
+	URL      string `yaml:"url,omitempty"`      // State object URL accepting GET and PUT (http)
+	Token    string `yaml:"token,omitempty"`    // Bearer token (http)
+	Endpoint string `yaml:"endpoint,omitempty"` // S3-compatible endpoint, e.g. https://storage.googleapis.com; AWS if empty (s3)
+	Region   string `yaml:"region,omitempty"`   // Bucket region, us-east-1 if empty; auto for GCS (s3)
+	Bucket   string `yaml:"bucket,omitempty"`   // (s3)
+	Key      string `yaml:"key,omitempty"`      // Object key, e.g. jira-sync/state.json (s3), or row name (sqlite)
+
+	// S3 credentials, normally taken from the AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables
+	AccessKeyID     string `yaml:"access_key_id,omitempty"`
+	SecretAccessKey string `yaml:"secret_access_key,omitempty"`
+	SessionToken    string `yaml:"session_token,omitempty"`
+}
+
+// Store builds the configured state store for a beads repository
+func (s StateConfig) Store(outputDir string) (syncstate.Store, error) {
+	switch s.Backend {
+	case "", "file":
+		return syncstate.NewFileStore(outputDir), nil
+	case "sqlite":
+		if s.Path == "" {
+			return nil, fmt.Errorf("the sqlite state backend requires a path")
+		}
+		name := s.Key
+		if name == "" {
+			name = "default"
+		}
+		return syncstate.NewSQLiteStore(s.Path, name), nil
+	case "http":
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("the http state backend requires an http(s) url, got %q", s.URL)
+		}
+		return syncstate.NewHTTPStore(s.URL, s.Token), nil
+	case "s3":
+		return syncstate.NewS3Store(syncstate.S3Options{
+			Endpoint:        s.Endpoint,
+			Region:          s.Region,
+			Bucket:          s.Bucket,
+			Key:             s.Key,
+			AccessKeyID:     s.AccessKeyID,
+			SecretAccessKey: s.SecretAccessKey,
+			SessionToken:    s.SessionToken,
+		})
+	default:
+		return nil, fmt.Errorf("unknown state backend %q, must be file, sqlite, http or s3", s.Backend)
+	}
+}
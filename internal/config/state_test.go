@@ -0,0 +1,39 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStateConfigStore(t *testing.T) {
+	s3 := StateConfig{Backend: "s3", Bucket: "ci-state", Key: "jira/state.json", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+
+	tests := []struct {
+		name    string
+		config  StateConfig
+		want    string
+		wantErr bool
+	}{
+		{name: "default", config: StateConfig{}, want: ".jira-sync-state.json"},
+		{name: "file", config: StateConfig{Backend: "file"}, want: ".jira-sync-state.json"},
+		{name: "http", config: StateConfig{Backend: "http", URL: "https://state.example.com/repo.json"}, want: "https://state.example.com/repo.json"},
+		{name: "s3", config: s3, want: "s3://ci-state/jira/state.json"},
+		{name: "http without url", config: StateConfig{Backend: "http", URL: "state.example.com"}, wantErr: true},
+		{name: "s3 without credentials", config: StateConfig{Backend: "s3", Bucket: "ci-state", Key: "state.json"}, wantErr: true},
+		{name: "sqlite", config: StateConfig{Backend: "sqlite", Path: "/var/lib/ci/state.db", Key: "proj"}, want: "sqlite:/var/lib/ci/state.db#proj"},
+		{name: "sqlite without path", config: StateConfig{Backend: "sqlite"}, wantErr: true},
+		{name: "unknown backend", config: StateConfig{Backend: "postgres"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := tt.config.Store(t.TempDir())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && !strings.HasSuffix(store.String(), tt.want) {
+				t.Errorf("Expected store %s, got %s", tt.want, store)
+			}
+		})
+	}
+}
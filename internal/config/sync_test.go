@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveSyncJQL(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	configContent := `# Work Jira
+jira:
+  base_url: https://file.jira.com
+  username: file@example.com
+sync:
+  jql: project = OLD
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+	configPathFunc = func() string { return configPath }
+	t.Setenv("JIRA_API_TOKEN", "envtoken")
+
+	jql := `project in ("API") AND status in ("To Do")`
+	if err := SaveSyncJQL(jql); err != nil {
+		t.Fatalf("SaveSyncJQL failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "envtoken") {
+		t.Errorf("Expected environment settings to stay out of the file, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "# Work Jira") {
+		t.Errorf("Expected comments to be kept, got:\n%s", data)
+	}
+	if !strings.Contains(string(data), "\n  base_url: https://file.jira.com\n") {
+		t.Errorf("Expected the two-space indentation to be kept, got:\n%s", data)
+	}
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Sync.JQL != jql {
+		t.Errorf("Expected sync.jql %q, got %q", jql, config.Sync.JQL)
+	}
+	if config.Jira.BaseURL != "https://file.jira.com" {
+		t.Errorf("Expected the rest of the file to be kept, got base URL %q", config.Jira.BaseURL)
+	}
+}
+
+func TestSaveSyncJQLWithoutConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "jira-beads-sync", "config.yml")
+	originalConfigPathFunc := configPathFunc
+	defer func() { configPathFunc = originalConfigPathFunc }()
+	configPathFunc = func() string { return configPath }
+
+	if err := SaveSyncJQL("project = NEW"); err != nil {
+		t.Fatalf("SaveSyncJQL failed: %v", err)
+	}
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.Sync.JQL != "project = NEW" {
+		t.Errorf("Expected sync.jql to be written, got %q", config.Sync.JQL)
+	}
+}
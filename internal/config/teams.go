@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TeamsFileName is the teams file loaded from the config directory when
+// teams_file is not set
+const TeamsFileName = "teams.yml"
+
+// TeamMapping assigns Jira users to teams, so converted issues can be
+// labelled with their assignee's team even when Jira has no team field.
+// Users are matched case-insensitively by email, account ID or display name.
+type TeamMapping struct {
+	Users map[string]string `yaml:"users,omitempty"` // Jira user → team name
+}
+
+// Validate checks that every user maps to a team name usable in a label
+func (t TeamMapping) Validate() error {
+	for user, team := range t.Users {
+		if strings.TrimSpace(user) == "" {
+			return fmt.Errorf("team mapping has an empty user for team %q", team)
+		}
+		if strings.TrimSpace(team) == "" {
+			return fmt.Errorf("team mapping for %q must set a team name", user)
+		}
+		if strings.ContainsAny(team, " \t\n") {
+			return fmt.Errorf("team name %q for %q must not contain whitespace", team, user)
+		}
+	}
+	return nil
+}
+
+// LoadTeams loads a teams file
+func LoadTeams(path string) (TeamMapping, error) {
+	var teams TeamMapping
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return teams, err
+	}
+	if err := yaml.Unmarshal(data, &teams); err != nil {
+		return teams, err
+	}
+
+	return teams, nil
+}
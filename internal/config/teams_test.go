@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigWithTeamsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `jira:
+  base_url: https://jira.example.com
+  username: user@example.com
+  api_token: token123
+teams_file: org/teams.yml
+`
+	teamsContent := `users:
+  alice@example.com: platform
+  Bob Jones: payments
+`
+
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(tmpDir, "org"), 0700); err != nil {
+		t.Fatalf("Failed to create teams directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "org", "teams.yml"), []byte(teamsContent), 0600); err != nil {
+		t.Fatalf("Failed to create test teams file: %v", err)
+	}
+
+	config, err := LoadFile(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.Teams.Users["alice@example.com"] != "platform" || config.Teams.Users["Bob Jones"] != "payments" {
+		t.Errorf("Expected the teams file to be loaded, got %v", config.Teams.Users)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected config to be valid, got: %v", err)
+	}
+}
+
+func TestLoadConfigWithMissingExplicitTeamsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `jira:
+  base_url: https://jira.example.com
+  api_token: token123
+teams_file: missing.yml
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	if _, err := LoadFile(configPath); err == nil || !strings.Contains(err.Error(), "teams file") {
+		t.Errorf("Expected an error for a missing teams file, got %v", err)
+	}
+}
+
+func TestTeamMappingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		users   map[string]string
+		wantErr string
+	}{
+		{"valid", map[string]string{"alice@example.com": "platform"}, ""},
+		{"empty team", map[string]string{"alice@example.com": " "}, "must set a team name"},
+		{"empty user", map[string]string{"": "platform"}, "empty user"},
+		{"whitespace in team", map[string]string{"alice@example.com": "core platform"}, "whitespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TeamMapping{Users: tt.users}.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
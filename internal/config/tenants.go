@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tenantName restricts tenant names to what can appear in a URL path
+var tenantName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// TenantsConfig lists the repositories a multi-tenant daemon keeps in sync.
+// It is kept in its own file, since each tenant has a full config of its own.
+type TenantsConfig struct {
+	AdminToken string         `yaml:"admin_token,omitempty"` // Bearer token for the admin endpoint, disabled when empty
+	Tenants    []TenantConfig `yaml:"tenants"`
+}
+
+// TenantConfig pairs a beads repository with the config syncing it
+type TenantConfig struct {
+	Name   string `yaml:"name"`   // Used in the webhook URL and as the metrics label
+	Repo   string `yaml:"repo"`   // Repository the .beads directory is written to
+	Config string `yaml:"config"` // Config file for this tenant
+}
+
+// LoadTenants loads a tenants file. Relative repo and config paths are
+// resolved against the file's directory. JIRA_BEADS_ADMIN_TOKEN overrides
+// the admin token.
+func LoadTenants(path string) (*TenantsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var tenants TenantsConfig
+	if err := yaml.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants file: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	for i := range tenants.Tenants {
+		tenant := &tenants.Tenants[i]
+		if tenant.Repo != "" && !filepath.IsAbs(tenant.Repo) {
+			tenant.Repo = filepath.Join(dir, tenant.Repo)
+		}
+		if tenant.Config != "" && !filepath.IsAbs(tenant.Config) {
+			tenant.Config = filepath.Join(dir, tenant.Config)
+		}
+	}
+
+	if token := os.Getenv("JIRA_BEADS_ADMIN_TOKEN"); token != "" {
+		tenants.AdminToken = token
+	}
+
+	return &tenants, nil
+}
+
+// Validate checks that every tenant is complete and that no two tenants
+// share a name or repository
+func (t *TenantsConfig) Validate() error {
+	if len(t.Tenants) == 0 {
+		return fmt.Errorf("no tenants configured")
+	}
+
+	names := make(map[string]bool)
+	repos := make(map[string]string)
+	for i, tenant := range t.Tenants {
+		if !tenantName.MatchString(tenant.Name) {
+			return fmt.Errorf("tenant %d must have a name of lowercase letters, digits, - and _, got: %q", i+1, tenant.Name)
+		}
+		if names[tenant.Name] {
+			return fmt.Errorf("duplicate tenant %q", tenant.Name)
+		}
+		names[tenant.Name] = true
+
+		if tenant.Repo == "" || tenant.Config == "" {
+			return fmt.Errorf("tenant %q must set both repo and config", tenant.Name)
+		}
+		repo := filepath.Clean(tenant.Repo)
+		if other, ok := repos[repo]; ok {
+			return fmt.Errorf("tenants %q and %q share the repository %s", other, tenant.Name, repo)
+		}
+		repos[repo] = tenant.Name
+	}
+	return nil
+}
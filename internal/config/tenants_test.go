@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTenants(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "tenants.yml")
+	content := `admin_token: admin123
+tenants:
+  - name: web
+    repo: repos/web
+    config: web.yml
+  - name: platform
+    repo: /srv/platform
+    config: /etc/jira-beads-sync/platform.yml
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write tenants file: %v", err)
+	}
+
+	tenants, err := LoadTenants(path)
+	if err != nil {
+		t.Fatalf("LoadTenants failed: %v", err)
+	}
+	if tenants.AdminToken != "admin123" {
+		t.Errorf("Expected admin token admin123, got %q", tenants.AdminToken)
+	}
+	if len(tenants.Tenants) != 2 {
+		t.Fatalf("Expected 2 tenants, got %d", len(tenants.Tenants))
+	}
+	if web := tenants.Tenants[0]; web.Repo != filepath.Join(tmpDir, "repos/web") || web.Config != filepath.Join(tmpDir, "web.yml") {
+		t.Errorf("Expected relative paths resolved against the tenants file, got %+v", web)
+	}
+	if platform := tenants.Tenants[1]; platform.Repo != "/srv/platform" || platform.Config != "/etc/jira-beads-sync/platform.yml" {
+		t.Errorf("Expected absolute paths kept, got %+v", platform)
+	}
+	if err := tenants.Validate(); err != nil {
+		t.Errorf("Expected valid tenants, got: %v", err)
+	}
+
+	t.Setenv("JIRA_BEADS_ADMIN_TOKEN", "from-env")
+	tenants, err = LoadTenants(path)
+	if err != nil {
+		t.Fatalf("LoadTenants failed: %v", err)
+	}
+	if tenants.AdminToken != "from-env" {
+		t.Errorf("Expected admin token from the environment, got %q", tenants.AdminToken)
+	}
+}
+
+func TestTenantsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenants []TenantConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			tenants: []TenantConfig{
+				{Name: "web", Repo: "/srv/web", Config: "web.yml"},
+				{Name: "platform_2", Repo: "/srv/platform", Config: "platform.yml"},
+			},
+		},
+		{name: "no tenants", wantErr: true},
+		{
+			name:    "name not usable in a URL",
+			tenants: []TenantConfig{{Name: "Web Team", Repo: "/srv/web", Config: "web.yml"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing config",
+			tenants: []TenantConfig{{Name: "web", Repo: "/srv/web"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			tenants: []TenantConfig{
+				{Name: "web", Repo: "/srv/web", Config: "web.yml"},
+				{Name: "web", Repo: "/srv/web2", Config: "web2.yml"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "shared repository",
+			tenants: []TenantConfig{
+				{Name: "web", Repo: "/srv/web", Config: "web.yml"},
+				{Name: "mobile", Repo: "/srv/web/", Config: "mobile.yml"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&TenantsConfig{Tenants: tt.tenants}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
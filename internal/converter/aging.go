@@ -0,0 +1,48 @@
+package converter
+
+import (
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// applyPriorityAging raises the priority of an open issue by one level for each
+// configured threshold its age exceeds. Only the local beads copy is changed;
+// the original priority is kept in metadata so the bump is visible.
+func (c *ProtoConverter) applyPriorityAging(issue *beadspb.Issue) {
+	if len(c.options.PriorityAging) == 0 || issue.Created == nil {
+		return
+	}
+	if issue.Status == beadspb.Status_STATUS_CLOSED {
+		return
+	}
+
+	age := c.options.Now().Sub(issue.Created.AsTime())
+	aged := issue.Priority
+	for _, threshold := range c.options.PriorityAging {
+		if age > threshold && aged > beadspb.Priority_PRIORITY_P0 {
+			aged--
+		}
+	}
+
+	if aged == issue.Priority {
+		return
+	}
+
+	setCustomMetadata(issue.Metadata, "priorityAgedFrom", priorityName(issue.Priority))
+	issue.Priority = aged
+}
+
+// priorityName returns the beads short name for a priority (e.g. "p2")
+func priorityName(priority beadspb.Priority) string {
+	switch priority {
+	case beadspb.Priority_PRIORITY_P0:
+		return "p0"
+	case beadspb.Priority_PRIORITY_P1:
+		return "p1"
+	case beadspb.Priority_PRIORITY_P3:
+		return "p3"
+	case beadspb.Priority_PRIORITY_P4:
+		return "p4"
+	default:
+		return "p2"
+	}
+}
@@ -0,0 +1,127 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestApplyPriorityAging(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name         string
+		thresholds   []time.Duration
+		ageDays      int
+		status       beadspb.Status
+		priority     beadspb.Priority
+		wantPriority beadspb.Priority
+		wantAgedFrom string
+	}{
+		{
+			name:         "aging disabled",
+			ageDays:      400,
+			status:       beadspb.Status_STATUS_OPEN,
+			priority:     beadspb.Priority_PRIORITY_P2,
+			wantPriority: beadspb.Priority_PRIORITY_P2,
+		},
+		{
+			name:         "younger than first threshold",
+			thresholds:   []time.Duration{30 * day, 90 * day},
+			ageDays:      10,
+			status:       beadspb.Status_STATUS_OPEN,
+			priority:     beadspb.Priority_PRIORITY_P2,
+			wantPriority: beadspb.Priority_PRIORITY_P2,
+		},
+		{
+			name:         "past first threshold",
+			thresholds:   []time.Duration{30 * day, 90 * day},
+			ageDays:      45,
+			status:       beadspb.Status_STATUS_OPEN,
+			priority:     beadspb.Priority_PRIORITY_P2,
+			wantPriority: beadspb.Priority_PRIORITY_P1,
+			wantAgedFrom: "p2",
+		},
+		{
+			name:         "past both thresholds",
+			thresholds:   []time.Duration{30 * day, 90 * day},
+			ageDays:      120,
+			status:       beadspb.Status_STATUS_IN_PROGRESS,
+			priority:     beadspb.Priority_PRIORITY_P3,
+			wantPriority: beadspb.Priority_PRIORITY_P1,
+			wantAgedFrom: "p3",
+		},
+		{
+			name:         "raised two levels at once when first synced after both thresholds",
+			thresholds:   []time.Duration{30 * day, 90 * day},
+			ageDays:      120,
+			status:       beadspb.Status_STATUS_OPEN,
+			priority:     beadspb.Priority_PRIORITY_P2,
+			wantPriority: beadspb.Priority_PRIORITY_P0,
+			wantAgedFrom: "p2",
+		},
+		{
+			name:         "capped at p0",
+			thresholds:   []time.Duration{30 * day, 90 * day},
+			ageDays:      120,
+			status:       beadspb.Status_STATUS_OPEN,
+			priority:     beadspb.Priority_PRIORITY_P1,
+			wantPriority: beadspb.Priority_PRIORITY_P0,
+			wantAgedFrom: "p1",
+		},
+		{
+			name:         "closed issues are not aged",
+			thresholds:   []time.Duration{30 * day},
+			ageDays:      120,
+			status:       beadspb.Status_STATUS_CLOSED,
+			priority:     beadspb.Priority_PRIORITY_P3,
+			wantPriority: beadspb.Priority_PRIORITY_P3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := NewProtoConverterWithOptions(Options{
+				PriorityAging: tt.thresholds,
+				Now:           func() time.Time { return now },
+			})
+
+			issue := &beadspb.Issue{
+				Status:   tt.status,
+				Priority: tt.priority,
+				Created:  timestamppb.New(now.Add(-time.Duration(tt.ageDays) * day)),
+				Metadata: &beadspb.Metadata{},
+			}
+
+			conv.applyPriorityAging(issue)
+
+			if issue.Priority != tt.wantPriority {
+				t.Errorf("Expected priority %v, got %v", tt.wantPriority, issue.Priority)
+			}
+			if got := issue.Metadata.Custom["priorityAgedFrom"]; got != tt.wantAgedFrom {
+				t.Errorf("Expected priorityAgedFrom %q, got %q", tt.wantAgedFrom, got)
+			}
+		})
+	}
+}
+
+func TestApplyPriorityAgingWithoutCreated(t *testing.T) {
+	conv := NewProtoConverterWithOptions(Options{
+		PriorityAging: []time.Duration{time.Hour},
+	})
+
+	issue := &beadspb.Issue{
+		Status:   beadspb.Status_STATUS_OPEN,
+		Priority: beadspb.Priority_PRIORITY_P2,
+		Metadata: &beadspb.Metadata{},
+	}
+
+	conv.applyPriorityAging(issue)
+
+	if issue.Priority != beadspb.Priority_PRIORITY_P2 {
+		t.Errorf("Expected priority to be unchanged, got %v", issue.Priority)
+	}
+}
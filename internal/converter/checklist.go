@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"strings"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// renderChecklist renders checklist plugin items as a Markdown task list,
+// keeping section headings and checked state
+func renderChecklist(items []*jirapb.ChecklistItem) string {
+	if len(items) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Checklist\n")
+	for _, item := range items {
+		switch {
+		case item.Header:
+			b.WriteString("\n### " + item.Name + "\n\n")
+		case item.Checked:
+			b.WriteString("- [x] " + item.Name + "\n")
+		default:
+			b.WriteString("- [ ] " + item.Name + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// appendChecklist appends the issue's checklist to its description
+func appendChecklist(description string, items []*jirapb.ChecklistItem) string {
+	checklist := renderChecklist(items)
+	switch {
+	case checklist == "":
+		return description
+	case strings.TrimSpace(description) == "":
+		return checklist
+	default:
+		return strings.TrimRight(description, "\n") + "\n\n" + checklist
+	}
+}
@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestConvertChecklist(t *testing.T) {
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Description = "Release the thing.\n"
+	issue.Fields.Checklist = []*jirapb.ChecklistItem{
+		{Name: "Release", Header: true},
+		{Name: "Tag version", Checked: true},
+		{Name: "Publish notes"},
+	}
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	want := "Release the thing.\n\n## Checklist\n\n### Release\n\n- [x] Tag version\n- [ ] Publish notes"
+	if got := export.Issues[0].Description; got != want {
+		t.Errorf("Expected description:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestAppendChecklist(t *testing.T) {
+	items := []*jirapb.ChecklistItem{{Name: "Only item"}}
+
+	if got := appendChecklist("", items); got != "## Checklist\n- [ ] Only item" {
+		t.Errorf("Expected checklist alone for an empty description, got %q", got)
+	}
+	if got := appendChecklist("Description", nil); got != "Description" {
+		t.Errorf("Expected description unchanged without a checklist, got %q", got)
+	}
+}
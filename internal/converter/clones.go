@@ -0,0 +1,91 @@
+package converter
+
+import (
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// isCloneLink reports whether a link is Jira's built-in clone link
+// ("Cloners": clones / is cloned by)
+func isCloneLink(link *jirapb.IssueLink) bool {
+	linkType := link.GetType()
+	return strings.EqualFold(linkType.GetName(), "Cloners") || strings.EqualFold(linkType.GetOutward(), "clones")
+}
+
+// cloneSource returns the key of the issue this issue was cloned from, or
+// "" if it isn't a clone
+func cloneSource(jiraIssue *jirapb.Issue) string {
+	for _, link := range jiraIssue.GetFields().GetIssueLinks() {
+		if isCloneLink(link) && link.OutwardIssue != nil {
+			return link.OutwardIssue.Key
+		}
+	}
+	return ""
+}
+
+// hasClones reports whether other issues were cloned from this one
+func hasClones(jiraIssue *jirapb.Issue) bool {
+	for _, link := range jiraIssue.GetFields().GetIssueLinks() {
+		if isCloneLink(link) && link.InwardIssue != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneFamily returns the original issue a chain of clones started from,
+// following clone sources through the export
+func (c *ProtoConverter) cloneFamily(jiraIssue *jirapb.Issue) string {
+	root := jiraIssue.Key
+	visited := map[string]bool{root: true}
+	for source := cloneSource(jiraIssue); source != "" && !visited[source]; {
+		root = source
+		visited[source] = true
+
+		next, ok := c.issueMap[source]
+		if !ok {
+			break
+		}
+		source = cloneSource(next)
+	}
+	return root
+}
+
+// addCloneMetadata records the clone source in clonedFrom and, for clones
+// and cloned originals alike, the original issue in cloneFamily
+func (c *ProtoConverter) addCloneMetadata(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	source := cloneSource(jiraIssue)
+	if source != "" {
+		setCustomMetadata(metadata, "clonedFrom", source)
+	}
+	if source != "" || hasClones(jiraIssue) {
+		setCustomMetadata(metadata, "cloneFamily", c.cloneFamily(jiraIssue))
+	}
+}
+
+// inheritFromCloneSource copies labels and the epic of the issue a clone
+// was made from, when enabled and the source is part of the export
+func (c *ProtoConverter) inheritFromCloneSource(jiraIssue *jirapb.Issue, issue *beadspb.Issue) {
+	if !c.options.CopyCloneLabels && !c.options.CopyCloneEpic {
+		return
+	}
+	source, ok := c.issueMap[cloneSource(jiraIssue)]
+	if !ok {
+		return
+	}
+
+	if c.options.CopyCloneLabels {
+		labels := append([]string(nil), issue.Labels...)
+		for _, label := range c.renameLabels(source.Fields.GetLabels()) {
+			if !contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		issue.Labels = labels
+	}
+	if c.options.CopyCloneEpic && issue.Epic == "" {
+		issue.Epic = c.epicFor(source)
+	}
+}
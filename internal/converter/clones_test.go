@@ -0,0 +1,116 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// cloneLink links issue to the issue it was cloned from
+func cloneLink(issue, source *jirapb.Issue) {
+	linkType := &jirapb.IssueLinkType{Name: "Cloners", Inward: "is cloned by", Outward: "clones"}
+	issue.Fields.IssueLinks = append(issue.Fields.IssueLinks, &jirapb.IssueLink{
+		Type:         linkType,
+		OutwardIssue: &jirapb.LinkedIssue{Key: source.Key},
+	})
+	source.Fields.IssueLinks = append(source.Fields.IssueLinks, &jirapb.IssueLink{
+		Type:        linkType,
+		InwardIssue: &jirapb.LinkedIssue{Key: issue.Key},
+	})
+}
+
+func TestCloneMetadata(t *testing.T) {
+	original := warningTestIssue("PROJ-1")
+	clone := warningTestIssue("PROJ-2")
+	cloneOfClone := warningTestIssue("PROJ-3")
+	unrelated := warningTestIssue("PROJ-4")
+	cloneLink(clone, original)
+	cloneLink(cloneOfClone, clone)
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{
+		Issues: []*jirapb.Issue{original, clone, cloneOfClone, unrelated},
+	})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	expected := []struct{ clonedFrom, family string }{
+		{"", "PROJ-1"},
+		{"PROJ-1", "PROJ-1"},
+		{"PROJ-2", "PROJ-1"},
+		{"", ""},
+	}
+	for i, want := range expected {
+		custom := export.Issues[i].Metadata.Custom
+		if custom["clonedFrom"] != want.clonedFrom || custom["cloneFamily"] != want.family {
+			t.Errorf("%s: expected clonedFrom %q and cloneFamily %q, got %q and %q", export.Issues[i].Id,
+				want.clonedFrom, want.family, custom["clonedFrom"], custom["cloneFamily"])
+		}
+	}
+
+	// Clones are not dependencies
+	for _, issue := range export.Issues {
+		if len(issue.DependsOn) != 0 {
+			t.Errorf("Expected no dependencies for %s, got %v", issue.Id, issue.DependsOn)
+		}
+	}
+}
+
+func TestCloneOfIssueOutsideExport(t *testing.T) {
+	clone := warningTestIssue("PROJ-2")
+	clone.Fields.IssueLinks = []*jirapb.IssueLink{{
+		Type:         &jirapb.IssueLinkType{Name: "Cloners", Inward: "is cloned by", Outward: "clones"},
+		OutwardIssue: &jirapb.LinkedIssue{Key: "OLD-7"},
+	}}
+
+	export, err := NewProtoConverterWithOptions(Options{CopyCloneLabels: true, CopyCloneEpic: true}).
+		Convert(&jirapb.Export{Issues: []*jirapb.Issue{clone}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	custom := export.Issues[0].Metadata.Custom
+	if custom["clonedFrom"] != "OLD-7" || custom["cloneFamily"] != "OLD-7" {
+		t.Errorf("Expected clone of OLD-7, got %v", custom)
+	}
+}
+
+func TestCloneInheritsLabelsAndEpic(t *testing.T) {
+	epic := warningTestIssue("PROJ-10")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+
+	original := warningTestIssue("PROJ-1")
+	original.Fields.Labels = []string{"backend", "q3"}
+	original.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+	clone := warningTestIssue("PROJ-2")
+	clone.Fields.Labels = []string{"q3", "hotfix"}
+	cloneLink(clone, original)
+
+	jiraExport := &jirapb.Export{Issues: []*jirapb.Issue{epic, original, clone}}
+
+	export, err := NewProtoConverter().Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if got := export.Issues[1]; strings.Join(got.Labels, ",") != "q3,hotfix" || got.Epic != "" {
+		t.Errorf("Expected nothing inherited by default, got labels %v and epic %q", got.Labels, got.Epic)
+	}
+
+	export, err = NewProtoConverterWithOptions(Options{CopyCloneLabels: true, CopyCloneEpic: true}).Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	got := export.Issues[1]
+	if strings.Join(got.Labels, ",") != "q3,hotfix,backend" {
+		t.Errorf("Expected labels q3,hotfix,backend, got %v", got.Labels)
+	}
+	if got.Epic != "proj-10" {
+		t.Errorf("Expected epic proj-10 from the source, got %q", got.Epic)
+	}
+	if strings.Join(clone.Fields.Labels, ",") != "q3,hotfix" {
+		t.Errorf("Expected Jira labels to be left unchanged, got %v", clone.Fields.Labels)
+	}
+}
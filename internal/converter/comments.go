@@ -0,0 +1,60 @@
+package converter
+
+import (
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// convertComments maps Jira comments to beads comments, oldest first, with
+// bodies converted to Markdown. With MaxComments set only the most recent
+// comments are kept.
+func (c *ProtoConverter) convertComments(jiraIssue *jirapb.Issue) []*beadspb.Comment {
+	comments := jiraIssue.Fields.Comments
+	if max := c.options.MaxComments; max > 0 && len(comments) > max {
+		comments = comments[len(comments)-max:]
+	}
+
+	var converted []*beadspb.Comment
+	for _, comment := range comments {
+		converted = append(converted, &beadspb.Comment{
+			Author:  userName(comment.Author),
+			Body:    c.renderMarkup(jiraIssue.Key, comment.Body, comment.BodyAdf),
+			Created: comment.Created,
+		})
+	}
+	return converted
+}
+
+// convertAttachments maps Jira attachment metadata to beads attachments.
+// File contents are not downloaded; the URL points back to Jira.
+func (c *ProtoConverter) convertAttachments(jiraIssue *jirapb.Issue) []*beadspb.Attachment {
+	if c.options.SkipAttachments {
+		return nil
+	}
+
+	var converted []*beadspb.Attachment
+	for _, attachment := range jiraIssue.Fields.Attachments {
+		converted = append(converted, &beadspb.Attachment{
+			Id:       attachment.Id,
+			Filename: attachment.Filename,
+			Url:      attachment.Content,
+			MimeType: attachment.MimeType,
+			Size:     attachment.Size,
+			Author:   userName(attachment.Author),
+			Created:  attachment.Created,
+		})
+	}
+	return converted
+}
+
+// userName identifies a Jira user by email address, falling back to the
+// display name when the email is hidden
+func userName(user *jirapb.User) string {
+	if user == nil {
+		return ""
+	}
+	if user.EmailAddress != "" {
+		return user.EmailAddress
+	}
+	return user.DisplayName
+}
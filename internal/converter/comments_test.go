@@ -0,0 +1,92 @@
+package converter
+
+import (
+	"fmt"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// commentedTestIssue returns an issue with n comments and one attachment
+func commentedTestIssue(n int) *jirapb.Issue {
+	issue := warningTestIssue("PROJ-1")
+	for i := 1; i <= n; i++ {
+		issue.Fields.Comments = append(issue.Fields.Comments, &jirapb.Comment{
+			Id:     fmt.Sprintf("%d", i),
+			Author: &jirapb.User{DisplayName: "Jane Doe", EmailAddress: "jane@example.com"},
+			Body:   fmt.Sprintf("Comment %d", i),
+		})
+	}
+	issue.Fields.Attachments = []*jirapb.Attachment{{
+		Id:       "200",
+		Filename: "trace.log",
+		Author:   &jirapb.User{DisplayName: "John Smith"},
+		Size:     2048,
+		MimeType: "text/plain",
+		Content:  "https://jira.example.com/secure/attachment/200/trace.log",
+	}}
+	return issue
+}
+
+func TestConvertComments(t *testing.T) {
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{commentedTestIssue(3)}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	comments := export.Issues[0].Comments
+	if len(comments) != 3 {
+		t.Fatalf("Expected 3 comments, got %d", len(comments))
+	}
+	if comments[0].Author != "jane@example.com" {
+		t.Errorf("Expected author jane@example.com, got %s", comments[0].Author)
+	}
+	if comments[0].Body != "Comment 1" || comments[2].Body != "Comment 3" {
+		t.Errorf("Expected comments oldest first, got %q ... %q", comments[0].Body, comments[2].Body)
+	}
+
+	attachments := export.Issues[0].Attachments
+	if len(attachments) != 1 {
+		t.Fatalf("Expected 1 attachment, got %d", len(attachments))
+	}
+	if attachments[0].Author != "John Smith" {
+		t.Errorf("Expected attachment author to fall back to display name, got %s", attachments[0].Author)
+	}
+	if attachments[0].Url != "https://jira.example.com/secure/attachment/200/trace.log" {
+		t.Errorf("Expected attachment URL, got %s", attachments[0].Url)
+	}
+	if attachments[0].Id != "200" {
+		t.Errorf("Expected attachment ID 200, got %s", attachments[0].Id)
+	}
+}
+
+func TestConvertCommentsMaxComments(t *testing.T) {
+	converter := NewProtoConverterWithOptions(Options{MaxComments: 2})
+	export, err := converter.Convert(&jirapb.Export{Issues: []*jirapb.Issue{commentedTestIssue(5)}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	comments := export.Issues[0].Comments
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+	if comments[0].Body != "Comment 4" || comments[1].Body != "Comment 5" {
+		t.Errorf("Expected the most recent comments, got %q and %q", comments[0].Body, comments[1].Body)
+	}
+}
+
+func TestConvertSkipAttachments(t *testing.T) {
+	converter := NewProtoConverterWithOptions(Options{SkipAttachments: true})
+	export, err := converter.Convert(&jirapb.Export{Issues: []*jirapb.Issue{commentedTestIssue(1)}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(export.Issues[0].Attachments) != 0 {
+		t.Errorf("Expected no attachments, got %d", len(export.Issues[0].Attachments))
+	}
+	if len(export.Issues[0].Comments) != 1 {
+		t.Errorf("Expected comments to be kept, got %d", len(export.Issues[0].Comments))
+	}
+}
@@ -0,0 +1,143 @@
+package converter
+
+import (
+	"sort"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
+// skippedKind is the kind of a Jira issue left out of an export
+const skippedKind = "skipped"
+
+// MappingChange is how one Jira issue converts differently in two exports
+// of the same Jira data, e.g. under an old and a new mapping file
+type MappingChange struct {
+	JiraKey string
+	Title   string
+	Fields  []FieldChange
+}
+
+// FieldChange is a field that differs between two conversions of an issue
+type FieldChange struct {
+	Field  string // kind, status, priority, disposition or labels
+	Before string
+	After  string
+}
+
+// mappedFields is the part of a converted issue or epic that mappings decide
+type mappedFields struct {
+	title       string
+	kind        string // epic, issue or skipped
+	status      string
+	priority    string
+	disposition string
+	labels      string
+}
+
+// CompareExports reports the issues whose kind, status, priority,
+// disposition or labels differ between two conversions of the same Jira
+// issues, sorted by Jira key. Issues missing from one export, such as
+// issues of a type one mapping skips, are compared as skipped.
+func CompareExports(before, after *beadspb.Export) []MappingChange {
+	old, updated := indexMapped(before), indexMapped(after)
+
+	keys := make([]string, 0, len(old)+len(updated))
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range updated {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []MappingChange
+	for _, key := range keys {
+		a, b := old[key], updated[key]
+		if a.kind == "" {
+			a.kind = skippedKind
+		}
+		if b.kind == "" {
+			b.kind = skippedKind
+		}
+
+		change := MappingChange{JiraKey: key, Title: b.title}
+		if change.Title == "" {
+			change.Title = a.title
+		}
+		add := func(field, before, after string) {
+			if before != after {
+				change.Fields = append(change.Fields, FieldChange{Field: field, Before: before, After: after})
+			}
+		}
+		add("kind", a.kind, b.kind)
+		if a.kind != skippedKind && b.kind != skippedKind {
+			add("status", a.status, b.status)
+			add("priority", a.priority, b.priority)
+			add("disposition", a.disposition, b.disposition)
+			add("labels", a.labels, b.labels)
+		}
+		if len(change.Fields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// indexMapped indexes the issues and epics of an export by Jira key
+func indexMapped(export *beadspb.Export) map[string]mappedFields {
+	indexed := make(map[string]mappedFields)
+	for _, epic := range export.GetEpics() {
+		indexed[epic.Metadata.GetJiraKey()] = mappedFields{
+			title:       epic.Name,
+			kind:        "epic",
+			status:      statusName(epic.Status),
+			disposition: dispositionName(epic.Disposition),
+		}
+	}
+	for _, issue := range export.GetIssues() {
+		indexed[issue.Metadata.GetJiraKey()] = mappedFields{
+			title:       issue.Title,
+			kind:        "issue",
+			status:      statusName(issue.Status),
+			priority:    priorityName(issue.Priority),
+			disposition: dispositionName(issue.Disposition),
+			labels:      strings.Join(issue.Labels, ", "),
+		}
+	}
+	return indexed
+}
+
+// statusName is the inverse of ParseStatus
+func statusName(status beadspb.Status) string {
+	switch status {
+	case beadspb.Status_STATUS_OPEN:
+		return "open"
+	case beadspb.Status_STATUS_IN_PROGRESS:
+		return "in_progress"
+	case beadspb.Status_STATUS_BLOCKED:
+		return "blocked"
+	case beadspb.Status_STATUS_CLOSED:
+		return "closed"
+	default:
+		return ""
+	}
+}
+
+// dispositionName is the inverse of ParseDisposition
+func dispositionName(disposition beadspb.Disposition) string {
+	switch disposition {
+	case beadspb.Disposition_DISPOSITION_DONE:
+		return "done"
+	case beadspb.Disposition_DISPOSITION_WONT_DO:
+		return "wont-do"
+	case beadspb.Disposition_DISPOSITION_DUPLICATE:
+		return "duplicate"
+	case beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE:
+		return "cannot-reproduce"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestCompareExports(t *testing.T) {
+	triage := warningTestIssue("PROJ-1")
+	triage.Fields.Status = &jirapb.Status{Name: "Triage", StatusCategory: &jirapb.StatusCategory{Key: "new"}}
+	blocker := warningTestIssue("PROJ-2")
+	blocker.Fields.Priority = &jirapb.Priority{Name: "Blocker"}
+	spike := warningTestIssue("PROJ-3")
+	spike.Fields.IssueType = &jirapb.IssueType{Name: "Spike"}
+	unchanged := warningTestIssue("PROJ-4")
+	jiraExport := &jirapb.Export{Issues: []*jirapb.Issue{triage, blocker, spike, unchanged}}
+
+	before, _, err := NewProtoConverter().ConvertWithWarnings(jiraExport)
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+	after, _, err := NewProtoConverterWithOptions(Options{
+		StatusMap:   map[string]beadspb.Status{"triage": beadspb.Status_STATUS_BLOCKED},
+		PriorityMap: map[string]beadspb.Priority{"blocker": beadspb.Priority_PRIORITY_P0},
+		IssueTypes:  map[string]IssueClass{"spike": IssueClassSkip},
+	}).ConvertWithWarnings(jiraExport)
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	want := []MappingChange{
+		{JiraKey: "PROJ-1", Title: "Issue PROJ-1", Fields: []FieldChange{{Field: "status", Before: "open", After: "blocked"}}},
+		{JiraKey: "PROJ-2", Title: "Issue PROJ-2", Fields: []FieldChange{{Field: "priority", Before: "p2", After: "p0"}}},
+		{JiraKey: "PROJ-3", Title: "Issue PROJ-3", Fields: []FieldChange{{Field: "kind", Before: "issue", After: "skipped"}}},
+	}
+	if got := CompareExports(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected changes:\n got: %+v\nwant: %+v", got, want)
+	}
+	if got := CompareExports(before, before); len(got) != 0 {
+		t.Errorf("Expected no changes comparing an export with itself, got %+v", got)
+	}
+}
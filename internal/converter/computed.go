@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"strconv"
+	"time"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// addComputedFields records ageDays (created to now) and, for resolved
+// issues, cycleTimeDays (created to resolved) in metadata so local reports
+// don't need to do date math
+func (c *ProtoConverter) addComputedFields(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	if !c.options.ComputedFields || jiraIssue.Fields.Created == nil {
+		return
+	}
+
+	created := jiraIssue.Fields.Created.AsTime()
+	setCustomMetadata(metadata, "ageDays", strconv.Itoa(wholeDays(c.options.Now().Sub(created))))
+
+	if jiraIssue.Fields.Resolved != nil {
+		cycleTime := jiraIssue.Fields.Resolved.AsTime().Sub(created)
+		setCustomMetadata(metadata, "cycleTimeDays", strconv.Itoa(wholeDays(cycleTime)))
+	}
+}
+
+// wholeDays converts a duration to whole days, never returning a negative value
+func wholeDays(d time.Duration) int {
+	if d < 0 {
+		return 0
+	}
+	return int(d.Hours() / 24)
+}
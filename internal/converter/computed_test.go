@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAddComputedFields(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	open := warningTestIssue("PROJ-1")
+	open.Fields.Created = timestamppb.New(created)
+
+	closed := warningTestIssue("PROJ-2")
+	closed.Fields.Created = timestamppb.New(created)
+	closed.Fields.Resolved = timestamppb.New(created.Add(10*24*time.Hour + 3*time.Hour))
+	closed.Fields.Status = &jirapb.Status{Name: "Done", StatusCategory: &jirapb.StatusCategory{Key: "done"}}
+
+	conv := NewProtoConverterWithOptions(Options{
+		ComputedFields: true,
+		Now:            func() time.Time { return now },
+	})
+
+	export, err := conv.Convert(&jirapb.Export{Issues: []*jirapb.Issue{open, closed}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	openMeta := export.Issues[0].Metadata.Custom
+	if openMeta["ageDays"] != "60" {
+		t.Errorf("Expected ageDays 60, got %q", openMeta["ageDays"])
+	}
+	if _, ok := openMeta["cycleTimeDays"]; ok {
+		t.Error("Expected no cycleTimeDays for unresolved issue")
+	}
+
+	closedMeta := export.Issues[1].Metadata.Custom
+	if closedMeta["cycleTimeDays"] != "10" {
+		t.Errorf("Expected cycleTimeDays 10, got %q", closedMeta["cycleTimeDays"])
+	}
+}
+
+func TestAddComputedFieldsDisabled(t *testing.T) {
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Created = timestamppb.New(time.Now().Add(-48 * time.Hour))
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if _, ok := export.Issues[0].Metadata.Custom["ageDays"]; ok {
+		t.Error("Expected no computed fields when disabled")
+	}
+}
+
+func TestWholeDays(t *testing.T) {
+	if got := wholeDays(47 * time.Hour); got != 1 {
+		t.Errorf("Expected 1 day, got %d", got)
+	}
+	if got := wholeDays(-time.Hour); got != 0 {
+		t.Errorf("Expected negative durations to clamp to 0, got %d", got)
+	}
+}
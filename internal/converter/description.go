@@ -0,0 +1,41 @@
+package converter
+
+import (
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/markup"
+)
+
+// renderMarkup converts Jira rich text to Markdown: an ADF document when
+// the v3 API returned one, wiki markup otherwise. A malformed ADF document
+// is reported and kept as is so no content is lost.
+func (c *ProtoConverter) renderMarkup(jiraKey, text, adf string) string {
+	if adf == "" {
+		return markup.WikiToMarkdown(text)
+	}
+
+	rendered, err := markup.ADFToMarkdown([]byte(adf))
+	if err != nil {
+		c.warn(WarningInvalidMarkup, jiraKey, "kept rich text unconverted: %v", err)
+		return adf
+	}
+	return rendered
+}
+
+// preserveRawDescription keeps the description as Jira returned it in
+// rawDescription metadata, with its format in rawDescriptionFormat
+func (c *ProtoConverter) preserveRawDescription(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	if !c.options.PreserveRawDescription {
+		return
+	}
+
+	fields := jiraIssue.Fields
+	switch {
+	case fields.DescriptionAdf != "":
+		setCustomMetadata(metadata, "rawDescription", fields.DescriptionAdf)
+		setCustomMetadata(metadata, "rawDescriptionFormat", "adf")
+	case fields.Description != "":
+		setCustomMetadata(metadata, "rawDescription", fields.Description)
+		setCustomMetadata(metadata, "rawDescriptionFormat", "wiki")
+	}
+}
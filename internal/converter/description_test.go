@@ -0,0 +1,128 @@
+package converter
+
+import (
+	"strconv"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+const descriptionTestADF = `{"type":"doc","content":[
+	{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Steps"}]},
+	{"type":"bulletList","content":[
+		{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"reproduce"}]}]}
+	]}
+]}`
+
+func TestConvertDescriptionMarkup(t *testing.T) {
+	wiki := warningTestIssue("PROJ-1")
+	wiki.Fields.Description = "h2. Steps\n* *reproduce*"
+
+	adf := warningTestIssue("PROJ-2")
+	adf.Fields.DescriptionAdf = descriptionTestADF
+	adf.Fields.Comments = []*jirapb.Comment{
+		{Id: "1", BodyAdf: `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"done","marks":[{"type":"em"}]}]}]}`},
+		{Id: "2", Body: "_wiki_ comment"},
+	}
+
+	invalid := warningTestIssue("PROJ-3")
+	invalid.Fields.DescriptionAdf = `{"type":"paragraph"}`
+
+	export, warnings, err := NewProtoConverter().ConvertWithWarnings(&jirapb.Export{
+		Issues: []*jirapb.Issue{wiki, adf, invalid},
+	})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if got := export.Issues[0].Description; got != "## Steps\n- **reproduce**" {
+		t.Errorf("Expected wiki description as Markdown, got %q", got)
+	}
+	if got := export.Issues[1].Description; got != "## Steps\n\n- reproduce" {
+		t.Errorf("Expected ADF description as Markdown, got %q", got)
+	}
+
+	comments := export.Issues[1].Comments
+	if len(comments) != 2 || comments[0].Body != "*done*" || comments[1].Body != "*wiki* comment" {
+		t.Errorf("Expected comment bodies as Markdown, got %v", comments)
+	}
+
+	if got := export.Issues[2].Description; got != invalid.Fields.DescriptionAdf {
+		t.Errorf("Expected invalid ADF kept as is, got %q", got)
+	}
+	if invalidMarkup := warnings.OfKind(WarningInvalidMarkup); len(invalidMarkup) != 1 || invalidMarkup[0].JiraKey != "PROJ-3" {
+		t.Errorf("Expected one invalid_markup warning for PROJ-3, got %v", invalidMarkup)
+	}
+
+	if custom := export.Issues[0].Metadata.Custom; custom["rawDescription"] != "" {
+		t.Errorf("Expected no raw description by default, got %q", custom["rawDescription"])
+	}
+}
+
+func TestConvertPreserveRawDescription(t *testing.T) {
+	wiki := warningTestIssue("PROJ-1")
+	wiki.Fields.Description = "h2. Steps"
+
+	adf := warningTestIssue("PROJ-2")
+	adf.Fields.DescriptionAdf = descriptionTestADF
+
+	empty := warningTestIssue("PROJ-3")
+
+	c := NewProtoConverterWithOptions(Options{PreserveRawDescription: true})
+	export, err := c.Convert(&jirapb.Export{Issues: []*jirapb.Issue{wiki, adf, empty}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	tests := []struct {
+		raw    string
+		format string
+	}{
+		{"h2. Steps", "wiki"},
+		{descriptionTestADF, "adf"},
+		{"", ""},
+	}
+	for i, tt := range tests {
+		custom := export.Issues[i].Metadata.Custom
+		if custom["rawDescription"] != tt.raw || custom["rawDescriptionFormat"] != tt.format {
+			t.Errorf("Issue %d: expected raw %q (%s), got %q (%s)", i, tt.raw, tt.format,
+				custom["rawDescription"], custom["rawDescriptionFormat"])
+		}
+	}
+	if export.Issues[0].Description != "## Steps" {
+		t.Errorf("Expected converted description alongside raw, got %q", export.Issues[0].Description)
+	}
+}
+
+func TestConvertDescriptionKeepsCodeBlocks(t *testing.T) {
+	code := "kubectl apply -f - <<EOF\napiVersion: v1\nkind: ConfigMap\ndata:\n  key: \"*value*\"\n\tindented: {{x}}\nEOF"
+
+	wiki := warningTestIssue("PROJ-1")
+	wiki.Fields.Description = "Repro:\n{code:language=bash}\n" + code + "\n{code}"
+
+	adf := warningTestIssue("PROJ-2")
+	adf.Fields.DescriptionAdf = `{"type":"doc","content":[{"type":"codeBlock","attrs":{"language":"bash"},"content":[{"type":"text","text":` +
+		strconv.Quote(code) + `}]}]}`
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{wiki, adf}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if got, want := export.Issues[0].Description, "Repro:\n```bash\n"+code+"\n```"; got != want {
+		t.Errorf("Expected the wiki code block byte for byte:\n%s\ngot:\n%s", want, got)
+	}
+	if got, want := export.Issues[1].Description, "```bash\n"+code+"\n```"; got != want {
+		t.Errorf("Expected the ADF code block byte for byte:\n%s\ngot:\n%s", want, got)
+	}
+
+	truncated := warningTestIssue("PROJ-3")
+	truncated.Fields.Description = wiki.Fields.Description
+	conv := NewProtoConverterWithOptions(Options{MaxDescriptionLength: 40})
+	export, err = conv.Convert(&jirapb.Export{Issues: []*jirapb.Issue{truncated}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if got := export.Issues[0].Description; got != "Repro:" {
+		t.Errorf("Expected truncation to drop the partial code block, got %q", got)
+	}
+}
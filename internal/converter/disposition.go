@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// defaultDispositions maps the resolutions Jira ships with, and common
+// custom ones, by lower-cased name
+var defaultDispositions = map[string]beadspb.Disposition{
+	"done":                beadspb.Disposition_DISPOSITION_DONE,
+	"fixed":               beadspb.Disposition_DISPOSITION_DONE,
+	"resolved":            beadspb.Disposition_DISPOSITION_DONE,
+	"complete":            beadspb.Disposition_DISPOSITION_DONE,
+	"completed":           beadspb.Disposition_DISPOSITION_DONE,
+	"won't do":            beadspb.Disposition_DISPOSITION_WONT_DO,
+	"won't fix":           beadspb.Disposition_DISPOSITION_WONT_DO,
+	"declined":            beadspb.Disposition_DISPOSITION_WONT_DO,
+	"rejected":            beadspb.Disposition_DISPOSITION_WONT_DO,
+	"abandoned":           beadspb.Disposition_DISPOSITION_WONT_DO,
+	"obsolete":            beadspb.Disposition_DISPOSITION_WONT_DO,
+	"incomplete":          beadspb.Disposition_DISPOSITION_WONT_DO,
+	"works as designed":   beadspb.Disposition_DISPOSITION_WONT_DO,
+	"duplicate":           beadspb.Disposition_DISPOSITION_DUPLICATE,
+	"cannot reproduce":    beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE,
+	"can't reproduce":     beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE,
+	"unable to reproduce": beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE,
+	"not reproducible":    beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE,
+}
+
+// ParseDisposition parses a beads disposition name (done, wont-do,
+// duplicate, cannot-reproduce)
+func ParseDisposition(name string) (beadspb.Disposition, error) {
+	switch strings.ToLower(name) {
+	case "done":
+		return beadspb.Disposition_DISPOSITION_DONE, nil
+	case "wont-do":
+		return beadspb.Disposition_DISPOSITION_WONT_DO, nil
+	case "duplicate":
+		return beadspb.Disposition_DISPOSITION_DUPLICATE, nil
+	case "cannot-reproduce":
+		return beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE, nil
+	default:
+		return beadspb.Disposition_DISPOSITION_UNSPECIFIED, fmt.Errorf("unknown beads disposition %q", name)
+	}
+}
+
+// convertDisposition derives why a closed issue was closed from its Jira
+// resolution. Issues that aren't closed have no disposition, and closed
+// issues without a resolution count as done.
+func (c *ProtoConverter) convertDisposition(jiraIssue *jirapb.Issue, status beadspb.Status) beadspb.Disposition {
+	disposition, _, known := c.explainDisposition(jiraIssue, status)
+	if !known {
+		c.warn(WarningUnknownResolution, jiraIssue.Key,
+			"unknown resolution %q, defaulting to done", jiraIssue.Fields.GetResolution().GetName())
+	}
+	return disposition
+}
+
+// explainDisposition derives a disposition like convertDisposition, without
+// warning, and describes the rule that decided it
+func (c *ProtoConverter) explainDisposition(jiraIssue *jirapb.Issue, status beadspb.Status) (beadspb.Disposition, string, bool) {
+	if status != beadspb.Status_STATUS_CLOSED {
+		return beadspb.Disposition_DISPOSITION_UNSPECIFIED, "not closed", true
+	}
+
+	resolution := jiraIssue.Fields.GetResolution().GetName()
+	if resolution == "" {
+		return beadspb.Disposition_DISPOSITION_DONE, "closed without a resolution, defaulting to done", true
+	}
+
+	// Jira Cloud spells "Won’t Do" with a typographic apostrophe
+	name := strings.ToLower(strings.ReplaceAll(resolution, "’", "'"))
+	if disposition, ok := c.options.DispositionMap[name]; ok {
+		return disposition, "resolutions in mapping.yml", true
+	}
+	if disposition, ok := defaultDispositions[name]; ok {
+		return disposition, "built-in resolution", true
+	}
+	return beadspb.Disposition_DISPOSITION_DONE, "unknown resolution, defaulting to done", false
+}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestConvertDisposition(t *testing.T) {
+	closed := &jirapb.Status{Name: "Done", StatusCategory: &jirapb.StatusCategory{Key: "done"}}
+
+	tests := []struct {
+		name       string
+		status     *jirapb.Status
+		resolution string
+		want       beadspb.Disposition
+		warns      bool
+	}{
+		{name: "open issue", resolution: "Won't Do", want: beadspb.Disposition_DISPOSITION_UNSPECIFIED},
+		{name: "done", status: closed, resolution: "Done", want: beadspb.Disposition_DISPOSITION_DONE},
+		{name: "closed without resolution", status: closed, want: beadspb.Disposition_DISPOSITION_DONE},
+		{name: "won't do", status: closed, resolution: "Won't Do", want: beadspb.Disposition_DISPOSITION_WONT_DO},
+		{name: "typographic apostrophe", status: closed, resolution: "Won’t Fix", want: beadspb.Disposition_DISPOSITION_WONT_DO},
+		{name: "duplicate", status: closed, resolution: "Duplicate", want: beadspb.Disposition_DISPOSITION_DUPLICATE},
+		{name: "cannot reproduce", status: closed, resolution: "Cannot Reproduce", want: beadspb.Disposition_DISPOSITION_CANNOT_REPRODUCE},
+		{name: "custom mapping", status: closed, resolution: "Out of Scope", want: beadspb.Disposition_DISPOSITION_WONT_DO},
+		{name: "mapping overrides default", status: closed, resolution: "Obsolete", want: beadspb.Disposition_DISPOSITION_DONE},
+		{name: "unknown resolution", status: closed, resolution: "Shipped Elsewhere", want: beadspb.Disposition_DISPOSITION_DONE, warns: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jiraIssue := warningTestIssue("PROJ-1")
+			if tt.status != nil {
+				jiraIssue.Fields.Status = tt.status
+			}
+			if tt.resolution != "" {
+				jiraIssue.Fields.Resolution = &jirapb.Resolution{Name: tt.resolution}
+			}
+
+			conv := NewProtoConverterWithOptions(Options{
+				DispositionMap: map[string]beadspb.Disposition{
+					"Out of Scope": beadspb.Disposition_DISPOSITION_WONT_DO,
+					"obsolete":     beadspb.Disposition_DISPOSITION_DONE,
+				},
+			})
+			export, warnings, err := conv.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{jiraIssue}})
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			if got := export.Issues[0].Disposition; got != tt.want {
+				t.Errorf("Expected disposition %v, got %v", tt.want, got)
+			}
+			if got := len(warnings.OfKind(WarningUnknownResolution)) > 0; got != tt.warns {
+				t.Errorf("Expected unknown resolution warning %v, got %v", tt.warns, warnings)
+			}
+		})
+	}
+}
+
+func TestParseDisposition(t *testing.T) {
+	if got, err := ParseDisposition("Wont-Do"); err != nil || got != beadspb.Disposition_DISPOSITION_WONT_DO {
+		t.Errorf("Expected wont-do, got %v (%v)", got, err)
+	}
+	if _, err := ParseDisposition("abandoned"); err == nil {
+		t.Error("Expected an error for an unknown disposition")
+	}
+}
@@ -0,0 +1,59 @@
+package converter
+
+import (
+	"path"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// addEpicLabels copies the epic labels selected by Options.EpicLabels to an
+// issue. Subtasks get the labels of their parent's epic. Only epics in the
+// export are considered, since parent references carry no labels.
+func (c *ProtoConverter) addEpicLabels(jiraIssue *jirapb.Issue, issue *beadspb.Issue) {
+	if len(c.options.EpicLabels) == 0 {
+		return
+	}
+	epic := c.epicIssueOf(jiraIssue)
+	if epic == nil {
+		return
+	}
+
+	var selected []string
+	for _, label := range epic.Fields.GetLabels() {
+		if matchesAny(c.options.EpicLabels, label) {
+			selected = append(selected, label)
+		}
+	}
+	addLabels(issue, c.renameLabels(selected)...)
+}
+
+// epicIssueOf returns the Jira epic above an issue, walking up through
+// parents in the export, or nil if there is none. Parent chains have been
+// checked for loops and depth before conversion.
+func (c *ProtoConverter) epicIssueOf(jiraIssue *jirapb.Issue) *jirapb.Issue {
+	for parent := jiraIssue.Fields.GetParent(); parent != nil; {
+		current, ok := c.issueMap[parent.Key]
+		if !ok {
+			return nil
+		}
+		if c.IsEpicType(current.Fields.GetIssueType()) {
+			return current
+		}
+		parent = current.Fields.GetParent()
+	}
+	return nil
+}
+
+// matchesAny reports whether a label matches one of the patterns,
+// ignoring case
+func matchesAny(patterns []string, label string) bool {
+	label = strings.ToLower(label)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), label); ok {
+			return true
+		}
+	}
+	return false
+}
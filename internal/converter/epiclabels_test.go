@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestEpicLabels(t *testing.T) {
+	epic := warningTestIssue("PROJ-10")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Labels = []string{"Quarter:2024Q3", "initiative-payments", "design"}
+
+	story := warningTestIssue("PROJ-1")
+	story.Fields.Labels = []string{"backend"}
+	story.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+	subtask := warningTestIssue("PROJ-2")
+	subtask.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-1",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Story"}},
+	}
+	orphan := warningTestIssue("PROJ-3")
+	outside := warningTestIssue("PROJ-4")
+	outside.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-99",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+
+	converter := NewProtoConverterWithOptions(Options{
+		EpicLabels:   []string{"quarter:*", "initiative-payments"},
+		LabelRenames: map[string]string{"initiative-payments": "initiative:payments"},
+	})
+	export, err := converter.Convert(&jirapb.Export{Issues: []*jirapb.Issue{epic, story, subtask, orphan, outside}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	want := map[string]string{
+		"PROJ-1": "backend,Quarter:2024Q3,initiative:payments",
+		"PROJ-2": "Quarter:2024Q3,initiative:payments",
+		"PROJ-3": "",
+		"PROJ-4": "",
+	}
+	for _, issue := range export.Issues {
+		if got := strings.Join(issue.Labels, ","); got != want[issue.Metadata.JiraKey] {
+			t.Errorf("Expected labels %q on %s, got %q", want[issue.Metadata.JiraKey], issue.Metadata.JiraKey, got)
+		}
+	}
+	if strings.Join(story.Fields.Labels, ",") != "backend" {
+		t.Errorf("Expected the Jira labels to be left unchanged, got %v", story.Fields.Labels)
+	}
+}
+
+func TestEpicLabelsDisabledByDefault(t *testing.T) {
+	epic := warningTestIssue("PROJ-10")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Labels = []string{"quarter:2024Q3"}
+	story := warningTestIssue("PROJ-1")
+	story.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{epic, story}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(export.Issues[0].Labels) != 0 {
+		t.Errorf("Expected no epic labels without epic_labels, got %v", export.Issues[0].Labels)
+	}
+}
@@ -0,0 +1,35 @@
+package converter
+
+import (
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// nameEpic sets an epic's name from its summary or, when configured, its
+// epic name field. The value not used as the name is kept in metadata
+// (summary or epicName) or at the top of the description.
+func (c *ProtoConverter) nameEpic(jiraIssue *jirapb.Issue, epic *beadspb.Epic) {
+	summary := c.normalizeTitle(jiraIssue, epic.Metadata)
+	epic.Name = summary
+
+	field := jiraIssue.Fields.CustomFields[c.options.EpicNameField]
+	if c.options.EpicNameField == "" || field == "" || field == summary {
+		return
+	}
+
+	other, otherKey := field, "epicName"
+	if c.options.EpicNameFromField {
+		epic.Name = field
+		other, otherKey = summary, "summary"
+	}
+
+	if !c.options.EpicOtherInDescription {
+		setCustomMetadata(epic.Metadata, otherKey, other)
+		return
+	}
+	if epic.Description == "" {
+		epic.Description = other
+	} else {
+		epic.Description = other + "\n\n" + epic.Description
+	}
+}
@@ -0,0 +1,99 @@
+package converter
+
+import (
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func epicNameTestIssue() *jirapb.Issue {
+	epic := warningTestIssue("PROJ-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Summary = "Rebuild the checkout flow to support saved cards"
+	epic.Fields.Description = "Goals and scope"
+	epic.Fields.CustomFields = map[string]string{"customfield_10011": "Checkout v2"}
+	return epic
+}
+
+func TestEpicNameSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		wantName    string
+		wantDesc    string
+		wantMeta    map[string]string
+		notWantMeta []string
+	}{
+		{
+			name:        "summary without field",
+			opts:        Options{},
+			wantName:    "Rebuild the checkout flow to support saved cards",
+			wantDesc:    "Goals and scope",
+			notWantMeta: []string{"epicName", "summary"},
+		},
+		{
+			name:     "summary keeps epic name in metadata",
+			opts:     Options{EpicNameField: "customfield_10011"},
+			wantName: "Rebuild the checkout flow to support saved cards",
+			wantDesc: "Goals and scope",
+			wantMeta: map[string]string{"epicName": "Checkout v2"},
+		},
+		{
+			name:     "field keeps summary in metadata",
+			opts:     Options{EpicNameField: "customfield_10011", EpicNameFromField: true},
+			wantName: "Checkout v2",
+			wantDesc: "Goals and scope",
+			wantMeta: map[string]string{"summary": "Rebuild the checkout flow to support saved cards"},
+		},
+		{
+			name:        "field puts summary in description",
+			opts:        Options{EpicNameField: "customfield_10011", EpicNameFromField: true, EpicOtherInDescription: true},
+			wantName:    "Checkout v2",
+			wantDesc:    "Rebuild the checkout flow to support saved cards\n\nGoals and scope",
+			notWantMeta: []string{"summary"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export, err := NewProtoConverterWithOptions(tt.opts).Convert(&jirapb.Export{Issues: []*jirapb.Issue{epicNameTestIssue()}})
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			epic := export.Epics[0]
+			if epic.Name != tt.wantName {
+				t.Errorf("Expected name %q, got %q", tt.wantName, epic.Name)
+			}
+			if epic.Description != tt.wantDesc {
+				t.Errorf("Expected description %q, got %q", tt.wantDesc, epic.Description)
+			}
+			for key, want := range tt.wantMeta {
+				if got := epic.Metadata.Custom[key]; got != want {
+					t.Errorf("Expected %s metadata %q, got %q", key, want, got)
+				}
+			}
+			for _, key := range tt.notWantMeta {
+				if _, ok := epic.Metadata.Custom[key]; ok {
+					t.Errorf("Expected no %s metadata, got %q", key, epic.Metadata.Custom[key])
+				}
+			}
+		})
+	}
+}
+
+func TestEpicNameFromEmptyFieldKeepsSummary(t *testing.T) {
+	epic := epicNameTestIssue()
+	epic.Fields.CustomFields = nil
+
+	export, err := NewProtoConverterWithOptions(Options{EpicNameField: "customfield_10011", EpicNameFromField: true}).
+		Convert(&jirapb.Export{Issues: []*jirapb.Issue{epic}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if export.Epics[0].Name != "Rebuild the checkout flow to support saved cards" {
+		t.Errorf("Expected the summary when the field is empty, got %q", export.Epics[0].Name)
+	}
+	if len(export.Epics[0].Metadata.Custom) != 0 {
+		t.Errorf("Expected no extra metadata, got %v", export.Epics[0].Metadata.Custom)
+	}
+}
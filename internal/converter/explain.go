@@ -0,0 +1,314 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// Explanation says how one Jira issue became a beads record
+type Explanation struct {
+	JiraKey  string
+	ID       string // Beads ID, empty when the issue was skipped
+	Kind     string // epic, issue or skipped
+	Fields   []FieldExplanation
+	Warnings Warnings // Warnings raised for the issue
+}
+
+// FieldExplanation says how one field of a beads record was derived
+type FieldExplanation struct {
+	Field  string // Beads field, e.g. status
+	Value  string // Converted value; empty when unset
+	Source string // Jira field the value came from, e.g. status "In Review"
+	Rule   string // Mapping rule or default that decided the value
+}
+
+// Explain converts an export like ConvertWithWarnings and explains how the
+// issue with the given Jira key was converted. Epics and dependencies are
+// only resolved within the export, so it should hold the issue's parents
+// and linked issues, as a sync would.
+func (c *ProtoConverter) Explain(jiraExport *jirapb.Export, key string) (*Explanation, error) {
+	var jiraIssue *jirapb.Issue
+	for _, issue := range jiraExport.GetIssues() {
+		if strings.EqualFold(issue.Key, key) {
+			jiraIssue = issue
+		}
+	}
+	if jiraIssue == nil {
+		return nil, fmt.Errorf("%s is not in the export", key)
+	}
+
+	beadsExport, warnings, err := c.ConvertWithWarnings(jiraExport)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{JiraKey: jiraIssue.Key}
+	for _, warning := range warnings {
+		if warning.JiraKey == jiraIssue.Key {
+			explanation.Warnings = append(explanation.Warnings, warning)
+		}
+	}
+
+	issueType := jiraIssue.Fields.GetIssueType()
+	class, classRule, _ := c.explainClass(issueType)
+	kind := FieldExplanation{Field: "kind", Value: string(class), Source: fmt.Sprintf("issue type %q", issueType.GetName()), Rule: classRule}
+	switch {
+	case contains(c.optedOut, jiraIssue.Key):
+		explanation.Kind = skippedKind
+		explanation.Fields = []FieldExplanation{{
+			Field:  "kind",
+			Value:  skippedKind,
+			Source: fmt.Sprintf("labels %s", strings.Join(jiraIssue.Fields.GetLabels(), ", ")),
+			Rule:   fmt.Sprintf("opt-out label %q", c.options.OptOutLabel),
+		}}
+		return explanation, nil
+	case class == IssueClassSkip:
+		explanation.Kind = skippedKind
+		kind.Value = skippedKind
+		explanation.Fields = []FieldExplanation{kind}
+		return explanation, nil
+	}
+
+	for _, epic := range beadsExport.Epics {
+		if epic.Metadata.GetJiraKey() == jiraIssue.Key {
+			explanation.ID, explanation.Kind = epic.Id, "epic"
+			explanation.Fields = append([]FieldExplanation{kind}, c.explainEpic(jiraIssue, epic)...)
+			return explanation, nil
+		}
+	}
+	for _, issue := range beadsExport.Issues {
+		if issue.Metadata.GetJiraKey() == jiraIssue.Key {
+			explanation.ID, explanation.Kind = issue.Id, "issue"
+			explanation.Fields = append([]FieldExplanation{kind}, c.explainIssue(jiraIssue, issue, beadsExport)...)
+			return explanation, nil
+		}
+	}
+	return nil, fmt.Errorf("%s was not converted", jiraIssue.Key)
+}
+
+// explainEpic explains the fields of a converted epic
+func (c *ProtoConverter) explainEpic(jiraIssue *jirapb.Issue, epic *beadspb.Epic) []FieldExplanation {
+	fields := []FieldExplanation{c.explainID(jiraIssue, epic.Id)}
+
+	name := FieldExplanation{Field: "name", Value: epic.Name, Source: fmt.Sprintf("summary %q", jiraIssue.Fields.GetSummary()), Rule: "copied"}
+	if field := c.options.EpicNameField; c.options.EpicNameFromField && field != "" && epic.Name == jiraIssue.Fields.CustomFields[field] && epic.Name != jiraIssue.Fields.GetSummary() {
+		name.Source = fmt.Sprintf("%s %q", field, epic.Name)
+		name.Rule = "epic_name_field in mapping.yml"
+	} else if _, ok := epic.Metadata.GetCustom()["originalSummary"]; ok {
+		name.Rule = "rewritten by title rules"
+	}
+	fields = append(fields, name)
+
+	status, statusRule, _ := c.explainStatus(jiraIssue.Fields.GetStatus())
+	fields = append(fields,
+		FieldExplanation{Field: "status", Value: statusName(status), Source: describeStatus(jiraIssue.Fields.GetStatus()), Rule: statusRule},
+		c.explainDispositionField(jiraIssue, status),
+		c.explainDescription(jiraIssue, epic.Description),
+	)
+	return fields
+}
+
+// explainIssue explains the fields of a converted issue
+func (c *ProtoConverter) explainIssue(jiraIssue *jirapb.Issue, issue *beadspb.Issue, beadsExport *beadspb.Export) []FieldExplanation {
+	fields := []FieldExplanation{c.explainID(jiraIssue, issue.Id)}
+
+	title := FieldExplanation{Field: "title", Value: issue.Title, Source: fmt.Sprintf("summary %q", jiraIssue.Fields.GetSummary()), Rule: "copied"}
+	if _, ok := issue.Metadata.GetCustom()["originalSummary"]; ok {
+		title.Rule = "rewritten by title rules"
+	}
+	fields = append(fields, title)
+
+	status, statusRule, _ := c.explainStatus(jiraIssue.Fields.GetStatus())
+	fields = append(fields, FieldExplanation{Field: "status", Value: statusName(status), Source: describeStatus(jiraIssue.Fields.GetStatus()), Rule: statusRule})
+
+	_, priorityRule, _ := c.explainPriority(jiraIssue.Fields.GetPriority())
+	priority := FieldExplanation{Field: "priority", Value: priorityName(issue.Priority), Source: "no priority", Rule: priorityRule}
+	if name := jiraIssue.Fields.GetPriority().GetName(); name != "" {
+		priority.Source = fmt.Sprintf("priority %q", name)
+	}
+	if agedFrom, ok := issue.Metadata.GetCustom()["priorityAgedFrom"]; ok {
+		priority.Rule += fmt.Sprintf(", then raised from %s by priority aging", agedFrom)
+	}
+	fields = append(fields,
+		priority,
+		c.explainDispositionField(jiraIssue, status),
+		explainAssignee(jiraIssue, issue),
+		c.explainEpicLink(jiraIssue, issue, beadsExport),
+	)
+	fields = append(fields, c.explainLabels(jiraIssue, issue)...)
+	fields = append(fields, c.explainDependencies(jiraIssue, issue)...)
+	fields = append(fields, c.explainDescription(jiraIssue, issue.Description))
+	return fields
+}
+
+// explainID explains a record's beads ID
+func (c *ProtoConverter) explainID(jiraIssue *jirapb.Issue, id string) FieldExplanation {
+	return FieldExplanation{Field: "id", Value: id, Source: fmt.Sprintf("key %s", jiraIssue.Key), Rule: "lower-cased Jira key"}
+}
+
+// explainDispositionField explains a record's disposition
+func (c *ProtoConverter) explainDispositionField(jiraIssue *jirapb.Issue, status beadspb.Status) FieldExplanation {
+	disposition, rule, _ := c.explainDisposition(jiraIssue, status)
+	field := FieldExplanation{Field: "disposition", Value: dispositionName(disposition), Source: "no resolution", Rule: rule}
+	if name := jiraIssue.Fields.GetResolution().GetName(); name != "" {
+		field.Source = fmt.Sprintf("resolution %q", name)
+	}
+	return field
+}
+
+// explainAssignee explains an issue's assignee
+func explainAssignee(jiraIssue *jirapb.Issue, issue *beadspb.Issue) FieldExplanation {
+	field := FieldExplanation{Field: "assignee", Value: issue.Assignee, Source: "no assignee", Rule: "unassigned"}
+	assignee := jiraIssue.Fields.GetAssignee()
+	if assignee == nil {
+		return field
+	}
+	field.Source = fmt.Sprintf("assignee %q", assignee.DisplayName)
+	if assignee.EmailAddress != "" {
+		field.Rule = "assignee's email address"
+	} else {
+		field.Rule = "assignee has no email address, using the display name"
+	}
+	return field
+}
+
+// explainEpicLink explains which epic an issue belongs to
+func (c *ProtoConverter) explainEpicLink(jiraIssue *jirapb.Issue, issue *beadspb.Issue, beadsExport *beadspb.Export) FieldExplanation {
+	field := FieldExplanation{Field: "epic", Value: issue.Epic, Source: "no parent", Rule: "no epic"}
+	parent := jiraIssue.Fields.GetParent()
+	if parent != nil {
+		field.Source = fmt.Sprintf("parent %s", parent.Key)
+	}
+
+	for _, epic := range beadsExport.Epics {
+		if epic.Id == issue.Epic && epic.Metadata.GetJiraIssueType() == ReleaseIssueType {
+			field.Source = fmt.Sprintf("fix version %q", epic.Name)
+			field.Rule = "release_epics in config.yml"
+			if len(jiraIssue.Fields.GetFixVersions()) == 0 && parent != nil {
+				field.Source = fmt.Sprintf("fix version %q of parent %s", epic.Name, parent.Key)
+			}
+			return field
+		}
+	}
+
+	switch {
+	case issue.Epic != "" && issue.Epic == c.epicFor(jiraIssue):
+		field.Rule = "parent is an epic"
+	case issue.Epic != "":
+		field.Source = fmt.Sprintf("clone source %s", cloneSource(jiraIssue))
+		field.Rule = "copy_clone_epic in config.yml"
+	case parent != nil && c.IsEpicType(parent.Fields.GetIssueType()):
+		field.Rule = "parent epic is not part of the sync"
+	case parent != nil:
+		field.Rule = "parent is not an epic"
+	}
+	return field
+}
+
+// explainLabels explains where each of an issue's labels came from
+func (c *ProtoConverter) explainLabels(jiraIssue *jirapb.Issue, issue *beadspb.Issue) []FieldExplanation {
+	if len(issue.Labels) == 0 {
+		return []FieldExplanation{{Field: "labels", Source: "no labels", Rule: "none added"}}
+	}
+
+	// Jira labels by their name after renames
+	jiraLabels := make(map[string]string)
+	for _, label := range jiraIssue.Fields.GetLabels() {
+		for _, renamed := range c.renameLabels([]string{label}) {
+			jiraLabels[renamed] = label
+		}
+	}
+	var epicLabels []string
+	if epic := c.epicIssueOf(jiraIssue); epic != nil {
+		epicLabels = c.renameLabels(epic.Fields.GetLabels())
+	}
+
+	fields := make([]FieldExplanation, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		field := FieldExplanation{Field: "labels", Value: label}
+		switch original, ok := jiraLabels[label]; {
+		case ok && original != label:
+			field.Source, field.Rule = fmt.Sprintf("label %q", original), "labels in mapping.yml"
+		case ok:
+			field.Source, field.Rule = fmt.Sprintf("label %q", original), "copied"
+		case strings.HasPrefix(label, "sprint:"):
+			field.Source, field.Rule = "sprint", "planning_labels.sprint in config.yml"
+		case strings.HasPrefix(label, "release:"):
+			field.Source, field.Rule = "fix versions", "planning_labels.fix_version in config.yml"
+		case strings.HasPrefix(label, "component:"):
+			field.Source, field.Rule = "components", "planning_labels.component in config.yml"
+		case strings.HasPrefix(label, "team:"):
+			field.Source, field.Rule = fmt.Sprintf("assignee %q", userName(jiraIssue.Fields.GetAssignee())), "teams file"
+		case contains(epicLabels, label):
+			field.Source, field.Rule = fmt.Sprintf("labels of epic %s", c.epicIssueOf(jiraIssue).Key), "epic_labels in config.yml"
+		default:
+			field.Source, field.Rule = fmt.Sprintf("labels of clone source %s", cloneSource(jiraIssue)), "copy_clone_labels in config.yml"
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// explainDependencies explains where each of an issue's dependencies came
+// from
+func (c *ProtoConverter) explainDependencies(jiraIssue *jirapb.Issue, issue *beadspb.Issue) []FieldExplanation {
+	if len(issue.DependsOn) == 0 {
+		return []FieldExplanation{{Field: "depends_on", Source: "no blocking links", Rule: "none"}}
+	}
+
+	sources := make(map[string]FieldExplanation)
+	if parent := jiraIssue.Fields.GetParent(); parent != nil && jiraIssue.Fields.GetIssueType().GetSubtask() {
+		sources[c.generateBeadsID(parent.Key)] = FieldExplanation{Source: fmt.Sprintf("parent %s", parent.Key), Rule: "subtasks depend on their parent"}
+	}
+	for _, link := range jiraIssue.Fields.GetIssueLinks() {
+		if link.GetType().GetInward() == "is blocked by" && link.InwardIssue != nil {
+			sources[c.generateBeadsID(link.InwardIssue.Key)] = FieldExplanation{Source: fmt.Sprintf("link \"is blocked by\" %s", link.InwardIssue.Key), Rule: "blocking link"}
+		}
+		if link.GetType().GetOutward() == "depends on" && link.OutwardIssue != nil {
+			sources[c.generateBeadsID(link.OutwardIssue.Key)] = FieldExplanation{Source: fmt.Sprintf("link \"depends on\" %s", link.OutwardIssue.Key), Rule: "dependency link"}
+		}
+	}
+
+	fields := make([]FieldExplanation, 0, len(issue.DependsOn))
+	for _, id := range issue.DependsOn {
+		field := sources[id]
+		field.Field, field.Value = "depends_on", id
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// explainDescription explains how a record's description was rendered
+func (c *ProtoConverter) explainDescription(jiraIssue *jirapb.Issue, description string) FieldExplanation {
+	fields := jiraIssue.Fields
+	field := FieldExplanation{Field: "description", Value: fmt.Sprintf("%d characters", len([]rune(description)))}
+	switch {
+	case fields.GetDescriptionAdf() != "":
+		field.Source, field.Rule = "description (ADF)", "converted to Markdown"
+	case fields.GetDescription() != "":
+		field.Source, field.Rule = "description (wiki markup)", "converted to Markdown"
+	default:
+		field.Source, field.Rule = "no description", "empty"
+	}
+	if len(fields.GetChecklist()) > 0 {
+		field.Rule += ", checklist appended"
+	}
+	if limit := c.options.MaxDescriptionLength; limit > 0 {
+		field.Rule += fmt.Sprintf(", max_description_length %d", limit)
+	}
+	return field
+}
+
+// describeStatus describes a Jira status and its category
+func describeStatus(status *jirapb.Status) string {
+	if status == nil {
+		return "no status"
+	}
+	if category := status.GetStatusCategory().GetKey(); category != "" {
+		return fmt.Sprintf("status %q (category %s)", status.Name, category)
+	}
+	return fmt.Sprintf("status %q", status.Name)
+}
@@ -0,0 +1,126 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// explainedField returns the first explanation of a field, failing the test
+// when there is none
+func explainedField(t *testing.T, explanation *Explanation, field string) FieldExplanation {
+	t.Helper()
+	for _, f := range explanation.Fields {
+		if f.Field == field {
+			return f
+		}
+	}
+	t.Fatalf("Expected an explanation of %s, got %+v", field, explanation.Fields)
+	return FieldExplanation{}
+}
+
+func TestExplainIssue(t *testing.T) {
+	epic := warningTestIssue("PROJ-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Labels = []string{"quarter:Q3"}
+
+	issue := warningTestIssue("PROJ-2")
+	issue.Fields.Status = &jirapb.Status{Name: "In Review", StatusCategory: &jirapb.StatusCategory{Key: "indeterminate"}}
+	issue.Fields.Priority = &jirapb.Priority{Name: "Urgent"}
+	issue.Fields.Labels = []string{"BE", "no-estimate"}
+	issue.Fields.Assignee = &jirapb.User{DisplayName: "Jane Doe"}
+	issue.Fields.Parent = &jirapb.Parent{Key: "PROJ-1", Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}}}
+	issue.Fields.IssueLinks = []*jirapb.IssueLink{{
+		Type:        &jirapb.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+		InwardIssue: &jirapb.LinkedIssue{Key: "PROJ-3"},
+	}}
+	blocker := warningTestIssue("PROJ-3")
+
+	conv := NewProtoConverterWithOptions(Options{
+		StatusMap:    map[string]beadspb.Status{"In Review": beadspb.Status_STATUS_BLOCKED},
+		LabelRenames: map[string]string{"be": "backend"},
+		EpicLabels:   []string{"quarter:*"},
+	})
+	explanation, err := conv.Explain(&jirapb.Export{Issues: []*jirapb.Issue{epic, issue, blocker}}, "proj-2")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if explanation.ID != "proj-2" || explanation.Kind != "issue" {
+		t.Errorf("Unexpected record %s (%s)", explanation.ID, explanation.Kind)
+	}
+
+	tests := []struct {
+		field, value, source, rule string
+	}{
+		{"kind", "issue", `issue type "Task"`, "built-in issue type"},
+		{"status", "blocked", `status "In Review" (category indeterminate)`, "statuses in mapping.yml"},
+		{"priority", "p2", `priority "Urgent"`, "unknown priority, defaulting to p2"},
+		{"disposition", "", "no resolution", "not closed"},
+		{"assignee", "Jane Doe", `assignee "Jane Doe"`, "assignee has no email address, using the display name"},
+		{"epic", "proj-1", "parent PROJ-1", "parent is an epic"},
+		{"depends_on", "proj-3", `link "is blocked by" PROJ-3`, "blocking link"},
+	}
+	for _, tt := range tests {
+		got := explainedField(t, explanation, tt.field)
+		if got.Value != tt.value || got.Source != tt.source || got.Rule != tt.rule {
+			t.Errorf("Expected %s to be %q from %q by %q, got %+v", tt.field, tt.value, tt.source, tt.rule, got)
+		}
+	}
+
+	rules := make(map[string]string)
+	for _, f := range explanation.Fields {
+		if f.Field == "labels" {
+			rules[f.Value] = f.Rule
+		}
+	}
+	want := map[string]string{"backend": "labels in mapping.yml", "no-estimate": "copied", "quarter:Q3": "epic_labels in config.yml"}
+	for label, rule := range want {
+		if rules[label] != rule {
+			t.Errorf("Expected label %s by %q, got %q", label, rule, rules[label])
+		}
+	}
+
+	if len(explanation.Warnings) != 2 {
+		t.Errorf("Expected the priority and assignee warnings, got %v", explanation.Warnings)
+	}
+}
+
+func TestExplainEpicAndSkipped(t *testing.T) {
+	epic := warningTestIssue("PROJ-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Status = &jirapb.Status{Name: "Closed", StatusCategory: &jirapb.StatusCategory{Key: "done"}}
+	epic.Fields.Resolution = &jirapb.Resolution{Name: "Won’t Do"}
+	optedOut := warningTestIssue("PROJ-2")
+	optedOut.Fields.Labels = []string{"no-beads-sync"}
+	spike := warningTestIssue("PROJ-3")
+	spike.Fields.IssueType = &jirapb.IssueType{Name: "Spike"}
+	export := &jirapb.Export{Issues: []*jirapb.Issue{epic, optedOut, spike}}
+	conv := NewProtoConverterWithOptions(Options{IssueTypes: map[string]IssueClass{"spike": IssueClassSkip}})
+
+	explanation, err := conv.Explain(export, "PROJ-1")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if explanation.Kind != "epic" {
+		t.Errorf("Expected an epic, got %s", explanation.Kind)
+	}
+	if got := explainedField(t, explanation, "disposition"); got.Value != "wont-do" || got.Rule != "built-in resolution" {
+		t.Errorf("Unexpected disposition explanation %+v", got)
+	}
+
+	for key, rule := range map[string]string{"PROJ-2": `opt-out label "no-beads-sync"`, "PROJ-3": "issue_types in mapping.yml"} {
+		explanation, err := conv.Explain(export, key)
+		if err != nil {
+			t.Fatalf("Explain failed: %v", err)
+		}
+		if got := explainedField(t, explanation, "kind"); explanation.Kind != "skipped" || got.Rule != rule {
+			t.Errorf("Expected %s to be skipped by %q, got %+v", key, rule, got)
+		}
+	}
+
+	if _, err := conv.Explain(export, "PROJ-9"); err == nil || !strings.Contains(err.Error(), "not in the export") {
+		t.Errorf("Expected an error for a missing issue, got %v", err)
+	}
+}
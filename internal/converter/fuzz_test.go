@@ -0,0 +1,69 @@
+package converter
+
+import (
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// fuzzOptions turns on the optional conversion paths, so malformed payloads
+// reach as much of the converter as possible
+func fuzzOptions() Options {
+	return Options{
+		PriorityAging:          []time.Duration{24 * time.Hour},
+		ComputedFields:         true,
+		MaxDescriptionLength:   64,
+		ComponentOwners:        map[string]map[string]string{"PROJ": {"Backend": "alice@example.com"}},
+		TitleRules:             []TitleRule{{Pattern: regexp.MustCompile(`^\[(\w+)\]\s*`), Replace: "$1: "}},
+		MaxComments:            1,
+		PreserveRawDescription: true,
+		CopyCloneLabels:        true,
+		CopyCloneEpic:          true,
+		SprintLabels:           true,
+		FixVersionLabels:       true,
+		ComponentLabels:        true,
+		ComponentSeparator:     "/",
+		EpicNameFromField:      true,
+		EpicOtherInDescription: true,
+		EpicLabels:             []string{"quarter:*"},
+		ReleaseEpicProjects:    []string{"*"},
+		IssueTypes:             map[string]IssueClass{"Initiative": IssueClassEpic, "Spike": IssueClassSkip},
+		Teams:                  map[string]string{"alice@example.com": "platform"},
+		MaxChainDepth:          3,
+		CustomFields:           map[string]string{"customfield_10030": "points"},
+		ParticipantsField:      "customfield_10026",
+		TargetStartField:       "customfield_10022",
+		TargetEndField:         "customfield_10023",
+		Now:                    func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+// FuzzConvert feeds Jira JSON through the adapter and, when it parses,
+// the converter with default and fully loaded options. Neither may panic.
+func FuzzConvert(f *testing.F) {
+	if data, err := os.ReadFile("../../testdata/sample-jira-export.json"); err == nil {
+		f.Add(data)
+	}
+	f.Add([]byte(`{"issues":[{"id":"1","key":"PROJ-1","fields":{"summary":"[API] s","status":{"name":"Open"},"issuetype":{"name":"Epic"},"labels":["quarter:Q3"],"components":[{"name":"Backend/API"}],"fixVersions":[{"id":"10","name":"2.0"}]}},` +
+		`{"id":"2","key":"PROJ-2","fields":{"summary":"s","status":{"name":"Done"},"issuetype":{"name":"Story"},"parent":{"key":"PROJ-1","fields":{"issuetype":{"name":"Epic"}}},"assignee":{"emailAddress":"alice@example.com"},"issuelinks":[{"type":{"name":"Cloners","inward":"is cloned by","outward":"clones"},"outwardIssue":{"key":"PROJ-1"}},{"type":{"name":"Blocks"},"inwardIssue":{"key":"PROJ-2"}}],"customfield_10022":"2024-01-01","customfield_10023":"soon","customfield_10026":[{"displayName":"Bob"}]}},` +
+		`{"id":"3","key":"PROJ-3","fields":{"summary":"s","status":{"name":"Open"},"issuetype":{"name":"Sub-task","subtask":true},"parent":{"key":"PROJ-2"}}}]}`))
+	f.Add([]byte(`{"issues":[{"key":"PROJ-1","fields":{"summary":"s","issuetype":{"name":"Sub-task","subtask":true},"parent":{"key":"PROJ-1"}}}]}`))
+	// A duplicate key whose last copy is its own parent used to hang
+	f.Add([]byte(`{"issues":[{"key":"PROJ-1","fields":{"summary":"s","issuetype":{"name":"Task"}}},{"key":"PROJ-1","fields":{"summary":"s","issuetype":{"name":"Sub-task","subtask":true},"parent":{"key":"PROJ-1"}}}]}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		jiraExport, err := jira.NewAdapter().Parse(data)
+		if err != nil {
+			return
+		}
+		for _, opts := range []Options{{}, fuzzOptions()} {
+			export, _, err := NewProtoConverterWithOptions(opts).ConvertWithWarnings(jiraExport)
+			if err == nil && export == nil {
+				t.Fatal("ConvertWithWarnings returned neither an export nor an error")
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// DefaultMaxChainDepth is the deepest parent chain accepted when
+// Options.MaxChainDepth is zero. Jira's own hierarchy is a few levels deep;
+// anything near this limit is almost certainly corrupt data.
+const DefaultMaxChainDepth = 100
+
+// ChainError reports a parent chain that loops or exceeds the maximum
+// depth. Path lists the Jira keys from the issue up to the offending parent.
+type ChainError struct {
+	Path     []string
+	Loop     bool
+	MaxDepth int
+}
+
+func (e *ChainError) Error() string {
+	path := strings.Join(e.Path, " → ")
+	if e.Loop {
+		return fmt.Sprintf("parent chain loops: %s", path)
+	}
+	return fmt.Sprintf("parent chain deeper than %d levels: %s", e.MaxDepth, path)
+}
+
+// checkParentChains walks every issue's parent chain within the export,
+// failing on loops and chains deeper than the configured maximum. The walk
+// is iterative and each issue's depth is memoised, so the whole check is
+// linear in the number of issues.
+func (c *ProtoConverter) checkParentChains(jiraExport *jirapb.Export) error {
+	maxDepth := c.options.MaxChainDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxChainDepth
+	}
+
+	// depth is the number of ancestors above an issue within the export
+	depth := make(map[string]int, len(c.issueMap))
+	for _, issue := range jiraExport.Issues {
+		if _, done := depth[issue.Key]; done {
+			continue
+		}
+		// Walk from the issue lookups resolve the key to, which is the last
+		// of any duplicates, so the chains conversion follows are the ones
+		// checked
+		issue = c.issueMap[issue.Key]
+
+		var path []string
+		onPath := make(map[string]int)
+		base := 0
+		for current := issue; current != nil; {
+			if d, done := depth[current.Key]; done {
+				base = d
+				break
+			}
+			if start, seen := onPath[current.Key]; seen {
+				return &ChainError{Path: append(path[start:], current.Key), Loop: true}
+			}
+			onPath[current.Key] = len(path)
+			path = append(path, current.Key)
+			if len(path) > maxDepth+1 {
+				return &ChainError{Path: c.chain(issue, maxDepth+2), MaxDepth: maxDepth}
+			}
+
+			current = c.parentOf(current)
+			if current == nil {
+				base = -1
+			}
+		}
+
+		// Depths count down from the top of the chain
+		for i, key := range path {
+			depth[key] = base + len(path) - i
+			if depth[key] > maxDepth {
+				return &ChainError{Path: c.chain(c.issueMap[key], maxDepth+2), MaxDepth: maxDepth}
+			}
+		}
+	}
+	return nil
+}
+
+// chain lists the keys of an issue and its ancestors, at most limit of them
+func (c *ProtoConverter) chain(issue *jirapb.Issue, limit int) []string {
+	var keys []string
+	for current := issue; current != nil && len(keys) < limit; current = c.parentOf(current) {
+		keys = append(keys, current.Key)
+	}
+	return keys
+}
+
+// parentOf returns an issue's parent if it is part of the export
+func (c *ProtoConverter) parentOf(issue *jirapb.Issue) *jirapb.Issue {
+	parent := issue.GetFields().GetParent()
+	if parent == nil || parent.Key == "" {
+		return nil
+	}
+	return c.issueMap[parent.Key]
+}
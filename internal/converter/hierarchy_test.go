@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// parentChain returns issues PROJ-1 … PROJ-n where each is a sub-task of
+// the one before it
+func parentChain(n int) []*jirapb.Issue {
+	issues := make([]*jirapb.Issue, n)
+	for i := range issues {
+		issues[i] = warningTestIssue(fmt.Sprintf("PROJ-%d", i+1))
+		if i > 0 {
+			setParent(issues[i], issues[i-1].Key)
+		}
+	}
+	return issues
+}
+
+func setParent(issue *jirapb.Issue, parentKey string) {
+	issue.Fields.IssueType = &jirapb.IssueType{Name: "Sub-task", Subtask: true}
+	issue.Fields.Parent = &jirapb.Parent{
+		Key:    parentKey,
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Sub-task", Subtask: true}},
+	}
+}
+
+func TestCheckParentChainsAcceptsDeepChain(t *testing.T) {
+	conv := NewProtoConverterWithOptions(Options{MaxChainDepth: 10})
+
+	// Eleven issues have ten levels of parents; reversed so walks hit the
+	// memoised depths of earlier chains
+	issues := parentChain(11)
+	for i, j := 0, len(issues)-1; i < j; i, j = i+1, j-1 {
+		issues[i], issues[j] = issues[j], issues[i]
+	}
+
+	export, err := conv.Convert(&jirapb.Export{Issues: issues})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(export.Issues) != 11 {
+		t.Errorf("Expected 11 issues, got %d", len(export.Issues))
+	}
+}
+
+func TestCheckParentChainsRejectsTooDeep(t *testing.T) {
+	for _, reversed := range []bool{false, true} {
+		issues := parentChain(12)
+		if reversed {
+			for i, j := 0, len(issues)-1; i < j; i, j = i+1, j-1 {
+				issues[i], issues[j] = issues[j], issues[i]
+			}
+		}
+
+		_, err := NewProtoConverterWithOptions(Options{MaxChainDepth: 10}).Convert(&jirapb.Export{Issues: issues})
+		var chainErr *ChainError
+		if !errors.As(err, &chainErr) {
+			t.Fatalf("Expected a ChainError, got %v", err)
+		}
+		if chainErr.Loop {
+			t.Error("Expected a depth error, not a loop")
+		}
+		if len(chainErr.Path) != 12 || chainErr.Path[0] != "PROJ-12" || chainErr.Path[11] != "PROJ-1" {
+			t.Errorf("Expected path PROJ-12 → … → PROJ-1, got %v", chainErr.Path)
+		}
+		if !strings.HasPrefix(err.Error(), "parent chain deeper than 10 levels: PROJ-12 → PROJ-11 → ") {
+			t.Errorf("Unexpected error message: %v", err)
+		}
+	}
+}
+
+func TestCheckParentChainsRejectsLoop(t *testing.T) {
+	issues := parentChain(4)
+	// PROJ-2 → PROJ-1 → PROJ-3 → PROJ-2, with PROJ-4 hanging off the loop
+	setParent(issues[0], "PROJ-3")
+
+	_, err := NewProtoConverter().Convert(&jirapb.Export{Issues: issues})
+	var chainErr *ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("Expected a ChainError, got %v", err)
+	}
+	if !chainErr.Loop {
+		t.Error("Expected a loop error")
+	}
+	if got := err.Error(); got != "parent chain loops: PROJ-1 → PROJ-3 → PROJ-2 → PROJ-1" {
+		t.Errorf("Unexpected error message: %s", got)
+	}
+}
+
+func TestCheckParentChainsRejectsLoopInDuplicateKey(t *testing.T) {
+	// Overlapping search pages can repeat a key; lookups resolve it to the
+	// last copy, whose parent is itself
+	first := warningTestIssue("PROJ-1")
+	second := warningTestIssue("PROJ-1")
+	setParent(second, "PROJ-1")
+
+	_, err := NewProtoConverterWithOptions(Options{EpicLabels: []string{"*"}}).Convert(&jirapb.Export{Issues: []*jirapb.Issue{first, second}})
+	var chainErr *ChainError
+	if !errors.As(err, &chainErr) || !chainErr.Loop {
+		t.Fatalf("Expected a loop error, got %v", err)
+	}
+}
+
+func TestCheckParentChainsIgnoresParentsOutsideExport(t *testing.T) {
+	issue := warningTestIssue("PROJ-2")
+	setParent(issue, "OTHER-1")
+
+	if _, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}}); err != nil {
+		t.Errorf("Expected parent outside the export to end the chain, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// IssueClass says how issues of a Jira issue type are converted
+type IssueClass string
+
+const (
+	// IssueClassEpic converts issues to beads epics that other issues can belong to
+	IssueClassEpic IssueClass = "epic"
+	// IssueClassIssue converts issues to beads issues
+	IssueClassIssue IssueClass = "issue"
+	// IssueClassSkip leaves issues out of beads
+	IssueClassSkip IssueClass = "skip"
+)
+
+// builtinIssueTypes classifies the issue types Jira ships with, by
+// lower-cased name. Subtask types are recognised by their subtask flag.
+var builtinIssueTypes = map[string]IssueClass{
+	"epic":        IssueClassEpic,
+	"story":       IssueClassIssue,
+	"task":        IssueClassIssue,
+	"bug":         IssueClassIssue,
+	"sub-task":    IssueClassIssue,
+	"subtask":     IssueClassIssue,
+	"improvement": IssueClassIssue,
+	"new feature": IssueClassIssue,
+}
+
+// ParseIssueClass parses an issue class name (epic, issue, skip)
+func ParseIssueClass(name string) (IssueClass, error) {
+	switch class := IssueClass(strings.ToLower(name)); class {
+	case IssueClassEpic, IssueClassIssue, IssueClassSkip:
+		return class, nil
+	default:
+		return "", fmt.Errorf("unknown issue class %q", name)
+	}
+}
+
+// SkippedTypes returns the Jira keys the last conversion skipped because
+// their issue type is classified as skip. Like opted-out issues, incremental
+// syncs remove their existing records.
+func (c *ProtoConverter) SkippedTypes() []string {
+	return c.skippedTypes
+}
+
+// classify returns the class of an issue type and whether the type is
+// known. Options.IssueTypes take precedence over the built-in types;
+// unknown types are converted as issues.
+func (c *ProtoConverter) classify(issueType *jirapb.IssueType) (IssueClass, bool) {
+	class, _, known := c.explainClass(issueType)
+	return class, known
+}
+
+// explainClass is classify that also describes the rule that decided the
+// class
+func (c *ProtoConverter) explainClass(issueType *jirapb.IssueType) (IssueClass, string, bool) {
+	name := strings.ToLower(issueType.GetName())
+	if class, ok := c.options.IssueTypes[name]; ok {
+		return class, "issue_types in mapping.yml", true
+	}
+	if class, ok := builtinIssueTypes[name]; ok {
+		return class, "built-in issue type", true
+	}
+	if issueType.GetSubtask() {
+		return IssueClassIssue, "subtask type", true
+	}
+	return IssueClassIssue, "unknown issue type, converting as an issue", false
+}
+
+// IsEpicType reports whether issues of a type are converted to epics: Jira's
+// Epic type and any type Options.IssueTypes classifies as epic
+func (c *ProtoConverter) IsEpicType(issueType *jirapb.IssueType) bool {
+	class, _ := c.classify(issueType)
+	return class == IssueClassEpic
+}
+
+// skipIssueTypes returns the export without the issues whose type is
+// classified as skip, recording their keys, and warns once per unknown type
+func (c *ProtoConverter) skipIssueTypes(jiraExport *jirapb.Export) *jirapb.Export {
+	c.skippedTypes = nil
+	var kept []*jirapb.Issue
+	unknown := make(map[string]bool)
+	for _, jiraIssue := range jiraExport.Issues {
+		issueType := jiraIssue.GetFields().GetIssueType()
+		class, known := c.classify(issueType)
+		if !known && !unknown[strings.ToLower(issueType.GetName())] {
+			unknown[strings.ToLower(issueType.GetName())] = true
+			c.warn(WarningUnknownIssueType, jiraIssue.Key,
+				"issue type %q is not in the issue_types mapping, converted as an issue", issueType.GetName())
+		}
+		if class == IssueClassSkip {
+			c.skippedTypes = append(c.skippedTypes, jiraIssue.Key)
+		} else {
+			kept = append(kept, jiraIssue)
+		}
+	}
+	if len(c.skippedTypes) == 0 {
+		return jiraExport
+	}
+	return &jirapb.Export{Issues: kept}
+}
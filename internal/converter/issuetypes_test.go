@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestIssueTypeClassification(t *testing.T) {
+	initiative := warningTestIssue("PROJ-10")
+	initiative.Fields.IssueType = &jirapb.IssueType{Name: "Initiative"}
+
+	story := warningTestIssue("PROJ-1")
+	story.Fields.IssueType = &jirapb.IssueType{Name: "Story"}
+	story.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Initiative"}},
+	}
+	risk := warningTestIssue("PROJ-2")
+	risk.Fields.IssueType = &jirapb.IssueType{Name: "Risk"}
+	incident := warningTestIssue("PROJ-3")
+	incident.Fields.IssueType = &jirapb.IssueType{Name: "Incident"}
+	anotherIncident := warningTestIssue("PROJ-4")
+	anotherIncident.Fields.IssueType = &jirapb.IssueType{Name: "incident"}
+	anotherIncident.Fields.IssueLinks = []*jirapb.IssueLink{{
+		Type:        &jirapb.IssueLinkType{Inward: "is blocked by"},
+		InwardIssue: &jirapb.LinkedIssue{Key: "PROJ-2"},
+	}}
+	subtask := warningTestIssue("PROJ-5")
+	subtask.Fields.IssueType = &jirapb.IssueType{Name: "Checklist Item", Subtask: true}
+
+	converter := NewProtoConverterWithOptions(Options{IssueTypes: map[string]IssueClass{
+		"initiative": IssueClassEpic,
+		"RISK":       IssueClassSkip,
+	}})
+	export, warnings, err := converter.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{
+		initiative, story, risk, incident, anotherIncident, subtask,
+	}})
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+
+	if len(export.Epics) != 1 || export.Epics[0].Metadata.JiraKey != "PROJ-10" {
+		t.Fatalf("Expected PROJ-10 to be converted to an epic, got %v", export.Epics)
+	}
+	keys := make(map[string]bool)
+	for _, issue := range export.Issues {
+		keys[issue.Metadata.JiraKey] = true
+		if issue.Metadata.JiraKey == "PROJ-1" && issue.Epic != export.Epics[0].Id {
+			t.Errorf("Expected PROJ-1 to belong to the initiative epic, got %q", issue.Epic)
+		}
+		if issue.Metadata.JiraKey == "PROJ-4" && len(issue.DependsOn) != 0 {
+			t.Errorf("Expected the dependency on the skipped issue to be dropped, got %v", issue.DependsOn)
+		}
+	}
+	if len(keys) != 4 || keys["PROJ-2"] || !keys["PROJ-3"] || !keys["PROJ-5"] {
+		t.Errorf("Expected PROJ-1, PROJ-3, PROJ-4 and PROJ-5 as issues, got %v", keys)
+	}
+	if skipped := converter.SkippedTypes(); len(skipped) != 1 || skipped[0] != "PROJ-2" {
+		t.Errorf("Expected PROJ-2 to be skipped, got %v", skipped)
+	}
+
+	unknown := warnings.OfKind(WarningUnknownIssueType)
+	if len(unknown) != 1 || unknown[0].JiraKey != "PROJ-3" {
+		t.Errorf("Expected one unknown issue type warning for Incident, got %v", unknown)
+	}
+}
+
+func TestParseIssueClass(t *testing.T) {
+	if class, err := ParseIssueClass("Epic"); err != nil || class != IssueClassEpic {
+		t.Errorf("Expected epic, got %q (%v)", class, err)
+	}
+	if _, err := ParseIssueClass("ignore"); err == nil {
+		t.Error("Expected error for unknown issue class")
+	}
+}
@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+// ParseStatus parses a beads status name (open, in_progress, blocked, closed)
+func ParseStatus(name string) (beadspb.Status, error) {
+	switch strings.ToLower(name) {
+	case "open":
+		return beadspb.Status_STATUS_OPEN, nil
+	case "in_progress":
+		return beadspb.Status_STATUS_IN_PROGRESS, nil
+	case "blocked":
+		return beadspb.Status_STATUS_BLOCKED, nil
+	case "closed":
+		return beadspb.Status_STATUS_CLOSED, nil
+	default:
+		return beadspb.Status_STATUS_UNSPECIFIED, fmt.Errorf("unknown beads status %q", name)
+	}
+}
+
+// ParsePriority parses a beads priority name (p0 through p4)
+func ParsePriority(name string) (beadspb.Priority, error) {
+	switch strings.ToLower(name) {
+	case "p0":
+		return beadspb.Priority_PRIORITY_P0, nil
+	case "p1":
+		return beadspb.Priority_PRIORITY_P1, nil
+	case "p2":
+		return beadspb.Priority_PRIORITY_P2, nil
+	case "p3":
+		return beadspb.Priority_PRIORITY_P3, nil
+	case "p4":
+		return beadspb.Priority_PRIORITY_P4, nil
+	default:
+		return beadspb.Priority_PRIORITY_UNSPECIFIED, fmt.Errorf("unknown beads priority %q", name)
+	}
+}
+
+// lowerKeys returns a copy of m with lower-cased keys, so lookups by Jira
+// name are case-insensitive
+func lowerKeys[V any](m map[string]V) map[string]V {
+	if m == nil {
+		return nil
+	}
+	lowered := make(map[string]V, len(m))
+	for k, v := range m {
+		lowered[strings.ToLower(k)] = v
+	}
+	return lowered
+}
+
+// renameLabels applies the configured label renames
+func (c *ProtoConverter) renameLabels(labels []string) []string {
+	renamed, _ := beads.RenameLabels(labels, c.options.LabelRenames)
+	return renamed
+}
+
+// copyCustomFields copies mapped Jira custom field values into metadata
+func (c *ProtoConverter) copyCustomFields(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	for id, key := range c.options.CustomFields {
+		if value := jiraIssue.Fields.CustomFields[id]; value != "" {
+			setCustomMetadata(metadata, key, value)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package converter
+
+import (
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus("In_Progress")
+	if err != nil {
+		t.Fatalf("ParseStatus failed: %v", err)
+	}
+	if status != beadspb.Status_STATUS_IN_PROGRESS {
+		t.Errorf("Expected STATUS_IN_PROGRESS, got %v", status)
+	}
+
+	if _, err := ParseStatus("reviewing"); err == nil {
+		t.Error("Expected error for unknown status, got nil")
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	priority, err := ParsePriority("P4")
+	if err != nil {
+		t.Fatalf("ParsePriority failed: %v", err)
+	}
+	if priority != beadspb.Priority_PRIORITY_P4 {
+		t.Errorf("Expected PRIORITY_P4, got %v", priority)
+	}
+
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Error("Expected error for unknown priority, got nil")
+	}
+}
+
+func TestConvertWithMapping(t *testing.T) {
+	converter := NewProtoConverterWithOptions(Options{
+		StatusMap: map[string]beadspb.Status{
+			"Awaiting Review": beadspb.Status_STATUS_BLOCKED,
+		},
+		PriorityMap: map[string]beadspb.Priority{
+			"BLOCKER": beadspb.Priority_PRIORITY_P0,
+			"Trivial": beadspb.Priority_PRIORITY_P4,
+		},
+		CustomFields: map[string]string{
+			"customfield_10016": "storyPoints",
+			"customfield_10010": "sprint",
+		},
+	})
+
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Status = &jirapb.Status{
+		Name:           "awaiting review",
+		StatusCategory: &jirapb.StatusCategory{Key: "indeterminate"},
+	}
+	issue.Fields.Priority = &jirapb.Priority{Name: "Blocker"}
+	issue.Fields.CustomFields = map[string]string{
+		"customfield_10016": "5",
+		"customfield_10099": "not mapped",
+	}
+
+	trivial := warningTestIssue("PROJ-2")
+	trivial.Fields.Priority = &jirapb.Priority{Name: "Trivial"}
+
+	export, warnings, err := converter.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{issue, trivial}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected mapped names to produce no warnings, got %v", warnings)
+	}
+
+	converted := export.Issues[0]
+	if converted.Status != beadspb.Status_STATUS_BLOCKED {
+		t.Errorf("Expected mapped status STATUS_BLOCKED, got %v", converted.Status)
+	}
+	if converted.Priority != beadspb.Priority_PRIORITY_P0 {
+		t.Errorf("Expected mapped priority PRIORITY_P0, got %v", converted.Priority)
+	}
+	if converted.Metadata.Custom["storyPoints"] != "5" {
+		t.Errorf("Expected storyPoints metadata 5, got %q", converted.Metadata.Custom["storyPoints"])
+	}
+	if _, ok := converted.Metadata.Custom["sprint"]; ok {
+		t.Error("Expected no sprint metadata for an empty field")
+	}
+	if len(converted.Metadata.Custom) != 1 {
+		t.Errorf("Expected only mapped custom fields in metadata, got %v", converted.Metadata.Custom)
+	}
+
+	if export.Issues[1].Priority != beadspb.Priority_PRIORITY_P4 {
+		t.Errorf("Expected mapped priority PRIORITY_P4, got %v", export.Issues[1].Priority)
+	}
+}
+
+func TestConvertWithoutMappingKeepsDefaults(t *testing.T) {
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Priority = &jirapb.Priority{Name: "High"}
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if export.Issues[0].Priority != beadspb.Priority_PRIORITY_P1 {
+		t.Errorf("Expected default priority PRIORITY_P1, got %v", export.Issues[0].Priority)
+	}
+	if export.Issues[0].Status != beadspb.Status_STATUS_OPEN {
+		t.Errorf("Expected default status STATUS_OPEN, got %v", export.Issues[0].Status)
+	}
+}
+
+func TestConvertWithLabelRenames(t *testing.T) {
+	converter := NewProtoConverterWithOptions(Options{
+		LabelRenames: map[string]string{"Frontend": "ui"},
+	})
+
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Labels = []string{"frontend", "ui", "backend"}
+
+	export, err := converter.Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	labels := export.Issues[0].Labels
+	if len(labels) != 2 || labels[0] != "ui" || labels[1] != "backend" {
+		t.Errorf("Expected labels [ui backend], got %v", labels)
+	}
+	if issue.Fields.Labels[0] != "frontend" {
+		t.Errorf("Expected the Jira issue to be left alone, got %v", issue.Fields.Labels)
+	}
+}
@@ -0,0 +1,64 @@
+package converter
+
+import (
+	"slices"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// DefaultOptOutLabel is the Jira label that keeps an issue out of beads
+// when Options doesn't name another one
+const DefaultOptOutLabel = "no-beads-sync"
+
+// OptedOut returns the Jira keys the last conversion skipped because they
+// carry the opt-out label. Incremental syncs remove their existing records.
+func (c *ProtoConverter) OptedOut() []string {
+	return c.optedOut
+}
+
+// skipOptedOut returns the export without the issues carrying the opt-out
+// label, recording their keys
+func (c *ProtoConverter) skipOptedOut(jiraExport *jirapb.Export) *jirapb.Export {
+	c.optedOut = nil
+	var kept []*jirapb.Issue
+	for _, jiraIssue := range jiraExport.Issues {
+		if hasLabelFold(jiraIssue.GetFields().GetLabels(), c.options.OptOutLabel) {
+			c.optedOut = append(c.optedOut, jiraIssue.Key)
+		} else {
+			kept = append(kept, jiraIssue)
+		}
+	}
+	if len(c.optedOut) == 0 {
+		return jiraExport
+	}
+	return &jirapb.Export{Issues: kept}
+}
+
+// dropOptedOutDependencies removes dependencies on skipped issues, so the
+// beads IDs of opted-out issues and skipped issue types don't appear in the
+// output at all
+func (c *ProtoConverter) dropOptedOutDependencies(beadsExport *beadspb.Export) {
+	if len(c.optedOut) == 0 && len(c.skippedTypes) == 0 {
+		return
+	}
+
+	skipped := make(map[string]bool, len(c.optedOut)+len(c.skippedTypes))
+	for _, key := range append(slices.Clone(c.optedOut), c.skippedTypes...) {
+		skipped[c.generateBeadsID(key)] = true
+	}
+	for _, issue := range beadsExport.Issues {
+		issue.DependsOn = slices.DeleteFunc(issue.DependsOn, func(id string) bool { return skipped[id] })
+	}
+}
+
+// hasLabelFold reports whether labels contains label, ignoring case
+func hasLabelFold(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
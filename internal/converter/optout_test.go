@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"slices"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestOptOutLabelSkipsIssues(t *testing.T) {
+	optedOut := warningTestIssue("PROJ-1")
+	optedOut.Fields.Labels = []string{"No-Beads-Sync"}
+
+	blocked := warningTestIssue("PROJ-2")
+	blocked.Fields.IssueLinks = []*jirapb.IssueLink{{
+		Type:        &jirapb.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+		InwardIssue: &jirapb.LinkedIssue{Id: optedOut.Id, Key: optedOut.Key},
+	}}
+
+	c := NewProtoConverter()
+	beadsExport, _, err := c.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{optedOut, blocked}})
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	if len(beadsExport.Issues) != 1 || beadsExport.Issues[0].Metadata.JiraKey != "PROJ-2" {
+		t.Fatalf("Expected only PROJ-2 to be converted, got %d issue(s)", len(beadsExport.Issues))
+	}
+	if !slices.Equal(c.OptedOut(), []string{"PROJ-1"}) {
+		t.Errorf("Expected OptedOut [PROJ-1], got %v", c.OptedOut())
+	}
+	if deps := beadsExport.Issues[0].DependsOn; len(deps) != 0 {
+		t.Errorf("Expected the dependency on the skipped issue to be dropped, got %v", deps)
+	}
+}
+
+func TestOptOutLabelCustom(t *testing.T) {
+	custom := warningTestIssue("PROJ-1")
+	custom.Fields.Labels = []string{"private"}
+	defaultLabel := warningTestIssue("PROJ-2")
+	defaultLabel.Fields.Labels = []string{DefaultOptOutLabel}
+
+	c := NewProtoConverterWithOptions(Options{OptOutLabel: "private"})
+	beadsExport, _, err := c.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{custom, defaultLabel}})
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	if len(beadsExport.Issues) != 1 || beadsExport.Issues[0].Metadata.JiraKey != "PROJ-2" {
+		t.Errorf("Expected only PROJ-2 to be converted with a custom label")
+	}
+	if !slices.Equal(c.OptedOut(), []string{"PROJ-1"}) {
+		t.Errorf("Expected OptedOut [PROJ-1], got %v", c.OptedOut())
+	}
+}
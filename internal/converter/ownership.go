@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// WatchersMetadataKey is the metadata key holding the watchers of an issue,
+// as a semicolon-separated list of email addresses or names
+const WatchersMetadataKey = "watchers"
+
+// assignOwner sets the owner metadata field from the lead of the issue's
+// primary (first) component
+func (c *ProtoConverter) assignOwner(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	components := jiraIssue.Fields.GetComponents()
+	if len(c.options.ComponentOwners) == 0 || len(components) == 0 {
+		return
+	}
+
+	owners := c.options.ComponentOwners[jira.ProjectKey(jiraIssue.Key)]
+	if owner := owners[components[0].GetName()]; owner != "" {
+		setCustomMetadata(metadata, "owner", owner)
+	}
+}
+
+// addWatchers sets the watchers metadata field from the issue's watchers
+func (c *ProtoConverter) addWatchers(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	if watchers := c.options.Watchers[jiraIssue.Key]; len(watchers) > 0 {
+		setCustomMetadata(metadata, WatchersMetadataKey, strings.Join(watchers, "; "))
+	}
+}
@@ -0,0 +1,84 @@
+package converter
+
+import (
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestAssignOwner(t *testing.T) {
+	conv := NewProtoConverterWithOptions(Options{
+		ComponentOwners: map[string]map[string]string{
+			"PROJ": {"Frontend": "jane@example.com", "Infra": "ops@example.com"},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		key        string
+		components []string
+		want       string
+	}{
+		{name: "primary component owner", key: "PROJ-1", components: []string{"Infra", "Frontend"}, want: "ops@example.com"},
+		{name: "no components", key: "PROJ-2", want: ""},
+		{name: "component without lead", key: "PROJ-3", components: []string{"Docs"}, want: ""},
+		{name: "other project", key: "OTHER-1", components: []string{"Frontend"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &jirapb.Issue{Key: tt.key, Fields: &jirapb.Fields{}}
+			for _, name := range tt.components {
+				issue.Fields.Components = append(issue.Fields.Components, &jirapb.Component{Name: name})
+			}
+
+			metadata := &beadspb.Metadata{}
+			conv.assignOwner(issue, metadata)
+
+			if got := metadata.Custom["owner"]; got != tt.want {
+				t.Errorf("Expected owner %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConvertOwnerAndWatchers(t *testing.T) {
+	epic := warningTestIssue("PROJ-10")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Components = []*jirapb.Component{{Name: "Infra"}}
+	story := warningTestIssue("PROJ-1")
+	story.Fields.Components = []*jirapb.Component{{Name: "Frontend"}}
+	unwatched := warningTestIssue("PROJ-2")
+
+	conv := NewProtoConverterWithOptions(Options{
+		ComponentOwners: map[string]map[string]string{
+			"PROJ": {"Frontend": "jane@example.com", "Infra": "ops@example.com"},
+		},
+		Watchers: map[string][]string{
+			"PROJ-10": {"ops@example.com", "Lead Dev"},
+			"PROJ-1":  {"jane@example.com"},
+		},
+	})
+	export, err := conv.Convert(&jirapb.Export{Issues: []*jirapb.Issue{epic, story, unwatched}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(export.Epics) != 1 {
+		t.Fatalf("Expected 1 epic, got %d", len(export.Epics))
+	}
+	if got := export.Epics[0].Metadata.Custom["owner"]; got != "ops@example.com" {
+		t.Errorf("Expected epic owner ops@example.com, got %q", got)
+	}
+	if got := export.Epics[0].Metadata.Custom[WatchersMetadataKey]; got != "ops@example.com; Lead Dev" {
+		t.Errorf("Expected epic watchers, got %q", got)
+	}
+
+	for _, issue := range export.Issues {
+		want := map[string]string{"PROJ-1": "jane@example.com", "PROJ-2": ""}[issue.Metadata.JiraKey]
+		if got := issue.Metadata.Custom[WatchersMetadataKey]; got != want {
+			t.Errorf("Expected watchers %q on %s, got %q", want, issue.Metadata.JiraKey, got)
+		}
+	}
+}
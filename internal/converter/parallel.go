@@ -0,0 +1,77 @@
+package converter
+
+import (
+	"runtime"
+	"sync"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// workers returns the number of conversion goroutines to use
+func (c *ProtoConverter) workers(jobs int) int {
+	workers := c.options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > jobs {
+		workers = jobs
+	}
+	return workers
+}
+
+// worker returns a converter sharing this converter's lookup tables and
+// options but with its own warning list. The lookup tables must not be
+// modified while workers are running.
+func (c *ProtoConverter) worker() *ProtoConverter {
+	return &ProtoConverter{
+		issueMap: c.issueMap,
+		epicMap:  c.epicMap,
+		options:  c.options,
+	}
+}
+
+// convertAll converts issues concurrently and returns the results in input
+// order. Warnings are collected per issue and appended to c.warnings in input
+// order, and the reported error is the one for the earliest failing issue, so
+// output does not depend on scheduling.
+func convertAll[T any](c *ProtoConverter, issues []*jirapb.Issue, convert func(*ProtoConverter, *jirapb.Issue) (T, error)) ([]T, error) {
+	type result struct {
+		value    T
+		warnings Warnings
+		err      error
+	}
+
+	results := make([]result, len(issues))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < c.workers(len(issues)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := c.worker()
+			for i := range jobs {
+				worker.warnings = nil
+				value, err := convert(worker, issues[i])
+				results[i] = result{value: value, warnings: worker.warnings, err: err}
+			}
+		}()
+	}
+
+	for i := range issues {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		c.warnings = append(c.warnings, r.warnings...)
+		values = append(values, r.value)
+	}
+
+	return values, nil
+}
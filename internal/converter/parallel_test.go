@@ -0,0 +1,129 @@
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"google.golang.org/protobuf/proto"
+)
+
+// parallelTestExport builds an export of n issues grouped under epics, with
+// blocking links, unknown priorities and long descriptions so conversion
+// produces dependencies and warnings
+func parallelTestExport(n int) *jirapb.Export {
+	export := &jirapb.Export{}
+	description := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 40)
+
+	for i := 1; i <= n; i++ {
+		issue := warningTestIssue(fmt.Sprintf("PROJ-%d", i))
+		issue.Fields.Description = description
+
+		switch {
+		case i%100 == 1:
+			issue.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+		default:
+			epicKey := fmt.Sprintf("PROJ-%d", (i-1)/100*100+1)
+			issue.Fields.Parent = &jirapb.Parent{
+				Key:    epicKey,
+				Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+			}
+		}
+
+		if i%7 == 0 {
+			issue.Fields.Priority = &jirapb.Priority{Name: "Blocker"}
+		}
+		if i > 2 && i%3 == 0 {
+			issue.Fields.IssueLinks = []*jirapb.IssueLink{{
+				Type:        &jirapb.IssueLinkType{Inward: "is blocked by"},
+				InwardIssue: &jirapb.LinkedIssue{Key: fmt.Sprintf("PROJ-%d", i-1)},
+			}}
+		}
+
+		export.Issues = append(export.Issues, issue)
+	}
+
+	return export
+}
+
+func TestConvertParallelIsDeterministic(t *testing.T) {
+	jiraExport := parallelTestExport(500)
+
+	sequential := NewProtoConverterWithOptions(Options{Workers: 1, MaxDescriptionLength: 100})
+	want, wantWarnings, err := sequential.ConvertWithWarnings(jiraExport)
+	if err != nil {
+		t.Fatalf("Sequential conversion failed: %v", err)
+	}
+
+	for _, workers := range []int{2, 8, 0} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			conv := NewProtoConverterWithOptions(Options{Workers: workers, MaxDescriptionLength: 100})
+			got, gotWarnings, err := conv.ConvertWithWarnings(jiraExport)
+			if err != nil {
+				t.Fatalf("Parallel conversion failed: %v", err)
+			}
+
+			if !proto.Equal(want, got) {
+				t.Error("Expected parallel output to match sequential output")
+			}
+			if len(gotWarnings) != len(wantWarnings) {
+				t.Fatalf("Expected %d warnings, got %d", len(wantWarnings), len(gotWarnings))
+			}
+			for i := range wantWarnings {
+				if gotWarnings[i] != wantWarnings[i] {
+					t.Fatalf("Expected warning %d to be %v, got %v", i, wantWarnings[i], gotWarnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConvertAllReportsEarliestError(t *testing.T) {
+	issues := []*jirapb.Issue{
+		warningTestIssue("PROJ-1"),
+		warningTestIssue("PROJ-2"),
+		warningTestIssue("PROJ-3"),
+	}
+
+	conv := NewProtoConverterWithOptions(Options{Workers: 3})
+	_, err := convertAll(conv, issues, func(_ *ProtoConverter, issue *jirapb.Issue) (string, error) {
+		if issue.Key == "PROJ-1" {
+			return "", nil
+		}
+		return "", fmt.Errorf("bad %s", issue.Key)
+	})
+
+	if err == nil || err.Error() != "bad PROJ-2" {
+		t.Errorf("Expected error for PROJ-2, got %v", err)
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	jiraExport := parallelTestExport(10000)
+	rule, err := NewTitleRule(`^Issue\s+`, "")
+	if err != nil {
+		b.Fatalf("NewTitleRule failed: %v", err)
+	}
+
+	for _, workers := range []int{1, 0} {
+		name := "sequential"
+		if workers == 0 {
+			name = "parallel"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			conv := NewProtoConverterWithOptions(Options{
+				Workers:              workers,
+				MaxDescriptionLength: 500,
+				TitleRules:           []TitleRule{rule},
+			})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := conv.Convert(jiraExport); err != nil {
+					b.Fatalf("Convert failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// CCMetadataKey is the metadata key holding the people to keep informed
+// about an issue, as a semicolon-separated list of email addresses or names
+const CCMetadataKey = "cc"
+
+// addParticipants copies the users of the participants field into cc
+// metadata
+func (c *ProtoConverter) addParticipants(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	if c.options.ParticipantsField == "" {
+		return
+	}
+	if ccs := SplitCC(jiraIssue.Fields.CustomFields[jira.UserListKey(c.options.ParticipantsField)]); len(ccs) > 0 {
+		setCustomMetadata(metadata, CCMetadataKey, strings.Join(ccs, "; "))
+	}
+}
+
+// SplitCC splits a cc list into its entries, dropping empty entries and
+// case-insensitive duplicates. Entries are separated by semicolons or line
+// breaks. A list with neither is split at commas only if every entry is an
+// email address, so names such as "Doe, Jane" stay whole.
+func SplitCC(value string) []string {
+	entries := strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '\n' })
+	if !strings.ContainsAny(value, ";\n") {
+		entries = []string{value}
+		if parts := strings.Split(value, ","); allEmails(parts) {
+			entries = parts
+		}
+	}
+
+	var ccs []string
+	seen := make(map[string]bool)
+	for _, cc := range entries {
+		cc = strings.TrimSpace(cc)
+		if cc == "" || seen[strings.ToLower(cc)] {
+			continue
+		}
+		seen[strings.ToLower(cc)] = true
+		ccs = append(ccs, cc)
+	}
+	return ccs
+}
+
+// allEmails reports whether every non-empty entry looks like an email address
+func allEmails(entries []string) bool {
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" && !strings.Contains(entry, "@") {
+			return false
+		}
+	}
+	return true
+}
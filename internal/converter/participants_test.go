@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConvertParticipants(t *testing.T) {
+	issue := warningTestIssue("SUP-1")
+	issue.Fields.CustomFields = map[string]string{
+		"customfield_10026":       "Jane Doe, Doe, John, Jane Doe",
+		"customfield_10026#users": "jane@example.com\nDoe, John\nJANE@example.com",
+	}
+
+	tests := []struct {
+		name   string
+		opts   Options
+		wantCC string
+	}{
+		{"not configured", Options{}, ""},
+		{"participants field", Options{ParticipantsField: "customfield_10026"}, "jane@example.com; Doe, John"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := NewProtoConverterWithOptions(tt.opts).convertIssue(issue)
+			if err != nil {
+				t.Fatalf("convertIssue failed: %v", err)
+			}
+			if got := converted.Metadata.Custom[CCMetadataKey]; got != tt.wantCC {
+				t.Errorf("Expected cc %q, got %q", tt.wantCC, got)
+			}
+		})
+	}
+}
+
+func TestSplitCC(t *testing.T) {
+	if got := SplitCC(" a@example.com,,b@example.com , A@example.com"); !slices.Equal(got, []string{"a@example.com", "b@example.com"}) {
+		t.Errorf("Unexpected cc entries %v", got)
+	}
+	if got := SplitCC("Doe, Jane; ops@example.com"); !slices.Equal(got, []string{"Doe, Jane", "ops@example.com"}) {
+		t.Errorf("Expected names with commas to stay whole, got %v", got)
+	}
+	if got := SplitCC("Doe, Jane"); !slices.Equal(got, []string{"Doe, Jane"}) {
+		t.Errorf("Expected a comma list with names not to be split, got %v", got)
+	}
+	if got := SplitCC(""); len(got) != 0 {
+		t.Errorf("Expected no entries, got %v", got)
+	}
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"google.golang.org/protobuf/proto"
+)
+
+// PayloadHash returns a hash of an issue as fetched from Jira, combined
+// with settings, a fingerprint of the configuration conversion depends on.
+// The updated timestamp is left out, since Jira bumps it for changes that
+// don't reach the issue's fields. Issues whose hash matches the last sync
+// convert to the same records, so incremental syncs can skip them unless
+// the options are time dependent.
+func PayloadHash(issue *jirapb.Issue, settings string) string {
+	payload := proto.Clone(issue).(*jirapb.Issue)
+	if payload.Fields != nil {
+		payload.Fields.Updated = nil
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.New()
+	sum.Write([]byte(settings))
+	sum.Write([]byte{0})
+	sum.Write(data)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// TimeDependent reports whether converting the same payload can give a
+// different result later, because issue ages feed into priorities or
+// metadata
+func (o Options) TimeDependent() bool {
+	return len(o.PriorityAging) > 0 || o.ComputedFields
+}
+
+// DropUnchanged removes the issues unchanged reports true for from
+// jiraExport and returns their keys. Issues are converted together with
+// their parents, subtasks and linked issues, so an unchanged issue next to
+// a changed one is kept.
+func DropUnchanged(jiraExport *jirapb.Export, unchanged func(issue *jirapb.Issue) bool) []string {
+	neighbours := make(map[string][]string)
+	link := func(a, b string) {
+		if a != "" && b != "" {
+			neighbours[a] = append(neighbours[a], b)
+			neighbours[b] = append(neighbours[b], a)
+		}
+	}
+	for _, issue := range jiraExport.Issues {
+		fields := issue.GetFields()
+		if parent := fields.GetParent(); parent != nil {
+			link(issue.Key, parent.Key)
+		}
+		for _, subtask := range fields.GetSubtasks() {
+			link(issue.Key, subtask.Key)
+		}
+		for _, l := range fields.GetIssueLinks() {
+			link(issue.Key, l.GetInwardIssue().GetKey())
+			link(issue.Key, l.GetOutwardIssue().GetKey())
+		}
+	}
+
+	keep := make(map[string]bool)
+	for _, issue := range jiraExport.Issues {
+		if !unchanged(issue) {
+			keep[issue.Key] = true
+			for _, key := range neighbours[issue.Key] {
+				keep[key] = true
+			}
+		}
+	}
+
+	var dropped []string
+	kept := jiraExport.Issues[:0]
+	for _, issue := range jiraExport.Issues {
+		if keep[issue.Key] {
+			kept = append(kept, issue)
+		} else {
+			dropped = append(dropped, issue.Key)
+		}
+	}
+	jiraExport.Issues = kept
+	return dropped
+}
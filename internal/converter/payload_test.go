@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPayloadHash(t *testing.T) {
+	issue := &jirapb.Issue{
+		Key: "PROJ-1",
+		Fields: &jirapb.Fields{
+			Summary:      "Fix login",
+			Updated:      timestamppb.New(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)),
+			CustomFields: map[string]string{"customfield_1": "a", "customfield_2": "b"},
+		},
+	}
+	hash := PayloadHash(issue, "settings")
+	if hash == "" {
+		t.Fatal("Expected a hash")
+	}
+
+	touched := &jirapb.Issue{
+		Key: "PROJ-1",
+		Fields: &jirapb.Fields{
+			Summary:      "Fix login",
+			Updated:      timestamppb.New(time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)),
+			CustomFields: map[string]string{"customfield_2": "b", "customfield_1": "a"},
+		},
+	}
+	if got := PayloadHash(touched, "settings"); got != hash {
+		t.Error("Expected a new updated timestamp alone not to change the hash")
+	}
+	if issue.Fields.Updated == nil {
+		t.Error("Expected PayloadHash to leave the issue unchanged")
+	}
+
+	touched.Fields.Summary = "Fix login page"
+	if got := PayloadHash(touched, "settings"); got == hash {
+		t.Error("Expected a changed summary to change the hash")
+	}
+	if got := PayloadHash(issue, "other settings"); got == hash {
+		t.Error("Expected different settings to change the hash")
+	}
+}
+
+func TestOptionsTimeDependent(t *testing.T) {
+	if (Options{SprintLabels: true}).TimeDependent() {
+		t.Error("Expected plain options not to depend on the time")
+	}
+	if !(Options{ComputedFields: true}).TimeDependent() {
+		t.Error("Expected computed fields to depend on the time")
+	}
+	if !(Options{PriorityAging: []time.Duration{time.Hour}}).TimeDependent() {
+		t.Error("Expected priority aging to depend on the time")
+	}
+}
+
+func TestDropUnchanged(t *testing.T) {
+	blocks := &jirapb.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+	export := &jirapb.Export{Issues: []*jirapb.Issue{
+		{Key: "PROJ-1", Fields: &jirapb.Fields{Summary: "Epic"}},
+		{Key: "PROJ-2", Fields: &jirapb.Fields{Summary: "Changed story", Parent: &jirapb.Parent{Key: "PROJ-1"}}},
+		{Key: "PROJ-3", Fields: &jirapb.Fields{Summary: "Blocker", IssueLinks: []*jirapb.IssueLink{
+			{Type: blocks, OutwardIssue: &jirapb.LinkedIssue{Key: "PROJ-2"}},
+		}}},
+		{Key: "PROJ-4", Fields: &jirapb.Fields{Summary: "Untouched"}},
+	}}
+
+	dropped := DropUnchanged(export, func(issue *jirapb.Issue) bool { return issue.Key != "PROJ-2" })
+	if len(dropped) != 1 || dropped[0] != "PROJ-4" {
+		t.Errorf("Expected only the unrelated issue to be dropped, got %v", dropped)
+	}
+	var kept []string
+	for _, issue := range export.Issues {
+		kept = append(kept, issue.Key)
+	}
+	if strings.Join(kept, ",") != "PROJ-1,PROJ-2,PROJ-3" {
+		t.Errorf("Expected the changed issue, its epic and its blocker to be kept, got %v", kept)
+	}
+
+	if dropped := DropUnchanged(export, func(*jirapb.Issue) bool { return true }); len(dropped) != 3 || len(export.Issues) != 0 {
+		t.Errorf("Expected every issue to be dropped when none changed, got %v", dropped)
+	}
+}
@@ -2,45 +2,240 @@ package converter
 
 import (
 	"fmt"
+	"io"
 
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
 	"github.com/conallob/jira-beads-sync/internal/beads"
 	"github.com/conallob/jira-beads-sync/internal/jira"
+	"github.com/conallob/jira-beads-sync/internal/routing"
 )
 
 // Pipeline orchestrates the full conversion from Jira JSON to beads JSONL
 type Pipeline struct {
-	jiraAdapter   *jira.Adapter
-	converter     *ProtoConverter
-	jsonlRenderer *beads.JSONLRenderer
+	jiraAdapter *jira.Adapter
+	converter   *ProtoConverter
+	renderer    beads.Renderer
+	format      beads.Format
+	durability  beads.Durability
+	source      *beads.SourceOptions
+	outputDir   string
+	router      *routing.Router
+	warnings    Warnings
+	keyChanges  []beads.KeyChange
+	outputDirs  []string
+	growthCheck func(beads.Growth) error
+	stream      *beads.StreamRenderer
 }
 
 // NewPipeline creates a new conversion pipeline
 func NewPipeline(outputDir string) *Pipeline {
+	return NewPipelineWithOptions(outputDir, Options{})
+}
+
+// NewPipelineWithOptions creates a conversion pipeline with converter options
+func NewPipelineWithOptions(outputDir string, opts Options) *Pipeline {
 	return &Pipeline{
-		jiraAdapter:   jira.NewAdapter(),
-		converter:     NewProtoConverter(),
-		jsonlRenderer: beads.NewJSONLRenderer(outputDir),
+		jiraAdapter: jira.NewAdapter(),
+		converter:   NewProtoConverterWithOptions(opts),
+		renderer:    beads.NewJSONLRenderer(outputDir),
+		outputDir:   outputDir,
+	}
+}
+
+// SetRouter splits output across beads repositories by Jira component.
+// Relative repository paths are resolved against the output directory.
+func (p *Pipeline) SetRouter(router *routing.Router) {
+	p.router = router
+}
+
+// SetFormat selects the output format, JSONL by default
+func (p *Pipeline) SetFormat(format beads.Format) error {
+	renderer, err := beads.NewRendererWithOptions(p.outputDir, format, p.rendererOptions())
+	if err != nil {
+		return err
 	}
+	p.renderer = renderer
+	p.format = format
+	return nil
+}
+
+// SetDurability selects when written files are flushed to stable storage,
+// beads.DurabilityBatch by default
+func (p *Pipeline) SetDurability(durability beads.Durability) error {
+	p.durability = durability
+	return p.SetFormat(p.format)
+}
+
+// SetSource adds a source block to every rendered record
+func (p *Pipeline) SetSource(source *beads.SourceOptions) error {
+	p.source = source
+	return p.SetFormat(p.format)
+}
+
+// SetGrowthCheck plans each conversion before writing it and passes how
+// much it would grow the beads repositories to check. An error from check
+// stops the conversion before anything is written.
+func (p *Pipeline) SetGrowthCheck(check func(beads.Growth) error) {
+	p.growthCheck = check
+}
+
+// SetStream emits converted records through stream instead of writing the
+// beads files. Routing and the growth check don't apply to streamed output.
+func (p *Pipeline) SetStream(stream *beads.StreamRenderer) {
+	p.stream = stream
+}
+
+func (p *Pipeline) rendererOptions() beads.RendererOptions {
+	return beads.RendererOptions{Durability: p.durability, Source: p.source}
+}
+
+// Warnings returns the non-fatal warnings from the last conversion
+func (p *Pipeline) Warnings() Warnings {
+	return p.warnings
+}
+
+// KeyChanges returns the issues detected as moved (renamed Jira keys) by the last conversion
+func (p *Pipeline) KeyChanges() []beads.KeyChange {
+	return p.keyChanges
+}
+
+// OutputDirs returns the directories whose .beads files the last ConvertFile
+// or ConvertReader wrote, one per repository when routing
+func (p *Pipeline) OutputDirs() []string {
+	return p.outputDirs
 }
 
 // ConvertFile converts a Jira JSON export file to beads JSONL files
 func (p *Pipeline) ConvertFile(jiraFile string) error {
-	// Step 1: Parse Jira JSON to protobuf
 	jiraExport, err := p.jiraAdapter.ParseFile(jiraFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse Jira file: %w", err)
 	}
+	return p.render(jiraExport)
+}
+
+// ConvertReader converts Jira JSON read from r, such as a /search response
+// piped from curl, to beads JSONL files
+func (p *Pipeline) ConvertReader(r io.Reader) error {
+	jiraExport, err := p.parseReader(r)
+	if err != nil {
+		return err
+	}
+	return p.render(jiraExport)
+}
+
+// PlanFile converts a Jira JSON export file and returns the changes
+// ConvertFile would make to the beads JSONL files, without writing them
+func (p *Pipeline) PlanFile(jiraFile string) (*beads.Plan, error) {
+	jiraExport, err := p.jiraAdapter.ParseFile(jiraFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira file: %w", err)
+	}
+	return p.plan(jiraExport)
+}
+
+// PlanReader is PlanFile for Jira JSON read from r
+func (p *Pipeline) PlanReader(r io.Reader) (*beads.Plan, error) {
+	jiraExport, err := p.parseReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return p.plan(jiraExport)
+}
+
+// parseReader parses Jira JSON read from r
+func (p *Pipeline) parseReader(r io.Reader) (*jirapb.Export, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Jira JSON: %w", err)
+	}
+	jiraExport, err := p.jiraAdapter.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Jira JSON: %w", err)
+	}
+	return jiraExport, nil
+}
+
+// render converts a parsed Jira export and writes the beads files
+func (p *Pipeline) render(jiraExport *jirapb.Export) error {
+	if p.stream != nil {
+		beadsExport, err := p.convert(jiraExport)
+		if err != nil {
+			return err
+		}
+		p.keyChanges = nil
+		p.outputDirs = nil
+		if err := p.stream.RenderExport(beadsExport); err != nil {
+			return fmt.Errorf("failed to stream records: %w", err)
+		}
+		return nil
+	}
+
+	if p.growthCheck != nil {
+		plan, err := p.plan(jiraExport)
+		if err != nil {
+			return err
+		}
+		if err := p.growthCheck(plan.Growth()); err != nil {
+			return err
+		}
+	}
 
-	// Step 2: Convert Jira protobuf to beads protobuf
-	beadsExport, err := p.converter.Convert(jiraExport)
+	beadsExport, err := p.convert(jiraExport)
 	if err != nil {
-		return fmt.Errorf("failed to convert to beads format: %w", err)
+		return err
+	}
+
+	if p.router != nil {
+		exports := p.router.Split(jiraExport, beadsExport)
+		changes, err := routing.RenderAll(p.outputDir, exports, false, p.format, p.rendererOptions())
+		if err != nil {
+			return fmt.Errorf("failed to render JSONL files: %w", err)
+		}
+		p.keyChanges = changes
+		p.outputDirs = nil
+		for _, repo := range routing.Repos(exports) {
+			p.outputDirs = append(p.outputDirs, routing.ResolveRepo(p.outputDir, repo))
+		}
+		return nil
 	}
 
-	// Step 3: Render beads protobuf to JSONL files
-	if err := p.jsonlRenderer.RenderExport(beadsExport); err != nil {
+	if err := p.renderer.RenderExport(beadsExport); err != nil {
 		return fmt.Errorf("failed to render JSONL files: %w", err)
 	}
+	p.keyChanges = p.renderer.KeyChanges()
+	p.outputDirs = []string{p.outputDir}
 
 	return nil
 }
+
+// plan converts a parsed Jira export and returns the changes render would
+// make
+func (p *Pipeline) plan(jiraExport *jirapb.Export) (*beads.Plan, error) {
+	beadsExport, err := p.convert(jiraExport)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.router != nil {
+		return routing.PlanAll(p.outputDir, p.router.Split(jiraExport, beadsExport), false, p.format, p.rendererOptions())
+	}
+	plan, err := p.renderer.PlanExport(beadsExport, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan JSONL files: %w", err)
+	}
+	p.keyChanges = p.renderer.KeyChanges()
+	return plan, nil
+}
+
+// convert converts a parsed Jira export to beads protobuf
+func (p *Pipeline) convert(jiraExport *jirapb.Export) (*beadspb.Export, error) {
+	beadsExport, warnings, err := p.converter.ConvertWithWarnings(jiraExport)
+	p.warnings = warnings
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to beads format: %w", err)
+	}
+
+	return beadsExport, nil
+}
@@ -1,10 +1,15 @@
 package converter
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/routing"
 )
 
 func TestNewPipeline(t *testing.T) {
@@ -18,8 +23,8 @@ func TestNewPipeline(t *testing.T) {
 	if pipeline.converter == nil {
 		t.Error("converter is nil")
 	}
-	if pipeline.jsonlRenderer == nil {
-		t.Error("jsonlRenderer is nil")
+	if pipeline.renderer == nil {
+		t.Error("renderer is nil")
 	}
 }
 
@@ -80,6 +85,117 @@ func TestPipelineConvertFile(t *testing.T) {
 	}
 }
 
+func TestPipelinePlanFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	pipeline := NewPipeline(tmpDir)
+
+	plan, err := pipeline.PlanFile("../../testdata/sample-jira-export.json")
+	if err != nil {
+		t.Fatalf("PlanFile failed: %v", err)
+	}
+	if plan.Count(beads.ChangeCreated) == 0 || plan.Count(beads.ChangeCreated) != len(plan.Changes) {
+		t.Errorf("Expected only created records for an empty directory, got %+v", plan.Changes)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".beads")); !os.IsNotExist(err) {
+		t.Error("Expected PlanFile not to write the beads directory")
+	}
+
+	if err := pipeline.ConvertFile("../../testdata/sample-jira-export.json"); err != nil {
+		t.Fatalf("ConvertFile failed: %v", err)
+	}
+	plan, err = NewPipeline(tmpDir).PlanFile("../../testdata/sample-jira-export.json")
+	if err != nil {
+		t.Fatalf("PlanFile failed: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("Expected no changes after converting, got %+v", plan.Changes)
+	}
+}
+
+func TestPipelineConvertReader(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/sample-jira-export.json")
+	if err != nil {
+		t.Fatalf("Failed to read sample export: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	pipeline := NewPipeline(tmpDir)
+
+	plan, err := pipeline.PlanReader(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("PlanReader failed: %v", err)
+	}
+	if plan.Count(beads.ChangeCreated) == 0 {
+		t.Errorf("Expected created records for an empty directory, got %+v", plan.Changes)
+	}
+
+	if err := pipeline.ConvertReader(strings.NewReader(string(data))); err != nil {
+		t.Fatalf("ConvertReader failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read issues.jsonl: %v", err)
+	}
+	if !strings.Contains(string(content), `"jiraKey":"PROJ-2"`) {
+		t.Errorf("Expected PROJ-2 in issues.jsonl, got:\n%s", content)
+	}
+	if dirs := pipeline.OutputDirs(); len(dirs) != 1 || dirs[0] != tmpDir {
+		t.Errorf("Expected output dir %s, got %v", tmpDir, dirs)
+	}
+
+	if err := pipeline.ConvertReader(strings.NewReader("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestPipelineGrowthCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	pipeline := NewPipeline(tmpDir)
+
+	var checked beads.Growth
+	pipeline.SetGrowthCheck(func(growth beads.Growth) error {
+		checked = growth
+		return errors.New("too big")
+	})
+	if err := pipeline.ConvertFile("../../testdata/sample-jira-export.json"); err == nil {
+		t.Fatal("Expected the growth check to stop the conversion")
+	}
+	if checked.Records == 0 || checked.Bytes == 0 {
+		t.Errorf("Expected the new records to be measured, got %+v", checked)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".beads")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be written when the check fails")
+	}
+
+	pipeline.SetGrowthCheck(func(beads.Growth) error { return nil })
+	if err := pipeline.ConvertFile("../../testdata/sample-jira-export.json"); err != nil {
+		t.Fatalf("ConvertFile failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".beads", "issues.jsonl")); err != nil {
+		t.Errorf("Expected issues.jsonl after a passing check: %v", err)
+	}
+}
+
+func TestPipelineStream(t *testing.T) {
+	tmpDir := t.TempDir()
+	var issues, epics bytes.Buffer
+	pipeline := NewPipeline(tmpDir)
+	pipeline.SetStream(beads.NewStreamRenderer(beads.WriterStream(&issues, &epics)))
+
+	if err := pipeline.ConvertFile("../../testdata/sample-jira-export.json"); err != nil {
+		t.Fatalf("ConvertFile failed: %v", err)
+	}
+	if issues.Len() == 0 || epics.Len() == 0 {
+		t.Errorf("Expected streamed issues and epics, got %q and %q", issues.String(), epics.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".beads")); !os.IsNotExist(err) {
+		t.Error("Expected streaming not to write the beads directory")
+	}
+	if len(pipeline.OutputDirs()) != 0 {
+		t.Errorf("Expected no output directories, got %v", pipeline.OutputDirs())
+	}
+}
+
 func TestPipelineConvertFileInvalid(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "pipeline-test-*")
 	if err != nil {
@@ -176,3 +292,37 @@ func containsSubstring(s, substr string) bool {
 func splitLines(s string) []string {
 	return strings.Split(strings.TrimSpace(s), "\n")
 }
+
+func TestPipelineConvertFileWithRouter(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pipeline := NewPipeline(tmpDir)
+	pipeline.SetRouter(routing.NewRouter([]routing.Route{
+		{Component: "Backend", Repo: "backend"},
+	}, ""))
+
+	if err := pipeline.ConvertFile("../../testdata/sample-jira-export.json"); err != nil {
+		t.Fatalf("ConvertFile failed: %v", err)
+	}
+
+	backendIssues, err := os.ReadFile(filepath.Join(tmpDir, "backend", ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read routed issues.jsonl: %v", err)
+	}
+	if !strings.Contains(string(backendIssues), `"id":"proj-2"`) {
+		t.Errorf("Expected proj-2 in backend repo, got:\n%s", backendIssues)
+	}
+
+	// The epic of a routed issue is copied alongside it
+	if _, err := os.Stat(filepath.Join(tmpDir, "backend", ".beads", "epics.jsonl")); err != nil {
+		t.Errorf("Expected epics.jsonl in backend repo: %v", err)
+	}
+
+	defaultIssues, err := os.ReadFile(filepath.Join(tmpDir, ".beads", "issues.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read default issues.jsonl: %v", err)
+	}
+	if strings.Contains(string(defaultIssues), `"id":"proj-2"`) {
+		t.Error("Expected proj-2 not to be written to the default repo")
+	}
+}
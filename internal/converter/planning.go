@@ -0,0 +1,163 @@
+package converter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// Metadata keys of the component hierarchy, as comma-separated lists. Levels
+// are joined with "/" whatever the configured separator.
+const (
+	ComponentParentsKey = "componentParents" // Top-level components, e.g. Backend
+	ComponentPathsKey   = "componentPaths"   // Every level, e.g. Backend, Backend/API
+)
+
+// Metadata keys of an issue's sprint history, set when it has been in a
+// sprint. A sprint count above one means the issue was carried over.
+const (
+	SprintCountKey   = "sprintCount"   // Number of sprints the issue has been in
+	RecentSprintsKey = "recentSprints" // Last RecentSprintCount sprints, oldest first, comma-separated
+)
+
+// RecentSprintCount is the number of sprints kept in recentSprints metadata
+const RecentSprintCount = 3
+
+// addPlanningFields copies the current sprint, fix versions and components
+// of an issue and, when enabled, adds labels for them
+func (c *ProtoConverter) addPlanningFields(jiraIssue *jirapb.Issue, issue *beadspb.Issue) {
+	issue.Sprint = currentSprint(jiraIssue.Fields.GetSprints())
+	if history := sprintHistory(jiraIssue.Fields.GetSprints()); len(history) > 0 {
+		setCustomMetadata(issue.Metadata, SprintCountKey, strconv.Itoa(len(history)))
+		setCustomMetadata(issue.Metadata, RecentSprintsKey, strings.Join(history[max(len(history)-RecentSprintCount, 0):], ", "))
+	}
+	for _, version := range jiraIssue.Fields.GetFixVersions() {
+		issue.FixVersions = append(issue.FixVersions, version.GetName())
+	}
+	for _, component := range jiraIssue.Fields.GetComponents() {
+		issue.Components = append(issue.Components, component.GetName())
+	}
+
+	var labels []string
+	if c.options.SprintLabels && issue.Sprint != "" {
+		labels = append(labels, "sprint:"+issue.Sprint)
+	}
+	if c.options.FixVersionLabels {
+		for _, version := range issue.FixVersions {
+			labels = append(labels, "release:"+version)
+		}
+	}
+	if c.options.ComponentSeparator == "" {
+		if c.options.ComponentLabels {
+			for _, component := range issue.Components {
+				labels = append(labels, "component:"+component)
+			}
+		}
+		addLabels(issue, labels...)
+		return
+	}
+
+	var parents, paths []string
+	for _, component := range issue.Components {
+		levels := componentPaths(component, c.options.ComponentSeparator)
+		if len(levels) == 0 {
+			continue
+		}
+		if !contains(parents, levels[0]) {
+			parents = append(parents, levels[0])
+		}
+		for _, path := range levels {
+			if !contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if c.options.ComponentLabels {
+		for _, path := range paths {
+			labels = append(labels, "component:"+path)
+		}
+	}
+	addLabels(issue, labels...)
+	if len(paths) > 0 {
+		setCustomMetadata(issue.Metadata, ComponentParentsKey, strings.Join(parents, ", "))
+		setCustomMetadata(issue.Metadata, ComponentPathsKey, strings.Join(paths, ", "))
+	}
+}
+
+// componentPaths splits a component name into the path of each level, from
+// the top: "Backend/API/Auth" gives Backend, Backend/API and
+// Backend/API/Auth. Blank levels are dropped.
+func componentPaths(name, separator string) []string {
+	var levels, paths []string
+	for _, level := range strings.Split(name, separator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+			paths = append(paths, strings.Join(levels, "/"))
+		}
+	}
+	return paths
+}
+
+// addLabels adds labels an issue doesn't have yet
+func addLabels(issue *beadspb.Issue, labels ...string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	// The Jira labels slice is shared with the source issue
+	merged := append([]string(nil), issue.Labels...)
+	for _, label := range labels {
+		if !contains(merged, label) {
+			merged = append(merged, label)
+		}
+	}
+	issue.Labels = merged
+}
+
+// currentSprint returns the name of the sprint an issue is planned in: the
+// active sprint, else the next future sprint, else the last closed one
+func currentSprint(sprints []*jirapb.Sprint) string {
+	for _, state := range []string{"active", "future"} {
+		for _, sprint := range sprints {
+			if sprint.GetState() == state {
+				return sprint.GetName()
+			}
+		}
+	}
+	if ordered := orderSprints(sprints); len(ordered) > 0 {
+		return ordered[len(ordered)-1].GetName()
+	}
+	return ""
+}
+
+// sprintHistory returns the names of the sprints an issue has been in, in
+// the order of orderSprints
+func sprintHistory(sprints []*jirapb.Sprint) []string {
+	var names []string
+	for _, sprint := range orderSprints(sprints) {
+		if name := sprint.GetName(); name != "" && !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// orderSprints returns the sprints oldest first: closed sprints, then the
+// active one, then future ones. Jira doesn't list closed sprints in any
+// particular order, so they are ordered by ID, which Jira assigns as sprints
+// are created; the others keep the order Jira lists them in.
+func orderSprints(sprints []*jirapb.Sprint) []*jirapb.Sprint {
+	rank := map[string]int{"closed": 0, "active": 1, "future": 2}
+	ordered := append([]*jirapb.Sprint(nil), sprints...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if rank[a.GetState()] != rank[b.GetState()] {
+			return rank[a.GetState()] < rank[b.GetState()]
+		}
+		return a.GetState() == "closed" && a.GetId() < b.GetId()
+	})
+	return ordered
+}
@@ -0,0 +1,158 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestCurrentSprint(t *testing.T) {
+	closed1 := &jirapb.Sprint{Name: "Sprint 1", State: "closed"}
+	closed2 := &jirapb.Sprint{Name: "Sprint 2", State: "closed"}
+	active := &jirapb.Sprint{Name: "Sprint 3", State: "active"}
+	future := &jirapb.Sprint{Name: "Sprint 4", State: "future"}
+
+	tests := []struct {
+		name    string
+		sprints []*jirapb.Sprint
+		want    string
+	}{
+		{name: "no sprints", want: ""},
+		{name: "active wins", sprints: []*jirapb.Sprint{closed1, active, future}, want: "Sprint 3"},
+		{name: "future over closed", sprints: []*jirapb.Sprint{closed1, future}, want: "Sprint 4"},
+		{name: "last closed", sprints: []*jirapb.Sprint{closed1, closed2}, want: "Sprint 2"},
+		{name: "latest closed by ID", sprints: []*jirapb.Sprint{
+			{Id: 12, Name: "Sprint 12", State: "closed"},
+			{Id: 9, Name: "Sprint 9", State: "closed"},
+		}, want: "Sprint 12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentSprint(tt.sprints); got != tt.want {
+				t.Errorf("Expected sprint %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPlanningFields(t *testing.T) {
+	jiraIssue := warningTestIssue("PROJ-1")
+	jiraIssue.Fields.Labels = []string{"backend"}
+	jiraIssue.Fields.Sprints = []*jirapb.Sprint{{Id: 3, Name: "Sprint 3", State: "active"}}
+	jiraIssue.Fields.FixVersions = []*jirapb.Version{{Name: "2.0"}, {Name: "2.1"}}
+	jiraIssue.Fields.Components = []*jirapb.Component{{Name: "API"}}
+	jiraExport := &jirapb.Export{Issues: []*jirapb.Issue{jiraIssue}}
+
+	export, err := NewProtoConverter().Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	issue := export.Issues[0]
+	if issue.Sprint != "Sprint 3" {
+		t.Errorf("Expected sprint Sprint 3, got %q", issue.Sprint)
+	}
+	if strings.Join(issue.FixVersions, ",") != "2.0,2.1" {
+		t.Errorf("Expected fix versions 2.0,2.1, got %v", issue.FixVersions)
+	}
+	if strings.Join(issue.Components, ",") != "API" {
+		t.Errorf("Expected components API, got %v", issue.Components)
+	}
+	if strings.Join(issue.Labels, ",") != "backend" {
+		t.Errorf("Expected no planning labels by default, got %v", issue.Labels)
+	}
+
+	export, err = NewProtoConverterWithOptions(Options{
+		SprintLabels:     true,
+		FixVersionLabels: true,
+		ComponentLabels:  true,
+	}).Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	want := "backend,sprint:Sprint 3,release:2.0,release:2.1,component:API"
+	if got := strings.Join(export.Issues[0].Labels, ","); got != want {
+		t.Errorf("Expected labels %s, got %s", want, got)
+	}
+	if strings.Join(jiraIssue.Fields.Labels, ",") != "backend" {
+		t.Errorf("Expected Jira labels to be left unchanged, got %v", jiraIssue.Fields.Labels)
+	}
+}
+
+func TestSprintHistory(t *testing.T) {
+	jiraIssue := warningTestIssue("PROJ-1")
+	// Jira lists closed sprints in no particular order, so they are ordered
+	// by ID ahead of the active and future ones
+	jiraIssue.Fields.Sprints = []*jirapb.Sprint{
+		{Id: 5, Name: "Sprint 5", State: "future"},
+		{Id: 3, Name: "Sprint 3", State: "closed"},
+		{Id: 1, Name: "Sprint 1", State: "closed"},
+		{Id: 2, Name: "Sprint 2", State: "closed"},
+		{Id: 4, Name: "Sprint 4", State: "active"},
+	}
+	carried := warningTestIssue("PROJ-2")
+	carried.Fields.Sprints = []*jirapb.Sprint{{Id: 4, Name: "Sprint 4", State: "active"}}
+	unplanned := warningTestIssue("PROJ-3")
+
+	export, err := NewProtoConverter().Convert(&jirapb.Export{Issues: []*jirapb.Issue{jiraIssue, carried, unplanned}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	custom := export.Issues[0].Metadata.Custom
+	if got := custom[SprintCountKey]; got != "5" {
+		t.Errorf("Expected a sprint count of 5, got %q", got)
+	}
+	if got := custom[RecentSprintsKey]; got != "Sprint 3, Sprint 4, Sprint 5" {
+		t.Errorf("Expected the last three sprints, got %q", got)
+	}
+	custom = export.Issues[1].Metadata.Custom
+	if custom[SprintCountKey] != "1" || custom[RecentSprintsKey] != "Sprint 4" {
+		t.Errorf("Expected a single sprint, got %v", custom)
+	}
+	if _, ok := export.Issues[2].Metadata.Custom[SprintCountKey]; ok {
+		t.Errorf("Expected no sprint history for an unplanned issue, got %v", export.Issues[2].Metadata.Custom)
+	}
+}
+
+func TestComponentHierarchy(t *testing.T) {
+	jiraIssue := warningTestIssue("PROJ-1")
+	jiraIssue.Fields.Components = []*jirapb.Component{{Name: "Backend / API/Auth"}, {Name: "Backend/Jobs"}, {Name: "Docs"}}
+	jiraExport := &jirapb.Export{Issues: []*jirapb.Issue{jiraIssue}}
+
+	export, err := NewProtoConverterWithOptions(Options{ComponentLabels: true, ComponentSeparator: "/"}).Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	issue := export.Issues[0]
+	want := "component:Backend,component:Backend/API,component:Backend/API/Auth,component:Backend/Jobs,component:Docs"
+	if got := strings.Join(issue.Labels, ","); got != want {
+		t.Errorf("Expected labels %s, got %s", want, got)
+	}
+	if got := issue.Metadata.Custom[ComponentParentsKey]; got != "Backend, Docs" {
+		t.Errorf("Expected parents Backend, Docs, got %q", got)
+	}
+	if got := issue.Metadata.Custom[ComponentPathsKey]; got != "Backend, Backend/API, Backend/API/Auth, Backend/Jobs, Docs" {
+		t.Errorf("Unexpected component paths %q", got)
+	}
+	if got := strings.Join(issue.Components, ","); got != "Backend / API/Auth,Backend/Jobs,Docs" {
+		t.Errorf("Expected the Jira component names to be kept, got %v", issue.Components)
+	}
+}
+
+func TestComponentPaths(t *testing.T) {
+	tests := []struct {
+		name, separator string
+		want            string
+	}{
+		{name: "API", separator: "/", want: "API"},
+		{name: "Backend::API", separator: "::", want: "Backend,Backend/API"},
+		{name: "Backend//API/", separator: "/", want: "Backend,Backend/API"},
+		{name: " / ", separator: "/", want: ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(componentPaths(tt.name, tt.separator), ","); got != tt.want {
+			t.Errorf("componentPaths(%q, %q) = %q, want %q", tt.name, tt.separator, got, tt.want)
+		}
+	}
+}
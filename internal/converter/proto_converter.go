@@ -3,33 +3,217 @@ package converter
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
 	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/markup"
 )
 
+// Options controls optional conversion behaviour. The zero value keeps the
+// default mappings.
+type Options struct {
+	// PriorityAging lists age thresholds for open issues. Each threshold an
+	// issue's age exceeds raises its beads priority by one level.
+	PriorityAging []time.Duration
+
+	// ComputedFields adds ageDays and, for resolved issues, cycleTimeDays
+	// to metadata.
+	ComputedFields bool
+
+	// MaxDescriptionLength truncates longer descriptions (in characters).
+	// Zero means no limit.
+	MaxDescriptionLength int
+
+	// ComponentOwners maps project key → component name → owner. Issues and
+	// epics get an owner metadata field from their primary (first) component.
+	ComponentOwners map[string]map[string]string
+
+	// Watchers maps issue key → watchers. Issues and epics get a watchers
+	// metadata field listing them.
+	Watchers map[string][]string
+
+	// Workers is the number of goroutines converting issues concurrently.
+	// Zero uses GOMAXPROCS. Output order does not depend on this setting.
+	Workers int
+
+	// TitleRules normalize issue and epic titles, applied in order. The
+	// original summary is kept in originalSummary metadata.
+	TitleRules []TitleRule
+
+	// MaxComments keeps only the most recent comments on each issue.
+	// Zero means all comments.
+	MaxComments int
+
+	// SkipAttachments leaves attachment metadata out of converted issues.
+	SkipAttachments bool
+
+	// PreserveRawDescription keeps the unconverted description (wiki markup
+	// or ADF JSON) in rawDescription metadata alongside the Markdown.
+	PreserveRawDescription bool
+
+	// CopyCloneLabels adds the labels of the issue a clone was made from to
+	// the clone, when the source is part of the export.
+	CopyCloneLabels bool
+
+	// CopyCloneEpic puts a clone without an epic into its source's epic,
+	// when the source is part of the export.
+	CopyCloneEpic bool
+
+	// SprintLabels, FixVersionLabels and ComponentLabels add sprint:<name>,
+	// release:<name> and component:<name> labels, so bd can filter on the
+	// planning fields.
+	SprintLabels     bool
+	FixVersionLabels bool
+	ComponentLabels  bool
+
+	// ComponentSeparator splits component names such as "Backend/API"
+	// into levels. Each level then gets a component label, and the
+	// hierarchy is kept in componentParents and componentPaths metadata.
+	// Empty treats every component as a single level.
+	ComponentSeparator string
+
+	// EpicNameField is the custom field holding epic names. When set, its
+	// value is kept alongside the summary: as the epic name if
+	// EpicNameFromField is set, else in epicName metadata or the
+	// description.
+	EpicNameField string
+
+	// EpicNameFromField names epics from EpicNameField instead of the
+	// summary. Epics with an empty field keep their summary.
+	EpicNameFromField bool
+
+	// EpicOtherInDescription puts the value not used as the epic name at
+	// the top of the description instead of in metadata.
+	EpicOtherInDescription bool
+
+	// OptOutLabel excludes Jira issues carrying it from conversion.
+	// Empty uses DefaultOptOutLabel.
+	OptOutLabel string
+
+	// EpicLabels selects the epic labels copied to the epic's issues and
+	// their subtasks, so label filters work without walking the hierarchy.
+	// Entries may use * to match any text (e.g. quarter:*) and are matched
+	// case-insensitively.
+	EpicLabels []string
+
+	// ReleaseEpicProjects lists the projects, or * for all, organised by
+	// fix version rather than epic: each fix version becomes an epic, and
+	// issues go into the epic of their first fix version. Project keys are
+	// matched case-insensitively.
+	ReleaseEpicProjects []string
+
+	// IssueTypes classifies Jira issue types as epic, issue or skip, taking
+	// precedence over the built-in types. Types that are neither listed nor
+	// built in are converted as issues with a warning. Names are matched
+	// case-insensitively.
+	IssueTypes map[string]IssueClass
+
+	// Teams maps Jira users (email, account ID or display name) to team
+	// names. Issues get a team:<name> label for their assignee's team.
+	// Users are matched case-insensitively.
+	Teams map[string]string
+
+	// MaxChainDepth is the deepest parent chain (issue → parent → …)
+	// accepted before conversion fails. Zero uses DefaultMaxChainDepth.
+	MaxChainDepth int
+
+	// StatusMap maps Jira status names to beads statuses, taking precedence
+	// over the status category. Names are matched case-insensitively.
+	StatusMap map[string]beadspb.Status
+
+	// PriorityMap maps Jira priority names to beads priorities, taking
+	// precedence over the built-in names. Names are matched case-insensitively.
+	PriorityMap map[string]beadspb.Priority
+
+	// DispositionMap maps Jira resolution names to beads dispositions,
+	// taking precedence over the built-in names. Names are matched
+	// case-insensitively.
+	DispositionMap map[string]beadspb.Disposition
+
+	// LabelRenames maps Jira labels to the labels beads issues get instead,
+	// so renamed labels stay consistent. Labels are matched
+	// case-insensitively.
+	LabelRenames map[string]string
+
+	// CustomFields maps Jira custom field IDs (customfield_XXXXX) to the
+	// metadata keys their values are copied to.
+	CustomFields map[string]string
+
+	// ParticipantsField is the user list custom field copied to the cc
+	// metadata of issues.
+	ParticipantsField string
+
+	// TargetStartField and TargetEndField are the planned date custom
+	// fields copied to targetStart and targetEnd metadata as YYYY-MM-DD.
+	TargetStartField string
+	TargetEndField   string
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
 // ProtoConverter handles converting Jira protobuf to beads protobuf
 type ProtoConverter struct {
 	issueMap map[string]*jirapb.Issue // Map of Jira keys to issues
 	epicMap  map[string]string        // Map of Jira epic keys to beads epic IDs
+	options  Options
+	warnings Warnings
+	optedOut []string // Jira keys skipped for carrying the opt-out label
+
+	skippedTypes []string // Jira keys skipped for their issue type
 }
 
 // NewProtoConverter creates a new protobuf-based converter
 func NewProtoConverter() *ProtoConverter {
+	return NewProtoConverterWithOptions(Options{})
+}
+
+// NewProtoConverterWithOptions creates a converter with optional behaviour enabled
+func NewProtoConverterWithOptions(opts Options) *ProtoConverter {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.OptOutLabel == "" {
+		opts.OptOutLabel = DefaultOptOutLabel
+	}
+	opts.StatusMap = lowerKeys(opts.StatusMap)
+	opts.PriorityMap = lowerKeys(opts.PriorityMap)
+	opts.DispositionMap = lowerKeys(opts.DispositionMap)
+	opts.Teams = lowerKeys(opts.Teams)
+	opts.LabelRenames = lowerKeys(opts.LabelRenames)
+	opts.IssueTypes = lowerKeys(opts.IssueTypes)
+
 	return &ProtoConverter{
 		issueMap: make(map[string]*jirapb.Issue),
 		epicMap:  make(map[string]string),
+		options:  opts,
 	}
 }
 
 // Convert converts a Jira export to beads format
 func (c *ProtoConverter) Convert(jiraExport *jirapb.Export) (*beadspb.Export, error) {
+	beadsExport, _, err := c.ConvertWithWarnings(jiraExport)
+	return beadsExport, err
+}
+
+// ConvertWithWarnings converts a Jira export to beads format and also returns
+// the non-fatal problems encountered along the way
+func (c *ProtoConverter) ConvertWithWarnings(jiraExport *jirapb.Export) (*beadspb.Export, Warnings, error) {
 	if jiraExport == nil {
-		return nil, fmt.Errorf("jira export is nil")
+		return nil, nil, fmt.Errorf("jira export is nil")
 	}
 
+	c.warnings = nil
+	jiraExport = c.skipOptedOut(jiraExport)
+	jiraExport = c.skipIssueTypes(jiraExport)
+
 	// Build issue map for quick lookups
 	c.issueMap = c.buildIssueMap(jiraExport)
+	if err := c.checkParentChains(jiraExport); err != nil {
+		return nil, nil, err
+	}
 
 	beadsExport := &beadspb.Export{
 		Issues: []*beadspb.Issue{},
@@ -37,36 +221,50 @@ func (c *ProtoConverter) Convert(jiraExport *jirapb.Export) (*beadspb.Export, er
 	}
 
 	// Convert epics first so we can reference them in issues
-	epics := c.getEpics(jiraExport)
-	for _, jiraIssue := range epics {
-		beadsEpic, err := c.convertEpic(jiraIssue)
+	epics, err := convertAll(c, c.getEpics(jiraExport), func(w *ProtoConverter, jiraIssue *jirapb.Issue) (*beadspb.Epic, error) {
+		beadsEpic, err := w.convertEpic(jiraIssue)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert epic %s: %w", jiraIssue.Key, err)
 		}
+		return beadsEpic, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, beadsEpic := range epics {
 		beadsExport.Epics = append(beadsExport.Epics, beadsEpic)
-		c.epicMap[jiraIssue.Key] = beadsEpic.Id
+		c.epicMap[beadsEpic.Metadata.JiraKey] = beadsEpic.Id
 	}
 
-	// Convert all issues (stories, tasks, subtasks)
+	// Convert all issues (stories, tasks, subtasks), skipping epics as
+	// they've already been converted
+	var jiraIssues []*jirapb.Issue
 	for _, jiraIssue := range jiraExport.Issues {
-		// Skip epics as they've already been converted
-		if jiraIssue.Fields.IssueType.Name == "Epic" {
-			continue
+		if !c.IsEpicType(jiraIssue.Fields.IssueType) {
+			jiraIssues = append(jiraIssues, jiraIssue)
 		}
+	}
 
-		beadsIssue, err := c.convertIssue(jiraIssue)
+	issues, err := convertAll(c, jiraIssues, func(w *ProtoConverter, jiraIssue *jirapb.Issue) (*beadspb.Issue, error) {
+		beadsIssue, err := w.convertIssue(jiraIssue)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert issue %s: %w", jiraIssue.Key, err)
 		}
-		beadsExport.Issues = append(beadsExport.Issues, beadsIssue)
+		return beadsIssue, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	beadsExport.Issues = append(beadsExport.Issues, issues...)
 
 	// Add dependencies after all issues are converted
 	if err := c.addDependencies(jiraExport, beadsExport); err != nil {
-		return nil, fmt.Errorf("failed to add dependencies: %w", err)
+		return nil, nil, fmt.Errorf("failed to add dependencies: %w", err)
 	}
+	c.dropOptedOutDependencies(beadsExport)
+	c.addReleaseEpics(beadsExport)
 
-	return beadsExport, nil
+	return beadsExport, c.warnings, nil
 }
 
 // convertEpic converts a Jira epic to a beads epic
@@ -74,8 +272,8 @@ func (c *ProtoConverter) convertEpic(jiraIssue *jirapb.Issue) (*beadspb.Epic, er
 	epic := &beadspb.Epic{
 		Id:          c.generateBeadsID(jiraIssue.Key),
 		Name:        jiraIssue.Fields.Summary,
-		Description: jiraIssue.Fields.Description,
-		Status:      c.mapStatus(jiraIssue.Fields.Status),
+		Description: c.convertDescription(jiraIssue),
+		Status:      c.convertStatus(jiraIssue),
 		Created:     jiraIssue.Fields.Created,
 		Updated:     jiraIssue.Fields.Updated,
 		Metadata: &beadspb.Metadata{
@@ -85,6 +283,16 @@ func (c *ProtoConverter) convertEpic(jiraIssue *jirapb.Issue) (*beadspb.Epic, er
 		},
 	}
 
+	epic.Disposition = c.convertDisposition(jiraIssue, epic.Status)
+	c.nameEpic(jiraIssue, epic)
+	c.preserveRawDescription(jiraIssue, epic.Metadata)
+	c.addCloneMetadata(jiraIssue, epic.Metadata)
+	c.copyCustomFields(jiraIssue, epic.Metadata)
+	c.addTargetDates(jiraIssue, epic.Metadata)
+	c.addComputedFields(jiraIssue, epic.Metadata)
+	c.assignOwner(jiraIssue, epic.Metadata)
+	c.addWatchers(jiraIssue, epic.Metadata)
+
 	return epic, nil
 }
 
@@ -93,10 +301,10 @@ func (c *ProtoConverter) convertIssue(jiraIssue *jirapb.Issue) (*beadspb.Issue,
 	issue := &beadspb.Issue{
 		Id:          c.generateBeadsID(jiraIssue.Key),
 		Title:       jiraIssue.Fields.Summary,
-		Description: jiraIssue.Fields.Description,
-		Status:      c.mapStatus(jiraIssue.Fields.Status),
-		Priority:    c.mapPriority(jiraIssue.Fields.Priority),
-		Labels:      jiraIssue.Fields.Labels,
+		Description: c.convertDescription(jiraIssue),
+		Status:      c.convertStatus(jiraIssue),
+		Priority:    c.convertPriority(jiraIssue),
+		Labels:      c.renameLabels(jiraIssue.Fields.Labels),
 		DependsOn:   []string{},
 		Created:     jiraIssue.Fields.Created,
 		Updated:     jiraIssue.Fields.Updated,
@@ -112,31 +320,77 @@ func (c *ProtoConverter) convertIssue(jiraIssue *jirapb.Issue) (*beadspb.Issue,
 		issue.Assignee = jiraIssue.Fields.Assignee.EmailAddress
 		if issue.Assignee == "" {
 			issue.Assignee = jiraIssue.Fields.Assignee.DisplayName
+			c.warn(WarningMissingAssigneeEmail, jiraIssue.Key,
+				"assignee %q has no email address, using display name", issue.Assignee)
 		}
 	}
 
 	// Link to epic if this issue belongs to one
-	if jiraIssue.Fields.Parent != nil {
-		// Check if parent is an epic
-		if jiraIssue.Fields.Parent.Fields.IssueType.Name == "Epic" {
-			if epicID, exists := c.epicMap[jiraIssue.Fields.Parent.Key]; exists {
-				issue.Epic = epicID
-			}
-		}
-	}
+	issue.Epic = c.epicFor(jiraIssue)
 
 	// Handle dependencies from parent-child relationships
 	if jiraIssue.Fields.Parent != nil && jiraIssue.Fields.IssueType.Subtask {
 		// Subtasks depend on their parent (unless parent is an epic)
-		if jiraIssue.Fields.Parent.Fields.IssueType.Name != "Epic" {
+		if !c.IsEpicType(jiraIssue.Fields.Parent.Fields.GetIssueType()) {
 			parentBeadsID := c.generateBeadsID(jiraIssue.Fields.Parent.Key)
 			issue.DependsOn = append(issue.DependsOn, parentBeadsID)
 		}
 	}
 
+	issue.Disposition = c.convertDisposition(jiraIssue, issue.Status)
+	issue.Comments = c.convertComments(jiraIssue)
+	issue.Attachments = c.convertAttachments(jiraIssue)
+
+	issue.Title = c.normalizeTitle(jiraIssue, issue.Metadata)
+	c.preserveRawDescription(jiraIssue, issue.Metadata)
+	c.addCloneMetadata(jiraIssue, issue.Metadata)
+	c.inheritFromCloneSource(jiraIssue, issue)
+	c.addPlanningFields(jiraIssue, issue)
+	c.addEpicLabels(jiraIssue, issue)
+	c.addTeamLabel(jiraIssue, issue)
+	c.copyCustomFields(jiraIssue, issue.Metadata)
+	c.addParticipants(jiraIssue, issue.Metadata)
+	c.addTargetDates(jiraIssue, issue.Metadata)
+	c.addComputedFields(jiraIssue, issue.Metadata)
+	c.assignOwner(jiraIssue, issue.Metadata)
+	c.addWatchers(jiraIssue, issue.Metadata)
+	c.applyPriorityAging(issue)
+
 	return issue, nil
 }
 
+// epicFor returns the beads ID of the converted epic an issue belongs to,
+// or "" if its parent isn't an epic in the export
+func (c *ProtoConverter) epicFor(jiraIssue *jirapb.Issue) string {
+	parent := jiraIssue.Fields.Parent
+	if parent == nil || !c.IsEpicType(parent.Fields.GetIssueType()) {
+		return ""
+	}
+	return c.epicMap[parent.Key]
+}
+
+// MissingEpics returns the keys of parent epics referenced by the export's
+// issues but not part of it, in discovery order. Issues are only linked to
+// epics converted alongside them, so partial fetches such as incremental
+// syncs need these added to keep their epic links.
+func (c *ProtoConverter) MissingEpics(jiraExport *jirapb.Export) []string {
+	present := make(map[string]bool, len(jiraExport.Issues))
+	for _, issue := range jiraExport.Issues {
+		present[issue.Key] = true
+	}
+
+	var keys []string
+	for _, issue := range jiraExport.Issues {
+		parent := issue.GetFields().GetParent()
+		if parent == nil || present[parent.Key] || !c.IsEpicType(parent.Fields.GetIssueType()) {
+			continue
+		}
+		present[parent.Key] = true
+		keys = append(keys, parent.Key)
+	}
+	return keys
+}
+
 // addDependencies adds dependency relationships from Jira issue links
 func (c *ProtoConverter) addDependencies(jiraExport *jirapb.Export, beadsExport *beadspb.Export) error {
 	// Get dependencies from Jira
@@ -170,57 +424,140 @@ func (c *ProtoConverter) addDependencies(jiraExport *jirapb.Export, beadsExport
 	return nil
 }
 
+// convertStatus maps an issue's status, warning when it is not recognised
+func (c *ProtoConverter) convertStatus(jiraIssue *jirapb.Issue) beadspb.Status {
+	status, known := c.resolveStatus(jiraIssue.Fields.Status)
+	if !known {
+		c.warn(WarningUnknownStatus, jiraIssue.Key,
+			"unknown status %q, defaulting to open", jiraIssue.Fields.Status.GetName())
+	}
+	return status
+}
+
+// convertPriority maps an issue's priority, warning when it is not recognised
+func (c *ProtoConverter) convertPriority(jiraIssue *jirapb.Issue) beadspb.Priority {
+	priority, known := c.resolvePriority(jiraIssue.Fields.Priority)
+	if !known {
+		c.warn(WarningUnknownPriority, jiraIssue.Key,
+			"unknown priority %q, defaulting to p2", jiraIssue.Fields.Priority.GetName())
+	}
+	return priority
+}
+
+// convertDescription returns the issue description as Markdown followed by
+// any checklist, truncated to the configured maximum length
+func (c *ProtoConverter) convertDescription(jiraIssue *jirapb.Issue) string {
+	fields := jiraIssue.Fields
+	description := c.renderMarkup(jiraIssue.Key, fields.Description, fields.DescriptionAdf)
+	description = appendChecklist(description, fields.Checklist)
+	limit := c.options.MaxDescriptionLength
+	if limit <= 0 {
+		return description
+	}
+
+	runes := []rune(description)
+	if len(runes) <= limit {
+		return description
+	}
+
+	truncated := markup.Truncate(description, limit)
+	c.warn(WarningTruncatedDescription, jiraIssue.Key,
+		"description truncated from %d to %d characters", len(runes), utf8.RuneCountInString(truncated))
+	return truncated
+}
+
 // mapStatus maps Jira status to beads status
 func (c *ProtoConverter) mapStatus(jiraStatus *jirapb.Status) beadspb.Status {
-	if jiraStatus == nil || jiraStatus.StatusCategory == nil {
-		return beadspb.Status_STATUS_OPEN
+	status, _ := c.resolveStatus(jiraStatus)
+	return status
+}
+
+// resolveStatus maps Jira status to beads status and reports whether the
+// status was recognised. A missing status is not considered unknown.
+func (c *ProtoConverter) resolveStatus(jiraStatus *jirapb.Status) (beadspb.Status, bool) {
+	status, _, known := c.explainStatus(jiraStatus)
+	return status, known
+}
+
+// explainStatus is resolveStatus that also describes the rule that decided
+// the status
+func (c *ProtoConverter) explainStatus(jiraStatus *jirapb.Status) (beadspb.Status, string, bool) {
+	if status, ok := c.options.StatusMap[strings.ToLower(jiraStatus.GetName())]; ok {
+		return status, "statuses in mapping.yml", true
+	}
+
+	if jiraStatus == nil {
+		return beadspb.Status_STATUS_OPEN, "no status, defaulting to open", true
+	}
+	if jiraStatus.StatusCategory == nil {
+		return beadspb.Status_STATUS_OPEN, "no status category, defaulting to open", true
 	}
 
 	switch jiraStatus.StatusCategory.Key {
 	case "new":
-		return beadspb.Status_STATUS_OPEN
+		return beadspb.Status_STATUS_OPEN, `status category "new"`, true
 	case "indeterminate":
-		return beadspb.Status_STATUS_IN_PROGRESS
+		return beadspb.Status_STATUS_IN_PROGRESS, `status category "indeterminate"`, true
 	case "done":
-		return beadspb.Status_STATUS_CLOSED
+		return beadspb.Status_STATUS_CLOSED, `status category "done"`, true
 	default:
 		// Check specific status names
 		statusName := strings.ToLower(jiraStatus.Name)
 		if strings.Contains(statusName, "block") {
-			return beadspb.Status_STATUS_BLOCKED
+			return beadspb.Status_STATUS_BLOCKED, `status name contains "block"`, true
 		}
 		if strings.Contains(statusName, "progress") || strings.Contains(statusName, "doing") {
-			return beadspb.Status_STATUS_IN_PROGRESS
+			return beadspb.Status_STATUS_IN_PROGRESS, `status name contains "progress" or "doing"`, true
 		}
 		if strings.Contains(statusName, "done") || strings.Contains(statusName, "closed") {
-			return beadspb.Status_STATUS_CLOSED
+			return beadspb.Status_STATUS_CLOSED, `status name contains "done" or "closed"`, true
 		}
-		return beadspb.Status_STATUS_OPEN
+		if statusName == "open" || statusName == "to do" || statusName == "new" {
+			return beadspb.Status_STATUS_OPEN, "built-in status name", true
+		}
+		return beadspb.Status_STATUS_OPEN, "unknown status, defaulting to open", false
 	}
 }
 
 // mapPriority maps Jira priority to beads priority
 func (c *ProtoConverter) mapPriority(jiraPriority *jirapb.Priority) beadspb.Priority {
-	if jiraPriority == nil {
-		return beadspb.Priority_PRIORITY_P2
+	priority, _ := c.resolvePriority(jiraPriority)
+	return priority
+}
+
+// resolvePriority maps Jira priority to beads priority and reports whether the
+// priority was recognised. A missing priority is not considered unknown.
+func (c *ProtoConverter) resolvePriority(jiraPriority *jirapb.Priority) (beadspb.Priority, bool) {
+	priority, _, known := c.explainPriority(jiraPriority)
+	return priority, known
+}
+
+// explainPriority is resolvePriority that also describes the rule that
+// decided the priority
+func (c *ProtoConverter) explainPriority(jiraPriority *jirapb.Priority) (beadspb.Priority, string, bool) {
+	if jiraPriority == nil || jiraPriority.Name == "" {
+		return beadspb.Priority_PRIORITY_P2, "no priority, defaulting to p2", true
 	}
 
 	priorityName := strings.ToLower(jiraPriority.Name)
+	if priority, ok := c.options.PriorityMap[priorityName]; ok {
+		return priority, "priorities in mapping.yml", true
+	}
 
 	switch {
 	case strings.Contains(priorityName, "critical") || strings.Contains(priorityName, "highest"):
-		return beadspb.Priority_PRIORITY_P0
+		return beadspb.Priority_PRIORITY_P0, `priority name contains "critical" or "highest"`, true
 	case strings.Contains(priorityName, "high"):
-		return beadspb.Priority_PRIORITY_P1
+		return beadspb.Priority_PRIORITY_P1, `priority name contains "high"`, true
 	case strings.Contains(priorityName, "medium"):
-		return beadspb.Priority_PRIORITY_P2
+		return beadspb.Priority_PRIORITY_P2, `priority name contains "medium"`, true
 	case strings.Contains(priorityName, "lowest"):
-		return beadspb.Priority_PRIORITY_P4
+		return beadspb.Priority_PRIORITY_P4, `priority name contains "lowest"`, true
 	case strings.Contains(priorityName, "low"):
-		return beadspb.Priority_PRIORITY_P3
+		return beadspb.Priority_PRIORITY_P3, `priority name contains "low"`, true
 	default:
 		// Default to medium priority
-		return beadspb.Priority_PRIORITY_P2
+		return beadspb.Priority_PRIORITY_P2, "unknown priority, defaulting to p2", false
 	}
 }
 
@@ -243,7 +580,7 @@ func (c *ProtoConverter) buildIssueMap(export *jirapb.Export) map[string]*jirapb
 func (c *ProtoConverter) getEpics(export *jirapb.Export) []*jirapb.Issue {
 	var epics []*jirapb.Issue
 	for _, issue := range export.Issues {
-		if issue.Fields.IssueType.Name == "Epic" {
+		if c.IsEpicType(issue.Fields.IssueType) {
 			epics = append(epics, issue)
 		}
 	}
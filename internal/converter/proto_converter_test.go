@@ -375,3 +375,44 @@ func TestProtoGetDependencies(t *testing.T) {
 		t.Errorf("Expected PROJ-1 to depend on PROJ-2, got %v", proj1Deps)
 	}
 }
+
+func TestMissingEpics(t *testing.T) {
+	story := warningTestIssue("PROJ-1")
+	story.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+	sibling := warningTestIssue("PROJ-2")
+	sibling.Fields.Parent = story.Fields.Parent
+	subtask := warningTestIssue("PROJ-3")
+	subtask.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-1",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Story"}},
+	}
+
+	conv := NewProtoConverter()
+	partial := &jirapb.Export{Issues: []*jirapb.Issue{story, sibling, subtask}}
+	missing := conv.MissingEpics(partial)
+	if len(missing) != 1 || missing[0] != "PROJ-10" {
+		t.Fatalf("Expected PROJ-10 to be missing, got %v", missing)
+	}
+
+	// An incremental sync fetching only the story must still link it to
+	// its epic once the missing epic is added
+	epic := warningTestIssue("PROJ-10")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	partial.Issues = append(partial.Issues, epic)
+	if missing := conv.MissingEpics(partial); len(missing) != 0 {
+		t.Errorf("Expected no missing epics, got %v", missing)
+	}
+
+	export, err := conv.Convert(partial)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	for _, issue := range export.Issues {
+		if issue.Metadata.JiraKey == "PROJ-1" && issue.Epic != "proj-10" {
+			t.Errorf("Expected PROJ-1 to keep its epic link, got %q", issue.Epic)
+		}
+	}
+}
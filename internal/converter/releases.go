@@ -0,0 +1,169 @@
+package converter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// ReleaseIssueType is the jiraIssueType metadata of epics made from Jira
+// versions
+const ReleaseIssueType = "Version"
+
+// Metadata keys of release epics and their issues
+const (
+	ReleaseProjectKey = "jiraProject"   // Project the version belongs to
+	ReleaseVersionKey = "jiraVersion"   // Version name
+	ReleaseIDKey      = "jiraVersionId" // Version ID
+	ReleaseDateKey    = "releaseDate"   // YYYY-MM-DD, when set in Jira
+	JiraEpicKey       = "jiraEpic"      // Beads ID of the Jira epic an issue moved to a release epic belongs to
+)
+
+// releaseSlug matches runs of characters left out of release epic IDs
+var releaseSlug = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// releaseEpicsFor reports whether a project's fix versions become epics
+func (c *ProtoConverter) releaseEpicsFor(project string) bool {
+	for _, selected := range c.options.ReleaseEpicProjects {
+		if selected == "*" || strings.EqualFold(selected, project) {
+			return true
+		}
+	}
+	return false
+}
+
+// addReleaseEpics creates an epic for each fix version of the projects
+// selected by Options.ReleaseEpicProjects and moves their issues into the
+// epic of their earliest fix version. Subtasks without fix versions follow
+// their parent. Issues without fix versions keep their Jira epic.
+func (c *ProtoConverter) addReleaseEpics(beadsExport *beadspb.Export) {
+	if len(c.options.ReleaseEpicProjects) == 0 {
+		return
+	}
+
+	epics := make(map[string]*beadspb.Epic)
+	for _, issue := range beadsExport.Issues {
+		jiraIssue, ok := c.issueMap[issue.Metadata.GetJiraKey()]
+		if !ok {
+			continue
+		}
+		project := jiraProject(jiraIssue.Key)
+		if !c.releaseEpicsFor(project) {
+			continue
+		}
+		version := c.releaseOf(jiraIssue)
+		if version == nil {
+			continue
+		}
+
+		id := releaseEpicID(project, version)
+		if _, ok := epics[id]; !ok {
+			epics[id] = releaseEpic(id, project, version)
+		}
+		if issue.Epic != "" && issue.Epic != id {
+			setCustomMetadata(issue.Metadata, JiraEpicKey, issue.Epic)
+		}
+		issue.Epic = id
+	}
+
+	ids := make([]string, 0, len(epics))
+	for id := range epics {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		beadsExport.Epics = append(beadsExport.Epics, epics[id])
+	}
+}
+
+// releaseOf returns the fix version an issue is released in, or that of
+// its parent for subtasks without one
+func (c *ProtoConverter) releaseOf(jiraIssue *jirapb.Issue) *jirapb.Version {
+	if versions := jiraIssue.Fields.GetFixVersions(); len(versions) > 0 {
+		return earliestRelease(versions)
+	}
+	if parent := jiraIssue.Fields.GetParent(); parent != nil && jiraIssue.Fields.GetIssueType().GetSubtask() {
+		if parentIssue, ok := c.issueMap[parent.Key]; ok {
+			if versions := parentIssue.Fields.GetFixVersions(); len(versions) > 0 {
+				return earliestRelease(versions)
+			}
+		}
+	}
+	return nil
+}
+
+// earliestRelease picks the version an issue ships in from its fix
+// versions, which Jira lists in no particular order: the unreleased version
+// with the earliest release date, or the earliest released version when all
+// are released. Undated versions come after dated ones, in Jira's order.
+func earliestRelease(versions []*jirapb.Version) *jirapb.Version {
+	var earliest *jirapb.Version
+	for _, version := range versions {
+		if earliest == nil || releasedBefore(version, earliest) {
+			earliest = version
+		}
+	}
+	return earliest
+}
+
+// releasedBefore reports whether version a ships before version b
+func releasedBefore(a, b *jirapb.Version) bool {
+	if a.GetReleased() != b.GetReleased() {
+		return !a.GetReleased()
+	}
+	dateA, dateB := a.GetReleaseDate(), b.GetReleaseDate()
+	if (dateA == "") != (dateB == "") {
+		return dateA != ""
+	}
+	return dateA < dateB
+}
+
+// releaseEpic creates the epic of a Jira version. Released versions are
+// closed.
+func releaseEpic(id, project string, version *jirapb.Version) *beadspb.Epic {
+	epic := &beadspb.Epic{
+		Id:     id,
+		Name:   version.GetName(),
+		Status: beadspb.Status_STATUS_OPEN,
+		Metadata: &beadspb.Metadata{
+			JiraIssueType: ReleaseIssueType,
+			Custom: map[string]string{
+				ReleaseProjectKey: project,
+				ReleaseVersionKey: version.GetName(),
+			},
+		},
+	}
+	if version.GetReleased() {
+		epic.Status = beadspb.Status_STATUS_CLOSED
+	}
+	if version.GetId() != "" {
+		epic.Metadata.Custom[ReleaseIDKey] = version.GetId()
+	}
+	if version.GetReleaseDate() != "" {
+		epic.Metadata.Custom[ReleaseDateKey] = version.GetReleaseDate()
+	}
+	return epic
+}
+
+// releaseEpicID returns the beads ID of a version's epic, e.g.
+// proj-release-10042 for version 10042 of PROJ. The version ID is used
+// rather than the name, so renaming a version keeps its epic; versions
+// without an ID, as in some exports, fall back to the name.
+func releaseEpicID(project string, version *jirapb.Version) string {
+	slug := version.GetId()
+	if slug == "" {
+		slug = strings.Trim(releaseSlug.ReplaceAllString(strings.ToLower(version.GetName()), "-"), "-.")
+	}
+	return strings.ToLower(project) + "-release-" + slug
+}
+
+// jiraProject returns the project key of an issue key
+func jiraProject(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		return key[:i]
+	}
+	return key
+}
@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestReleaseEpics(t *testing.T) {
+	epic := warningTestIssue("MOB-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+
+	story := warningTestIssue("MOB-2")
+	story.Fields.Parent = &jirapb.Parent{Key: "MOB-1", Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}}}
+	// Jira lists fix versions in no particular order
+	story.Fields.FixVersions = []*jirapb.Version{
+		{Id: "10002", Name: "Mobile 2.1"},
+		{Id: "9000", Name: "1.9", Released: true, ReleaseDate: "2024-03-01"},
+		{Id: "10001", Name: "Mobile 2.0", ReleaseDate: "2024-06-01"},
+	}
+	subtask := warningTestIssue("MOB-3")
+	subtask.Fields.IssueType = &jirapb.IssueType{Name: "Sub-task", Subtask: true}
+	subtask.Fields.Parent = &jirapb.Parent{Key: "MOB-2", Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Story"}}}
+	released := warningTestIssue("MOB-4")
+	released.Fields.FixVersions = []*jirapb.Version{{Id: "9000", Name: "1.9", Released: true}}
+	unversioned := warningTestIssue("MOB-5")
+	unversioned.Fields.Parent = story.Fields.Parent
+	// Other projects keep organising by epic
+	web := warningTestIssue("WEB-1")
+	web.Fields.FixVersions = []*jirapb.Version{{Id: "20000", Name: "3.0"}}
+
+	jiraExport := &jirapb.Export{Issues: []*jirapb.Issue{epic, story, subtask, released, unversioned, web}}
+	export, err := NewProtoConverterWithOptions(Options{ReleaseEpicProjects: []string{"mob"}}).Convert(jiraExport)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	epics := make(map[string]*beadspb.Epic)
+	for _, e := range export.Epics {
+		epics[e.Id] = e
+	}
+	if len(epics) != 3 || epics["mob-1"] == nil {
+		t.Fatalf("Expected the Jira epic and two release epics, got %v", export.Epics)
+	}
+	release := epics["mob-release-10001"]
+	if release == nil {
+		t.Fatalf("Expected an epic for Mobile 2.0, got %v", export.Epics)
+	}
+	if release.Name != "Mobile 2.0" || release.Status != beadspb.Status_STATUS_OPEN {
+		t.Errorf("Unexpected release epic %+v", release)
+	}
+	if got := release.Metadata.Custom; got[ReleaseIDKey] != "10001" || got[ReleaseDateKey] != "2024-06-01" || got[ReleaseProjectKey] != "MOB" {
+		t.Errorf("Unexpected release metadata %v", got)
+	}
+	if epics["mob-release-9000"].GetStatus() != beadspb.Status_STATUS_CLOSED {
+		t.Errorf("Expected the released version's epic to be closed, got %v", epics["mob-release-9000"])
+	}
+
+	byKey := make(map[string]*beadspb.Issue)
+	for _, issue := range export.Issues {
+		byKey[issue.Metadata.JiraKey] = issue
+	}
+	tests := []struct {
+		key, epic, jiraEpic string
+	}{
+		{key: "MOB-2", epic: "mob-release-10001", jiraEpic: "mob-1"},
+		{key: "MOB-3", epic: "mob-release-10001"},
+		{key: "MOB-4", epic: "mob-release-9000"},
+		{key: "MOB-5", epic: "mob-1"},
+		{key: "WEB-1", epic: ""},
+	}
+	for _, tt := range tests {
+		issue := byKey[tt.key]
+		if issue.Epic != tt.epic {
+			t.Errorf("Expected %s in epic %q, got %q", tt.key, tt.epic, issue.Epic)
+		}
+		if got := issue.Metadata.Custom[JiraEpicKey]; got != tt.jiraEpic {
+			t.Errorf("Expected %s to keep Jira epic %q, got %q", tt.key, tt.jiraEpic, got)
+		}
+	}
+}
+
+func TestReleaseEpicID(t *testing.T) {
+	tests := []struct {
+		version *jirapb.Version
+		want    string
+	}{
+		{&jirapb.Version{Id: "10042", Name: "2.0"}, "proj-release-10042"},
+		{&jirapb.Version{Name: "2.0"}, "proj-release-2.0"},
+		{&jirapb.Version{Name: "Q3 / Beta!"}, "proj-release-q3-beta"},
+	}
+	for _, tt := range tests {
+		if got := releaseEpicID("PROJ", tt.version); got != tt.want {
+			t.Errorf("releaseEpicID(%q) = %q, want %q", tt.version.Name, got, tt.want)
+		}
+	}
+}
+
+func TestEarliestRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []*jirapb.Version
+		want     string
+	}{
+		{"earliest unreleased", []*jirapb.Version{{Id: "3", ReleaseDate: "2024-09-01"}, {Id: "2", ReleaseDate: "2024-06-01"}}, "2"},
+		{"unreleased before released", []*jirapb.Version{{Id: "1", Released: true, ReleaseDate: "2024-01-01"}, {Id: "2", ReleaseDate: "2024-06-01"}}, "2"},
+		{"dated before undated", []*jirapb.Version{{Id: "4"}, {Id: "3", ReleaseDate: "2024-09-01"}}, "3"},
+		{"undated in Jira order", []*jirapb.Version{{Id: "4"}, {Id: "5"}}, "4"},
+		{"earliest released", []*jirapb.Version{{Id: "2", Released: true, ReleaseDate: "2024-06-01"}, {Id: "1", Released: true, ReleaseDate: "2024-01-01"}}, "1"},
+	}
+	for _, tt := range tests {
+		if got := earliestRelease(tt.versions).GetId(); got != tt.want {
+			t.Errorf("%s: expected version %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// addTeamLabel labels an issue with its assignee's team as team:<name>,
+// warning when the assignee isn't in the teams mapping
+func (c *ProtoConverter) addTeamLabel(jiraIssue *jirapb.Issue, issue *beadspb.Issue) {
+	assignee := jiraIssue.Fields.GetAssignee()
+	if len(c.options.Teams) == 0 || assignee == nil {
+		return
+	}
+
+	team := teamOf(c.options.Teams, assignee)
+	if team == "" {
+		c.warn(WarningUnmappedAssignee, jiraIssue.Key,
+			"assignee %q is not in the teams file, no team label added", userName(assignee))
+		return
+	}
+	addLabels(issue, "team:"+team)
+}
+
+// teamOf looks a user up by email, account ID and display name, in that
+// order. teams must have lower-cased keys.
+func teamOf(teams map[string]string, user *jirapb.User) string {
+	for _, name := range []string{user.EmailAddress, user.AccountId, user.DisplayName} {
+		if name == "" {
+			continue
+		}
+		if team, ok := teams[strings.ToLower(name)]; ok {
+			return team
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestTeamLabels(t *testing.T) {
+	byEmail := warningTestIssue("PROJ-1")
+	byEmail.Fields.Labels = []string{"backend"}
+	byEmail.Fields.Assignee = &jirapb.User{EmailAddress: "Alice@Example.com", DisplayName: "Alice"}
+	byName := warningTestIssue("PROJ-2")
+	byName.Fields.Assignee = &jirapb.User{DisplayName: "Bob Jones"}
+	unmapped := warningTestIssue("PROJ-3")
+	unmapped.Fields.Assignee = &jirapb.User{EmailAddress: "carol@example.com"}
+	unassigned := warningTestIssue("PROJ-4")
+	unassigned.Fields.Assignee = nil
+
+	converter := NewProtoConverterWithOptions(Options{Teams: map[string]string{
+		"alice@example.com": "platform",
+		"bob jones":         "payments",
+	}})
+	export, warnings, err := converter.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{byEmail, byName, unmapped, unassigned}})
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+
+	want := map[string]string{
+		"PROJ-1": "backend,team:platform",
+		"PROJ-2": "team:payments",
+		"PROJ-3": "",
+		"PROJ-4": "",
+	}
+	for _, issue := range export.Issues {
+		if got := strings.Join(issue.Labels, ","); got != want[issue.Metadata.JiraKey] {
+			t.Errorf("Expected labels %q on %s, got %q", want[issue.Metadata.JiraKey], issue.Metadata.JiraKey, got)
+		}
+	}
+	if strings.Join(byEmail.Fields.Labels, ",") != "backend" {
+		t.Errorf("Expected the Jira labels to be left unchanged, got %v", byEmail.Fields.Labels)
+	}
+
+	unmappedWarnings := warnings.OfKind(WarningUnmappedAssignee)
+	if len(unmappedWarnings) != 1 || unmappedWarnings[0].JiraKey != "PROJ-3" {
+		t.Errorf("Expected one unmapped assignee warning for PROJ-3, got %v", unmappedWarnings)
+	}
+}
+
+func TestTeamLabelsDisabledWithoutMapping(t *testing.T) {
+	jiraIssue := warningTestIssue("PROJ-1")
+	jiraIssue.Fields.Assignee = &jirapb.User{EmailAddress: "alice@example.com"}
+
+	export, warnings, err := NewProtoConverter().ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{jiraIssue}})
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+	if len(export.Issues[0].Labels) != 0 {
+		t.Errorf("Expected no team label without a teams mapping, got %v", export.Issues[0].Labels)
+	}
+	if len(warnings.OfKind(WarningUnmappedAssignee)) != 0 {
+		t.Error("Expected no unmapped assignee warnings without a teams mapping")
+	}
+}
@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"time"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+// targetDateLayouts are the layouts of Jira date and date-time fields
+var targetDateLayouts = []string{"2006-01-02", "2006-01-02T15:04:05.000-0700", time.RFC3339}
+
+// addTargetDates copies the target date fields into metadata as YYYY-MM-DD
+func (c *ProtoConverter) addTargetDates(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) {
+	fields := [][2]string{
+		{beads.TargetStartKey, c.options.TargetStartField},
+		{beads.TargetEndKey, c.options.TargetEndField},
+	}
+	for _, f := range fields {
+		key, field := f[0], f[1]
+		value := jiraIssue.Fields.CustomFields[field]
+		if field == "" || value == "" {
+			continue
+		}
+		date, ok := parseTargetDate(value)
+		if !ok {
+			c.warn(WarningInvalidTargetDate, jiraIssue.Key, "%s %q is not a date", field, value)
+			continue
+		}
+		setCustomMetadata(metadata, key, date)
+	}
+}
+
+// parseTargetDate parses a Jira date or date-time value into YYYY-MM-DD
+func parseTargetDate(value string) (string, bool) {
+	for _, layout := range targetDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+func TestConvertTargetDates(t *testing.T) {
+	epic := warningTestIssue("PROJ-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.CustomFields = map[string]string{"customfield_10022": "2024-05-01", "customfield_10023": "2024-06-30T17:00:00.000+0000"}
+
+	issue := warningTestIssue("PROJ-2")
+	issue.Fields.CustomFields = map[string]string{"customfield_10022": "next week"}
+
+	conv := NewProtoConverterWithOptions(Options{TargetStartField: "customfield_10022", TargetEndField: "customfield_10023"})
+	export, warnings, err := conv.ConvertWithWarnings(&jirapb.Export{Issues: []*jirapb.Issue{epic, issue}})
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+
+	meta := export.Epics[0].Metadata.Custom
+	if meta[beads.TargetStartKey] != "2024-05-01" || meta[beads.TargetEndKey] != "2024-06-30" {
+		t.Errorf("Expected target dates 2024-05-01 to 2024-06-30, got %v", meta)
+	}
+	if _, ok := export.Issues[0].Metadata.Custom[beads.TargetStartKey]; ok {
+		t.Error("Expected the invalid target start to be left out")
+	}
+	if len(warnings) != 1 || warnings[0].Kind != WarningInvalidTargetDate || warnings[0].JiraKey != "PROJ-2" {
+		t.Errorf("Expected an invalid_target_date warning for PROJ-2, got %v", warnings)
+	}
+}
@@ -0,0 +1,49 @@
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// TitleRule rewrites Jira summaries during conversion, e.g. to strip emoji
+// status markers that downstream tools choke on
+type TitleRule struct {
+	Pattern *regexp.Regexp
+	Replace string // May reference capture groups ($1, ${name})
+}
+
+// NewTitleRule compiles a title normalization rule
+func NewTitleRule(pattern, replace string) (TitleRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return TitleRule{}, fmt.Errorf("invalid title pattern %q: %w", pattern, err)
+	}
+	return TitleRule{Pattern: re, Replace: replace}, nil
+}
+
+// normalizeTitle applies the configured title rules in order and trims the
+// result. When the title changes, the original summary is kept in metadata.
+// A rule that would blank the title is ignored.
+func (c *ProtoConverter) normalizeTitle(jiraIssue *jirapb.Issue, metadata *beadspb.Metadata) string {
+	summary := jiraIssue.Fields.Summary
+	if len(c.options.TitleRules) == 0 {
+		return summary
+	}
+
+	title := summary
+	for _, rule := range c.options.TitleRules {
+		title = rule.Pattern.ReplaceAllString(title, rule.Replace)
+	}
+	title = strings.TrimSpace(title)
+
+	if title == "" || title == summary {
+		return summary
+	}
+
+	setCustomMetadata(metadata, "originalSummary", summary)
+	return title
+}
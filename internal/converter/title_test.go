@@ -0,0 +1,100 @@
+package converter
+
+import (
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	stripEmoji, err := NewTitleRule(`^[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}\x{FE0F}\s]+`, "")
+	if err != nil {
+		t.Fatalf("NewTitleRule failed: %v", err)
+	}
+	status, err := NewTitleRule(`^\[(WIP|BLOCKED)\]\s*`, "")
+	if err != nil {
+		t.Fatalf("NewTitleRule failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		summary      string
+		wantTitle    string
+		wantOriginal string
+	}{
+		{
+			name:         "emoji prefix stripped",
+			summary:      "🔥 ✅ Fix login",
+			wantTitle:    "Fix login",
+			wantOriginal: "🔥 ✅ Fix login",
+		},
+		{
+			name:         "rules applied in order",
+			summary:      "🚧 [WIP] Rework cache",
+			wantTitle:    "Rework cache",
+			wantOriginal: "🚧 [WIP] Rework cache",
+		},
+		{
+			name:      "unchanged title keeps no original",
+			summary:   "Plain title",
+			wantTitle: "Plain title",
+		},
+		{
+			name:      "title that would be blank is kept",
+			summary:   "🔥",
+			wantTitle: "🔥",
+		},
+	}
+
+	conv := NewProtoConverterWithOptions(Options{TitleRules: []TitleRule{stripEmoji, status}})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := warningTestIssue("PROJ-1")
+			issue.Fields.Summary = tt.summary
+
+			export, err := conv.Convert(&jirapb.Export{Issues: []*jirapb.Issue{issue}})
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			got := export.Issues[0]
+			if got.Title != tt.wantTitle {
+				t.Errorf("Expected title %q, got %q", tt.wantTitle, got.Title)
+			}
+			if original := got.Metadata.Custom["originalSummary"]; original != tt.wantOriginal {
+				t.Errorf("Expected originalSummary %q, got %q", tt.wantOriginal, original)
+			}
+		})
+	}
+}
+
+func TestNormalizeTitleReplace(t *testing.T) {
+	rule, err := NewTitleRule(`^(\w+)-(\d+): `, "[$1 $2] ")
+	if err != nil {
+		t.Fatalf("NewTitleRule failed: %v", err)
+	}
+
+	epic := warningTestIssue("PROJ-1")
+	epic.Fields.IssueType = &jirapb.IssueType{Name: "Epic"}
+	epic.Fields.Summary = "OPS-12: Migrate database"
+
+	conv := NewProtoConverterWithOptions(Options{TitleRules: []TitleRule{rule}})
+	export, err := conv.Convert(&jirapb.Export{Issues: []*jirapb.Issue{epic}})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(export.Epics) != 1 {
+		t.Fatalf("Expected 1 epic, got %d", len(export.Epics))
+	}
+	if export.Epics[0].Name != "[OPS 12] Migrate database" {
+		t.Errorf("Expected rewritten epic name, got %q", export.Epics[0].Name)
+	}
+}
+
+func TestNewTitleRuleInvalid(t *testing.T) {
+	if _, err := NewTitleRule(`[unclosed`, ""); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+}
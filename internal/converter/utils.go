@@ -1,5 +1,9 @@
 package converter
 
+import (
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+)
+
 // contains checks if a string slice contains a value
 func contains(slice []string, value string) bool {
 	for _, item := range slice {
@@ -9,3 +13,15 @@ func contains(slice []string, value string) bool {
 	}
 	return false
 }
+
+// setCustomMetadata stores a key/value pair in the custom metadata map,
+// allocating the map on first use
+func setCustomMetadata(metadata *beadspb.Metadata, key, value string) {
+	if metadata == nil {
+		return
+	}
+	if metadata.Custom == nil {
+		metadata.Custom = make(map[string]string)
+	}
+	metadata.Custom[key] = value
+}
@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WarningKind classifies a non-fatal conversion problem
+type WarningKind string
+
+const (
+	// WarningUnknownStatus means a Jira status could not be mapped and defaulted to open
+	WarningUnknownStatus WarningKind = "unknown_status"
+	// WarningUnknownPriority means a Jira priority could not be mapped and defaulted to p2
+	WarningUnknownPriority WarningKind = "unknown_priority"
+	// WarningMissingAssigneeEmail means an assignee had no email and the display name was used
+	WarningMissingAssigneeEmail WarningKind = "missing_assignee_email"
+	// WarningTruncatedDescription means a description exceeded the configured maximum length
+	WarningTruncatedDescription WarningKind = "truncated_description"
+	// WarningInvalidMarkup means a rich-text ADF document could not be converted to Markdown
+	WarningInvalidMarkup WarningKind = "invalid_markup"
+	// WarningUnknownResolution means a Jira resolution could not be mapped and defaulted to done
+	WarningUnknownResolution WarningKind = "unknown_resolution"
+	// WarningUnmappedAssignee means an assignee was not in the teams file, so no team label was added
+	WarningUnmappedAssignee WarningKind = "unmapped_assignee"
+	// WarningUnknownIssueType means an issue type is not classified and was converted as an issue
+	WarningUnknownIssueType WarningKind = "unknown_issue_type"
+	// WarningInvalidTargetDate means a target date field was not a date and was left out
+	WarningInvalidTargetDate WarningKind = "invalid_target_date"
+)
+
+// Warning describes a non-fatal problem encountered while converting an issue
+type Warning struct {
+	Kind    WarningKind
+	JiraKey string
+	Message string
+}
+
+// String formats the warning for display
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.JiraKey, w.Message)
+}
+
+// Warnings is the collection of warnings produced by a conversion
+type Warnings []Warning
+
+// CountByKind returns the number of warnings of each kind
+func (w Warnings) CountByKind() map[WarningKind]int {
+	counts := make(map[WarningKind]int)
+	for _, warning := range w {
+		counts[warning.Kind]++
+	}
+	return counts
+}
+
+// OfKind returns the warnings of the given kind
+func (w Warnings) OfKind(kind WarningKind) Warnings {
+	var filtered Warnings
+	for _, warning := range w {
+		if warning.Kind == kind {
+			filtered = append(filtered, warning)
+		}
+	}
+	return filtered
+}
+
+// Kinds returns the distinct warning kinds present, sorted by name
+func (w Warnings) Kinds() []WarningKind {
+	counts := w.CountByKind()
+	kinds := make([]WarningKind, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// warn records a conversion warning for the given Jira issue
+func (c *ProtoConverter) warn(kind WarningKind, jiraKey, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, Warning{
+		Kind:    kind,
+		JiraKey: jiraKey,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
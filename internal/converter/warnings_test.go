@@ -0,0 +1,115 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func warningTestIssue(key string) *jirapb.Issue {
+	return &jirapb.Issue{
+		Id:  key + "-id",
+		Key: key,
+		Fields: &jirapb.Fields{
+			Summary:   "Issue " + key,
+			IssueType: &jirapb.IssueType{Name: "Task"},
+			Status: &jirapb.Status{
+				Name:           "To Do",
+				StatusCategory: &jirapb.StatusCategory{Key: "new"},
+			},
+			Priority: &jirapb.Priority{Name: "Medium"},
+		},
+	}
+}
+
+func TestConvertWithWarnings(t *testing.T) {
+	unknownStatus := warningTestIssue("PROJ-1")
+	unknownStatus.Fields.Status = &jirapb.Status{
+		Name:           "Awaiting Triage",
+		StatusCategory: &jirapb.StatusCategory{Key: "undefined"},
+	}
+
+	unknownPriority := warningTestIssue("PROJ-2")
+	unknownPriority.Fields.Priority = &jirapb.Priority{Name: "Blocker"}
+
+	noEmail := warningTestIssue("PROJ-3")
+	noEmail.Fields.Assignee = &jirapb.User{DisplayName: "Jane Doe"}
+
+	longDescription := warningTestIssue("PROJ-4")
+	longDescription.Fields.Description = strings.Repeat("x", 50)
+
+	clean := warningTestIssue("PROJ-5")
+
+	conv := NewProtoConverterWithOptions(Options{MaxDescriptionLength: 20})
+	export, warnings, err := conv.ConvertWithWarnings(&jirapb.Export{
+		Issues: []*jirapb.Issue{unknownStatus, unknownPriority, noEmail, longDescription, clean},
+	})
+	if err != nil {
+		t.Fatalf("ConvertWithWarnings failed: %v", err)
+	}
+
+	if len(warnings) != 4 {
+		t.Fatalf("Expected 4 warnings, got %d: %v", len(warnings), warnings)
+	}
+
+	expected := map[WarningKind]string{
+		WarningUnknownStatus:        "PROJ-1",
+		WarningUnknownPriority:      "PROJ-2",
+		WarningMissingAssigneeEmail: "PROJ-3",
+		WarningTruncatedDescription: "PROJ-4",
+	}
+	for kind, key := range expected {
+		got := warnings.OfKind(kind)
+		if len(got) != 1 || got[0].JiraKey != key {
+			t.Errorf("Expected one %s warning for %s, got %v", kind, key, got)
+		}
+	}
+
+	if got := len(export.Issues[3].Description); got != 20 {
+		t.Errorf("Expected description truncated to 20 characters, got %d", got)
+	}
+}
+
+func TestConvertWithWarningsResetsBetweenRuns(t *testing.T) {
+	issue := warningTestIssue("PROJ-1")
+	issue.Fields.Priority = &jirapb.Priority{Name: "Blocker"}
+
+	conv := NewProtoConverter()
+	export := &jirapb.Export{Issues: []*jirapb.Issue{issue}}
+
+	if _, warnings, _ := conv.ConvertWithWarnings(export); len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning on first run, got %d", len(warnings))
+	}
+	if _, warnings, _ := conv.ConvertWithWarnings(export); len(warnings) != 1 {
+		t.Errorf("Expected warnings to reset between runs, got %d", len(warnings))
+	}
+}
+
+func TestWarningsCountByKind(t *testing.T) {
+	warnings := Warnings{
+		{Kind: WarningUnknownStatus, JiraKey: "PROJ-1"},
+		{Kind: WarningUnknownStatus, JiraKey: "PROJ-2"},
+		{Kind: WarningUnknownPriority, JiraKey: "PROJ-3"},
+	}
+
+	counts := warnings.CountByKind()
+	if counts[WarningUnknownStatus] != 2 {
+		t.Errorf("Expected 2 unknown status warnings, got %d", counts[WarningUnknownStatus])
+	}
+	if counts[WarningUnknownPriority] != 1 {
+		t.Errorf("Expected 1 unknown priority warning, got %d", counts[WarningUnknownPriority])
+	}
+
+	kinds := warnings.Kinds()
+	if len(kinds) != 2 || kinds[0] != WarningUnknownPriority || kinds[1] != WarningUnknownStatus {
+		t.Errorf("Expected sorted kinds [unknown_priority unknown_status], got %v", kinds)
+	}
+}
+
+func TestWarningString(t *testing.T) {
+	w := Warning{Kind: WarningUnknownStatus, JiraKey: "PROJ-1", Message: "unknown status"}
+	if w.String() != "PROJ-1: unknown status" {
+		t.Errorf("Unexpected warning string: %s", w.String())
+	}
+}
@@ -0,0 +1,269 @@
+// Package datefmt formats dates for people reading reports and summaries,
+// in their language and preferred layout. Files meant for tools, such as
+// the beads files and the sync state, keep RFC 3339.
+package datefmt
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Named layouts that may be used instead of a pattern
+const (
+	LayoutISO     = "iso"     // 2024-05-01 14:30 CEST
+	LayoutRFC3339 = "rfc3339" // 2024-05-01T14:30:00+02:00
+)
+
+// locale holds the names and default pattern of a language. Abbreviations
+// are listed rather than cut from the full names, since languages don't
+// abbreviate by a fixed number of letters (März is Mär, maart is mrt); they
+// follow the C library's strftime for the locale.
+type locale struct {
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string // From Sunday
+	shortWeekdays [7]string
+	pattern       string
+}
+
+// locales are keyed by lower-cased language tag. Lookups fall back from
+// language-region (en-gb) to language (en).
+var locales = map[string]locale{
+	"en": {
+		months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		pattern:       "%a, %b %-d, %Y %-I:%M %p %Z",
+	},
+	"en-gb": {
+		months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		pattern:       "%a %-d %b %Y %H:%M %Z",
+	},
+	"de": {
+		months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		pattern:       "%a, %-d. %B %Y, %H:%M %Z",
+	},
+	"fr": {
+		months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths:   [12]string{"janv.", "févr.", "mars", "avril", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		pattern:       "%a %-d %B %Y %H:%M %Z",
+	},
+	"es": {
+		months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		pattern:       "%a, %-d de %B de %Y, %H:%M %Z",
+	},
+	"nl": {
+		months:        [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		shortMonths:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		weekdays:      [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortWeekdays: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		pattern:       "%a %-d %B %Y %H:%M %Z",
+	},
+	"pt": {
+		months:        [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		shortMonths:   [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		weekdays:      [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortWeekdays: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		pattern:       "%a, %-d de %B de %Y, %H:%M %Z",
+	},
+	"ja": {
+		months:        [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		pattern:       "%Y年%-m月%-d日(%a) %H:%M %Z",
+	},
+}
+
+// Formatter formats times for display
+type Formatter struct {
+	locale   locale
+	pattern  string
+	location *time.Location
+}
+
+// New creates a formatter for a locale, such as de-DE or en_GB.UTF-8, and a
+// pattern. An empty locale is taken from LC_ALL, LC_TIME or LANG, falling
+// back to English. An empty pattern uses the locale's default; otherwise
+// it is LayoutISO, LayoutRFC3339 or a strftime pattern using %a %A %b %B
+// %d %-d %m %-m %Y %H %I %-I %M %S %p %Z and %%.
+func New(localeName, pattern string) (*Formatter, error) {
+	explicit := localeName != ""
+	if !explicit {
+		localeName = envLocale()
+	}
+	loc, ok := lookup(localeName)
+	if !ok {
+		if explicit {
+			return nil, fmt.Errorf("unsupported locale %q, must be one of: %s", localeName, strings.Join(Locales(), ", "))
+		}
+		loc = locales["en"]
+	}
+
+	switch strings.ToLower(pattern) {
+	case "":
+		pattern = loc.pattern
+	case LayoutISO:
+		pattern = "%Y-%m-%d %H:%M %Z"
+	case LayoutRFC3339:
+		pattern = LayoutRFC3339
+	default:
+		if err := checkPattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Formatter{locale: loc, pattern: pattern, location: time.Local}, nil
+}
+
+// Locales lists the supported locales
+func Locales() []string {
+	names := make([]string, 0, len(locales))
+	for name := range locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// In returns a copy of the formatter that shows times in loc instead of
+// local time
+func (f *Formatter) In(loc *time.Location) *Formatter {
+	copied := *f
+	copied.location = loc
+	return &copied
+}
+
+// Format formats t in the formatter's locale, layout and time zone
+func (f *Formatter) Format(t time.Time) string {
+	t = t.In(f.location)
+	if f.pattern == LayoutRFC3339 {
+		return t.Format(time.RFC3339)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(f.pattern); i++ {
+		c := f.pattern[i]
+		if c != '%' || i+1 == len(f.pattern) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		pad := true
+		if f.pattern[i] == '-' && i+1 < len(f.pattern) {
+			pad = false
+			i++
+		}
+		b.WriteString(f.directive(f.pattern[i], pad, t))
+	}
+	return b.String()
+}
+
+// directive formats a single % directive
+func (f *Formatter) directive(c byte, pad bool, t time.Time) string {
+	number := func(n int) string {
+		if pad && n < 10 {
+			return "0" + strconv.Itoa(n)
+		}
+		return strconv.Itoa(n)
+	}
+
+	switch c {
+	case 'a':
+		return f.locale.shortWeekdays[t.Weekday()]
+	case 'A':
+		return f.locale.weekdays[t.Weekday()]
+	case 'b':
+		return f.locale.shortMonths[t.Month()-1]
+	case 'B':
+		return f.locale.months[t.Month()-1]
+	case 'd':
+		return number(t.Day())
+	case 'm':
+		return number(int(t.Month()))
+	case 'Y':
+		return strconv.Itoa(t.Year())
+	case 'H':
+		return number(t.Hour())
+	case 'I':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return number(hour)
+	case 'M':
+		return number(t.Minute())
+	case 'S':
+		return number(t.Second())
+	case 'p':
+		if t.Hour() < 12 {
+			return "AM"
+		}
+		return "PM"
+	case 'Z':
+		name, _ := t.Zone()
+		return name
+	case '%':
+		return "%"
+	default:
+		return "%" + string(c)
+	}
+}
+
+// checkPattern rejects patterns with unknown directives
+func checkPattern(pattern string) error {
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(pattern) && pattern[i] == '-' {
+			i++
+		}
+		if i == len(pattern) || !strings.ContainsRune("aAbBdmYHIMSpZ%", rune(pattern[i])) {
+			return fmt.Errorf("invalid date format %q: unsupported directive at position %d", pattern, i)
+		}
+	}
+	return nil
+}
+
+// lookup finds a locale by a tag such as de-AT or de_AT.UTF-8, falling
+// back to its language
+func lookup(name string) (locale, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	if loc, ok := locales[name]; ok {
+		return loc, true
+	}
+	language, _, _ := strings.Cut(name, "-")
+	loc, ok := locales[language]
+	return loc, ok
+}
+
+// envLocale returns the time locale of the environment
+func envLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if value := os.Getenv(name); value != "" && value != "C" && value != "POSIX" {
+			return value
+		}
+	}
+	return ""
+}
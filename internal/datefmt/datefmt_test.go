@@ -0,0 +1,91 @@
+package datefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	at := time.Date(2024, 5, 1, 14, 5, 9, 0, time.UTC)
+
+	tests := []struct {
+		locale  string
+		pattern string
+		want    string
+	}{
+		{locale: "en-US", want: "Wed, May 1, 2024 2:05 PM UTC"},
+		{locale: "en_GB.UTF-8", want: "Wed 1 May 2024 14:05 UTC"},
+		{locale: "de-AT", want: "Mi, 1. Mai 2024, 14:05 UTC"},
+		{locale: "fr", want: "mer. 1 mai 2024 14:05 UTC"},
+		{locale: "ja-JP", want: "2024年5月1日(水) 14:05 UTC"},
+		{locale: "de", pattern: "%A, %d.%m.%Y %H:%M:%S", want: "Mittwoch, 01.05.2024 14:05:09"},
+		{locale: "es", pattern: "iso", want: "2024-05-01 14:05 UTC"},
+		{locale: "en", pattern: "rfc3339", want: "2024-05-01T14:05:09Z"},
+		{locale: "en", pattern: "%-I%p, 100%%", want: "2PM, 100%"},
+	}
+
+	for _, tt := range tests {
+		f, err := New(tt.locale, tt.pattern)
+		if err != nil {
+			t.Fatalf("New(%q, %q) failed: %v", tt.locale, tt.pattern, err)
+		}
+		if got := f.In(time.UTC).Format(at); got != tt.want {
+			t.Errorf("New(%q, %q).Format() = %q, want %q", tt.locale, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestAbbreviatedNames(t *testing.T) {
+	march := time.Date(2024, 3, 7, 12, 0, 0, 0, time.UTC) // A Thursday
+
+	tests := map[string]string{
+		"en": "Thu 7 Mar",
+		"de": "Do 7 Mär",
+		"fr": "jeu. 7 mars",
+		"es": "jue 7 mar",
+		"nl": "do 7 mrt",
+		"pt": "qui 7 mar",
+		"ja": "木 7 3月",
+	}
+	for locale, want := range tests {
+		f, err := New(locale, "%a %-d %b")
+		if err != nil {
+			t.Fatalf("New(%q) failed: %v", locale, err)
+		}
+		if got := f.In(time.UTC).Format(march); got != want {
+			t.Errorf("%s: got %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestNewFromEnvironment(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "nl_NL.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	f, err := New("", "%A")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if got := f.Format(time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)); got != "woensdag" {
+		t.Errorf("Expected LC_TIME to select Dutch, got %q", got)
+	}
+
+	// Unsupported environment locales fall back to English
+	t.Setenv("LC_TIME", "sv_SE.UTF-8")
+	if _, err := New("", ""); err != nil {
+		t.Errorf("Expected an unsupported environment locale to fall back, got: %v", err)
+	}
+}
+
+func TestNewRejectsInvalidSettings(t *testing.T) {
+	if _, err := New("xx-YY", ""); err == nil {
+		t.Error("Expected error for an unsupported locale")
+	}
+	if _, err := New("en", "%Y-%q"); err == nil {
+		t.Error("Expected error for an unsupported directive")
+	}
+	if _, err := New("en", "%Y %"); err == nil {
+		t.Error("Expected error for a trailing %")
+	}
+}
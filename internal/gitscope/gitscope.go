@@ -0,0 +1,88 @@
+package gitscope
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultCommitDepth is the number of recent commits scanned for issue keys
+const DefaultCommitDepth = 20
+
+var (
+	// branchKeyPattern matches issue keys in branch names, which are usually
+	// lowercase (e.g. "feature/proj-123-add-login")
+	branchKeyPattern = regexp.MustCompile(`(?i)\b([a-z][a-z0-9]+-[0-9]+)\b`)
+	// commitKeyPattern matches issue keys in commit messages. Only uppercase keys
+	// are accepted to avoid false positives such as "utf-8".
+	commitKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9]+-[0-9]+)\b`)
+)
+
+// Detector finds Jira issue keys referenced by the current git checkout
+type Detector struct {
+	dir     string
+	commits int
+	runGit  func(dir string, args ...string) (string, error)
+}
+
+// NewDetector creates a detector for the git work tree at dir.
+// commits is the number of recent commits to scan; zero or less uses DefaultCommitDepth.
+func NewDetector(dir string, commits int) *Detector {
+	if commits <= 0 {
+		commits = DefaultCommitDepth
+	}
+
+	return &Detector{
+		dir:     dir,
+		commits: commits,
+		runGit:  runGit,
+	}
+}
+
+// IssueKeys returns the issue keys mentioned in the current branch name and
+// recent commit messages, in order of first appearance
+func (d *Detector) IssueKeys() ([]string, error) {
+	branch, err := d.runGit(d.dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine current branch: %w", err)
+	}
+
+	log, err := d.runGit(d.dir, "log", fmt.Sprintf("-n%d", d.commits), "--format=%B")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit log: %w", err)
+	}
+
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(matches []string) {
+		for _, key := range matches {
+			key = strings.ToUpper(key)
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if branch != "HEAD" {
+		add(branchKeyPattern.FindAllString(branch, -1))
+	}
+	add(commitKeyPattern.FindAllString(log, -1))
+
+	return keys, nil
+}
+
+// runGit runs a git command in dir and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,87 @@
+package gitscope
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func fakeGit(branch, log string) func(string, ...string) (string, error) {
+	return func(dir string, args ...string) (string, error) {
+		switch args[0] {
+		case "rev-parse":
+			return branch, nil
+		case "log":
+			return log, nil
+		default:
+			return "", fmt.Errorf("unexpected git command: %s", strings.Join(args, " "))
+		}
+	}
+}
+
+func TestNewDetectorDefaultsCommitDepth(t *testing.T) {
+	d := NewDetector(".", 0)
+	if d.commits != DefaultCommitDepth {
+		t.Errorf("Expected commit depth %d, got %d", DefaultCommitDepth, d.commits)
+	}
+}
+
+func TestIssueKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		log    string
+		want   []string
+	}{
+		{
+			name:   "key in lowercase branch name",
+			branch: "feature/proj-123-add-login",
+			log:    "Add login form",
+			want:   []string{"PROJ-123"},
+		},
+		{
+			name:   "keys from branch and commits without duplicates",
+			branch: "PROJ-123",
+			log:    "PROJ-123: wire up form\n\nAlso fixes PROJ-124 and OPS-7",
+			want:   []string{"PROJ-123", "PROJ-124", "OPS-7"},
+		},
+		{
+			name:   "lowercase tokens in commits are ignored",
+			branch: "main",
+			log:    "Convert files to utf-8\n\nRelates to proj-9",
+			want:   []string{},
+		},
+		{
+			name:   "detached HEAD",
+			branch: "HEAD",
+			log:    "CORE-42 hotfix",
+			want:   []string{"CORE-42"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDetector(".", 5)
+			d.runGit = fakeGit(tt.branch, tt.log)
+
+			got, err := d.IssueKeys()
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected keys %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIssueKeysGitError(t *testing.T) {
+	d := NewDetector(".", 5)
+	d.runGit = func(dir string, args ...string) (string, error) {
+		return "", fmt.Errorf("not a git repository")
+	}
+
+	if _, err := d.IssueKeys(); err == nil {
+		t.Error("Expected error when git fails")
+	}
+}
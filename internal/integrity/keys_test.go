@@ -0,0 +1,72 @@
+package integrity
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestLoadKeys(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	loadedPrivate, err := LoadPrivateKey(writePEM(t, "PRIVATE KEY", privateDER))
+	if err != nil {
+		t.Fatalf("LoadPrivateKey failed: %v", err)
+	}
+	if !loadedPrivate.Equal(privateKey) {
+		t.Error("Expected the loaded private key to match")
+	}
+
+	loadedPublic, err := LoadPublicKey(writePEM(t, "PUBLIC KEY", publicDER))
+	if err != nil {
+		t.Fatalf("LoadPublicKey failed: %v", err)
+	}
+	if !loadedPublic.Equal(publicKey) {
+		t.Error("Expected the loaded public key to match")
+	}
+
+	// A public key where a private key is expected
+	if _, err := LoadPrivateKey(writePEM(t, "PUBLIC KEY", publicDER)); err == nil {
+		t.Error("Expected error loading a public key as private, got nil")
+	}
+}
+
+func TestLoadKeysRejectsOtherAlgorithms(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if _, err := LoadPrivateKey(writePEM(t, "PRIVATE KEY", der)); err == nil {
+		t.Error("Expected error for an ECDSA key, got nil")
+	}
+}
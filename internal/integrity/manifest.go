@@ -0,0 +1,208 @@
+// Package integrity records hashes of a repository's beads files after each
+// sync and verifies them later, to detect corruption or manual edits.
+//
+// The manifest is written to .beads/manifest.json. It can be signed with an
+// Ed25519 key; the detached signature is written to .beads/manifest.sig so
+// that anyone holding the public key can check the manifest is genuine.
+package integrity
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ManifestFile is the manifest's name in the .beads directory
+	ManifestFile = "manifest.json"
+	// SignatureFile holds the base64 Ed25519 signature of the manifest
+	SignatureFile = "manifest.sig"
+
+	manifestVersion = 1
+)
+
+// Files lists the beads files covered by the manifest. Machine-specific
+// files such as the sync state are left out.
+var Files = []string{"issues.jsonl", "epics.jsonl"}
+
+// Manifest lists the hash of every beads file present after a sync
+type Manifest struct {
+	Version int        `json:"version"`
+	Files   []FileHash `json:"files"`
+}
+
+// FileHash is the SHA-256 of one file, relative to the .beads directory
+type FileHash struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Build hashes the beads files in outputDir
+func Build(outputDir string) (*Manifest, error) {
+	manifest := &Manifest{Version: manifestVersion, Files: []FileHash{}}
+	for _, name := range Files {
+		hash, err := hashFile(filepath.Join(outputDir, ".beads", name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hash.Path = name
+		manifest.Files = append(manifest.Files, hash)
+	}
+	return manifest, nil
+}
+
+// Write records the current hashes of the beads files in outputDir. With a
+// key the manifest is signed too; without one any earlier signature is
+// removed, since it would no longer match. Files are only rewritten when
+// their content changes.
+func Write(outputDir string, key ed25519.PrivateKey) error {
+	manifest, err := Build(outputDir)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	beadsDir := filepath.Join(outputDir, ".beads")
+	if err := writeIfChanged(filepath.Join(beadsDir, ManifestFile), data); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	sigFile := filepath.Join(beadsDir, SignatureFile)
+	if key == nil {
+		if err := os.Remove(sigFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale manifest signature: %w", err)
+		}
+		return nil
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)) + "\n"
+	if err := writeIfChanged(sigFile, []byte(signature)); err != nil {
+		return fmt.Errorf("failed to write manifest signature: %w", err)
+	}
+	return nil
+}
+
+// Problem is a discrepancy found by Verify
+type Problem struct {
+	Path   string // Relative to the .beads directory
+	Reason string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Reason)
+}
+
+// Result is the outcome of verifying a repository
+type Result struct {
+	Problems []Problem
+	Files    int  // Files listed in the manifest
+	Signed   bool // Whether a signature was found
+	Checked  bool // Whether the signature was verified against a public key
+}
+
+// Verify compares the beads files in outputDir with the manifest. With a
+// public key the manifest must carry a valid signature by the matching
+// private key. An error is only returned when the manifest can't be read.
+func Verify(outputDir string, publicKey ed25519.PublicKey) (*Result, error) {
+	beadsDir := filepath.Join(outputDir, ".beads")
+	data, err := os.ReadFile(filepath.Join(beadsDir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no manifest found in %s; run a sync to create one", beadsDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	result := &Result{Files: len(manifest.Files)}
+	result.Signed, result.Checked, result.Problems = verifySignature(beadsDir, data, publicKey)
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, want := range manifest.Files {
+		listed[want.Path] = true
+		got, err := hashFile(filepath.Join(beadsDir, filepath.FromSlash(want.Path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			result.Problems = append(result.Problems, Problem{want.Path, "missing"})
+		case err != nil:
+			result.Problems = append(result.Problems, Problem{want.Path, err.Error()})
+		case got.SHA256 != want.SHA256:
+			result.Problems = append(result.Problems, Problem{want.Path, "modified since the last sync"})
+		}
+	}
+	for _, name := range Files {
+		if listed[name] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(beadsDir, name)); err == nil {
+			result.Problems = append(result.Problems, Problem{name, "not listed in the manifest"})
+		}
+	}
+
+	return result, nil
+}
+
+// verifySignature checks the manifest's detached signature, if any
+func verifySignature(beadsDir string, manifest []byte, publicKey ed25519.PublicKey) (signed, checked bool, problems []Problem) {
+	encoded, err := os.ReadFile(filepath.Join(beadsDir, SignatureFile))
+	if err != nil {
+		if publicKey != nil {
+			problems = append(problems, Problem{SignatureFile, "manifest is not signed"})
+		}
+		return false, false, problems
+	}
+	if publicKey == nil {
+		return true, false, nil
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(publicKey, manifest, signature) {
+		problems = append(problems, Problem{SignatureFile, "signature does not match the manifest and public key"})
+	}
+	return true, true, problems
+}
+
+func hashFile(path string) (FileHash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileHash{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return FileHash{}, fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return FileHash{SHA256: hex.EncodeToString(h.Sum(nil)), Size: size}, nil
+}
+
+func writeIfChanged(path string, data []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,168 @@
+package integrity
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeBeadsFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("Failed to create .beads: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func problemReasons(result *Result) string {
+	var reasons []string
+	for _, problem := range result.Problems {
+		reasons = append(reasons, problem.String())
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func TestWriteAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	writeBeadsFile(t, dir, "issues.jsonl", `{"id":"proj-1"}`+"\n")
+	writeBeadsFile(t, dir, "epics.jsonl", `{"id":"proj-10"}`+"\n")
+
+	if err := Write(dir, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	manifest, err := Build(dir)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(manifest.Files) != 2 || manifest.Files[0].Path != "issues.jsonl" || manifest.Files[0].Size != 16 {
+		t.Errorf("Expected issues.jsonl and epics.jsonl in the manifest, got %+v", manifest.Files)
+	}
+
+	result, err := Verify(dir, nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 0 {
+		t.Errorf("Expected no problems, got %s", problemReasons(result))
+	}
+	if result.Files != 2 || result.Signed {
+		t.Errorf("Expected 2 unsigned files, got %d files, signed %v", result.Files, result.Signed)
+	}
+}
+
+func TestVerifyDetectsChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeBeadsFile(t, dir, "issues.jsonl", `{"id":"proj-1"}`+"\n")
+	writeBeadsFile(t, dir, "epics.jsonl", `{"id":"proj-10"}`+"\n")
+	if err := Write(dir, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// Hand-edited issue and deleted epics
+	writeBeadsFile(t, dir, "issues.jsonl", `{"id":"proj-1","status":"closed"}`+"\n")
+	if err := os.Remove(filepath.Join(dir, ".beads", "epics.jsonl")); err != nil {
+		t.Fatalf("Failed to remove epics.jsonl: %v", err)
+	}
+
+	result, err := Verify(dir, nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	expected := "issues.jsonl: modified since the last sync; epics.jsonl: missing"
+	if got := problemReasons(result); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestVerifyDetectsUnlistedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeBeadsFile(t, dir, "issues.jsonl", `{"id":"proj-1"}`+"\n")
+	if err := Write(dir, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	writeBeadsFile(t, dir, "epics.jsonl", `{"id":"proj-10"}`+"\n")
+
+	result, err := Verify(dir, nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got := problemReasons(result); got != "epics.jsonl: not listed in the manifest" {
+		t.Errorf("Expected unlisted epics.jsonl, got %q", got)
+	}
+}
+
+func TestVerifyWithoutManifest(t *testing.T) {
+	if _, err := Verify(t.TempDir(), nil); err == nil {
+		t.Error("Expected error without a manifest, got nil")
+	}
+}
+
+func TestSignedManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeBeadsFile(t, dir, "issues.jsonl", `{"id":"proj-1"}`+"\n")
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	if err := Write(dir, privateKey); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	result, err := Verify(dir, publicKey)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 0 || !result.Signed || !result.Checked {
+		t.Errorf("Expected a checked valid signature, got signed %v, checked %v, problems %q",
+			result.Signed, result.Checked, problemReasons(result))
+	}
+
+	result, err = Verify(dir, otherKey)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got := problemReasons(result); !strings.HasPrefix(got, "manifest.sig: signature does not match") {
+		t.Errorf("Expected signature mismatch for another key, got %q", got)
+	}
+
+	// Tampering with the manifest itself breaks the signature
+	manifestFile := filepath.Join(dir, ".beads", ManifestFile)
+	data, err := os.ReadFile(manifestFile)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if err := os.WriteFile(manifestFile, []byte(strings.Replace(string(data), `"size": 16`, `"size": 17`, 1)), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	result, err = Verify(dir, publicKey)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 1 || result.Problems[0].Path != SignatureFile {
+		t.Errorf("Expected signature problem after editing the manifest, got %q", problemReasons(result))
+	}
+
+	// An unsigned rewrite drops the stale signature
+	if err := Write(dir, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	result, err = Verify(dir, publicKey)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if got := problemReasons(result); got != "manifest.sig: manifest is not signed" {
+		t.Errorf("Expected missing signature, got %q", got)
+	}
+}
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -86,8 +87,9 @@ func (a *Adapter) convertIssue(jsonIssue *jsonIssue) (*pb.Issue, error) {
 		Key:  jsonIssue.Key,
 		Self: jsonIssue.Self,
 		Fields: &pb.Fields{
-			Summary:     jsonIssue.Fields.Summary,
-			Description: jsonIssue.Fields.Description,
+			Summary:        jsonIssue.Fields.Summary,
+			Description:    jsonIssue.Fields.Description.Text,
+			DescriptionAdf: string(jsonIssue.Fields.Description.ADF),
 			IssueType: &pb.IssueType{
 				Name:        jsonIssue.Fields.IssueType.Name,
 				Description: jsonIssue.Fields.IssueType.Description,
@@ -104,9 +106,10 @@ func (a *Adapter) convertIssue(jsonIssue *jsonIssue) (*pb.Issue, error) {
 				Name: jsonIssue.Fields.Priority.Name,
 				Id:   jsonIssue.Fields.Priority.ID,
 			},
-			Labels:     jsonIssue.Fields.Labels,
-			IssueLinks: make([]*pb.IssueLink, len(jsonIssue.Fields.IssueLinks)),
-			Subtasks:   make([]*pb.Subtask, len(jsonIssue.Fields.Subtasks)),
+			Labels:       jsonIssue.Fields.Labels,
+			CustomFields: jsonIssue.Fields.CustomFields,
+			IssueLinks:   make([]*pb.IssueLink, len(jsonIssue.Fields.IssueLinks)),
+			Subtasks:     make([]*pb.Subtask, len(jsonIssue.Fields.Subtasks)),
 		},
 	}
 
@@ -117,6 +120,9 @@ func (a *Adapter) convertIssue(jsonIssue *jsonIssue) (*pb.Issue, error) {
 	if !jsonIssue.Fields.Updated.IsZero() {
 		issue.Fields.Updated = timestamppb.New(jsonIssue.Fields.Updated)
 	}
+	if !jsonIssue.Fields.Resolved.IsZero() {
+		issue.Fields.Resolved = timestamppb.New(jsonIssue.Fields.Resolved)
+	}
 
 	// Convert assignee
 	if jsonIssue.Fields.Assignee != nil {
@@ -158,6 +164,70 @@ func (a *Adapter) convertIssue(jsonIssue *jsonIssue) (*pb.Issue, error) {
 		}
 	}
 
+	// Convert components
+	for _, component := range jsonIssue.Fields.Components {
+		issue.Fields.Components = append(issue.Fields.Components, &pb.Component{
+			Id:   component.ID,
+			Name: component.Name,
+		})
+	}
+
+	// Convert resolution
+	if jsonIssue.Fields.Resolution != nil {
+		issue.Fields.Resolution = &pb.Resolution{
+			Id:   jsonIssue.Fields.Resolution.ID,
+			Name: jsonIssue.Fields.Resolution.Name,
+		}
+	}
+
+	// Convert fix versions
+	for _, version := range jsonIssue.Fields.FixVersions {
+		issue.Fields.FixVersions = append(issue.Fields.FixVersions, &pb.Version{
+			Id:          version.ID,
+			Name:        version.Name,
+			Released:    version.Released,
+			ReleaseDate: version.ReleaseDate,
+		})
+	}
+
+	// Convert sprints
+	for _, sprint := range jsonIssue.Fields.Sprints {
+		issue.Fields.Sprints = append(issue.Fields.Sprints, &pb.Sprint{
+			Id:    sprint.ID,
+			Name:  sprint.Name,
+			State: sprint.State,
+		})
+	}
+
+	// Convert comments
+	if jsonIssue.Fields.Comment != nil {
+		for _, comment := range jsonIssue.Fields.Comment.Comments {
+			converted, err := a.convertComment(&comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert comment on %s: %w", jsonIssue.Key, err)
+			}
+			issue.Fields.Comments = append(issue.Fields.Comments, converted)
+		}
+	}
+
+	// Convert attachments
+	for _, attachment := range jsonIssue.Fields.Attachments {
+		converted, err := a.convertAttachment(&attachment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert attachment on %s: %w", jsonIssue.Key, err)
+		}
+		issue.Fields.Attachments = append(issue.Fields.Attachments, converted)
+	}
+
+	// Convert checklist items
+	for _, item := range jsonIssue.Fields.Checklist {
+		issue.Fields.Checklist = append(issue.Fields.Checklist, &pb.ChecklistItem{
+			Name:    item.Name,
+			Checked: item.Checked != nil && *item.Checked,
+			Header:  item.IsHeader,
+		})
+	}
+
 	// Convert subtasks
 	for i, subtask := range jsonIssue.Fields.Subtasks {
 		issue.Fields.Subtasks[i] = &pb.Subtask{
@@ -185,6 +255,68 @@ func (a *Adapter) convertIssue(jsonIssue *jsonIssue) (*pb.Issue, error) {
 	return issue, nil
 }
 
+// convertComment converts a JSON comment to protobuf
+func (a *Adapter) convertComment(comment *jsonComment) (*pb.Comment, error) {
+	converted := &pb.Comment{
+		Id:      comment.ID,
+		Author:  convertUser(comment.Author),
+		Body:    comment.Body.Text,
+		BodyAdf: string(comment.Body.ADF),
+	}
+
+	var err error
+	if converted.Created, err = parseTimestamp(comment.Created); err != nil {
+		return nil, err
+	}
+	if converted.Updated, err = parseTimestamp(comment.Updated); err != nil {
+		return nil, err
+	}
+
+	return converted, nil
+}
+
+// convertAttachment converts JSON attachment metadata to protobuf
+func (a *Adapter) convertAttachment(attachment *jsonAttachment) (*pb.Attachment, error) {
+	created, err := parseTimestamp(attachment.Created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Attachment{
+		Id:       attachment.ID,
+		Filename: attachment.Filename,
+		Author:   convertUser(attachment.Author),
+		Created:  created,
+		Size:     attachment.Size,
+		MimeType: attachment.MimeType,
+		Content:  attachment.Content,
+	}, nil
+}
+
+// convertUser converts an optional JSON user to protobuf
+func convertUser(user *jsonUser) *pb.User {
+	if user == nil {
+		return nil
+	}
+	return &pb.User{
+		AccountId:    user.AccountID,
+		DisplayName:  user.DisplayName,
+		EmailAddress: user.EmailAddress,
+	}
+}
+
+// parseTimestamp parses a Jira timestamp, returning nil for an empty string
+func parseTimestamp(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", value)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
 // convertIssueLink converts a JSON issue link to protobuf
 func (a *Adapter) convertIssueLink(link *jsonIssueLink) *pb.IssueLink {
 	pbLink := &pb.IssueLink{
@@ -282,20 +414,51 @@ type jsonIssue struct {
 }
 
 type jsonFields struct {
-	Summary     string          `json:"summary"`
-	Description string          `json:"description"`
-	IssueType   jsonIssueType   `json:"issuetype"`
-	Status      jsonStatus      `json:"status"`
-	Priority    jsonPriority    `json:"priority"`
-	Assignee    *jsonUser       `json:"assignee,omitempty"`
-	Reporter    *jsonUser       `json:"reporter,omitempty"`
-	Created     time.Time       `json:"created"`
-	Updated     time.Time       `json:"updated"`
-	Labels      []string        `json:"labels"`
-	IssueLinks  []jsonIssueLink `json:"issuelinks"`
-	Parent      *jsonParent     `json:"parent,omitempty"`
-	Epic        *jsonEpic       `json:"epic,omitempty"`
-	Subtasks    []jsonSubtask   `json:"subtasks"`
+	Summary     string           `json:"summary"`
+	Description jsonRichText     `json:"description"`
+	IssueType   jsonIssueType    `json:"issuetype"`
+	Status      jsonStatus       `json:"status"`
+	Priority    jsonPriority     `json:"priority"`
+	Assignee    *jsonUser        `json:"assignee,omitempty"`
+	Reporter    *jsonUser        `json:"reporter,omitempty"`
+	Created     time.Time        `json:"created"`
+	Updated     time.Time        `json:"updated"`
+	Resolved    time.Time        `json:"resolutiondate"`
+	Labels      []string         `json:"labels"`
+	IssueLinks  []jsonIssueLink  `json:"issuelinks"`
+	Parent      *jsonParent      `json:"parent,omitempty"`
+	Epic        *jsonEpic        `json:"epic,omitempty"`
+	Subtasks    []jsonSubtask    `json:"subtasks"`
+	Components  []jsonComponent  `json:"components"`
+	Comment     *jsonCommentPage `json:"comment,omitempty"`
+	Attachments []jsonAttachment `json:"attachment"`
+	FixVersions []jsonVersion    `json:"fixVersions"`
+	Resolution  *jsonResolution  `json:"resolution,omitempty"`
+
+	CustomFields map[string]string   `json:"-"` // customfield_XXXXX display values
+	Checklist    []jsonChecklistItem `json:"-"` // Items from checklist plugin fields
+	Sprints      []jsonSprint        `json:"-"` // From the agile API or the sprint custom field
+}
+
+// jsonRichText is a rich-text field: wiki markup from the v2 API, or an
+// Atlassian Document Format object from the v3 API
+type jsonRichText struct {
+	Text string
+	ADF  json.RawMessage
+}
+
+// UnmarshalJSON accepts a string or an ADF document
+func (rt *jsonRichText) UnmarshalJSON(b []byte) error {
+	trimmed := bytes.TrimSpace(b)
+	switch {
+	case bytes.Equal(trimmed, []byte("null")):
+		return nil
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		rt.ADF = append(json.RawMessage(nil), trimmed...)
+		return nil
+	default:
+		return json.Unmarshal(b, &rt.Text)
+	}
 }
 
 type jsonIssueType struct {
@@ -367,6 +530,39 @@ type jsonEpic struct {
 	Done    bool   `json:"done"`
 }
 
+type jsonResolution struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type jsonComponent struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type jsonCommentPage struct {
+	Comments []jsonComment `json:"comments"`
+	Total    int           `json:"total"`
+}
+
+type jsonComment struct {
+	ID      string       `json:"id"`
+	Author  *jsonUser    `json:"author,omitempty"`
+	Body    jsonRichText `json:"body"`
+	Created string       `json:"created"`
+	Updated string       `json:"updated"`
+}
+
+type jsonAttachment struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Author   *jsonUser `json:"author,omitempty"`
+	Created  string    `json:"created"`
+	Size     int64     `json:"size"`
+	MimeType string    `json:"mimeType"`
+	Content  string    `json:"content"`
+}
+
 type jsonSubtask struct {
 	ID     string           `json:"id"`
 	Key    string           `json:"key"`
@@ -378,17 +574,27 @@ type jsonSubtask struct {
 func (jf *jsonFields) UnmarshalJSON(b []byte) error {
 	type Alias jsonFields
 	aux := &struct {
-		Created string `json:"created"`
-		Updated string `json:"updated"`
+		Created  string `json:"created"`
+		Updated  string `json:"updated"`
+		Resolved string `json:"resolutiondate"`
 		*Alias
 	}{
 		Alias: (*Alias)(jf),
 	}
 
-	if err := json.Unmarshal(b, &aux); err != nil {
+	// Decode into aux rather than &aux, so "fields": null leaves it set
+	if err := json.Unmarshal(b, aux); err != nil {
 		return err
 	}
 
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	jf.Checklist = extractChecklists(raw)
+	jf.Sprints = extractSprints(raw)
+	jf.CustomFields = customFieldValues(raw)
+
 	// Parse Jira timestamp format
 	if aux.Created != "" {
 		t, err := time.Parse("2006-01-02T15:04:05.000-0700", aux.Created)
@@ -406,5 +612,13 @@ func (jf *jsonFields) UnmarshalJSON(b []byte) error {
 		jf.Updated = t
 	}
 
+	if aux.Resolved != "" {
+		t, err := time.Parse("2006-01-02T15:04:05.000-0700", aux.Resolved)
+		if err != nil {
+			return err
+		}
+		jf.Resolved = t
+	}
+
 	return nil
 }
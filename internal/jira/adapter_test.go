@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"strings"
 	"testing"
 
 	pb "github.com/conallob/jira-beads-sync/gen/jira"
@@ -207,3 +208,193 @@ func TestAdapterConvertParent(t *testing.T) {
 		}
 	}
 }
+
+func TestAdapterConvertComponents(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.ParseFile("../../testdata/sample-jira-export.json")
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	var proj2 *pb.Issue
+	for _, issue := range export.Issues {
+		if issue.Key == "PROJ-2" {
+			proj2 = issue
+			break
+		}
+	}
+
+	if proj2 == nil {
+		t.Fatal("Could not find PROJ-2")
+	}
+
+	if len(proj2.Fields.Components) != 1 {
+		t.Fatalf("Expected 1 component, got %d", len(proj2.Fields.Components))
+	}
+	if proj2.Fields.Components[0].Name != "Backend" {
+		t.Errorf("Expected component 'Backend', got %s", proj2.Fields.Components[0].Name)
+	}
+	if proj2.Fields.Components[0].Id != "10200" {
+		t.Errorf("Expected component ID '10200', got %s", proj2.Fields.Components[0].Id)
+	}
+}
+
+func TestAdapterConvertResolutionDate(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.ParseFile("../../testdata/sample-jira-export.json")
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+
+	for _, issue := range export.Issues {
+		switch issue.Key {
+		case "PROJ-4":
+			if issue.Fields.Resolved == nil {
+				t.Fatal("Expected PROJ-4 to have a resolution date")
+			}
+			if got := issue.Fields.Resolved.AsTime().Format("2006-01-02T15:04"); got != "2024-01-03T16:00" {
+				t.Errorf("Expected resolution date 2024-01-03T16:00, got %s", got)
+			}
+		case "PROJ-2":
+			if issue.Fields.Resolved != nil {
+				t.Error("Expected unresolved PROJ-2 to have no resolution date")
+			}
+		}
+	}
+}
+
+func TestAdapterConvertResolution(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.Parse([]byte(`{"issues": [
+		{"id": "1", "key": "PROJ-1", "fields": {
+			"summary": "Resolved",
+			"issuetype": {"name": "Task"},
+			"status": {"name": "Done", "statusCategory": {"key": "done"}},
+			"resolution": {"id": "10001", "name": "Won't Do"}
+		}},
+		{"id": "2", "key": "PROJ-2", "fields": {
+			"summary": "Unresolved",
+			"issuetype": {"name": "Task"},
+			"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+			"resolution": null
+		}}
+	]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if resolution := export.Issues[0].Fields.Resolution; resolution.GetName() != "Won't Do" || resolution.GetId() != "10001" {
+		t.Errorf("Expected resolution Won't Do, got %v", resolution)
+	}
+	if resolution := export.Issues[1].Fields.Resolution; resolution != nil {
+		t.Errorf("Expected no resolution for an unresolved issue, got %v", resolution)
+	}
+}
+
+func TestAdapterConvertCommentsAndAttachments(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"comment": {"total": 1, "comments": [{
+			"id": "100",
+			"author": {"accountId": "abc", "displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+			"body": "Looks good",
+			"created": "2024-01-02T10:00:00.000+0000",
+			"updated": "2024-01-02T11:00:00.000+0000"
+		}]},
+		"attachment": [{
+			"id": "200",
+			"filename": "trace.log",
+			"author": {"displayName": "John Smith"},
+			"created": "2024-01-03T09:30:00.000+0000",
+			"size": 2048,
+			"mimeType": "text/plain",
+			"content": "https://jira.example.com/secure/attachment/200/trace.log"
+		}]
+	}}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	fields := export.Issues[0].Fields
+	if len(fields.Comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(fields.Comments))
+	}
+	comment := fields.Comments[0]
+	if comment.Id != "100" || comment.Body != "Looks good" {
+		t.Errorf("Expected comment 100 'Looks good', got %s %q", comment.Id, comment.Body)
+	}
+	if comment.Author.GetEmailAddress() != "jane@example.com" {
+		t.Errorf("Expected comment author jane@example.com, got %s", comment.Author.GetEmailAddress())
+	}
+	if got := comment.Created.AsTime().Format("2006-01-02T15:04"); got != "2024-01-02T10:00" {
+		t.Errorf("Expected comment created 2024-01-02T10:00, got %s", got)
+	}
+
+	if len(fields.Attachments) != 1 {
+		t.Fatalf("Expected 1 attachment, got %d", len(fields.Attachments))
+	}
+	attachment := fields.Attachments[0]
+	if attachment.Filename != "trace.log" || attachment.Size != 2048 || attachment.MimeType != "text/plain" {
+		t.Errorf("Unexpected attachment metadata: %v", attachment)
+	}
+	if attachment.Content != "https://jira.example.com/secure/attachment/200/trace.log" {
+		t.Errorf("Expected attachment content URL, got %s", attachment.Content)
+	}
+}
+
+func TestAdapterConvertInvalidCommentDate(t *testing.T) {
+	adapter := NewAdapter()
+	_, err := adapter.Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"comment": {"total": 1, "comments": [{"id": "100", "body": "x", "created": "yesterday"}]}
+	}}]}`))
+	if err == nil {
+		t.Error("Expected error for invalid comment date, got nil")
+	}
+}
+
+func TestAdapterConvertRichText(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"description": {"type": "doc", "version": 1, "content": []},
+		"comment": {"total": 2, "comments": [
+			{"id": "100", "body": "h1. Wiki", "created": "2024-01-02T10:00:00.000+0000"},
+			{"id": "101", "body": {"type": "doc", "content": []}, "created": "2024-01-02T10:00:00.000+0000"}
+		]}
+	}}, {"id": "2", "key": "PROJ-2", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"description": null
+	}}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	fields := export.Issues[0].Fields
+	if fields.Description != "" || !strings.HasPrefix(fields.DescriptionAdf, `{"type": "doc"`) {
+		t.Errorf("Expected ADF description, got text %q and ADF %q", fields.Description, fields.DescriptionAdf)
+	}
+	if c := fields.Comments[0]; c.Body != "h1. Wiki" || c.BodyAdf != "" {
+		t.Errorf("Expected wiki comment body, got %q / %q", c.Body, c.BodyAdf)
+	}
+	if c := fields.Comments[1]; c.Body != "" || c.BodyAdf == "" {
+		t.Errorf("Expected ADF comment body, got %q / %q", c.Body, c.BodyAdf)
+	}
+
+	if fields := export.Issues[1].Fields; fields.Description != "" || fields.DescriptionAdf != "" {
+		t.Errorf("Expected empty description for null, got %q / %q", fields.Description, fields.DescriptionAdf)
+	}
+}
@@ -0,0 +1,69 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AttachmentURL returns the download URL of an attachment on this Jira
+// instance, for attachments recorded without their content URL
+func (c *Client) AttachmentURL(id, filename string) string {
+	return fmt.Sprintf("%s/secure/attachment/%s/%s", c.baseURL, url.PathEscape(id), url.PathEscape(filename))
+}
+
+// DownloadAttachment streams the attachment at contentURL to w and returns
+// the number of bytes written. Credentials are only sent to the configured
+// Jira instance, so URLs pointing at any other host are rejected.
+func (c *Client) DownloadAttachment(ctx context.Context, contentURL string, w io.Writer) (n int64, err error) {
+	if err := c.checkSameHost(contentURL); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", contentURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return 0, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	n, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	return n, nil
+}
+
+// checkSameHost rejects URLs that don't point at the client's Jira instance
+func (c *Client) checkSameHost(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid attachment URL %q: %w", rawURL, err)
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid Jira base URL %q: %w", c.baseURL, err)
+	}
+	if !strings.EqualFold(target.Scheme, base.Scheme) || !strings.EqualFold(target.Host, base.Host) {
+		return fmt.Errorf("attachment URL %s is not on the configured Jira instance %s", rawURL, c.baseURL)
+	}
+	return nil
+}
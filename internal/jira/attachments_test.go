@@ -0,0 +1,66 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/secure/attachment/200/trace.log" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if user, token, ok := r.BasicAuth(); !ok || user != "user@example.com" || token != "token123" {
+			t.Errorf("Expected basic auth credentials, got %q/%q", user, token)
+		}
+		_, _ = w.Write([]byte("panic: boom\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	var buf bytes.Buffer
+	n, err := client.DownloadAttachment(context.Background(), client.AttachmentURL("200", "trace.log"), &buf)
+	if err != nil {
+		t.Fatalf("DownloadAttachment failed: %v", err)
+	}
+	if n != 12 || buf.String() != "panic: boom\n" {
+		t.Errorf("Expected the attachment content, got %d bytes: %q", n, buf.String())
+	}
+
+	if _, err := client.DownloadAttachment(context.Background(), server.URL+"/secure/attachment/201/missing.log", &buf); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+}
+
+func TestDownloadAttachmentRejectsOtherHosts(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewClient("https://jira.example.com", "user@example.com", "token123", "basic")
+
+	var buf bytes.Buffer
+	if _, err := client.DownloadAttachment(context.Background(), server.URL+"/secure/attachment/200/trace.log", &buf); err == nil {
+		t.Error("Expected an error for a URL outside the Jira instance")
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to be sent, got %d", requests)
+	}
+}
+
+func TestAttachmentURLEscapesFilename(t *testing.T) {
+	client := NewClient("https://jira.example.com/", "", "token", "bearer")
+
+	got := client.AttachmentURL("200", "crash report.txt")
+	if got != "https://jira.example.com/secure/attachment/200/crash%20report.txt" {
+		t.Errorf("Expected an escaped attachment URL, got %s", got)
+	}
+}
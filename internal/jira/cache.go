@@ -0,0 +1,189 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DefaultCacheTTL is how long cached issues are served before being refetched
+const DefaultCacheTTL = 15 * time.Minute
+
+// CachedClient is a read-through cache in front of a Client. Issues are
+// stored on disk, one JSON file per key, and served from there until their
+// TTL expires, so embedding services can answer repeated lookups without
+// calling Jira each time. Writes are atomic, so several processes may share
+// a cache directory.
+type CachedClient struct {
+	client *Client
+	dir    string
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu       sync.Mutex
+	warnings []error // Failed cache writes, see Warnings
+}
+
+// cacheEntry is the on-disk representation of a cached issue
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Issue     json.RawMessage `json:"issue"`
+}
+
+// NewCachedClient wraps a client with an on-disk issue cache in dir.
+// A non-positive ttl uses DefaultCacheTTL.
+func NewCachedClient(client *Client, dir string, ttl time.Duration) *CachedClient {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &CachedClient{
+		client: client,
+		dir:    dir,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// DefaultCacheDir returns the per-user issue cache directory
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "jira-beads-sync", "issues"), nil
+}
+
+// FetchIssue returns a cached issue if it is younger than the TTL, and
+// otherwise fetches it from Jira and caches it. Cache read and write
+// failures fall back to Jira rather than failing the lookup; write failures
+// are kept for Warnings.
+func (c *CachedClient) FetchIssue(issueKey string) (*pb.Issue, error) {
+	return c.FetchIssueContext(context.Background(), issueKey)
+}
+
+// FetchIssueContext is FetchIssue with a context for cancellation
+func (c *CachedClient) FetchIssueContext(ctx context.Context, issueKey string) (*pb.Issue, error) {
+	if issue, ok := c.lookup(issueKey); ok {
+		return issue, nil
+	}
+
+	issue, err := c.client.FetchIssueContext(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.store(issueKey, issue); err != nil {
+		c.mu.Lock()
+		c.warnings = append(c.warnings, fmt.Errorf("failed to cache %s: %w", issueKey, err))
+		c.mu.Unlock()
+	}
+
+	return issue, nil
+}
+
+// FetchIssueWithDependencies fetches an issue and all its dependencies
+// recursively, serving each issue from the cache where possible
+func (c *CachedClient) FetchIssueWithDependencies(issueKey string) (*pb.Export, error) {
+	return c.FetchIssueWithDependenciesContext(context.Background(), issueKey)
+}
+
+// FetchIssueWithDependenciesContext is FetchIssueWithDependencies with a
+// context for cancellation
+func (c *CachedClient) FetchIssueWithDependenciesContext(ctx context.Context, issueKey string) (*pb.Export, error) {
+	issues, err := walkDependencies(ctx, []string{issueKey}, c.FetchIssueContext, c.client.maxConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Export{Issues: issues}, nil
+}
+
+// Warnings returns the cache writes that failed since the last call and
+// clears them. The lookups themselves succeeded from Jira, so it is up to
+// the caller whether to report them, e.g. after a batch of lookups.
+func (c *CachedClient) Warnings() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	warnings := c.warnings
+	c.warnings = nil
+	return warnings
+}
+
+// Invalidate removes an issue from the cache, e.g. after a webhook reports a change
+func (c *CachedClient) Invalidate(issueKey string) error {
+	if err := os.Remove(c.path(issueKey)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to invalidate %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// lookup returns a fresh cached issue
+func (c *CachedClient) lookup(issueKey string) (*pb.Issue, bool) {
+	data, err := os.ReadFile(c.path(issueKey))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if c.now().Sub(entry.FetchedAt) >= c.ttl {
+		return nil, false
+	}
+
+	issue := &pb.Issue{}
+	if err := protojson.Unmarshal(entry.Issue, issue); err != nil {
+		return nil, false
+	}
+
+	return issue, true
+}
+
+// store writes an issue to the cache atomically
+func (c *CachedClient) store(issueKey string, issue *pb.Issue) error {
+	issueJSON, err := protojson.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("failed to marshal issue: %w", err)
+	}
+
+	data, err := json.Marshal(cacheEntry{FetchedAt: c.now(), Issue: issueJSON})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), c.path(issueKey))
+}
+
+// path returns the cache file for an issue key
+func (c *CachedClient) path(issueKey string) string {
+	return filepath.Join(c.dir, url.PathEscape(strings.ToUpper(issueKey))+".json")
+}
@@ -0,0 +1,186 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer serves minimal issues and counts requests per key
+func newCountingServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(createMinimalIssue(key, "Issue "+key)); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+}
+
+func TestCachedClientServesFromCache(t *testing.T) {
+	var requests int32
+	server := newCountingServer(t, &requests)
+	defer server.Close()
+
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), t.TempDir(), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		issue, err := cached.FetchIssue("PROJ-1")
+		if err != nil {
+			t.Fatalf("FetchIssue failed: %v", err)
+		}
+		if issue.Key != "PROJ-1" || issue.Fields.Summary != "Issue PROJ-1" {
+			t.Errorf("Unexpected issue: %s %s", issue.Key, issue.Fields.Summary)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request to Jira, got %d", requests)
+	}
+}
+
+func TestCachedClientExpiresEntries(t *testing.T) {
+	var requests int32
+	server := newCountingServer(t, &requests)
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), t.TempDir(), 10*time.Minute)
+	cached.now = func() time.Time { return now }
+
+	if _, err := cached.FetchIssue("PROJ-1"); err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+
+	now = now.Add(5 * time.Minute)
+	if _, err := cached.FetchIssue("PROJ-1"); err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected fresh entry to be served from cache, got %d requests", requests)
+	}
+
+	now = now.Add(10 * time.Minute)
+	if _, err := cached.FetchIssue("PROJ-1"); err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected expired entry to be refetched, got %d requests", requests)
+	}
+}
+
+func TestCachedClientInvalidate(t *testing.T) {
+	var requests int32
+	server := newCountingServer(t, &requests)
+	defer server.Close()
+
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), t.TempDir(), time.Hour)
+
+	if _, err := cached.FetchIssue("PROJ-1"); err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if err := cached.Invalidate("PROJ-1"); err != nil {
+		t.Fatalf("Invalidate failed: %v", err)
+	}
+	if err := cached.Invalidate("PROJ-404"); err != nil {
+		t.Errorf("Expected invalidating an uncached key to succeed, got: %v", err)
+	}
+	if _, err := cached.FetchIssue("PROJ-1"); err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests after invalidation, got %d", requests)
+	}
+}
+
+func TestCachedClientDoesNotCacheErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), t.TempDir(), time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached.FetchIssue("PROJ-404"); err == nil {
+			t.Error("Expected error for missing issue")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected failed lookups not to be cached, got %d requests", requests)
+	}
+}
+
+func TestCachedClientFetchIssueWithDependencies(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+
+		issue := createMinimalIssue(key, "Issue "+key)
+		if key == "PROJ-1" {
+			fields := issue["fields"].(map[string]interface{})
+			fields["subtasks"] = []map[string]interface{}{{"key": "PROJ-2"}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(issue); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), t.TempDir(), time.Hour)
+
+	for i := 0; i < 2; i++ {
+		export, err := cached.FetchIssueWithDependencies("PROJ-1")
+		if err != nil {
+			t.Fatalf("FetchIssueWithDependencies failed: %v", err)
+		}
+		if len(export.Issues) != 2 {
+			t.Errorf("Expected 2 issues, got %d", len(export.Issues))
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected each issue to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestCachedClientCollectsWriteFailures(t *testing.T) {
+	var requests int32
+	server := newCountingServer(t, &requests)
+	defer server.Close()
+
+	// A file where the cache directory should be makes every write fail
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.WriteFile(dir, nil, 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	cached := NewCachedClient(NewClient(server.URL, "user", "token", "basic"), dir, time.Hour)
+
+	for _, key := range []string{"PROJ-1", "PROJ-2"} {
+		if _, err := cached.FetchIssue(key); err != nil {
+			t.Fatalf("Expected the lookup to succeed despite the cache, got: %v", err)
+		}
+	}
+
+	warnings := cached.Warnings()
+	if len(warnings) != 2 || !strings.Contains(warnings[0].Error(), "failed to cache PROJ-1") {
+		t.Errorf("Expected a warning per failed write, got %v", warnings)
+	}
+	if warnings := cached.Warnings(); len(warnings) != 0 {
+		t.Errorf("Expected warnings to be cleared once returned, got %v", warnings)
+	}
+}
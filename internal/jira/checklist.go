@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// checklistLine matches a text checklist item such as "* [x] Write tests"
+	// (Markdown) or "* [in progress] Write tests" (Issue Checklist)
+	checklistLine = regexp.MustCompile(`^[-*]\s*\[([^\]]*)\]\s*(.*)$`)
+
+	// checklistHeader matches a text checklist section heading such as
+	// "--- Backend" or "## Backend"
+	checklistHeader = regexp.MustCompile(`^(?:---+|#+)\s*(.+)$`)
+)
+
+// checkedStatuses are the text checklist states that count as done
+var checkedStatuses = map[string]bool{"x": true, "done": true, "checked": true, "skipped": true}
+
+// uncheckedStatuses are the text checklist states that count as open
+var uncheckedStatuses = map[string]bool{"": true, "open": true, "in progress": true, "unchecked": true}
+
+type jsonChecklistItem struct {
+	Name     string `json:"name"`
+	Checked  *bool  `json:"checked"`
+	IsHeader bool   `json:"isHeader"`
+}
+
+// extractChecklists finds custom fields holding checklist plugin data and
+// removes them from fields, so they are not also copied as opaque values.
+// Items of several checklist fields are concatenated in field ID order.
+func extractChecklists(fields map[string]json.RawMessage) []jsonChecklistItem {
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		if strings.HasPrefix(id, customFieldPrefix) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var items []jsonChecklistItem
+	for _, id := range ids {
+		if checklist, ok := parseChecklist(fields[id]); ok {
+			items = append(items, checklist...)
+			delete(fields, id)
+		}
+	}
+	return items
+}
+
+// parseChecklist recognises the JSON item arrays of Checklist for Jira and
+// the text format of Issue Checklist and Markdown task lists
+func parseChecklist(raw json.RawMessage) ([]jsonChecklistItem, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	switch raw[0] {
+	case '[':
+		var items []jsonChecklistItem
+		if err := json.Unmarshal(raw, &items); err != nil || len(items) == 0 {
+			return nil, false
+		}
+		for _, item := range items {
+			if item.Name == "" || (item.Checked == nil && !item.IsHeader) {
+				return nil, false
+			}
+		}
+		return items, true
+	case '"':
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, false
+		}
+		return parseChecklistText(text)
+	default:
+		return nil, false
+	}
+}
+
+// parseChecklistText parses a text checklist. Every non-empty line must be
+// an item or heading, and there must be at least one item.
+func parseChecklistText(text string) ([]jsonChecklistItem, bool) {
+	var items []jsonChecklistItem
+	hasItem := false
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if match := checklistLine.FindStringSubmatch(line); match != nil {
+			status := strings.ToLower(strings.TrimSpace(match[1]))
+			if !checkedStatuses[status] && !uncheckedStatuses[status] {
+				return nil, false
+			}
+			checked := checkedStatuses[status]
+			items = append(items, jsonChecklistItem{Name: strings.TrimSpace(match[2]), Checked: &checked})
+			hasItem = true
+			continue
+		}
+
+		if match := checklistHeader.FindStringSubmatch(line); match != nil {
+			items = append(items, jsonChecklistItem{Name: strings.TrimSpace(match[1]), IsHeader: true})
+			continue
+		}
+
+		return nil, false
+	}
+
+	if !hasItem {
+		return nil, false
+	}
+	return items, true
+}
@@ -0,0 +1,103 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseChecklist(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		wantOK      bool
+		wantItems   int
+		wantChecked []bool
+	}{
+		{
+			name:        "checklist for jira",
+			raw:         `[{"id": 1, "name": "Write tests", "checked": true, "mandatory": false, "rank": 0}, {"id": 2, "name": "Update docs", "checked": false, "rank": 1}]`,
+			wantOK:      true,
+			wantItems:   2,
+			wantChecked: []bool{true, false},
+		},
+		{
+			name:        "checklist for jira with header",
+			raw:         `[{"name": "Backend", "isHeader": true}, {"name": "Migrate schema", "checked": false}]`,
+			wantOK:      true,
+			wantItems:   2,
+			wantChecked: []bool{false, false},
+		},
+		{
+			name:        "issue checklist text",
+			raw:         `"--- Release\n* [done] Tag version\n* [in progress] Publish notes\n* [open] Announce"`,
+			wantOK:      true,
+			wantItems:   4,
+			wantChecked: []bool{false, true, false, false},
+		},
+		{
+			name:        "markdown task list",
+			raw:         `"- [x] Reproduce\n- [ ] Fix"`,
+			wantOK:      true,
+			wantItems:   2,
+			wantChecked: []bool{true, false},
+		},
+		{name: "plain text", raw: `"Just a note\n- with a bullet"`},
+		{name: "bullet list", raw: `"- first\n- second"`},
+		{name: "headers only", raw: `"--- Nothing to do"`},
+		{name: "unknown status", raw: `"* [maybe] Think about it"`},
+		{name: "option array", raw: `[{"value": "iOS"}, {"value": "Android"}]`},
+		{name: "string array", raw: `["a", "b"]`},
+		{name: "number", raw: `5`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, ok := parseChecklist(json.RawMessage(tt.raw))
+			if ok != tt.wantOK {
+				t.Fatalf("parseChecklist ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(items) != tt.wantItems {
+				t.Fatalf("Expected %d items, got %d", tt.wantItems, len(items))
+			}
+			for i, want := range tt.wantChecked {
+				got := items[i].Checked != nil && *items[i].Checked
+				if got != want {
+					t.Errorf("Item %d (%q) checked = %v, want %v", i, items[i].Name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdapterConvertChecklist(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"customfield_10050": [{"name": "Write tests", "checked": true}, {"name": "Update docs", "checked": false}],
+		"customfield_10060": "Platform"
+	}}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	fields := export.Issues[0].Fields
+	if len(fields.Checklist) != 2 {
+		t.Fatalf("Expected 2 checklist items, got %d", len(fields.Checklist))
+	}
+	if fields.Checklist[0].Name != "Write tests" || !fields.Checklist[0].Checked {
+		t.Errorf("Expected checked 'Write tests', got %v", fields.Checklist[0])
+	}
+	if fields.Checklist[1].Checked {
+		t.Error("Expected 'Update docs' to be unchecked")
+	}
+
+	if _, ok := fields.CustomFields["customfield_10050"]; ok {
+		t.Error("Expected checklist field not to be copied as a custom field value")
+	}
+	if fields.CustomFields["customfield_10060"] != "Platform" {
+		t.Errorf("Expected other custom fields to be kept, got %v", fields.CustomFields)
+	}
+}
@@ -1,12 +1,15 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	pb "github.com/conallob/jira-beads-sync/gen/jira"
 )
@@ -19,6 +22,43 @@ type Client struct {
 	apiToken   string
 	authMethod string // "basic" or "bearer"
 	adapter    *Adapter
+
+	customFields []string // Extra fields requested by searches
+
+	apiVersion string
+	fallbacks  sync.Map // API path prefixes the instance lacks → their fallback
+
+	maxConcurrency int
+	maxRetries     int
+	fetchOrder     FetchOrder
+	retryBaseDelay time.Duration
+	jitter         func(time.Duration) time.Duration
+	throttle       throttle
+}
+
+// BaseURL returns the URL of the Jira instance the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// ClientOptions tunes how a Client fetches from Jira
+type ClientOptions struct {
+	// MaxConcurrency is the number of issues fetched in parallel.
+	// Zero uses DefaultMaxConcurrency.
+	MaxConcurrency int
+
+	// MaxRetries is how often a request rejected with 429 or a transient
+	// 5xx is retried. Zero uses DefaultMaxRetries; negative disables retries.
+	MaxRetries int
+
+	// FetchOrder sorts the issues matching a search, so the most active
+	// ones are fetched first. Queries with an ORDER BY clause keep theirs.
+	FetchOrder FetchOrder
+
+	// APIVersion is the REST API version requests use, one of APIVersions.
+	// Empty uses DefaultAPIVersion. Instances without the version fall back
+	// to an older one, see do.
+	APIVersion string
 }
 
 // NewClient creates a new Jira API client
@@ -26,18 +66,39 @@ type Client struct {
 // For basic auth: username is email/username, apiToken is API token
 // For bearer auth: apiToken is the bearer token, username is optional
 func NewClient(baseURL, username, apiToken, authMethod string) *Client {
+	return NewClientWithOptions(baseURL, username, apiToken, authMethod, ClientOptions{})
+}
+
+// NewClientWithOptions creates a Jira API client with the given concurrency
+// and retry settings
+func NewClientWithOptions(baseURL, username, apiToken, authMethod string, opts ClientOptions) *Client {
 	// Default to basic auth if not specified
 	if authMethod == "" {
 		authMethod = "basic"
 	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = DefaultMaxConcurrency
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+	if opts.APIVersion == "" {
+		opts.APIVersion = DefaultAPIVersion
+	}
 
 	return &Client{
-		baseURL:    strings.TrimSuffix(baseURL, "/"),
-		httpClient: &http.Client{},
-		username:   username,
-		apiToken:   apiToken,
-		authMethod: authMethod,
-		adapter:    NewAdapter(),
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		httpClient:     newHTTPClient(),
+		username:       username,
+		apiToken:       apiToken,
+		authMethod:     authMethod,
+		adapter:        NewAdapter(),
+		maxConcurrency: opts.MaxConcurrency,
+		maxRetries:     opts.MaxRetries,
+		fetchOrder:     opts.FetchOrder,
+		apiVersion:     opts.APIVersion,
+		retryBaseDelay: defaultRetryBaseDelay,
+		jitter:         randomJitter,
 	}
 }
 
@@ -53,9 +114,14 @@ func (c *Client) setAuthHeader(req *http.Request) {
 
 // FetchIssue fetches a single issue by key (e.g., "PROJ-123")
 func (c *Client) FetchIssue(issueKey string) (*pb.Issue, error) {
-	apiURL := fmt.Sprintf("%s/rest/api/2/issue/%s", c.baseURL, issueKey)
+	return c.FetchIssueContext(context.Background(), issueKey)
+}
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+// FetchIssueContext is FetchIssue with a context for cancellation
+func (c *Client) FetchIssueContext(ctx context.Context, issueKey string) (*pb.Issue, error) {
+	apiURL := fmt.Sprintf("%s/issue/%s", c.apiBase(), issueKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -63,7 +129,7 @@ func (c *Client) FetchIssue(issueKey string) (*pb.Issue, error) {
 	c.setAuthHeader(req)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issue: %w", err)
 	}
@@ -78,14 +144,9 @@ func (c *Client) FetchIssue(issueKey string) (*pb.Issue, error) {
 		return nil, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	// Parse single issue into an export with one issue
 	var jsonIssue jsonIssue
-	if err := json.Unmarshal(body, &jsonIssue); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&jsonIssue); err != nil {
 		return nil, fmt.Errorf("failed to parse issue: %w", err)
 	}
 
@@ -94,6 +155,12 @@ func (c *Client) FetchIssue(issueKey string) (*pb.Issue, error) {
 		return nil, fmt.Errorf("failed to convert issue: %w", err)
 	}
 
+	if commentsTruncated(&jsonIssue) {
+		if err := c.completeComments(ctx, []*pb.Issue{issue}); err != nil {
+			return nil, err
+		}
+	}
+
 	return issue, nil
 }
 
@@ -108,7 +175,7 @@ type UserInfo struct {
 // GetCurrentUser fetches information about the currently authenticated user
 // This is useful for validating credentials and testing connectivity
 func (c *Client) GetCurrentUser() (*UserInfo, error) {
-	apiURL := fmt.Sprintf("%s/rest/api/2/myself", c.baseURL)
+	apiURL := fmt.Sprintf("%s/myself", c.apiBase())
 
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -118,7 +185,7 @@ func (c *Client) GetCurrentUser() (*UserInfo, error) {
 	c.setAuthHeader(req)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Jira: %w", err)
 	}
@@ -136,13 +203,8 @@ func (c *Client) GetCurrentUser() (*UserInfo, error) {
 		return nil, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
 	var userInfo UserInfo
-	if err := json.Unmarshal(body, &userInfo); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
 		return nil, fmt.Errorf("failed to parse user info: %w", err)
 	}
 
@@ -151,61 +213,24 @@ func (c *Client) GetCurrentUser() (*UserInfo, error) {
 
 // FetchIssueWithDependencies fetches an issue and all its dependencies recursively
 func (c *Client) FetchIssueWithDependencies(issueKey string) (*pb.Export, error) {
-	visited := make(map[string]bool)
-	issues := make([]*pb.Issue, 0)
-
-	if err := c.fetchRecursive(issueKey, visited, &issues); err != nil {
-		return nil, err
-	}
-
-	return &pb.Export{Issues: issues}, nil
+	return c.FetchIssueWithDependenciesContext(context.Background(), issueKey)
 }
 
-// fetchRecursive recursively fetches an issue and all its related issues
-func (c *Client) fetchRecursive(issueKey string, visited map[string]bool, issues *[]*pb.Issue) error {
-	if visited[issueKey] {
-		return nil
-	}
-
-	fmt.Printf("Fetching %s...\n", issueKey)
-	visited[issueKey] = true
+// FetchIssueWithDependenciesContext is FetchIssueWithDependencies with a
+// context for cancellation
+func (c *Client) FetchIssueWithDependenciesContext(ctx context.Context, issueKey string) (*pb.Export, error) {
+	return c.fetchWithDependencies(ctx, []string{issueKey})
+}
 
-	issue, err := c.FetchIssue(issueKey)
+// fetchWithDependencies fetches the given issues and all their related
+// issues, up to maxConcurrency at a time
+func (c *Client) fetchWithDependencies(ctx context.Context, issueKeys []string) (*pb.Export, error) {
+	issues, err := walkDependencies(ctx, issueKeys, c.FetchIssueContext, c.maxConcurrency)
 	if err != nil {
-		return fmt.Errorf("failed to fetch %s: %w", issueKey, err)
-	}
-
-	*issues = append(*issues, issue)
-
-	// Fetch subtasks
-	for _, subtask := range issue.Fields.Subtasks {
-		if err := c.fetchRecursive(subtask.Key, visited, issues); err != nil {
-			return err
-		}
-	}
-
-	// Fetch linked issues (dependencies)
-	for _, link := range issue.Fields.IssueLinks {
-		if link.InwardIssue != nil {
-			if err := c.fetchRecursive(link.InwardIssue.Key, visited, issues); err != nil {
-				return err
-			}
-		}
-		if link.OutwardIssue != nil {
-			if err := c.fetchRecursive(link.OutwardIssue.Key, visited, issues); err != nil {
-				return err
-			}
-		}
-	}
-
-	// Fetch parent if it exists and isn't an epic
-	if issue.Fields.Parent != nil && issue.Fields.Parent.Fields.IssueType.Name != "Epic" {
-		if err := c.fetchRecursive(issue.Fields.Parent.Key, visited, issues); err != nil {
-			return err
-		}
+		return nil, err
 	}
 
-	return nil
+	return &pb.Export{Issues: issues}, nil
 }
 
 // ParseIssueKeyFromURL extracts the issue key from a Jira URL
@@ -257,20 +282,28 @@ func GetBaseURLFromIssueURL(jiraURL string) (string, error) {
 
 // SearchIssuesByLabel fetches all issues with a given label using JQL
 func (c *Client) SearchIssuesByLabel(label string) ([]string, error) {
-	// Build JQL query for label with proper quoting
+	return c.SearchIssues(labelJQL(label))
+}
+
+// labelJQL builds a JQL query for label with proper quoting
+func labelJQL(label string) string {
 	// Escape any quotes in the label value
 	escapedLabel := strings.ReplaceAll(label, `"`, `\"`)
-	jql := fmt.Sprintf(`labels = "%s"`, escapedLabel)
-	return c.SearchIssues(jql)
+	return fmt.Sprintf(`labels = "%s"`, escapedLabel)
 }
 
 // SearchIssues performs a JQL search and returns issue keys
 func (c *Client) SearchIssues(jql string) ([]string, error) {
+	return c.searchKeys(context.Background(), jql)
+}
+
+// searchKeys performs a JQL search and returns issue keys
+func (c *Client) searchKeys(ctx context.Context, jql string) ([]string, error) {
 	// URL encode the JQL query
 	encodedJQL := url.QueryEscape(jql)
-	apiURL := fmt.Sprintf("%s/rest/api/2/search?jql=%s&fields=key&maxResults=1000", c.baseURL, encodedJQL)
+	apiURL := fmt.Sprintf("%s/search?jql=%s&fields=key&maxResults=1000", c.apiBase(), encodedJQL)
 
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -278,7 +311,7 @@ func (c *Client) SearchIssues(jql string) ([]string, error) {
 	c.setAuthHeader(req)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search issues: %w", err)
 	}
@@ -293,31 +326,18 @@ func (c *Client) SearchIssues(jql string) ([]string, error) {
 		return nil, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// Stream search results, collecting issue keys
+	issueKeys := make([]string, 0)
+	total, err := decodeSearchResponse(resp.Body, func(issue *jsonIssue) error {
+		issueKeys = append(issueKeys, issue.Key)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse search results
-	var searchResult struct {
-		Issues []struct {
-			Key string `json:"key"`
-		} `json:"issues"`
-		Total int `json:"total"`
-	}
-
-	if err := json.Unmarshal(body, &searchResult); err != nil {
 		return nil, fmt.Errorf("failed to parse search results: %w", err)
 	}
 
-	// Extract issue keys
-	issueKeys := make([]string, 0, len(searchResult.Issues))
-	for _, issue := range searchResult.Issues {
-		issueKeys = append(issueKeys, issue.Key)
-	}
-
-	if len(issueKeys) < searchResult.Total {
-		fmt.Printf("⚠ Warning: Retrieved %d of %d total issues (pagination limit)\n", len(issueKeys), searchResult.Total)
+	if len(issueKeys) < total {
+		fmt.Printf("⚠ Warning: Retrieved %d of %d total issues (pagination limit)\n", len(issueKeys), total)
 	}
 
 	return issueKeys, nil
@@ -325,9 +345,15 @@ func (c *Client) SearchIssues(jql string) ([]string, error) {
 
 // FetchIssuesByLabel fetches all issues with a given label and their dependencies
 func (c *Client) FetchIssuesByLabel(label string) (*pb.Export, error) {
+	return c.FetchIssuesByLabelContext(context.Background(), label)
+}
+
+// FetchIssuesByLabelContext is FetchIssuesByLabel with a context for
+// cancellation
+func (c *Client) FetchIssuesByLabelContext(ctx context.Context, label string) (*pb.Export, error) {
 	fmt.Printf("Searching for issues with label: %s\n", label)
 
-	issueKeys, err := c.SearchIssuesByLabel(label)
+	issueKeys, err := c.searchKeys(ctx, OrderedJQL(labelJQL(label), c.fetchOrder))
 	if err != nil {
 		return nil, fmt.Errorf("failed to search by label: %w", err)
 	}
@@ -340,23 +366,19 @@ func (c *Client) FetchIssuesByLabel(label string) (*pb.Export, error) {
 	fmt.Println()
 
 	// Fetch all issues and their dependencies
-	visited := make(map[string]bool)
-	issues := make([]*pb.Issue, 0)
-
-	for _, key := range issueKeys {
-		if err := c.fetchRecursive(key, visited, &issues); err != nil {
-			return nil, err
-		}
-	}
-
-	return &pb.Export{Issues: issues}, nil
+	return c.fetchWithDependencies(ctx, issueKeys)
 }
 
 // FetchIssuesByJQL fetches all issues matching a JQL query and their dependencies
 func (c *Client) FetchIssuesByJQL(jql string) (*pb.Export, error) {
+	return c.FetchIssuesByJQLContext(context.Background(), jql)
+}
+
+// FetchIssuesByJQLContext is FetchIssuesByJQL with a context for cancellation
+func (c *Client) FetchIssuesByJQLContext(ctx context.Context, jql string) (*pb.Export, error) {
 	fmt.Printf("Searching with JQL: %s\n", jql)
 
-	issueKeys, err := c.SearchIssues(jql)
+	issueKeys, err := c.searchKeys(ctx, OrderedJQL(jql, c.fetchOrder))
 	if err != nil {
 		return nil, fmt.Errorf("failed to search by JQL: %w", err)
 	}
@@ -369,14 +391,212 @@ func (c *Client) FetchIssuesByJQL(jql string) (*pb.Export, error) {
 	fmt.Println()
 
 	// Fetch all issues and their dependencies
+	return c.fetchWithDependencies(ctx, issueKeys)
+}
+
+// FetchIssuesWithBlockers fetches the given issues and, transitively, every
+// issue blocking them. Unlike FetchIssueWithDependencies it does not follow
+// subtasks, parents or non-blocking links, keeping the result focused.
+// Root keys that cannot be fetched are skipped with a warning, since they
+// are often guesses (e.g. keys detected in branch names).
+func (c *Client) FetchIssuesWithBlockers(issueKeys []string) (*pb.Export, error) {
+	return c.FetchIssuesWithBlockersContext(context.Background(), issueKeys)
+}
+
+// FetchIssuesWithBlockersContext is FetchIssuesWithBlockers with a context
+// for cancellation
+func (c *Client) FetchIssuesWithBlockersContext(ctx context.Context, issueKeys []string) (*pb.Export, error) {
 	visited := make(map[string]bool)
-	issues := make([]*pb.Issue, 0)
 
+	var roots []string
 	for _, key := range issueKeys {
-		if err := c.fetchRecursive(key, visited, &issues); err != nil {
-			return nil, err
+		if !visited[key] {
+			fmt.Printf("Fetching %s...\n", key)
+			visited[key] = true
+			roots = append(roots, key)
 		}
 	}
 
-	return &pb.Export{Issues: issues}, nil
+	fetched, errs := fetchIssues(ctx, roots, c.FetchIssueContext, c.maxConcurrency)
+	issues := make([]*pb.Issue, 0, len(fetched))
+	for i, err := range errs {
+		if err != nil {
+			fmt.Printf("⚠ Warning: skipping %s: %v\n", roots[i], err)
+			continue
+		}
+		issues = append(issues, fetched[i])
+	}
+
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("none of the requested issues could be fetched")
+	}
+
+	blockers, err := c.fetchBlockers(ctx, issues, visited)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Export{Issues: append(issues, blockers...)}, nil
+}
+
+// fetchBlockers fetches the issues blocking the given issues, level by
+// level, until no unvisited blocker remains
+func (c *Client) fetchBlockers(ctx context.Context, issues []*pb.Issue, visited map[string]bool) ([]*pb.Issue, error) {
+	var blockers []*pb.Issue
+
+	for level := blockerKeys(issues, visited); len(level) > 0; level = blockerKeys(issues, visited) {
+		for _, key := range level {
+			fmt.Printf("Fetching %s...\n", key)
+			visited[key] = true
+		}
+
+		fetched, errs := fetchIssues(ctx, level, c.FetchIssueContext, c.maxConcurrency)
+		for i, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s: %w", level[i], err)
+			}
+		}
+
+		blockers = append(blockers, fetched...)
+		issues = fetched
+	}
+
+	return blockers, nil
+}
+
+// blockerKeys returns the unvisited keys of issues blocking the given
+// issues, in discovery order
+func blockerKeys(issues []*pb.Issue, visited map[string]bool) []string {
+	var keys []string
+	seen := make(map[string]bool)
+
+	for _, issue := range issues {
+		for _, link := range issue.Fields.IssueLinks {
+			if link.Type.GetInward() != "is blocked by" || link.InwardIssue == nil {
+				continue
+			}
+			if key := link.InwardIssue.Key; !visited[key] && !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// ComponentInfo describes a project component and its lead
+type ComponentInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Lead *User  `json:"lead,omitempty"`
+}
+
+// FetchProjectComponents fetches the components defined in a project, including their leads
+func (c *Client) FetchProjectComponents(projectKey string) ([]ComponentInfo, error) {
+	apiURL := fmt.Sprintf("%s/project/%s/components", c.apiBase(), url.PathEscape(projectKey))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch components: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var components []ComponentInfo
+	if err := json.NewDecoder(resp.Body).Decode(&components); err != nil {
+		return nil, fmt.Errorf("failed to parse components: %w", err)
+	}
+
+	return components, nil
+}
+
+// FetchComponentOwners resolves component leads for every project in an export.
+// The result maps project key → component name → lead (email, or display name
+// when the email is hidden). Components without a lead are omitted.
+func (c *Client) FetchComponentOwners(export *pb.Export) (map[string]map[string]string, error) {
+	owners := make(map[string]map[string]string)
+
+	for _, issue := range export.Issues {
+		projectKey := ProjectKey(issue.Key)
+		if projectKey == "" || owners[projectKey] != nil {
+			continue
+		}
+
+		components, err := c.FetchProjectComponents(projectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch components for %s: %w", projectKey, err)
+		}
+
+		owners[projectKey] = make(map[string]string)
+		for _, component := range components {
+			if component.Lead == nil {
+				continue
+			}
+			owner := component.Lead.EmailAddress
+			if owner == "" {
+				owner = component.Lead.DisplayName
+			}
+			if owner != "" {
+				owners[projectKey][component.Name] = owner
+			}
+		}
+	}
+
+	return owners, nil
+}
+
+// FetchWatchers fetches the watchers of every issue in an export. The result
+// maps issue key → watchers (email, or display name when the email is
+// hidden), in the order Jira lists them. Issues without watchers are
+// omitted. Listing watchers needs the View Voters and Watchers permission.
+func (c *Client) FetchWatchers(ctx context.Context, export *pb.Export) (map[string][]string, error) {
+	watchers := make(map[string][]string)
+
+	for _, issue := range export.Issues {
+		apiURL := fmt.Sprintf("%s/issue/%s/watchers", c.apiBase(), url.PathEscape(issue.Key))
+		var response struct {
+			Watchers []User `json:"watchers"`
+		}
+		if err := c.getJSON(ctx, apiURL, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch watchers of %s: %w", issue.Key, err)
+		}
+
+		for _, user := range response.Watchers {
+			watcher := user.EmailAddress
+			if watcher == "" {
+				watcher = user.DisplayName
+			}
+			if watcher != "" {
+				watchers[issue.Key] = append(watchers[issue.Key], watcher)
+			}
+		}
+	}
+
+	return watchers, nil
+}
+
+// ProjectKey returns the project part of an issue key ("PROJ-123" → "PROJ")
+func ProjectKey(issueKey string) string {
+	idx := strings.LastIndex(issueKey, "-")
+	if idx <= 0 {
+		return ""
+	}
+	return issueKey[:idx]
 }
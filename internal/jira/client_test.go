@@ -1,12 +1,16 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
 )
 
 func TestNewClient(t *testing.T) {
@@ -271,11 +275,14 @@ func TestFetchIssueUnauthorized(t *testing.T) {
 func TestFetchIssueWithDependencies(t *testing.T) {
 	// Track which issues were fetched
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+		mu.Lock()
 		fetchedIssues[issueKey] = true
+		mu.Unlock()
 
 		var response map[string]interface{}
 
@@ -623,10 +630,13 @@ func TestGetBaseURLFromIssueURL(t *testing.T) {
 func TestFetchRecursiveSkipsEpicParents(t *testing.T) {
 	// Test that parent issues that are epics are not fetched
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+		mu.Lock()
 		fetchedIssues[issueKey] = true
+		mu.Unlock()
 
 		var response map[string]interface{}
 
@@ -695,10 +705,13 @@ func TestFetchRecursiveSkipsEpicParents(t *testing.T) {
 func TestFetchRecursiveFetchesNonEpicParents(t *testing.T) {
 	// Test that parent issues that are NOT epics ARE fetched
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+		mu.Lock()
 		fetchedIssues[issueKey] = true
+		mu.Unlock()
 
 		var response map[string]interface{}
 
@@ -800,10 +813,13 @@ func TestFetchIssueInvalidJSON(t *testing.T) {
 
 func TestFetchIssueWithBothInwardAndOutwardLinks(t *testing.T) {
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+		mu.Lock()
 		fetchedIssues[issueKey] = true
+		mu.Unlock()
 
 		var response map[string]interface{}
 
@@ -1099,6 +1115,7 @@ func TestSearchIssuesByLabelWithQuotes(t *testing.T) {
 
 func TestFetchIssuesByLabel(t *testing.T) {
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -1119,7 +1136,9 @@ func TestFetchIssuesByLabel(t *testing.T) {
 		case "/rest/api/2/issue/PROJ-100", "/rest/api/2/issue/PROJ-101":
 			// Fetch individual issues
 			issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+			mu.Lock()
 			fetchedIssues[issueKey] = true
+			mu.Unlock()
 
 			response := createMinimalIssue(issueKey, fmt.Sprintf("Issue %s", issueKey))
 
@@ -1157,6 +1176,7 @@ func TestFetchIssuesByLabel(t *testing.T) {
 
 func TestFetchIssuesByLabelWithDependencies(t *testing.T) {
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -1176,7 +1196,9 @@ func TestFetchIssuesByLabelWithDependencies(t *testing.T) {
 		default:
 			// Fetch individual issues
 			issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+			mu.Lock()
 			fetchedIssues[issueKey] = true
+			mu.Unlock()
 
 			var response map[string]interface{}
 
@@ -1431,6 +1453,7 @@ func TestGetCurrentUserServerError(t *testing.T) {
 
 func TestFetchIssuesByJQL(t *testing.T) {
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -1458,7 +1481,9 @@ func TestFetchIssuesByJQL(t *testing.T) {
 		default:
 			// Fetch individual issues
 			issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+			mu.Lock()
 			fetchedIssues[issueKey] = true
+			mu.Unlock()
 
 			response := createMinimalIssue(issueKey, fmt.Sprintf("Issue %s", issueKey))
 
@@ -1496,6 +1521,7 @@ func TestFetchIssuesByJQL(t *testing.T) {
 
 func TestFetchIssuesByJQLWithDependencies(t *testing.T) {
 	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -1515,7 +1541,9 @@ func TestFetchIssuesByJQLWithDependencies(t *testing.T) {
 		default:
 			// Fetch individual issues
 			issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+			mu.Lock()
 			fetchedIssues[issueKey] = true
+			mu.Unlock()
 
 			var response map[string]interface{}
 
@@ -1845,6 +1873,7 @@ func TestFetchIssuesByJQLPaginationWarning(t *testing.T) {
 
 func TestFetchIssuesByJQLWithCircularDependencies(t *testing.T) {
 	fetchCount := make(map[string]int)
+	var mu sync.Mutex
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -1862,7 +1891,9 @@ func TestFetchIssuesByJQLWithCircularDependencies(t *testing.T) {
 			}
 		default:
 			issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+			mu.Lock()
 			fetchCount[issueKey]++
+			mu.Unlock()
 
 			var response map[string]interface{}
 			switch issueKey {
@@ -1959,3 +1990,208 @@ func TestFetchIssuesByJQLWithCircularDependencies(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchIssuesWithBlockers(t *testing.T) {
+	fetchedIssues := make(map[string]bool)
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issueKey := r.URL.Path[len("/rest/api/2/issue/"):]
+		mu.Lock()
+		fetchedIssues[issueKey] = true
+		mu.Unlock()
+
+		var response map[string]interface{}
+		switch issueKey {
+		case "PROJ-1":
+			response = createMinimalIssue("PROJ-1", "Branch issue")
+			fields := response["fields"].(map[string]interface{})
+			fields["issuelinks"] = []map[string]interface{}{
+				{
+					"type":        map[string]interface{}{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+					"inwardIssue": map[string]interface{}{"key": "PROJ-2"},
+				},
+				{
+					"type":         map[string]interface{}{"name": "Relates", "inward": "relates to", "outward": "relates to"},
+					"outwardIssue": map[string]interface{}{"key": "PROJ-9"},
+				},
+			}
+			fields["subtasks"] = []map[string]interface{}{{"key": "PROJ-10"}}
+		case "PROJ-2":
+			response = createMinimalIssue("PROJ-2", "Blocker")
+			fields := response["fields"].(map[string]interface{})
+			fields["issuelinks"] = []map[string]interface{}{
+				{
+					"type":        map[string]interface{}{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+					"inwardIssue": map[string]interface{}{"key": "PROJ-3"},
+				},
+			}
+		case "PROJ-3":
+			response = createMinimalIssue("PROJ-3", "Transitive blocker")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	export, err := client.FetchIssuesWithBlockers([]string{"PROJ-1", "RELEASE-2024"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(export.Issues) != 3 {
+		t.Errorf("Expected 3 issues, got %d", len(export.Issues))
+	}
+
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-3"} {
+		if !fetchedIssues[key] {
+			t.Errorf("Expected %s to be fetched", key)
+		}
+	}
+	for _, key := range []string{"PROJ-9", "PROJ-10"} {
+		if fetchedIssues[key] {
+			t.Errorf("Expected non-blocking issue %s not to be fetched", key)
+		}
+	}
+}
+
+func TestFetchIssuesWithBlockersNoneFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	if _, err := client.FetchIssuesWithBlockers([]string{"PROJ-1"}); err == nil {
+		t.Error("Expected error when no issues can be fetched")
+	}
+}
+
+func TestFetchComponentOwners(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/rest/api/2/project/PROJ/components" {
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		response := []map[string]interface{}{
+			{
+				"id":   "1",
+				"name": "Frontend",
+				"lead": map[string]interface{}{"displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+			},
+			{
+				"id":   "2",
+				"name": "Infra",
+				"lead": map[string]interface{}{"displayName": "Ops Lead"},
+			},
+			{
+				"id":   "3",
+				"name": "Docs",
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	export := &pb.Export{Issues: []*pb.Issue{{Key: "PROJ-1"}, {Key: "PROJ-2"}}}
+	owners, err := client.FetchComponentOwners(export)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected components to be fetched once per project, got %d requests", requests)
+	}
+	if owners["PROJ"]["Frontend"] != "jane@example.com" {
+		t.Errorf("Expected Frontend owner jane@example.com, got %q", owners["PROJ"]["Frontend"])
+	}
+	if owners["PROJ"]["Infra"] != "Ops Lead" {
+		t.Errorf("Expected Infra owner 'Ops Lead', got %q", owners["PROJ"]["Infra"])
+	}
+	if _, ok := owners["PROJ"]["Docs"]; ok {
+		t.Error("Expected component without lead to be omitted")
+	}
+}
+
+func TestFetchWatchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{"watchers": []map[string]interface{}{}}
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-1/watchers":
+			response["watchers"] = []map[string]interface{}{
+				{"displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+				{"displayName": "Ops Lead"},
+			}
+		case "/rest/api/2/issue/PROJ-2/watchers":
+		default:
+			t.Errorf("Unexpected path '%s'", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	export := &pb.Export{Issues: []*pb.Issue{{Key: "PROJ-1"}, {Key: "PROJ-2"}}}
+	watchers, err := client.FetchWatchers(context.Background(), export)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if got := strings.Join(watchers["PROJ-1"], ", "); got != "jane@example.com, Ops Lead" {
+		t.Errorf("Expected PROJ-1 watchers 'jane@example.com, Ops Lead', got %q", got)
+	}
+	if _, ok := watchers["PROJ-2"]; ok {
+		t.Error("Expected an issue without watchers to be omitted")
+	}
+}
+
+func TestFetchProjectComponentsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+
+	if _, err := client.FetchProjectComponents("NOPE"); err == nil {
+		t.Error("Expected error for missing project")
+	}
+}
+
+func TestProjectKey(t *testing.T) {
+	tests := map[string]string{
+		"PROJ-123":    "PROJ",
+		"MY-PROJ-7":   "MY-PROJ",
+		"NOHYPHEN":    "",
+		"-1":          "",
+		"ABC2-999999": "ABC2",
+	}
+	for key, want := range tests {
+		if got := ProjectKey(key); got != want {
+			t.Errorf("ProjectKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// commentPageSize is the maxResults requested per comment page
+const commentPageSize = 100
+
+// FetchComments fetches every comment on an issue, oldest first. Issue and
+// search responses embed only the first page of comments; this pages through
+// the comment endpoint for the rest.
+func (c *Client) FetchComments(issueKey string) ([]*pb.Comment, error) {
+	return c.fetchComments(context.Background(), issueKey)
+}
+
+// fetchComments pages through the comments on an issue
+func (c *Client) fetchComments(ctx context.Context, issueKey string) ([]*pb.Comment, error) {
+	var comments []*pb.Comment
+
+	for {
+		page, total, err := c.fetchCommentPage(ctx, issueKey, len(comments))
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page...)
+		if len(page) == 0 || len(comments) >= total {
+			return comments, nil
+		}
+	}
+}
+
+// fetchCommentPage fetches one page of comments starting at startAt
+func (c *Client) fetchCommentPage(ctx context.Context, issueKey string, startAt int) (comments []*pb.Comment, total int, err error) {
+	params := url.Values{}
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(commentPageSize))
+	params.Set("orderBy", "created")
+	apiURL := fmt.Sprintf("%s/issue/%s/comment?%s", c.apiBase(), issueKey, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var page jsonCommentPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse comments: %w", err)
+	}
+
+	for _, comment := range page.Comments {
+		converted, err := c.adapter.convertComment(&comment)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to convert comment on %s: %w", issueKey, err)
+		}
+		comments = append(comments, converted)
+	}
+
+	return comments, page.Total, nil
+}
+
+// commentsTruncated reports whether a response embedded fewer comments than
+// the issue has
+func commentsTruncated(jsonIssue *jsonIssue) bool {
+	page := jsonIssue.Fields.Comment
+	return page != nil && page.Total > len(page.Comments)
+}
+
+// completeComments replaces the embedded comments of each issue with the
+// full list from the comment endpoint, up to maxConcurrency issues at a time
+func (c *Client) completeComments(ctx context.Context, issues []*pb.Issue) error {
+	errs := make([]error, len(issues))
+	forEach(len(issues), c.maxConcurrency, func(i int) {
+		comments, err := c.fetchComments(ctx, issues[i].Key)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to fetch comments for %s: %w", issues[i].Key, err)
+			return
+		}
+		issues[i].Fields.Comments = comments
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
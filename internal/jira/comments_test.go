@@ -0,0 +1,124 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// commentJSON builds a Jira comment with a numbered body
+func commentJSON(n int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      strconv.Itoa(n),
+		"author":  map[string]interface{}{"displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+		"body":    fmt.Sprintf("Comment %d", n),
+		"created": "2024-01-02T10:00:00.000+0000",
+	}
+}
+
+func TestFetchComments(t *testing.T) {
+	const total = 150
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1/comment" {
+			t.Errorf("Expected path '/rest/api/2/issue/PROJ-1/comment', got '%s'", r.URL.Path)
+		}
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		var comments []map[string]interface{}
+		for i := startAt + 1; i <= total && i <= startAt+maxResults; i++ {
+			comments = append(comments, commentJSON(i))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt":  startAt,
+			"total":    total,
+			"comments": comments,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token", "basic")
+	comments, err := client.FetchComments("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchComments failed: %v", err)
+	}
+
+	if len(comments) != total {
+		t.Fatalf("Expected %d comments, got %d", total, len(comments))
+	}
+	if comments[0].Body != "Comment 1" || comments[total-1].Body != "Comment 150" {
+		t.Errorf("Expected comments in order, got %q ... %q", comments[0].Body, comments[total-1].Body)
+	}
+}
+
+func TestFetchIssueCompletesTruncatedComments(t *testing.T) {
+	commentRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/rest/api/2/issue/PROJ-1":
+			issue := createMinimalIssue("PROJ-1", "Busy issue")
+			issue["fields"].(map[string]interface{})["comment"] = map[string]interface{}{
+				"total":    3,
+				"comments": []map[string]interface{}{commentJSON(1)},
+			}
+			_ = json.NewEncoder(w).Encode(issue)
+		case "/rest/api/2/issue/PROJ-1/comment":
+			commentRequests++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"total":    3,
+				"comments": []map[string]interface{}{commentJSON(1), commentJSON(2), commentJSON(3)},
+			})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token", "basic")
+	issue, err := client.FetchIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+
+	if commentRequests != 1 {
+		t.Errorf("Expected 1 comment request, got %d", commentRequests)
+	}
+	if len(issue.Fields.Comments) != 3 {
+		t.Errorf("Expected 3 comments, got %d", len(issue.Fields.Comments))
+	}
+}
+
+func TestFetchIssueSkipsCompleteComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1" {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+		issue := createMinimalIssue("PROJ-1", "Quiet issue")
+		issue["fields"].(map[string]interface{})["comment"] = map[string]interface{}{
+			"total":    1,
+			"comments": []map[string]interface{}{commentJSON(1)},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(issue)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token", "basic")
+	issue, err := client.FetchIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if len(issue.Fields.Comments) != 1 {
+		t.Errorf("Expected 1 comment, got %d", len(issue.Fields.Comments))
+	}
+}
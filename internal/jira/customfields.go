@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// customFieldPrefix identifies Jira custom fields in issue responses
+const customFieldPrefix = "customfield_"
+
+// legacySprintName extracts the name from the serialized sprint strings
+// older Jira Server versions return for the sprint field
+var legacySprintName = regexp.MustCompile(`\[.*\bname=([^,\]]*)`)
+
+// customFieldValues returns the display value of every non-empty custom
+// field in a raw fields object
+func customFieldValues(fields map[string]json.RawMessage) map[string]string {
+	var values map[string]string
+	for id, raw := range fields {
+		if !strings.HasPrefix(id, customFieldPrefix) {
+			continue
+		}
+		if value := customFieldValue(raw); value != "" {
+			if values == nil {
+				values = make(map[string]string)
+			}
+			values[id] = value
+			if users := userList(raw); users != "" {
+				values[UserListKey(id)] = users
+			}
+		}
+	}
+	return values
+}
+
+// UserListKey is the custom field values key holding the users of a user
+// custom field, one per line, by email address where Jira shares it and by
+// username or display name otherwise. The field's own value joins display
+// names with commas, which names such as "Doe, Jane" contain too.
+func UserListKey(fieldID string) string {
+	return fieldID + "#users"
+}
+
+// userList renders a user or list of users one per line, or returns "" if
+// raw holds anything else. Users are told apart from options and sprints by
+// their displayName.
+func userList(raw json.RawMessage) string {
+	var users []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &users); err != nil {
+		var user map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return ""
+		}
+		users = []map[string]json.RawMessage{user}
+	}
+
+	var ids []string
+	for _, user := range users {
+		if _, ok := user["displayName"]; !ok {
+			return ""
+		}
+		for _, key := range []string{"emailAddress", "name", "displayName"} {
+			if id := customFieldValue(user[key]); id != "" {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return strings.Join(ids, "\n")
+}
+
+// customFieldValue renders a custom field as text. Strings, numbers and
+// booleans are used as is, option and user objects by their value or name,
+// and arrays as a comma-separated list. Anything else renders as "".
+func customFieldValue(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return ""
+	}
+
+	switch raw[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return ""
+		}
+		if match := legacySprintName.FindStringSubmatch(s); match != nil {
+			return match[1]
+		}
+		return s
+	case '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return ""
+		}
+		for _, key := range []string{"value", "name", "displayName", "key"} {
+			if value, ok := object[key]; ok {
+				return customFieldValue(value)
+			}
+		}
+		return ""
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return ""
+		}
+		var values []string
+		for _, item := range items {
+			if value := customFieldValue(item); value != "" {
+				values = append(values, value)
+			}
+		}
+		return strings.Join(values, ", ")
+	case 'n':
+		return "" // null
+	default:
+		// Numbers and booleans
+		return string(raw)
+	}
+}
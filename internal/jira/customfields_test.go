@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCustomFieldValue(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"string", `"Platform"`, "Platform"},
+		{"number", `5.5`, "5.5"},
+		{"boolean", `true`, "true"},
+		{"null", `null`, ""},
+		{"option", `{"self": "x", "value": "Team A", "id": "1"}`, "Team A"},
+		{"user", `{"accountId": "abc", "displayName": "Jane Doe"}`, "Jane Doe"},
+		{"user with email", `{"accountId": "abc", "displayName": "Jane Doe", "emailAddress": "jane@example.com"}`, "Jane Doe"},
+		{"multi select", `[{"value": "iOS"}, {"value": "Android"}]`, "iOS, Android"},
+		{"cloud sprint", `[{"id": 3, "name": "Sprint 3", "state": "closed"}]`, "Sprint 3"},
+		{"legacy sprint", `["com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=3,rapidViewId=1,state=ACTIVE,name=Sprint 3,startDate=2024-01-01]"]`, "Sprint 3"},
+		{"unrecognised object", `{"foo": "bar"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := customFieldValue(json.RawMessage(tt.raw)); got != tt.want {
+				t.Errorf("customFieldValue(%s) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdapterConvertCustomFields(t *testing.T) {
+	adapter := NewAdapter()
+	export, err := adapter.Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"priority": {"name": "Medium"},
+		"customfield_10016": 8,
+		"customfield_10020": null,
+		"customfield_10030": {"value": "Team A"}
+	}}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	custom := export.Issues[0].Fields.CustomFields
+	if len(custom) != 2 {
+		t.Fatalf("Expected 2 custom fields, got %v", custom)
+	}
+	if custom["customfield_10016"] != "8" {
+		t.Errorf("Expected customfield_10016 = 8, got %q", custom["customfield_10016"])
+	}
+	if custom["customfield_10030"] != "Team A" {
+		t.Errorf("Expected customfield_10030 = Team A, got %q", custom["customfield_10030"])
+	}
+}
+
+func TestAdapterConvertUserListFields(t *testing.T) {
+	export, err := NewAdapter().Parse([]byte(`{"issues": [{"id": "1", "key": "SUP-1", "fields": {
+		"summary": "Test",
+		"issuetype": {"name": "Task"},
+		"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+		"customfield_10026": [
+			{"accountId": "a", "displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+			{"accountId": "b", "displayName": "Doe, John"},
+			{"name": "sam", "displayName": "Sam Lee", "emailAddress": ""}
+		],
+		"customfield_10030": [{"value": "iOS"}]
+	}}]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	custom := export.Issues[0].Fields.CustomFields
+	if got := custom["customfield_10026"]; got != "Jane Doe, Doe, John, sam" {
+		t.Errorf("Expected user fields to render names, got %q", got)
+	}
+	if got := custom[UserListKey("customfield_10026")]; got != "jane@example.com\nDoe, John\nsam" {
+		t.Errorf("Expected one user per line by email or name, got %q", got)
+	}
+	if _, ok := custom[UserListKey("customfield_10030")]; ok {
+		t.Error("Expected no user list for an option field")
+	}
+}
+
+func TestSearchRequestsCustomFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := r.URL.Query().Get("fields")
+		if !strings.HasSuffix(fields, ",fixVersions,customfield_10016") {
+			t.Errorf("Expected custom fields after the default fields, got %q", fields)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"total":  1,
+			"issues": []map[string]interface{}{createMinimalIssue("PROJ-1", "Test")},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token", "basic")
+	client.RequestCustomFields("customfield_10016")
+	if _, err := client.FetchByJQL("project = PROJ"); err != nil {
+		t.Fatalf("FetchByJQL failed: %v", err)
+	}
+}
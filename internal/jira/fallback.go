@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultAPIVersion is the REST API version used when ClientOptions
+// doesn't say otherwise
+const DefaultAPIVersion = "2"
+
+// APIVersions lists the supported REST API versions. Version 3 returns rich
+// text as ADF, which is converted like version 2's wiki markup.
+var APIVersions = []string{"2", "3"}
+
+// apiFallbacks maps API path prefixes to the older equivalent tried when an
+// instance answers 404 or 410. A fallback may itself fall back further.
+var apiFallbacks = []struct{ from, to string }{
+	{"/rest/api/3/", "/rest/api/2/"},
+	{"/rest/api/latest/", "/rest/api/2/"},
+	{"/rest/agile/latest/", "/rest/agile/1.0/"},
+}
+
+// apiBase returns the URL of the configured REST API version
+func (c *Client) apiBase() string {
+	return fmt.Sprintf("%s/rest/api/%s", c.baseURL, c.apiVersion)
+}
+
+// do sends a request with send. When the instance lacks the API version
+// the request uses, answering 404 or 410, the request is retried with the
+// older equivalent in apiFallbacks. Once a fallback works, later requests
+// go straight to it and the switch is reported once. A 404 for a missing
+// issue is retried too but doesn't switch versions, since the fallback
+// fails the same way.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for _, fallback := range apiFallbacks {
+		if _, ok := c.fallbacks.Load(fallback.from); ok {
+			rewritePath(req, fallback.from, fallback.to)
+		}
+	}
+
+	resp, err := c.send(req)
+	for err == nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+		from, to, ok := findFallback(req)
+		if !ok || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		older := req.Clone(req.Context())
+		rewritePath(older, from, to)
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return resp, nil
+			}
+			older.Body = body
+		}
+
+		olderResp, olderErr := c.send(older)
+		if olderErr != nil {
+			return resp, nil
+		}
+		if olderResp.StatusCode != http.StatusNotFound && olderResp.StatusCode != http.StatusGone {
+			if _, loaded := c.fallbacks.LoadOrStore(from, to); !loaded {
+				fmt.Printf("⚠ Jira doesn't support %s (status %d), falling back to %s\n", strings.TrimSuffix(from, "/"), resp.StatusCode, strings.TrimSuffix(to, "/"))
+			}
+		}
+		_ = resp.Body.Close()
+		req, resp = older, olderResp
+	}
+	return resp, err
+}
+
+// findFallback returns the API path prefix of req and its older equivalent
+func findFallback(req *http.Request) (from, to string, ok bool) {
+	for _, fallback := range apiFallbacks {
+		if strings.Contains(req.URL.Path, fallback.from) {
+			return fallback.from, fallback.to, true
+		}
+	}
+	return "", "", false
+}
+
+// rewritePath replaces the first from in the request path with to
+func rewritePath(req *http.Request, from, to string) {
+	req.URL.Path = strings.Replace(req.URL.Path, from, to, 1)
+	if req.URL.RawPath != "" {
+		req.URL.RawPath = strings.Replace(req.URL.RawPath, from, to, 1)
+	}
+}
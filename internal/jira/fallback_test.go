@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// oldJiraServer only has version 2 of the REST API and agile 1.0, like an
+// older Jira Server. It records the paths requested.
+type oldJiraServer struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (s *oldJiraServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.paths = append(s.paths, r.URL.Path)
+	s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/rest/api/2/myself":
+		_ = json.NewEncoder(w).Encode(UserInfo{AccountID: "abc", DisplayName: "Jane Doe"})
+	case "/rest/api/2/issue/PROJ-1":
+		_ = json.NewEncoder(w).Encode(createMinimalIssue("PROJ-1", "Old Jira"))
+	case "/rest/agile/1.0/board":
+		_, _ = w.Write([]byte(`{"values": []}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *oldJiraServer) requested(prefix string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, path := range s.paths {
+		if strings.HasPrefix(path, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAPIVersionFallback(t *testing.T) {
+	fake := &oldJiraServer{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{APIVersion: "3"})
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("GetCurrentUser failed: %v", err)
+	}
+	if user.DisplayName != "Jane Doe" {
+		t.Errorf("Expected the version 2 response, got %+v", user)
+	}
+
+	// Later requests go straight to version 2
+	issue, err := client.FetchIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if issue.Fields.Summary != "Old Jira" {
+		t.Errorf("Expected PROJ-1 from version 2, got %q", issue.Fields.Summary)
+	}
+	if got := fake.requested("/rest/api/3/"); got != 1 {
+		t.Errorf("Expected a single version 3 request, got %d", got)
+	}
+}
+
+func TestAPIVersionFallbackMissingIssue(t *testing.T) {
+	fake := &oldJiraServer{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{APIVersion: "3"})
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchIssue("PROJ-404"); err == nil {
+			t.Fatal("Expected an error for a missing issue")
+		}
+	}
+
+	// A missing issue is missing in every version, so version 3 is kept
+	if got := fake.requested("/rest/api/3/"); got != 2 {
+		t.Errorf("Expected version 3 to be tried for each fetch, got %d requests", got)
+	}
+}
+
+func TestAgileFallback(t *testing.T) {
+	fake := &oldJiraServer{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL+"/rest/agile/latest/board", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatalf("do failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the agile 1.0 board list, got status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,54 @@
+package jira
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// fuzzSeedIssues are issue payloads exercising the less common shapes the
+// adapter accepts: ADF descriptions, links, parents and custom fields
+var fuzzSeedIssues = []string{
+	`{"id":"1","key":"PROJ-1","fields":{"summary":"s","status":{"name":"Open"},"issuetype":{"name":"Task"},"created":"2024-01-01T00:00:00.000+0000","updated":"2024-01-02T00:00:00.000+0000"}}`,
+	`{"id":"2","key":"PROJ-2","fields":{"summary":"s","description":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"hi","marks":[{"type":"strong"}]}]}]},"status":{"name":"Done","statusCategory":{"key":"done"}},"issuetype":{"name":"Story"}}}`,
+	`{"id":"3","key":"PROJ-3","fields":{"summary":"s","status":{"name":"Open"},"issuetype":{"name":"Sub-task","subtask":true},"parent":{"key":"PROJ-2","fields":{"issuetype":{"name":"Story"}}},"issuelinks":[{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"PROJ-1"}},{"type":{"name":"Relates"},"inwardIssue":{"key":"PROJ-9"}}]}}`,
+	`{"id":"4","key":"PROJ-4","fields":{"summary":"s","status":{"name":"Open"},"issuetype":{"name":"Epic"},"customfield_10011":"Epic name","customfield_10020":[{"id":1,"name":"Sprint 1","state":"active"}],"fixVersions":[{"id":"10","name":"2.0","released":true}],"comment":{"comments":[{"author":{"displayName":"A"},"body":"c","created":"2024-01-01T00:00:00.000+0000"}],"total":1,"maxResults":1}}}`,
+	`{"key":"PROJ-5","fields":{"summary":null,"status":null,"issuetype":{},"created":"not a date","labels":[null],"attachment":[{"id":"1","filename":"a.txt","created":"x"}]}}`,
+}
+
+func FuzzAdapterParse(f *testing.F) {
+	if data, err := os.ReadFile("../../testdata/sample-jira-export.json"); err == nil {
+		f.Add(data)
+	}
+	for _, issue := range fuzzSeedIssues {
+		f.Add([]byte(`{"issues":[` + issue + `]}`))
+	}
+	f.Add([]byte(`{"issues":[{"key":"PROJ-1"`))
+	f.Add([]byte(`{"issues":null}`))
+	f.Add([]byte(`{"issues":[{"key":"PROJ-1","fields":null}]}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		export, err := NewAdapter().Parse(data)
+		if err == nil && export == nil {
+			t.Fatal("Parse returned neither an export nor an error")
+		}
+	})
+}
+
+// FuzzConvertIssue feeds single issue payloads through the decoder the
+// client uses for FetchIssue and webhook re-fetches
+func FuzzConvertIssue(f *testing.F) {
+	for _, issue := range fuzzSeedIssues {
+		f.Add([]byte(issue))
+	}
+	f.Add([]byte(`{"key":"PROJ-1","fields":`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var issue jsonIssue
+		if err := json.Unmarshal(data, &issue); err != nil {
+			return
+		}
+		_, _ = NewAdapter().convertIssue(&issue)
+		_ = commentsTruncated(&issue)
+	})
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"context"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// Fetcher looks up issues by key. It is implemented by Client and
+// CachedClient; code that only reads issues should accept a Fetcher, so it
+// can be tested with jiratest.Fetcher instead of an HTTP test server.
+type Fetcher interface {
+	// FetchIssue fetches a single issue by key or numeric ID
+	FetchIssue(issueKey string) (*pb.Issue, error)
+	// FetchIssueWithDependencies fetches an issue together with its
+	// subtasks, linked issues and parents, recursively
+	FetchIssueWithDependencies(issueKey string) (*pb.Export, error)
+}
+
+// Searcher finds issues with JQL. It is implemented by Client and mocked by
+// jiratest.Searcher.
+type Searcher interface {
+	// FetchByJQL fetches every issue matching a JQL query, plus the issues
+	// they depend on
+	FetchByJQL(jql string) (*pb.Export, error)
+	// FetchIssuesByLabel fetches every issue with a label, plus the issues
+	// they depend on
+	FetchIssuesByLabel(label string) (*pb.Export, error)
+	// FetchIssuesByKey fetches the given issues without the issues they
+	// depend on, leaving out keys that cannot be fetched
+	FetchIssuesByKey(ctx context.Context, keys []string) ([]*pb.Issue, error)
+	// SearchIssues returns the keys of the issues matching a JQL query
+	SearchIssues(jql string) ([]string, error)
+}
+
+// OwnershipFetcher looks up the component leads and watchers a sync records
+// as ownership metadata. It is implemented by Client and mocked by
+// jiratest.OwnershipFetcher.
+type OwnershipFetcher interface {
+	// FetchComponentOwners returns the lead of each component of the
+	// export's projects, by project key and component name
+	FetchComponentOwners(export *pb.Export) (map[string]map[string]string, error)
+	// FetchWatchers returns the watchers of each issue in the export, by key
+	FetchWatchers(ctx context.Context, export *pb.Export) (map[string][]string, error)
+}
+
+// PropertyStore keeps the sync state of issues in Jira issue properties. It
+// is implemented by Client and mocked by jiratest.PropertyStore.
+type PropertyStore interface {
+	// FetchSyncProperties returns the sync state stored on each issue that
+	// has one, by key
+	FetchSyncProperties(ctx context.Context, issueKeys []string) (map[string]SyncProperty, error)
+	// StoreSyncProperties writes the sync state of each issue and returns
+	// how many were written
+	StoreSyncProperties(ctx context.Context, properties map[string]SyncProperty) (int, error)
+}
+
+var (
+	_ Fetcher          = (*Client)(nil)
+	_ Fetcher          = (*CachedClient)(nil)
+	_ Searcher         = (*Client)(nil)
+	_ OwnershipFetcher = (*Client)(nil)
+	_ PropertyStore    = (*Client)(nil)
+)
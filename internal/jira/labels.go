@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SearchIssuesWithLabels returns the keys of the issues carrying any of
+// labels
+func (c *Client) SearchIssuesWithLabels(ctx context.Context, labels []string) ([]string, error) {
+	quoted := make([]string, 0, len(labels))
+	for _, label := range labels {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, strings.ReplaceAll(label, `"`, `\"`)))
+	}
+	return c.searchKeys(ctx, fmt.Sprintf("labels in (%s)", strings.Join(quoted, ", ")))
+}
+
+// RenameLabel replaces a label on an issue, leaving its other labels as
+// they are. This needs permission to edit the issue.
+func (c *Client) RenameLabel(ctx context.Context, issueKey, from, to string) (err error) {
+	update := map[string]any{
+		"update": map[string]any{
+			"labels": []map[string]string{{"remove": from}, {"add": to}},
+		},
+	}
+	data, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode label update: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/issue/%s", c.apiBase(), url.PathEscape(issueKey))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update labels: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
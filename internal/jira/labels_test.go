@@ -0,0 +1,67 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRenameLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/api/2/issue/PROJ-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Update struct {
+				Labels []map[string]string `json:"labels"`
+			} `json:"update"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		labels := body.Update.Labels
+		if len(labels) != 2 || labels[0]["remove"] != "frontend" || labels[1]["add"] != "ui" {
+			t.Errorf("Expected remove frontend and add ui, got %v", labels)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	if err := client.RenameLabel(context.Background(), "PROJ-1", "frontend", "ui"); err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if err := client.RenameLabel(context.Background(), "PROJ-2", "frontend", "ui"); err == nil {
+		t.Error("Expected an error for a missing issue")
+	}
+}
+
+func TestSearchIssuesWithLabels(t *testing.T) {
+	var jql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql = r.URL.Query().Get("jql")
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"issues": []map[string]string{{"key": "PROJ-1"}, {"key": "PROJ-3"}},
+			"total":  2,
+		}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	keys, err := client.SearchIssuesWithLabels(context.Background(), []string{"frontend", `say "hi"`})
+	if err != nil {
+		t.Fatalf("SearchIssuesWithLabels failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "PROJ-1" || keys[1] != "PROJ-3" {
+		t.Errorf("Expected PROJ-1 and PROJ-3, got %v", keys)
+	}
+	if jql != `labels in ("frontend", "say \"hi\"")` {
+		t.Errorf("Unexpected JQL %s", jql)
+	}
+}
@@ -0,0 +1,118 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// Project is a Jira project the user can browse
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// labelPageSize is the number of labels requested per page
+const labelPageSize = 1000
+
+// FetchProjects returns the projects visible to the user, by key
+func (c *Client) FetchProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if err := c.getJSON(ctx, c.apiBase()+"/project", &projects); err != nil {
+		return nil, fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Key < projects[j].Key })
+	return projects, nil
+}
+
+// FetchStatuses returns the names of the statuses used by a project's
+// workflows, or of every status when projectKey is empty, sorted
+func (c *Client) FetchStatuses(ctx context.Context, projectKey string) ([]string, error) {
+	type status struct {
+		Name string `json:"name"`
+	}
+
+	var statuses []status
+	if projectKey == "" {
+		if err := c.getJSON(ctx, c.apiBase()+"/status", &statuses); err != nil {
+			return nil, fmt.Errorf("failed to fetch statuses: %w", err)
+		}
+	} else {
+		// Statuses are listed per issue type
+		var issueTypes []struct {
+			Statuses []status `json:"statuses"`
+		}
+		apiURL := fmt.Sprintf("%s/project/%s/statuses", c.apiBase(), url.PathEscape(projectKey))
+		if err := c.getJSON(ctx, apiURL, &issueTypes); err != nil {
+			return nil, fmt.Errorf("failed to fetch statuses of %s: %w", projectKey, err)
+		}
+		for _, issueType := range issueTypes {
+			statuses = append(statuses, issueType.Statuses...)
+		}
+	}
+
+	seen := make(map[string]bool, len(statuses))
+	var names []string
+	for _, s := range statuses {
+		if s.Name != "" && !seen[s.Name] {
+			seen[s.Name] = true
+			names = append(names, s.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// FetchLabels returns every label in use, sorted. Jira Server and Data
+// Center have no endpoint for this and return an error.
+func (c *Client) FetchLabels(ctx context.Context) ([]string, error) {
+	var labels []string
+	for startAt := 0; ; {
+		var page struct {
+			Values []string `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		apiURL := fmt.Sprintf("%s/label?startAt=%d&maxResults=%d", c.apiBase(), startAt, labelPageSize)
+		if err := c.getJSON(ctx, apiURL, &page); err != nil {
+			return nil, fmt.Errorf("failed to fetch labels: %w", err)
+		}
+		labels = append(labels, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// FetchAssignableUsers returns the users issues in a project can be
+// assigned to
+func (c *Client) FetchAssignableUsers(ctx context.Context, projectKey string) ([]Participant, error) {
+	params := url.Values{}
+	params.Set("project", projectKey)
+	params.Set("maxResults", "1000")
+	var users []Participant
+	if err := c.getJSON(ctx, c.apiBase()+"/user/assignable/search?"+params.Encode(), &users); err != nil {
+		return nil, fmt.Errorf("failed to fetch assignable users of %s: %w", projectKey, err)
+	}
+	return users, nil
+}
+
+// CountIssues returns the number of issues matching a JQL query, without
+// fetching them
+func (c *Client) CountIssues(ctx context.Context, jql string) (int, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("maxResults", strconv.Itoa(0))
+	params.Set("fields", "key")
+	var result struct {
+		Total int `json:"total"`
+	}
+	if err := c.getJSON(ctx, c.apiBase()+"/search?"+params.Encode(), &result); err != nil {
+		return 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+	return result.Total, nil
+}
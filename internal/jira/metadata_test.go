@@ -0,0 +1,141 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func metadataTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/project":
+			_, _ = w.Write([]byte(`[{"key": "WEB", "name": "Website"}, {"key": "API", "name": "Backend"}]`))
+		case "/rest/api/2/project/API/statuses":
+			_, _ = w.Write([]byte(`[
+				{"name": "Bug", "statuses": [{"name": "To Do"}, {"name": "Done"}]},
+				{"name": "Task", "statuses": [{"name": "In Progress"}, {"name": "To Do"}]}
+			]`))
+		case "/rest/api/2/status":
+			_, _ = w.Write([]byte(`[{"name": "Open"}, {"name": "Closed"}]`))
+		case "/rest/api/2/label":
+			if r.URL.Query().Get("startAt") == "0" {
+				_, _ = w.Write([]byte(`{"values": ["ui", "backend"], "isLast": false}`))
+			} else {
+				_, _ = w.Write([]byte(`{"values": ["api"], "isLast": true}`))
+			}
+		case "/rest/api/2/user/assignable/search":
+			if got := r.URL.Query().Get("project"); got != "API" {
+				t.Errorf("Expected users assignable in API, got %q", got)
+			}
+			_, _ = w.Write([]byte(`[{"accountId": "abc", "displayName": "Jane Doe"}]`))
+		case "/rest/api/2/search":
+			if got := r.URL.Query().Get("maxResults"); got != "0" {
+				t.Errorf("Expected a count without issues, got maxResults=%s", got)
+			}
+			if got := r.URL.Query().Get("jql"); got != `project in ("API")` {
+				t.Errorf("Unexpected JQL %q", got)
+			}
+			_, _ = w.Write([]byte(`{"total": 42, "issues": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchMetadata(t *testing.T) {
+	client := newRetryTestClient(metadataTestServer(t).URL, ClientOptions{})
+	ctx := context.Background()
+
+	projects, err := client.FetchProjects(ctx)
+	if err != nil {
+		t.Fatalf("FetchProjects failed: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Key != "API" || projects[1].Name != "Website" {
+		t.Errorf("Expected projects sorted by key, got %+v", projects)
+	}
+
+	statuses, err := client.FetchStatuses(ctx, "API")
+	if err != nil {
+		t.Fatalf("FetchStatuses failed: %v", err)
+	}
+	if !slices.Equal(statuses, []string{"Done", "In Progress", "To Do"}) {
+		t.Errorf("Expected the project's distinct statuses, got %v", statuses)
+	}
+	statuses, err = client.FetchStatuses(ctx, "")
+	if err != nil {
+		t.Fatalf("FetchStatuses failed: %v", err)
+	}
+	if !slices.Equal(statuses, []string{"Closed", "Open"}) {
+		t.Errorf("Expected every status, got %v", statuses)
+	}
+
+	labels, err := client.FetchLabels(ctx)
+	if err != nil {
+		t.Fatalf("FetchLabels failed: %v", err)
+	}
+	if !slices.Equal(labels, []string{"api", "backend", "ui"}) {
+		t.Errorf("Expected labels from both pages, got %v", labels)
+	}
+
+	users, err := client.FetchAssignableUsers(ctx, "API")
+	if err != nil {
+		t.Fatalf("FetchAssignableUsers failed: %v", err)
+	}
+	if len(users) != 1 || users[0].AccountID != "abc" {
+		t.Errorf("Expected Jane Doe, got %+v", users)
+	}
+
+	total, err := client.CountIssues(ctx, Scope{Projects: []string{"API"}}.JQL())
+	if err != nil {
+		t.Fatalf("CountIssues failed: %v", err)
+	}
+	if total != 42 {
+		t.Errorf("Expected 42 issues, got %d", total)
+	}
+
+	if _, err := client.FetchStatuses(ctx, "NOPE"); err == nil {
+		t.Error("Expected an error for an unknown project")
+	}
+}
+
+func TestScopeJQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope Scope
+		want  string
+	}{
+		{name: "empty", scope: Scope{}, want: ""},
+		{
+			name:  "project only",
+			scope: Scope{Projects: []string{"API"}},
+			want:  `project in ("API")`,
+		},
+		{
+			name: "every field",
+			scope: Scope{
+				Projects:  []string{"API", "WEB"},
+				Statuses:  []string{"To Do", `Say "hi"`},
+				Labels:    []string{"backend"},
+				Assignees: []string{AssigneeCurrentUser, AssigneeUnassigned, "abc"},
+			},
+			want: `project in ("API", "WEB") AND status in ("To Do", "Say \"hi\"") AND labels in ("backend") AND assignee in (currentUser(), EMPTY, "abc")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.JQL(); got != tt.want {
+				t.Errorf("JQL() = %q, want %q", got, tt.want)
+			}
+			if got := tt.scope.Empty(); got != (tt.want == "") {
+				t.Errorf("Empty() = %v for %q", got, tt.want)
+			}
+		})
+	}
+}
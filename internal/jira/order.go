@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// FetchOrder chooses which issues a search returns first, so that a run cut
+// short still lands the most active issues
+type FetchOrder string
+
+const (
+	// FetchOrderQuery keeps the order of the JQL query, Jira's default if it
+	// has no ORDER BY clause
+	FetchOrderQuery FetchOrder = ""
+
+	// FetchOrderUpdated fetches the most recently updated issues first
+	FetchOrderUpdated FetchOrder = "updated"
+
+	// FetchOrderLastViewed fetches the issues the API user viewed most
+	// recently first, useful when syncing with a personal token
+	FetchOrderLastViewed FetchOrder = "last_viewed"
+)
+
+// FetchOrders lists the supported fetch orders other than the query's own
+var FetchOrders = []FetchOrder{FetchOrderUpdated, FetchOrderLastViewed}
+
+// orderFields maps fetch orders to the JQL fields they sort on
+var orderFields = map[FetchOrder]string{
+	FetchOrderUpdated:    "updated",
+	FetchOrderLastViewed: "lastViewed",
+}
+
+// orderByClause matches an ORDER BY clause
+var orderByClause = regexp.MustCompile(`(?i)(^|\s)order\s+by\s`)
+
+// OrderedJQL sorts a query's results by the fetch order, most active first.
+// Queries with their own ORDER BY clause are left as they are.
+func OrderedJQL(jql string, order FetchOrder) string {
+	field, ok := orderFields[order]
+	if !ok || orderByClause.MatchString(jql) {
+		return jql
+	}
+	if strings.TrimSpace(jql) == "" {
+		return fmt.Sprintf("ORDER BY %s DESC", field)
+	}
+	return fmt.Sprintf("%s ORDER BY %s DESC", strings.TrimSpace(jql), field)
+}
+
+// SetFetchOrder changes the order later searches return issues in, e.g. to
+// only use an activity order for incremental runs
+func (c *Client) SetFetchOrder(order FetchOrder) {
+	c.fetchOrder = order
+}
+
+// SortByKey sorts issues by project key and issue number, so issues fetched
+// in activity order are still written in the same order on every run
+func SortByKey(issues []*pb.Issue) {
+	number := func(key string) int {
+		n, _ := strconv.Atoi(key[strings.LastIndex(key, "-")+1:])
+		return n
+	}
+	slices.SortStableFunc(issues, func(a, b *pb.Issue) int {
+		return cmp.Or(
+			cmp.Compare(ProjectKey(a.Key), ProjectKey(b.Key)),
+			cmp.Compare(number(a.Key), number(b.Key)),
+			cmp.Compare(a.Key, b.Key),
+		)
+	})
+}
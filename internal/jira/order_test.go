@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestOrderedJQL(t *testing.T) {
+	tests := []struct {
+		jql   string
+		order FetchOrder
+		want  string
+	}{
+		{"project = PROJ", FetchOrderQuery, "project = PROJ"},
+		{"project = PROJ", FetchOrderUpdated, "project = PROJ ORDER BY updated DESC"},
+		{"project = PROJ ", FetchOrderLastViewed, "project = PROJ ORDER BY lastViewed DESC"},
+		{"project = PROJ order by rank", FetchOrderUpdated, "project = PROJ order by rank"},
+		{"", FetchOrderUpdated, "ORDER BY updated DESC"},
+		{"project = PROJ", FetchOrder("priority"), "project = PROJ"},
+	}
+
+	for _, tt := range tests {
+		if got := OrderedJQL(tt.jql, tt.order); got != tt.want {
+			t.Errorf("OrderedJQL(%q, %q) = %q, want %q", tt.jql, tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestFetchByJQLFetchOrder(t *testing.T) {
+	// The second page repeats PROJ-2, as if PROJ-3 was updated between the
+	// page requests and moved to the front
+	pages := map[int][]string{
+		0: {"PROJ-3", "PROJ-2"},
+		2: {"PROJ-2", "PROJ-1"},
+	}
+
+	var jqls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jqls = append(jqls, r.URL.Query().Get("jql"))
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+
+		var issues []map[string]interface{}
+		for _, key := range pages[startAt] {
+			issues = append(issues, createMinimalIssue(key, "Issue "+key))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"startAt": startAt, "total": 4, "issues": issues})
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(server.URL, "user@example.com", "token123", "basic", ClientOptions{FetchOrder: FetchOrderUpdated})
+	export, err := client.FetchByJQL("project = PROJ")
+	if err != nil {
+		t.Fatalf("FetchByJQL failed: %v", err)
+	}
+
+	for _, jql := range jqls {
+		if jql != "project = PROJ ORDER BY updated DESC" {
+			t.Errorf("Expected the query to be ordered by activity, got %q", jql)
+		}
+	}
+
+	var keys []string
+	for _, issue := range export.Issues {
+		keys = append(keys, issue.Key)
+	}
+	if len(keys) != 3 || keys[0] != "PROJ-3" || keys[1] != "PROJ-2" || keys[2] != "PROJ-1" {
+		t.Errorf("Expected PROJ-3, PROJ-2, PROJ-1 once each, got %v", keys)
+	}
+}
+
+func TestSortByKey(t *testing.T) {
+	var issues []*pb.Issue
+	for _, key := range []string{"PROJ-10", "OPS-3", "PROJ-9", "PROJ-100"} {
+		issues = append(issues, &pb.Issue{Key: key})
+	}
+
+	SortByKey(issues)
+
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if got := strings.Join(keys, ","); got != "OPS-3,PROJ-9,PROJ-10,PROJ-100" {
+		t.Errorf("Expected issues sorted by project and number, got %s", got)
+	}
+}
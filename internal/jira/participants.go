@@ -0,0 +1,186 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Participant is a user in a user list field such as the request
+// participants of Jira Service Management
+type Participant struct {
+	AccountID    string `json:"accountId,omitempty"`
+	Name         string `json:"name,omitempty"` // Username on Jira Server and Data Center
+	DisplayName  string `json:"displayName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// matches reports whether cc, an email address or name, refers to p
+func (p Participant) matches(cc string) bool {
+	for _, id := range []string{p.EmailAddress, p.DisplayName, p.Name, p.AccountID} {
+		if id != "" && strings.EqualFold(id, cc) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingParticipants returns the CCs, in order, that refer to none of the
+// participants
+func MissingParticipants(participants []Participant, ccs []string) []string {
+	var missing []string
+	for _, cc := range ccs {
+		found := false
+		for _, p := range participants {
+			if p.matches(cc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, cc)
+		}
+	}
+	return missing
+}
+
+// FetchParticipants returns the users in a user list field of an issue
+func (c *Client) FetchParticipants(ctx context.Context, issueKey, field string) ([]Participant, error) {
+	apiURL := fmt.Sprintf("%s/issue/%s?fields=%s", c.apiBase(), url.PathEscape(issueKey), url.QueryEscape(field))
+	var issue struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := c.getJSON(ctx, apiURL, &issue); err != nil {
+		return nil, fmt.Errorf("failed to fetch participants of %s: %w", issueKey, err)
+	}
+
+	raw := bytes.TrimSpace(issue.Fields[field])
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var participants []Participant
+	if err := json.Unmarshal(raw, &participants); err != nil {
+		return nil, fmt.Errorf("field %s of %s is not a user list: %w", field, issueKey, err)
+	}
+	return participants, nil
+}
+
+// AddParticipants appends the CCs that aren't participants yet to a user
+// list field of an issue, leaving the existing participants as they are.
+// Each CC is looked up as a Jira user by email address or name; CCs that
+// match no single user are returned as unknown. This needs permission to
+// edit the issue.
+func (c *Client) AddParticipants(ctx context.Context, issueKey, field string, ccs []string) (added, unknown []string, err error) {
+	participants, err := c.FetchParticipants(ctx, issueKey, field)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var adds []map[string]map[string]string
+	for _, cc := range MissingParticipants(participants, ccs) {
+		user, found, err := c.findUser(ctx, cc)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			unknown = append(unknown, cc)
+			continue
+		}
+		ref := map[string]string{"accountId": user.AccountID}
+		if user.AccountID == "" {
+			ref = map[string]string{"name": user.Name}
+		}
+		adds = append(adds, map[string]map[string]string{"add": ref})
+		added = append(added, cc)
+	}
+	if len(adds) == 0 {
+		return nil, unknown, nil
+	}
+
+	data, err := json.Marshal(map[string]any{"update": map[string]any{field: adds}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode participant update: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/issue/%s", c.apiBase(), url.PathEscape(issueKey))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update participants: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, nil, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return added, unknown, nil
+}
+
+// findUser looks up the user an email address or name refers to. The
+// search matches prefixes and parts of names, so a result only counts when
+// it is the single user matching cc exactly.
+func (c *Client) findUser(ctx context.Context, cc string) (Participant, bool, error) {
+	apiURL := fmt.Sprintf("%s/user/search?query=%s", c.apiBase(), url.QueryEscape(cc))
+	var users []Participant
+	if err := c.getJSON(ctx, apiURL, &users); err != nil {
+		return Participant{}, false, fmt.Errorf("failed to look up user %q: %w", cc, err)
+	}
+
+	var exact []Participant
+	for _, user := range users {
+		if user.matches(cc) {
+			exact = append(exact, user)
+		}
+	}
+	if len(exact) != 1 {
+		return Participant{}, false, nil
+	}
+	return exact[0], true, nil
+}
+
+// getJSON fetches apiURL and decodes the JSON response into value
+func (c *Client) getJSON(ctx context.Context, apiURL string, value any) (err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
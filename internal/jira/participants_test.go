@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestMissingParticipants(t *testing.T) {
+	participants := []Participant{
+		{AccountID: "abc", DisplayName: "Jane Doe", EmailAddress: "jane@example.com"},
+		{Name: "jsmith", DisplayName: "John Smith"},
+	}
+	got := MissingParticipants(participants, []string{"JANE@example.com", "jsmith", "John Smith", "ops@example.com"})
+	if !slices.Equal(got, []string{"ops@example.com"}) {
+		t.Errorf("Expected only ops@example.com to be missing, got %v", got)
+	}
+}
+
+func TestAddParticipants(t *testing.T) {
+	var update map[string]map[string][]map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/api/2/issue/SUP-1":
+			if got := r.URL.Query().Get("fields"); got != "customfield_10026" {
+				t.Errorf("Expected only the participants field to be requested, got %q", got)
+			}
+			_, _ = w.Write([]byte(`{"fields": {"customfield_10026": [{"accountId": "abc", "emailAddress": "jane@example.com"}]}}`))
+		case r.Method == "GET" && r.URL.Path == "/rest/api/2/user/search":
+			switch r.URL.Query().Get("query") {
+			case "ops@example.com":
+				_, _ = w.Write([]byte(`[{"accountId": "ops", "emailAddress": "ops@example.com"}]`))
+			case "Sam":
+				_, _ = w.Write([]byte(`[{"accountId": "s1", "displayName": "Sam Lee"}, {"accountId": "s2", "displayName": "Sam Park"}]`))
+			case "Pat":
+				// The search matches prefixes, so a single result isn't enough
+				_, _ = w.Write([]byte(`[{"accountId": "p1", "displayName": "Patricia Jones"}]`))
+			default:
+				_, _ = w.Write([]byte(`[]`))
+			}
+		case r.Method == "PUT" && r.URL.Path == "/rest/api/2/issue/SUP-1":
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	added, unknown, err := client.AddParticipants(context.Background(), "SUP-1", "customfield_10026",
+		[]string{"jane@example.com", "ops@example.com", "Sam", "Pat"})
+	if err != nil {
+		t.Fatalf("AddParticipants failed: %v", err)
+	}
+	if !slices.Equal(added, []string{"ops@example.com"}) {
+		t.Errorf("Expected ops@example.com to be added, got %v", added)
+	}
+	if !slices.Equal(unknown, []string{"Sam", "Pat"}) {
+		t.Errorf("Expected the ambiguous Sam and the inexact Pat to be unknown, got %v", unknown)
+	}
+
+	ops := update["update"]["customfield_10026"]
+	if len(ops) != 1 || ops[0]["add"]["accountId"] != "ops" {
+		t.Errorf("Expected a single add of account ops, got %v", update)
+	}
+}
+
+func TestAddParticipantsNothingNew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no update, got %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"fields": {"customfield_10026": [{"name": "jsmith", "displayName": "John Smith"}]}}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	added, unknown, err := client.AddParticipants(context.Background(), "SUP-1", "customfield_10026", []string{"jsmith"})
+	if err != nil || len(added) != 0 || len(unknown) != 0 {
+		t.Errorf("Expected nothing to do, got added %v, unknown %v, err %v", added, unknown, err)
+	}
+}
@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// DefaultMaxConcurrency is the number of issues fetched in parallel when
+// ClientOptions doesn't say otherwise. Jira Cloud rate limits per user, so
+// more workers mostly trade throughput for 429s.
+const DefaultMaxConcurrency = 4
+
+// fetchFunc fetches a single issue
+type fetchFunc func(ctx context.Context, issueKey string) (*pb.Issue, error)
+
+// forEach calls fn for every index in [0, n), running at most workers calls
+// at a time, and returns once all of them have finished
+func forEach(n, workers int, fn func(i int)) {
+	workers = max(min(workers, n), 1)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// fetchIssues fetches issues concurrently. Issues and errors are returned
+// in key order, so results do not depend on scheduling.
+func fetchIssues(ctx context.Context, keys []string, fetch fetchFunc, workers int) ([]*pb.Issue, []error) {
+	issues := make([]*pb.Issue, len(keys))
+	errs := make([]error, len(keys))
+
+	forEach(len(keys), workers, func(i int) {
+		issues[i], errs[i] = fetch(ctx, keys[i])
+	})
+
+	return issues, errs
+}
+
+// walkDependencies fetches the root issues and everything related to them
+// (subtasks, links and non-epic parents) level by level, fetching each
+// level concurrently. Issues are returned in breadth-first order.
+func walkDependencies(ctx context.Context, roots []string, fetch fetchFunc, workers int) ([]*pb.Issue, error) {
+	visited := make(map[string]bool)
+	issues := make([]*pb.Issue, 0)
+
+	var level []string
+	for _, key := range roots {
+		if !visited[key] {
+			visited[key] = true
+			level = append(level, key)
+		}
+	}
+
+	for len(level) > 0 {
+		for _, key := range level {
+			fmt.Printf("Fetching %s...\n", key)
+			visited[key] = true
+		}
+
+		fetched, errs := fetchIssues(ctx, level, fetch, workers)
+		for i, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s: %w", level[i], err)
+			}
+		}
+
+		issues = append(issues, fetched...)
+		level = relatedKeys(fetched, visited)
+	}
+
+	return issues, nil
+}
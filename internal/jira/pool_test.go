@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+func TestForEachBoundsConcurrency(t *testing.T) {
+	const workers = 3
+
+	var active, peak int32
+	var mu sync.Mutex
+	called := make(map[int]bool)
+
+	forEach(20, workers, func(i int) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+
+		mu.Lock()
+		called[i] = true
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+	})
+
+	if len(called) != 20 {
+		t.Errorf("Expected 20 calls, got %d", len(called))
+	}
+	if peak > workers {
+		t.Errorf("Expected at most %d concurrent calls, got %d", workers, peak)
+	}
+}
+
+// graphFetch serves issues from an in-memory dependency graph of subtasks
+func graphFetch(graph map[string][]string, fail string) fetchFunc {
+	return func(ctx context.Context, key string) (*pb.Issue, error) {
+		if key == fail {
+			return nil, fmt.Errorf("jira API returned status 404")
+		}
+		issue := &pb.Issue{Key: key, Fields: &pb.Fields{}}
+		for _, child := range graph[key] {
+			issue.Fields.Subtasks = append(issue.Fields.Subtasks, &pb.Subtask{Key: child})
+		}
+		return issue, nil
+	}
+}
+
+func TestWalkDependencies(t *testing.T) {
+	graph := map[string][]string{
+		"PROJ-1": {"PROJ-2", "PROJ-3"},
+		"PROJ-2": {"PROJ-4", "PROJ-1"},
+		"PROJ-3": {"PROJ-4", "PROJ-5"},
+		"PROJ-6": {"PROJ-3"},
+	}
+
+	issues, err := walkDependencies(context.Background(), []string{"PROJ-1", "PROJ-6", "PROJ-1"}, graphFetch(graph, ""), 4)
+	if err != nil {
+		t.Fatalf("walkDependencies failed: %v", err)
+	}
+
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	want := "PROJ-1,PROJ-6,PROJ-2,PROJ-3,PROJ-4,PROJ-5"
+	if got := strings.Join(keys, ","); got != want {
+		t.Errorf("Expected breadth-first order %s, got %s", want, got)
+	}
+}
+
+func TestWalkDependenciesReportsFailure(t *testing.T) {
+	graph := map[string][]string{"PROJ-1": {"PROJ-2", "PROJ-3"}}
+
+	_, err := walkDependencies(context.Background(), []string{"PROJ-1"}, graphFetch(graph, "PROJ-3"), 4)
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch PROJ-3") {
+		t.Errorf("Expected failure for PROJ-3, got: %v", err)
+	}
+}
+
+func TestWalkDependenciesFetchesLevelsConcurrently(t *testing.T) {
+	graph := map[string][]string{"PROJ-1": {"PROJ-2", "PROJ-3", "PROJ-4"}}
+
+	// Each child waits until all three are in flight, so the walk only
+	// finishes if the level is fetched in parallel
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	fetch := graphFetch(graph, "")
+	concurrent := func(ctx context.Context, key string) (*pb.Issue, error) {
+		if key != "PROJ-1" {
+			arrived.Done()
+			arrived.Wait()
+		}
+		return fetch(ctx, key)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := walkDependencies(context.Background(), []string{"PROJ-1"}, concurrent, 3)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("walkDependencies failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected subtasks to be fetched concurrently")
+	}
+}
@@ -0,0 +1,50 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PresetDueSoon selects unresolved issues due within a number of days
+// (including overdue ones), assigned to the configured team
+const PresetDueSoon = "due-soon"
+
+// DefaultDueSoonDays is the horizon used by the due-soon preset
+const DefaultDueSoonDays = 7
+
+// Presets lists the built-in sync presets
+var Presets = []string{PresetDueSoon}
+
+// PresetOptions parameterises preset queries
+type PresetOptions struct {
+	Days      int      // Horizon in days, must be positive
+	Assignees []string // Team members; the current user when empty
+}
+
+// PresetJQL translates a built-in preset into a JQL query
+func PresetJQL(name string, opts PresetOptions) (string, error) {
+	switch name {
+	case PresetDueSoon:
+		if opts.Days <= 0 {
+			return "", fmt.Errorf("preset %s requires a positive number of days, got: %d", name, opts.Days)
+		}
+
+		return fmt.Sprintf("resolution = EMPTY AND duedate <= %dd AND %s ORDER BY duedate ASC",
+			opts.Days, assigneeClause(opts.Assignees)), nil
+	default:
+		return "", fmt.Errorf("unknown preset %q (supported: %s)", name, strings.Join(Presets, ", "))
+	}
+}
+
+// assigneeClause restricts a query to the given assignees, or the current user
+func assigneeClause(assignees []string) string {
+	if len(assignees) == 0 {
+		return "assignee = currentUser()"
+	}
+
+	quoted := make([]string, 0, len(assignees))
+	for _, assignee := range assignees {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, strings.ReplaceAll(assignee, `"`, `\"`)))
+	}
+	return fmt.Sprintf("assignee in (%s)", strings.Join(quoted, ", "))
+}
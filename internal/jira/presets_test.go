@@ -0,0 +1,55 @@
+package jira
+
+import "testing"
+
+func TestPresetJQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  string
+		opts    PresetOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "due soon defaults to current user",
+			preset: PresetDueSoon,
+			opts:   PresetOptions{Days: DefaultDueSoonDays},
+			want:   "resolution = EMPTY AND duedate <= 7d AND assignee = currentUser() ORDER BY duedate ASC",
+		},
+		{
+			name:   "due soon for team",
+			preset: PresetDueSoon,
+			opts:   PresetOptions{Days: 3, Assignees: []string{"alice@example.com", `bob "b"`}},
+			want:   `resolution = EMPTY AND duedate <= 3d AND assignee in ("alice@example.com", "bob \"b\"") ORDER BY duedate ASC`,
+		},
+		{
+			name:    "zero days",
+			preset:  PresetDueSoon,
+			opts:    PresetOptions{Days: 0},
+			wantErr: true,
+		},
+		{
+			name:    "negative days",
+			preset:  PresetDueSoon,
+			opts:    PresetOptions{Days: -1},
+			wantErr: true,
+		},
+		{
+			name:    "unknown preset",
+			preset:  "overdue-forever",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PresetJQL(tt.preset, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PresetJQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Expected JQL %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
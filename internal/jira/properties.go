@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SyncPropertyKey is the issue property sync bookkeeping is stored under
+const SyncPropertyKey = "jira-beads-sync"
+
+// SyncProperty is the sync bookkeeping stored on an issue, so it lives
+// alongside the issue and can be restored when the local sync state is lost
+type SyncProperty struct {
+	BeadsID string    `json:"beadsId"`
+	Hash    string    `json:"hash"`    // Hash of the rendered beads record
+	Updated time.Time `json:"updated"` // Jira updated time of the synced version
+}
+
+// GetIssueProperty decodes the value of an issue property into value and
+// reports whether the property exists
+func (c *Client) GetIssueProperty(ctx context.Context, issueKey, propertyKey string, value any) (found bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.propertyURL(issueKey, propertyKey), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch issue property: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return false, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var property struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&property); err != nil {
+		return false, fmt.Errorf("failed to parse issue property: %w", err)
+	}
+	if err := json.Unmarshal(property.Value, value); err != nil {
+		return false, fmt.Errorf("failed to parse issue property %s: %w", propertyKey, err)
+	}
+	return true, nil
+}
+
+// SetIssueProperty stores value as JSON in an issue property, creating or
+// replacing it. This needs permission to edit the issue.
+func (c *Client) SetIssueProperty(ctx context.Context, issueKey, propertyKey string, value any) (err error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue property: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.propertyURL(issueKey, propertyKey), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to store issue property: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// FetchSyncProperties fetches the sync property of each issue concurrently.
+// Issues without the property are left out of the result; issues whose
+// property couldn't be fetched are reported in the error.
+func (c *Client) FetchSyncProperties(ctx context.Context, issueKeys []string) (map[string]SyncProperty, error) {
+	properties := make(map[string]SyncProperty, len(issueKeys))
+	errs := make([]error, len(issueKeys))
+	var mu sync.Mutex
+
+	forEach(len(issueKeys), c.maxConcurrency, func(i int) {
+		var property SyncProperty
+		found, err := c.GetIssueProperty(ctx, issueKeys[i], SyncPropertyKey, &property)
+		if err != nil {
+			errs[i] = fmt.Errorf("%s: %w", issueKeys[i], err)
+			return
+		}
+		if found {
+			mu.Lock()
+			properties[issueKeys[i]] = property
+			mu.Unlock()
+		}
+	})
+
+	return properties, summarizeErrors(errs)
+}
+
+// StoreSyncProperties writes the sync property of each issue concurrently
+// and returns how many were stored. Failed issues are reported in the error.
+func (c *Client) StoreSyncProperties(ctx context.Context, properties map[string]SyncProperty) (int, error) {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	errs := make([]error, len(keys))
+
+	forEach(len(keys), c.maxConcurrency, func(i int) {
+		if err := c.SetIssueProperty(ctx, keys[i], SyncPropertyKey, properties[keys[i]]); err != nil {
+			errs[i] = fmt.Errorf("%s: %w", keys[i], err)
+		}
+	})
+
+	err := summarizeErrors(errs)
+	var failed *propertyErrors
+	if errors.As(err, &failed) {
+		return len(keys) - failed.count, err
+	}
+	return len(keys), nil
+}
+
+// propertyErrors summarizes per-issue failures by the first one, since a
+// restricted account typically fails the same way for every issue
+type propertyErrors struct {
+	count int
+	first error
+}
+
+func (e *propertyErrors) Error() string {
+	if e.count == 1 {
+		return e.first.Error()
+	}
+	return fmt.Sprintf("%d issues failed, first: %v", e.count, e.first)
+}
+
+func (e *propertyErrors) Unwrap() error {
+	return e.first
+}
+
+// summarizeErrors returns nil if every error is nil
+func summarizeErrors(errs []error) error {
+	var summary *propertyErrors
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if summary == nil {
+			summary = &propertyErrors{first: err}
+		}
+		summary.count++
+	}
+	if summary == nil {
+		return nil
+	}
+	return summary
+}
+
+func (c *Client) propertyURL(issueKey, propertyKey string) string {
+	return fmt.Sprintf("%s/issue/%s/properties/%s", c.apiBase(), url.PathEscape(issueKey), url.PathEscape(propertyKey))
+}
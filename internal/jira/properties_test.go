@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncProperties(t *testing.T) {
+	var mu sync.Mutex
+	stored := map[string]string{
+		"PROJ-1": `{"beadsId":"proj-1","hash":"abc","updated":"2024-05-01T12:00:00Z"}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, ok := strings.CutPrefix(r.URL.Path, "/rest/api/2/issue/")
+		key, ok2 := strings.CutSuffix(key, "/properties/"+SyncPropertyKey)
+		if !ok || !ok2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case "GET":
+			value, found := stored[key]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"key":"` + SyncPropertyKey + `","value":` + value + `}`))
+		case "PUT":
+			if key == "PROJ-3" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			if !json.Valid(body) {
+				t.Errorf("Expected a JSON body, got %q", body)
+			}
+			_, existed := stored[key]
+			stored[key] = string(body)
+			if existed {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusCreated)
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	ctx := context.Background()
+
+	properties, err := client.FetchSyncProperties(ctx, []string{"PROJ-1", "PROJ-2"})
+	if err != nil {
+		t.Fatalf("FetchSyncProperties failed: %v", err)
+	}
+	if len(properties) != 1 {
+		t.Fatalf("Expected only PROJ-1 to have a property, got %v", properties)
+	}
+	want := SyncProperty{BeadsID: "proj-1", Hash: "abc", Updated: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	if got := properties["PROJ-1"]; got.BeadsID != want.BeadsID || got.Hash != want.Hash || !got.Updated.Equal(want.Updated) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	count, err := client.StoreSyncProperties(ctx, map[string]SyncProperty{
+		"PROJ-1": {BeadsID: "proj-1", Hash: "def"},
+		"PROJ-2": {BeadsID: "proj-2", Hash: "ghi"},
+		"PROJ-3": {BeadsID: "proj-3", Hash: "jkl"},
+	})
+	if count != 2 {
+		t.Errorf("Expected 2 properties stored, got %d", count)
+	}
+	if err == nil || !strings.Contains(err.Error(), "PROJ-3") || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected the forbidden issue to be reported, got %v", err)
+	}
+
+	var property SyncProperty
+	found, err := client.GetIssueProperty(ctx, "PROJ-2", SyncPropertyKey, &property)
+	if err != nil || !found {
+		t.Fatalf("Expected the stored property, got found=%v err=%v", found, err)
+	}
+	if property.Hash != "ghi" {
+		t.Errorf("Expected hash ghi, got %s", property.Hash)
+	}
+}
+
+func TestSummarizeErrors(t *testing.T) {
+	if err := summarizeErrors([]error{nil, nil}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	first := io.ErrUnexpectedEOF
+	err := summarizeErrors([]error{nil, first, io.EOF})
+	if err == nil || !strings.Contains(err.Error(), "2 issues failed") {
+		t.Errorf("Expected a summary of 2 failures, got %v", err)
+	}
+	if !errors.Is(err, first) {
+		t.Errorf("Expected the summary to wrap the first error")
+	}
+}
+
+func TestSetIssuePropertyRetriesWithBody(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"hash":"abc"}` {
+			t.Errorf("Expected the property body on every attempt, got %q", body)
+		}
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	if err := client.SetIssueProperty(context.Background(), "PROJ-1", SyncPropertyKey, map[string]string{"hash": "abc"}); err != nil {
+		t.Fatalf("SetIssueProperty failed: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
@@ -0,0 +1,173 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how often a rate-limited or transiently failing
+	// request is retried when ClientOptions doesn't say otherwise
+	DefaultMaxRetries = 4
+
+	// defaultRetryBaseDelay is the backoff before the first retry; each
+	// further retry doubles it
+	defaultRetryBaseDelay = 500 * time.Millisecond
+
+	// maxRetryDelay caps the exponential backoff. Retry-After is honoured
+	// even when longer.
+	maxRetryDelay = 30 * time.Second
+)
+
+// send sends a request, retrying rate-limited (429) and transiently failing
+// (502, 503, 504) responses as well as network errors. The wait follows
+// Jira's Retry-After header when present and exponential backoff with
+// jitter otherwise. A 429 pauses every request sent through the client, so
+// concurrent workers back off together instead of each hitting the limit.
+// The final response is returned as is, so callers report the status.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.throttle.wait(ctx, c.jitter(c.retryBaseDelay)); err != nil {
+			return nil, err
+		}
+		if attempt > 0 && req.GetBody != nil {
+			// The previous attempt consumed the request body
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		if attempt >= c.maxRetries || (err == nil && !retryableStatus(resp.StatusCode)) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		rateLimited := false
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+			}
+			rateLimited = resp.StatusCode == http.StatusTooManyRequests
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+
+		if rateLimited {
+			fmt.Printf("⚠ Rate limited by Jira, retrying in %s\n", delay.Round(time.Second))
+			c.throttle.pauseFor(delay)
+			continue
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before retry attempt+1: the base delay doubled
+// per attempt, capped at maxRetryDelay, with the upper half randomised so
+// concurrent clients spread out
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := maxRetryDelay
+	if attempt < 16 {
+		delay = min(c.retryBaseDelay<<attempt, maxRetryDelay)
+	}
+	return delay/2 + c.jitter(delay/2)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// randomJitter returns a random duration in [0, d)
+func randomJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttle holds back every request while Jira is rate limiting the client
+type throttle struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// pauseFor holds back requests for d, unless a longer pause is in effect
+func (t *throttle) pauseFor(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(t.until) {
+		t.until = until
+	}
+}
+
+// wait blocks until no pause is in effect. Requests that had to wait are
+// delayed by a further jitter so they don't resume all at once.
+func (t *throttle) wait(ctx context.Context, jitter time.Duration) error {
+	for {
+		t.mu.Lock()
+		remaining := time.Until(t.until)
+		t.mu.Unlock()
+
+		if remaining <= 0 {
+			return ctx.Err()
+		}
+		// Loop in case another rate-limit response extended the pause
+		if err := sleep(ctx, remaining+jitter); err != nil {
+			return err
+		}
+	}
+}
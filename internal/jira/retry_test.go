@@ -0,0 +1,174 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client that retries without real waits
+func newRetryTestClient(url string, opts ClientOptions) *Client {
+	client := NewClientWithOptions(url, "user@example.com", "token123", "basic", opts)
+	client.retryBaseDelay = time.Millisecond
+	return client
+}
+
+func TestFetchIssueRetriesRateLimited(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(createMinimalIssue("PROJ-1", "Eventually"))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	issue, err := client.FetchIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if issue.Key != "PROJ-1" {
+		t.Errorf("Expected PROJ-1, got %s", issue.Key)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestFetchIssueRetryLimits(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		maxRetries   int
+		wantRequests int32
+	}{
+		{"gives up after max retries", http.StatusServiceUnavailable, 2, 3},
+		{"retries disabled", http.StatusServiceUnavailable, -1, 1},
+		{"default retries", http.StatusBadGateway, 0, DefaultMaxRetries + 1},
+		{"client errors are not retried", http.StatusNotFound, 2, 1},
+		{"server errors are not retried", http.StatusInternalServerError, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := newRetryTestClient(server.URL, ClientOptions{MaxRetries: tt.maxRetries})
+			_, err := client.FetchIssue("PROJ-1")
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("status %d", tt.status)) {
+				t.Errorf("Expected status in error, got: %v", err)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("Expected %d request(s), got %d", tt.wantRequests, requests)
+			}
+		})
+	}
+}
+
+func TestFetchIssueContextCancelsRetryWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, ClientOptions{})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.FetchIssueContext(ctx, "PROJ-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to interrupt the Retry-After wait, took %s", elapsed)
+	}
+}
+
+func TestRateLimitPausesOtherRequests(t *testing.T) {
+	client := newRetryTestClient("http://unused", ClientOptions{})
+	client.throttle.pauseFor(50 * time.Millisecond)
+
+	start := time.Now()
+	if err := client.throttle.wait(context.Background(), 0); err != nil {
+		t.Fatalf("wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected requests to wait out the pause, waited %s", elapsed)
+	}
+
+	// A shorter pause doesn't cut a longer one short
+	client.throttle.pauseFor(time.Hour)
+	client.throttle.pauseFor(time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.throttle.wait(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the longer pause to stay in effect, got: %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{"-5", 0, true},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"Mon, 01 Jan 2024 11:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	client := NewClient("https://jira.example.com", "user", "token", "basic")
+
+	client.jitter = func(time.Duration) time.Duration { return 0 }
+	for attempt, want := range []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, time.Second} {
+		if got := client.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+	if got := client.backoff(100); got != maxRetryDelay/2 {
+		t.Errorf("Expected backoff to be capped at %s, got %s", maxRetryDelay/2, got)
+	}
+
+	client.jitter = randomJitter
+	for i := 0; i < 100; i++ {
+		if got := client.backoff(1); got < 500*time.Millisecond || got >= time.Second {
+			t.Fatalf("Expected jittered backoff in [500ms, 1s), got %s", got)
+		}
+	}
+}
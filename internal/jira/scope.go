@@ -0,0 +1,59 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Assignee values that are JQL functions or keywords rather than users
+const (
+	AssigneeCurrentUser = "currentUser()"
+	AssigneeUnassigned  = "EMPTY"
+)
+
+// Scope is a sync scope picked field by field. Empty fields match
+// anything.
+type Scope struct {
+	Projects  []string
+	Statuses  []string
+	Labels    []string
+	Assignees []string // Account IDs, or AssigneeCurrentUser or AssigneeUnassigned
+}
+
+// Empty reports whether the scope selects every issue
+func (s Scope) Empty() bool {
+	return len(s.Projects) == 0 && len(s.Statuses) == 0 && len(s.Labels) == 0 && len(s.Assignees) == 0
+}
+
+// JQL returns the query selecting the scope's issues
+func (s Scope) JQL() string {
+	var clauses []string
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{"project", s.Projects},
+		{"status", s.Statuses},
+		{"labels", s.Labels},
+		{"assignee", s.Assignees},
+	} {
+		if len(field.values) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(field.values))
+		for _, value := range field.values {
+			values = append(values, jqlValue(value))
+		}
+		clauses = append(clauses, fmt.Sprintf("%s in (%s)", field.name, strings.Join(values, ", ")))
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// jqlValue quotes a value for a JQL list, leaving the assignee keywords
+// as they are
+func jqlValue(value string) string {
+	if value == AssigneeCurrentUser || value == AssigneeUnassigned {
+		return value
+	}
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(value, `"`, `\"`))
+}
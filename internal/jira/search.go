@@ -0,0 +1,253 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+)
+
+// SearchFields lists the issue fields requested from /search, limited to
+// the ones the adapter reads so responses stay small
+var SearchFields = []string{
+	"summary", "description", "issuetype", "status", "priority",
+	"assignee", "reporter", "created", "updated", "resolution", "resolutiondate",
+	"labels", "issuelinks", "parent", "epic", "subtasks", "components",
+	"comment", "attachment", "fixVersions",
+}
+
+// ErrNoIssuesFound is returned by FetchByJQL when the query matches nothing
+var ErrNoIssuesFound = errors.New("no issues found matching JQL query")
+
+const (
+	// searchPageSize is the maxResults requested per /search page
+	searchPageSize = 100
+
+	// expandBatchSize is the number of related issue keys fetched per search
+	expandBatchSize = 50
+)
+
+// FetchByJQL fetches every issue matching a JQL query using paginated
+// /search requests. Subtasks, linked issues and non-epic parents outside the
+// result are then fetched in batches of keys, repeating until nothing related
+// is missing. This replaces one request per issue with one per page or batch.
+func (c *Client) FetchByJQL(jql string) (*pb.Export, error) {
+	return c.FetchByJQLContext(context.Background(), jql)
+}
+
+// FetchByJQLContext is FetchByJQL with a context for cancellation. Batches
+// of related issues are fetched up to maxConcurrency at a time.
+func (c *Client) FetchByJQLContext(ctx context.Context, jql string) (*pb.Export, error) {
+	fmt.Printf("Searching with JQL: %s\n", jql)
+
+	issues, err := c.searchAll(ctx, OrderedJQL(jql, c.fetchOrder), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search by JQL: %w", err)
+	}
+
+	if len(issues) == 0 {
+		return nil, ErrNoIssuesFound
+	}
+
+	fmt.Printf("Found %d issue(s) matching query\n", len(issues))
+
+	visited := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		visited[issue.Key] = true
+	}
+
+	pending := relatedKeys(issues, visited)
+	for len(pending) > 0 {
+		fmt.Printf("Fetching %d related issue(s)...\n", len(pending))
+
+		fetched, err := c.FetchIssuesByKey(ctx, pending)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch related issues: %w", err)
+		}
+
+		// Keys that didn't come back (deleted, no permission) stay visited so
+		// they are not requested again
+		for _, key := range pending {
+			visited[key] = true
+		}
+
+		issues = append(issues, fetched...)
+		pending = relatedKeys(fetched, visited)
+	}
+
+	return &pb.Export{Issues: issues}, nil
+}
+
+// FetchIssuesByKey fetches the given issues with batched key searches,
+// without following their related issues. Keys that cannot be fetched
+// (deleted, no permission) are left out of the result.
+func (c *Client) FetchIssuesByKey(ctx context.Context, keys []string) ([]*pb.Issue, error) {
+	var batches [][]string
+	for start := 0; start < len(keys); start += expandBatchSize {
+		batches = append(batches, keys[start:min(start+expandBatchSize, len(keys))])
+	}
+
+	results := make([][]*pb.Issue, len(batches))
+	errs := make([]error, len(batches))
+	forEach(len(batches), c.maxConcurrency, func(i int) {
+		results[i], errs[i] = c.searchAll(ctx, keysJQL(batches[i]), true)
+	})
+
+	var issues []*pb.Issue
+	for i, batch := range results {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch issues by key: %w", errs[i])
+		}
+		issues = append(issues, batch...)
+	}
+	return issues, nil
+}
+
+// searchAll pages through a JQL search and returns every matching issue.
+// With lenient set, Jira is asked to warn about unknown keys rather than fail.
+// When results are sorted by activity, an issue updated mid-search moves to
+// the front and pushes others onto the next page, so repeats are dropped.
+func (c *Client) searchAll(ctx context.Context, jql string, lenient bool) ([]*pb.Issue, error) {
+	var issues []*pb.Issue
+	seen := make(map[string]bool)
+
+	for startAt := 0; ; {
+		page, total, err := c.searchPage(ctx, jql, startAt, lenient)
+		if err != nil {
+			return nil, err
+		}
+
+		startAt += len(page)
+		for _, issue := range page {
+			if !seen[issue.Key] {
+				seen[issue.Key] = true
+				issues = append(issues, issue)
+			}
+		}
+		if len(page) == 0 || startAt >= total {
+			return issues, nil
+		}
+	}
+}
+
+// searchPage fetches one page of search results starting at startAt
+func (c *Client) searchPage(ctx context.Context, jql string, startAt int, lenient bool) (issues []*pb.Issue, total int, err error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(searchPageSize))
+	params.Set("fields", strings.Join(c.searchFields(), ","))
+	if lenient {
+		params.Set("validateQuery", "warn")
+	}
+	apiURL := fmt.Sprintf("%s/search?%s", c.apiBase(), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	c.setAuthHeader(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("jira API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var truncated []*pb.Issue
+	total, err = decodeSearchResponse(resp.Body, func(jsonIssue *jsonIssue) error {
+		issue, err := c.adapter.convertIssue(jsonIssue)
+		if err != nil {
+			return fmt.Errorf("failed to convert issue %s: %w", jsonIssue.Key, err)
+		}
+		issues = append(issues, issue)
+		if commentsTruncated(jsonIssue) {
+			truncated = append(truncated, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	// Fetched after decoding so the search response is not held open mid-stream
+	if err := c.completeComments(ctx, truncated); err != nil {
+		return nil, 0, err
+	}
+
+	return issues, total, nil
+}
+
+// RequestCustomFields adds custom fields (e.g. "customfield_10016") to the
+// fields requested by searches. Single issue fetches return every field.
+func (c *Client) RequestCustomFields(ids ...string) {
+	c.customFields = append(c.customFields, ids...)
+}
+
+// searchFields returns SearchFields plus any requested custom fields
+func (c *Client) searchFields() []string {
+	if len(c.customFields) == 0 {
+		return SearchFields
+	}
+	fields := make([]string, 0, len(SearchFields)+len(c.customFields))
+	fields = append(fields, SearchFields...)
+	return append(fields, c.customFields...)
+}
+
+// relatedKeys returns the keys of subtasks, linked issues and non-epic
+// parents of the given issues that have not been visited, in discovery order
+func relatedKeys(issues []*pb.Issue, visited map[string]bool) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if key != "" && !visited[key] && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, issue := range issues {
+		for _, subtask := range issue.Fields.Subtasks {
+			add(subtask.Key)
+		}
+		for _, link := range issue.Fields.IssueLinks {
+			if link.InwardIssue != nil {
+				add(link.InwardIssue.Key)
+			}
+			if link.OutwardIssue != nil {
+				add(link.OutwardIssue.Key)
+			}
+		}
+		if parent := issue.Fields.Parent; parent != nil && parent.Fields.GetIssueType().GetName() != "Epic" {
+			add(parent.Key)
+		}
+	}
+
+	return keys
+}
+
+// keysJQL builds a JQL query matching the given issue keys
+func keysJQL(keys []string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, key))
+	}
+	return fmt.Sprintf("key in (%s)", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,177 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFetchByJQLPaginatesAndExpands(t *testing.T) {
+	const total = 150
+
+	var mu sync.Mutex
+	var queries []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("Expected path '/rest/api/2/search', got '%s'", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		if query.Get("fields") != strings.Join(SearchFields, ",") {
+			t.Errorf("Expected field selection, got %q", query.Get("fields"))
+		}
+
+		jql := query.Get("jql")
+		mu.Lock()
+		queries = append(queries, jql)
+		mu.Unlock()
+
+		var issues []map[string]interface{}
+		matches := 0
+
+		switch jql {
+		case "project = PROJ":
+			if query.Get("validateQuery") != "" {
+				t.Error("Expected the user query to be validated strictly")
+			}
+			startAt, _ := strconv.Atoi(query.Get("startAt"))
+			maxResults, _ := strconv.Atoi(query.Get("maxResults"))
+			for i := startAt + 1; i <= total && i <= startAt+maxResults; i++ {
+				issue := createMinimalIssue(fmt.Sprintf("PROJ-%d", i), "Project issue")
+				if i == 1 {
+					fields := issue["fields"].(map[string]interface{})
+					fields["issuelinks"] = []map[string]interface{}{{
+						"type":        map[string]interface{}{"inward": "is blocked by"},
+						"inwardIssue": map[string]interface{}{"key": "OTHER-1"},
+					}}
+				}
+				issues = append(issues, issue)
+			}
+			matches = total
+		case `key in ("OTHER-1")`:
+			if query.Get("validateQuery") != "warn" {
+				t.Error("Expected related issue batches to use validateQuery=warn")
+			}
+			issue := createMinimalIssue("OTHER-1", "Blocker")
+			fields := issue["fields"].(map[string]interface{})
+			fields["subtasks"] = []map[string]interface{}{{"key": "OTHER-2"}, {"key": "GONE-1"}}
+			issues = append(issues, issue)
+			matches = 1
+		case `key in ("OTHER-2", "GONE-1")`:
+			issues = append(issues, createMinimalIssue("OTHER-2", "Blocker subtask"))
+			matches = 1
+		default:
+			t.Errorf("Unexpected JQL %q", jql)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": query.Get("startAt"),
+			"issues":  issues,
+			"total":   matches,
+		}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	export, err := client.FetchByJQL("project = PROJ")
+	if err != nil {
+		t.Fatalf("FetchByJQL failed: %v", err)
+	}
+
+	if len(export.Issues) != total+2 {
+		t.Errorf("Expected %d issues, got %d", total+2, len(export.Issues))
+	}
+	if export.Issues[total].Key != "OTHER-1" || export.Issues[total+1].Key != "OTHER-2" {
+		t.Errorf("Expected related issues to follow the search results, got %s, %s",
+			export.Issues[total].Key, export.Issues[total+1].Key)
+	}
+
+	// Two pages for the query, then one batch per level of related issues
+	if len(queries) != 4 {
+		t.Errorf("Expected 4 search requests, got %d: %v", len(queries), queries)
+	}
+}
+
+func TestFetchByJQLNoResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"startAt": 0, "issues": [], "total": 0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	if _, err := client.FetchByJQL("project = EMPTY"); !errors.Is(err, ErrNoIssuesFound) {
+		t.Errorf("Expected ErrNoIssuesFound, got: %v", err)
+	}
+}
+
+func TestFetchByJQLError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages": ["Error in the JQL Query"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	_, err := client.FetchByJQL("project = ")
+	if err == nil {
+		t.Fatal("Expected error for invalid JQL")
+	}
+	if !strings.Contains(err.Error(), "400") {
+		t.Errorf("Expected status code in error, got: %v", err)
+	}
+}
+
+func TestKeysJQL(t *testing.T) {
+	if got := keysJQL([]string{"PROJ-1", "OPS-2"}); got != `key in ("PROJ-1", "OPS-2")` {
+		t.Errorf("Unexpected JQL: %s", got)
+	}
+}
+
+func TestFetchIssuesByKey(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if r.URL.Query().Get("validateQuery") != "warn" {
+			t.Error("Expected key batches to use validateQuery=warn")
+		}
+
+		issue := createMinimalIssue("EPIC-1", "Epic")
+		issue["fields"].(map[string]interface{})["subtasks"] = []map[string]interface{}{{"key": "EPIC-2"}}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 0,
+			"issues":  []map[string]interface{}{issue},
+			"total":   1,
+		}); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token123", "basic")
+	issues, err := client.FetchIssuesByKey(context.Background(), []string{"EPIC-1", "GONE-1"})
+	if err != nil {
+		t.Fatalf("FetchIssuesByKey failed: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Key != "EPIC-1" {
+		t.Errorf("Expected only EPIC-1, got %v", issues)
+	}
+	if len(queries) != 1 || queries[0] != `key in ("EPIC-1", "GONE-1")` {
+		t.Errorf("Expected a single key search without following subtasks, got %v", queries)
+	}
+}
@@ -0,0 +1,138 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// legacySprintID and legacySprintState extract fields from the serialized
+// sprint strings older Jira Server versions return, alongside
+// legacySprintName
+var (
+	legacySprintID    = regexp.MustCompile(`\[.*\bid=(\d+)`)
+	legacySprintState = regexp.MustCompile(`\[.*\bstate=(\w+)`)
+)
+
+// sprintStates are the states a sprint can be in
+var sprintStates = map[string]bool{"active": true, "future": true, "closed": true}
+
+type jsonSprint struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type jsonVersion struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Released    bool   `json:"released"`
+	ReleaseDate string `json:"releaseDate"`
+}
+
+// extractSprints returns the sprints an issue has been in. The agile API
+// returns them in the sprint and closedSprints fields; the platform API in
+// a custom field whose ID differs between instances, recognised by its
+// content. Sprints are listed in the order Jira returns them.
+func extractSprints(fields map[string]json.RawMessage) []jsonSprint {
+	var sprints []jsonSprint
+	seen := make(map[jsonSprint]bool)
+	add := func(found []jsonSprint) {
+		for _, sprint := range found {
+			if !seen[sprint] {
+				seen[sprint] = true
+				sprints = append(sprints, sprint)
+			}
+		}
+	}
+
+	if closed, ok := parseSprints(fields["closedSprints"]); ok {
+		add(closed)
+	}
+	if current, ok := parseSprints(fields["sprint"]); ok {
+		add(current)
+	}
+	if len(sprints) > 0 {
+		return sprints
+	}
+
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		if strings.HasPrefix(id, customFieldPrefix) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if found, ok := parseSprints(fields[id]); ok {
+			add(found)
+			break
+		}
+	}
+	return sprints
+}
+
+// parseSprints recognises a sprint object, an array of them, or an array of
+// legacy serialized sprint strings
+func parseSprints(raw json.RawMessage) ([]jsonSprint, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	switch raw[0] {
+	case '{':
+		sprint, ok := parseSprint(raw)
+		if !ok {
+			return nil, false
+		}
+		return []jsonSprint{sprint}, true
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil || len(items) == 0 {
+			return nil, false
+		}
+		sprints := make([]jsonSprint, 0, len(items))
+		for _, item := range items {
+			sprint, ok := parseSprint(item)
+			if !ok {
+				return nil, false
+			}
+			sprints = append(sprints, sprint)
+		}
+		return sprints, true
+	default:
+		return nil, false
+	}
+}
+
+// parseSprint parses one sprint, requiring a name and a known state
+func parseSprint(raw json.RawMessage) (jsonSprint, bool) {
+	var sprint jsonSprint
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return sprint, false
+		}
+		name := legacySprintName.FindStringSubmatch(s)
+		state := legacySprintState.FindStringSubmatch(s)
+		if name == nil || state == nil {
+			return sprint, false
+		}
+		sprint.Name = name[1]
+		sprint.State = state[1]
+		if id := legacySprintID.FindStringSubmatch(s); id != nil {
+			sprint.ID, _ = strconv.ParseInt(id[1], 10, 64)
+		}
+	} else if err := json.Unmarshal(raw, &sprint); err != nil {
+		return sprint, false
+	}
+
+	sprint.State = strings.ToLower(sprint.State)
+	return sprint, sprint.Name != "" && sprintStates[sprint.State]
+}
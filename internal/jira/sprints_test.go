@@ -0,0 +1,74 @@
+package jira
+
+import (
+	"testing"
+)
+
+func TestAdapterConvertSprintsAndFixVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		want   []string // name/state of each sprint
+	}{
+		{
+			name:   "cloud sprint field",
+			fields: `"customfield_10020": [{"id": 1, "name": "Sprint 1", "state": "closed", "boardId": 4}, {"id": 2, "name": "Sprint 2", "state": "active", "boardId": 4}]`,
+			want:   []string{"Sprint 1/closed", "Sprint 2/active"},
+		},
+		{
+			name:   "legacy sprint field",
+			fields: `"customfield_10010": ["com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=3,rapidViewId=1,state=ACTIVE,name=Sprint 3,startDate=2024-01-01]"]`,
+			want:   []string{"Sprint 3/active"},
+		},
+		{
+			name:   "agile API fields",
+			fields: `"sprint": {"id": 5, "name": "Sprint 5", "state": "future"}, "closedSprints": [{"id": 4, "name": "Sprint 4", "state": "closed"}]`,
+			want:   []string{"Sprint 4/closed", "Sprint 5/future"},
+		},
+		{
+			name:   "other custom fields",
+			fields: `"customfield_10030": [{"value": "iOS"}], "customfield_10031": ["text"]`,
+		},
+		{
+			name:   "no sprint",
+			fields: `"customfield_10020": null, "sprint": null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export, err := NewAdapter().Parse([]byte(`{"issues": [{"id": "1", "key": "PROJ-1", "fields": {
+				"summary": "Test",
+				"issuetype": {"name": "Task"},
+				"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+				"priority": {"name": "Medium"},
+				"fixVersions": [{"id": "10", "name": "2.0", "released": true, "releaseDate": "2024-03-01"}],
+				` + tt.fields + `
+			}}]}`))
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			fields := export.Issues[0].Fields
+			var got []string
+			for _, sprint := range fields.Sprints {
+				got = append(got, sprint.Name+"/"+sprint.State)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected sprints %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected sprint %d to be %s, got %s", i, tt.want[i], got[i])
+				}
+			}
+
+			if len(fields.FixVersions) != 1 {
+				t.Fatalf("Expected 1 fix version, got %d", len(fields.FixVersions))
+			}
+			if version := fields.FixVersions[0]; version.Name != "2.0" || !version.Released || version.ReleaseDate != "2024-03-01" {
+				t.Errorf("Unexpected fix version: %v", version)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// newHTTPClient creates the HTTP client used for Jira API calls.
+// It negotiates HTTP/2 over TLS and advertises gzip so large payloads
+// (descriptions dominate search responses) are compressed in transit;
+// the transport decompresses responses transparently.
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.DisableCompression = false
+
+	return &http.Client{Transport: transport}
+}
+
+// decodeSearchResponse streams a /search response body, calling fn for each
+// issue as it is decoded rather than buffering the whole payload first.
+// It returns the total number of matches reported by Jira.
+func decodeSearchResponse(r io.Reader, fn func(*jsonIssue) error) (int, error) {
+	decoder := json.NewDecoder(r)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, err
+		}
+		field, _ := token.(string)
+
+		switch field {
+		case "total":
+			if err := decoder.Decode(&total); err != nil {
+				return 0, fmt.Errorf("invalid total: %w", err)
+			}
+		case "issues":
+			if err := expectDelim(decoder, '['); err != nil {
+				return 0, err
+			}
+			for decoder.More() {
+				var issue jsonIssue
+				if err := decoder.Decode(&issue); err != nil {
+					return 0, fmt.Errorf("invalid issue: %w", err)
+				}
+				if err := fn(&issue); err != nil {
+					return 0, err
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return 0, err
+			}
+		default:
+			// Skip fields we don't use (startAt, maxResults, expand, ...)
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// expectDelim reads the next token and checks it is the given delimiter
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package jira
+
+import (
+	"compress/gzip"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewHTTPClientNegotiatesHTTP2(t *testing.T) {
+	var proto int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.ProtoMajor
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	httpClient := newHTTPClient()
+	transport := httpClient.Transport.(*http.Transport)
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+	}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if proto != 2 {
+		t.Errorf("Expected HTTP/2, got HTTP/%d", proto)
+	}
+}
+
+func TestClientRequestsGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Expected gzip in Accept-Encoding, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer func() { _ = gz.Close() }()
+		_ = json.NewEncoder(gz).Encode(createMinimalIssue("PROJ-1", "Compressed issue"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "token", "basic")
+	issue, err := client.FetchIssue("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+	if issue.Fields.Summary != "Compressed issue" {
+		t.Errorf("Expected summary 'Compressed issue', got %s", issue.Fields.Summary)
+	}
+}
+
+func TestDecodeSearchResponse(t *testing.T) {
+	body := `{
+		"expand": "schema,names",
+		"startAt": 0,
+		"maxResults": 2,
+		"issues": [
+			{"id": "1", "key": "PROJ-1", "fields": {"summary": "First"}},
+			{"id": "2", "key": "PROJ-2", "fields": {"summary": "Second"}}
+		],
+		"total": 5
+	}`
+
+	var keys []string
+	total, err := decodeSearchResponse(strings.NewReader(body), func(issue *jsonIssue) error {
+		keys = append(keys, issue.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("decodeSearchResponse failed: %v", err)
+	}
+
+	if total != 5 {
+		t.Errorf("Expected total 5, got %d", total)
+	}
+	if len(keys) != 2 || keys[0] != "PROJ-1" || keys[1] != "PROJ-2" {
+		t.Errorf("Expected keys [PROJ-1 PROJ-2], got %v", keys)
+	}
+}
+
+func TestDecodeSearchResponseInvalid(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`{"issues": {}}`,
+		`{"issues": [{"key": 1}]}`,
+		`{"total": "many"}`,
+	}
+
+	for _, body := range tests {
+		_, err := decodeSearchResponse(strings.NewReader(body), func(*jsonIssue) error { return nil })
+		if err == nil {
+			t.Errorf("Expected error for %s", body)
+		}
+	}
+}
@@ -55,11 +55,13 @@ type Fields struct {
 	Reporter    *User       `json:"reporter,omitempty"`
 	Created     JiraTime    `json:"created"`
 	Updated     JiraTime    `json:"updated"`
+	Resolved    JiraTime    `json:"resolutiondate"`
 	Labels      []string    `json:"labels"`
 	IssueLinks  []IssueLink `json:"issuelinks"`
 	Parent      *Parent     `json:"parent,omitempty"`
 	Epic        *Epic       `json:"epic,omitempty"`
 	Subtasks    []Subtask   `json:"subtasks"`
+	Components  []Component `json:"components"`
 }
 
 // IssueType represents the type of a Jira issue
@@ -142,6 +144,12 @@ type Epic struct {
 	Done    bool   `json:"done"`
 }
 
+// Component represents a project component assigned to an issue
+type Component struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // Subtask represents a subtask reference
 type Subtask struct {
 	ID     string       `json:"id"`
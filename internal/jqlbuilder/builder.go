@@ -0,0 +1,236 @@
+// Package jqlbuilder builds a sync scope interactively, offering the
+// projects, statuses, labels and assignees of the Jira instance and showing
+// how many issues match after each choice.
+package jqlbuilder
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// MaxListed is the most choices listed at once; the rest can still be
+// picked by typing them
+const MaxListed = 30
+
+// Metadata is the Jira metadata the builder offers choices from
+type Metadata interface {
+	FetchProjects(ctx context.Context) ([]jira.Project, error)
+	FetchStatuses(ctx context.Context, projectKey string) ([]string, error)
+	FetchLabels(ctx context.Context) ([]string, error)
+	FetchAssignableUsers(ctx context.Context, projectKey string) ([]jira.Participant, error)
+	CountIssues(ctx context.Context, jql string) (int, error)
+}
+
+// choice is a value that can be picked, and how it is shown
+type choice struct {
+	Value string
+	Label string
+}
+
+// Builder asks for a sync scope on in and writes its prompts to out
+type Builder struct {
+	meta Metadata
+	in   *bufio.Reader
+	out  io.Writer
+}
+
+// New creates a builder reading answers from in
+func New(meta Metadata, in io.Reader, out io.Writer) *Builder {
+	return &Builder{meta: meta, in: bufio.NewReader(in), out: out}
+}
+
+// Build asks for the projects, statuses, labels and assignees of the scope
+func (b *Builder) Build(ctx context.Context) (jira.Scope, error) {
+	var scope jira.Scope
+
+	projects, err := b.meta.FetchProjects(ctx)
+	if err != nil {
+		return scope, err
+	}
+	projectChoices := make([]choice, 0, len(projects))
+	for _, project := range projects {
+		projectChoices = append(projectChoices, choice{Value: project.Key, Label: fmt.Sprintf("%s (%s)", project.Key, project.Name)})
+	}
+	if scope.Projects, err = b.pick("Projects", projectChoices); err != nil {
+		return scope, err
+	}
+	if err := b.preview(ctx, scope); err != nil {
+		return scope, err
+	}
+
+	// Statuses are specific to a project's workflows
+	statusProject := ""
+	if len(scope.Projects) == 1 {
+		statusProject = scope.Projects[0]
+	}
+	statuses, err := b.meta.FetchStatuses(ctx, statusProject)
+	if err != nil {
+		return scope, err
+	}
+	if scope.Statuses, err = b.pick("Statuses", plainChoices(statuses)); err != nil {
+		return scope, err
+	}
+	if err := b.preview(ctx, scope); err != nil {
+		return scope, err
+	}
+
+	// Jira Server has no label list, so labels are typed in there
+	labels, err := b.meta.FetchLabels(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(b.out, "Labels can't be listed (%v); type them instead\n", err)
+	}
+	if scope.Labels, err = b.pick("Labels", plainChoices(labels)); err != nil {
+		return scope, err
+	}
+	if err := b.preview(ctx, scope); err != nil {
+		return scope, err
+	}
+
+	assignees, err := b.assigneeChoices(ctx, scope.Projects)
+	if err != nil {
+		return scope, err
+	}
+	if scope.Assignees, err = b.pick("Assignees", assignees); err != nil {
+		return scope, err
+	}
+	if err := b.preview(ctx, scope); err != nil {
+		return scope, err
+	}
+
+	if scope.Empty() {
+		return scope, errors.New("no project, status, label or assignee picked; a sync scope must narrow the search")
+	}
+	return scope, nil
+}
+
+// Confirm asks a yes/no question, defaulting to yes
+func (b *Builder) Confirm(question string) (bool, error) {
+	_, _ = fmt.Fprintf(b.out, "%s [Y/n]: ", question)
+	answer, err := b.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "", "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// assigneeChoices offers the current user, unassigned issues and the users
+// assignable in the picked projects
+func (b *Builder) assigneeChoices(ctx context.Context, projects []string) ([]choice, error) {
+	choices := []choice{
+		{Value: jira.AssigneeCurrentUser, Label: "Me (currentUser())"},
+		{Value: jira.AssigneeUnassigned, Label: "Unassigned"},
+	}
+	seen := make(map[string]bool)
+	for _, project := range projects {
+		users, err := b.meta.FetchAssignableUsers(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			// Jira Cloud identifies users by account ID, Jira Server by name
+			value := user.AccountID
+			if value == "" {
+				value = user.Name
+			}
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			label := user.DisplayName
+			if label == "" {
+				label = value
+			}
+			choices = append(choices, choice{Value: value, Label: label})
+		}
+	}
+	return choices, nil
+}
+
+// pick lists choices and reads the picked ones: numbers from the list or
+// values, separated by commas. Nothing picks everything.
+func (b *Builder) pick(title string, choices []choice) ([]string, error) {
+	_, _ = fmt.Fprintf(b.out, "\n%s\n", title)
+	for i, c := range choices {
+		if i == MaxListed {
+			_, _ = fmt.Fprintf(b.out, "  ... and %d more, which can be typed in\n", len(choices)-MaxListed)
+			break
+		}
+		_, _ = fmt.Fprintf(b.out, "  %2d. %s\n", i+1, c.Label)
+	}
+	_, _ = fmt.Fprint(b.out, "Pick numbers or names, comma-separated (Enter for any): ")
+
+	answer, err := b.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	var picked []string
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		value := field
+		if n, err := strconv.Atoi(field); err == nil {
+			if n < 1 || n > len(choices) {
+				return nil, fmt.Errorf("%s: no choice numbered %d", strings.ToLower(title), n)
+			}
+			value = choices[n-1].Value
+		} else {
+			for _, c := range choices {
+				if strings.EqualFold(c.Value, field) {
+					value = c.Value
+					break
+				}
+			}
+		}
+		picked = append(picked, value)
+	}
+	return picked, nil
+}
+
+// preview shows how many issues the scope picked so far matches
+func (b *Builder) preview(ctx context.Context, scope jira.Scope) error {
+	if scope.Empty() {
+		return nil
+	}
+	total, err := b.meta.CountIssues(ctx, scope.JQL())
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(b.out, "→ %d issues match: %s\n", total, scope.JQL())
+	return nil
+}
+
+// readLine reads an answer, without its line ending
+func (b *Builder) readLine() (string, error) {
+	line, err := b.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", errors.New("input ended before the scope was complete")
+		}
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// plainChoices offers values shown as they are
+func plainChoices(values []string) []choice {
+	choices := make([]choice, 0, len(values))
+	for _, value := range values {
+		choices = append(choices, choice{Value: value, Label: value})
+	}
+	return choices
+}
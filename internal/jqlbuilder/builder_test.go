@@ -0,0 +1,109 @@
+package jqlbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// fakeMetadata serves canned metadata and records the counted queries
+type fakeMetadata struct {
+	labelErr error
+	counted  []string
+}
+
+func (f *fakeMetadata) FetchProjects(context.Context) ([]jira.Project, error) {
+	return []jira.Project{{Key: "API", Name: "Backend"}, {Key: "WEB", Name: "Website"}}, nil
+}
+
+func (f *fakeMetadata) FetchStatuses(_ context.Context, projectKey string) ([]string, error) {
+	if projectKey == "API" {
+		return []string{"Done", "In Progress", "To Do"}, nil
+	}
+	return []string{"Closed", "Open"}, nil
+}
+
+func (f *fakeMetadata) FetchLabels(context.Context) ([]string, error) {
+	if f.labelErr != nil {
+		return nil, f.labelErr
+	}
+	return []string{"backend", "ui"}, nil
+}
+
+func (f *fakeMetadata) FetchAssignableUsers(context.Context, string) ([]jira.Participant, error) {
+	return []jira.Participant{{AccountID: "abc", DisplayName: "Jane Doe"}, {Name: "jsmith"}}, nil
+}
+
+func (f *fakeMetadata) CountIssues(_ context.Context, jql string) (int, error) {
+	f.counted = append(f.counted, jql)
+	return 10 * len(f.counted), nil
+}
+
+func TestBuild(t *testing.T) {
+	meta := &fakeMetadata{}
+	var out bytes.Buffer
+	// API; In Progress and To Do; any label; me and Jane Doe
+	builder := New(meta, strings.NewReader("1\n2, to do\n\n1,3\n"), &out)
+
+	scope, err := builder.Build(context.Background())
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	want := `project in ("API") AND status in ("In Progress", "To Do") AND assignee in (currentUser(), "abc")`
+	if got := scope.JQL(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if len(meta.counted) != 4 || meta.counted[3] != want {
+		t.Errorf("Expected a count after each step, got %v", meta.counted)
+	}
+	for _, shown := range []string{"API (Backend)", "In Progress", "Jane Doe", "jsmith", "→ 40 issues match"} {
+		if !strings.Contains(out.String(), shown) {
+			t.Errorf("Expected %q in the output:\n%s", shown, out.String())
+		}
+	}
+}
+
+func TestBuildTypedLabels(t *testing.T) {
+	meta := &fakeMetadata{labelErr: errors.New("jira API returned status 404")}
+	var out bytes.Buffer
+	builder := New(meta, strings.NewReader("\n\nbackend, team-a\n\ny\n"), &out)
+
+	scope, err := builder.Build(context.Background())
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if got := scope.JQL(); got != `labels in ("backend", "team-a")` {
+		t.Errorf("Expected the typed labels, got %q", got)
+	}
+	if !strings.Contains(out.String(), "type them instead") {
+		t.Errorf("Expected a note that labels must be typed, got:\n%s", out.String())
+	}
+	if ok, err := builder.Confirm("Save?"); err != nil || !ok {
+		t.Errorf("Expected confirmation, got %v, %v", ok, err)
+	}
+}
+
+func TestBuildRejectsEmptyScope(t *testing.T) {
+	builder := New(&fakeMetadata{}, strings.NewReader("\n\n\n\n"), &bytes.Buffer{})
+	if _, err := builder.Build(context.Background()); err == nil {
+		t.Error("Expected an error for a scope matching every issue")
+	}
+}
+
+func TestBuildRejectsUnknownNumber(t *testing.T) {
+	builder := New(&fakeMetadata{}, strings.NewReader("7\n"), &bytes.Buffer{})
+	if _, err := builder.Build(context.Background()); err == nil {
+		t.Error("Expected an error for a number not in the list")
+	}
+}
+
+func TestBuildEndOfInput(t *testing.T) {
+	builder := New(&fakeMetadata{}, strings.NewReader("1\n"), &bytes.Buffer{})
+	if _, err := builder.Build(context.Background()); err == nil {
+		t.Error("Expected an error when input ends early")
+	}
+}
@@ -0,0 +1,234 @@
+package lock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileLock is the content of a lock file
+type fileLock struct {
+	Owner   string    `json:"owner"`
+	Token   uint64    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// fileBackend keeps the lock in a file on storage shared by the runners,
+// e.g. an NFS mount. The lock file is created with link(2), which is atomic
+// on NFS unlike O_EXCL on older servers. Fencing tokens come from a counter
+// in a sibling ".fence" file, only written while holding the lock.
+type fileBackend struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileLocker creates a locker backed by the lock file at path
+func NewFileLocker(path string, opts Options) *Locker {
+	return newLocker(&fileBackend{path: path, now: time.Now}, opts)
+}
+
+func (f *fileBackend) fencePath() string {
+	return f.path + ".fence"
+}
+
+func (f *fileBackend) acquire(ctx context.Context, owner string, ttl time.Duration) (uint64, error) {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	// A stale lock is removed and acquisition retried once
+	for attempt := 0; attempt < 2; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		fence, err := f.readFence()
+		if err != nil {
+			return 0, err
+		}
+		lock := fileLock{Owner: owner, Token: fence + 1, Expires: f.now().Add(ttl)}
+
+		linked, err := f.link(lock)
+		if err != nil {
+			return 0, err
+		}
+		if linked {
+			if err := f.writeFence(lock.Token); err != nil {
+				_ = os.Remove(f.path)
+				return 0, err
+			}
+			return lock.Token, nil
+		}
+
+		current, raw, err := f.read()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if current.Expires.After(f.now()) {
+			return 0, &HeldError{Owner: current.Owner}
+		}
+		if err := f.removeIfUnchanged(raw); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, &HeldError{}
+}
+
+func (f *fileBackend) renew(ctx context.Context, owner string, token uint64, ttl time.Duration) error {
+	current, _, err := f.read()
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrLost
+	}
+	if err != nil {
+		return err
+	}
+	if current.Owner != owner || current.Token != token {
+		return ErrLost
+	}
+
+	current.Expires = f.now().Add(ttl)
+	data, err := json.Marshal(current)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock: %w", err)
+	}
+	return writeFileAtomic(f.path, data)
+}
+
+func (f *fileBackend) release(ctx context.Context, owner string, token uint64) error {
+	current, raw, err := f.read()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current.Owner != owner || current.Token != token {
+		return nil
+	}
+	return f.removeIfUnchanged(raw)
+}
+
+// link writes lock to a temporary file and links it to the lock path,
+// reporting whether the lock was free
+func (f *fileBackend) link(lock fileLock) (bool, error) {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode lock: %w", err)
+	}
+
+	tmp, err := writeTemp(f.path, data)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = os.Remove(tmp) }()
+
+	if err := os.Link(tmp, f.path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	return true, nil
+}
+
+// read returns the current lock and its raw content
+func (f *fileBackend) read() (fileLock, []byte, error) {
+	var lock fileLock
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return lock, nil, err
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		// A corrupt lock file can't be renewed by anyone, so it is
+		// treated as expired
+		return fileLock{}, data, nil
+	}
+	return lock, data, nil
+}
+
+// removeIfUnchanged removes the lock file unless it was rewritten since it
+// was read, e.g. by a runner that already took over a stale lock
+func (f *fileBackend) removeIfUnchanged(raw []byte) error {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read lock file: %w", err)
+	}
+	if !bytes.Equal(data, raw) {
+		return nil
+	}
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
+
+func (f *fileBackend) readFence() (uint64, error) {
+	data, err := os.ReadFile(f.fencePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read fence file: %w", err)
+	}
+	fence, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fence file %s: %w", f.fencePath(), err)
+	}
+	return fence, nil
+}
+
+func (f *fileBackend) writeFence(token uint64) error {
+	return writeFileAtomic(f.fencePath(), []byte(strconv.FormatUint(token, 10)+"\n"))
+}
+
+// writeTemp writes data to a new file next to path and returns its name
+func writeTemp(path string, data []byte) (name string, err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary lock file: %w", err)
+	}
+	defer func() {
+		if cerr := tmp.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write temporary lock file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return "", fmt.Errorf("failed to sync temporary lock file: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// writeFileAtomic replaces path with data via rename, so readers never see
+// a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := writeTemp(path, data)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
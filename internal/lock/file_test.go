@@ -0,0 +1,129 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestFileBackend(t *testing.T) (*fileBackend, *time.Time) {
+	t.Helper()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &fileBackend{
+		path: filepath.Join(t.TempDir(), "locks", "sync.lock"),
+		now:  func() time.Time { return now },
+	}, &now
+}
+
+func TestFileBackendAcquire(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newTestFileBackend(t)
+
+	token, err := f.acquire(ctx, "runner-a", time.Minute)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	if token != 1 {
+		t.Errorf("Expected first token 1, got %d", token)
+	}
+
+	_, err = f.acquire(ctx, "runner-b", time.Minute)
+	var held *HeldError
+	if !errors.As(err, &held) || held.Owner != "runner-a" {
+		t.Fatalf("Expected lock held by runner-a, got %v", err)
+	}
+
+	if err := f.release(ctx, "runner-a", token); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+	if _, err := os.Stat(f.path); !os.IsNotExist(err) {
+		t.Errorf("Expected lock file removed, got %v", err)
+	}
+
+	// Tokens keep increasing across holders
+	token, err = f.acquire(ctx, "runner-b", time.Minute)
+	if err != nil {
+		t.Fatalf("acquire after release failed: %v", err)
+	}
+	if token != 2 {
+		t.Errorf("Expected second token 2, got %d", token)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Expected only the lock and fence files, got %d entries", len(entries))
+	}
+}
+
+func TestFileBackendStaleLock(t *testing.T) {
+	ctx := context.Background()
+	f, now := newTestFileBackend(t)
+
+	stale, err := f.acquire(ctx, "runner-a", time.Minute)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	token, err := f.acquire(ctx, "runner-b", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected stale lock to be taken over, got %v", err)
+	}
+	if token <= stale {
+		t.Errorf("Expected token greater than %d, got %d", stale, token)
+	}
+
+	// The stalled holder has been fenced off
+	if err := f.renew(ctx, "runner-a", stale, time.Minute); !errors.Is(err, ErrLost) {
+		t.Errorf("Expected ErrLost renewing a taken-over lock, got %v", err)
+	}
+	if err := f.release(ctx, "runner-a", stale); err != nil {
+		t.Errorf("Expected releasing a lost lock to succeed, got %v", err)
+	}
+	if err := f.renew(ctx, "runner-b", token, time.Minute); err != nil {
+		t.Errorf("Expected new holder to renew, got %v", err)
+	}
+}
+
+func TestFileBackendRenewExtendsLease(t *testing.T) {
+	ctx := context.Background()
+	f, now := newTestFileBackend(t)
+
+	token, err := f.acquire(ctx, "runner-a", time.Minute)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	*now = now.Add(50 * time.Second)
+	if err := f.renew(ctx, "runner-a", token, time.Minute); err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+
+	*now = now.Add(50 * time.Second)
+	var held *HeldError
+	if _, err := f.acquire(ctx, "runner-b", time.Minute); !errors.As(err, &held) {
+		t.Errorf("Expected renewed lock to still be held, got %v", err)
+	}
+}
+
+func TestFileBackendCorruptLock(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newTestFileBackend(t)
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(f.path, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.acquire(ctx, "runner-a", time.Minute); err != nil {
+		t.Errorf("Expected corrupt lock file to be replaced, got %v", err)
+	}
+}
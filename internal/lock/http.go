@@ -0,0 +1,139 @@
+package lock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpRequest is the body sent to the lock service
+type httpRequest struct {
+	Owner      string `json:"owner"`
+	Token      uint64 `json:"token,omitempty"`
+	TTLSeconds int    `json:"ttl_seconds,omitempty"`
+}
+
+// httpResponse is the body returned by the lock service
+type httpResponse struct {
+	Token uint64 `json:"token"`
+	Owner string `json:"owner,omitempty"` // Current holder when the lock is held
+}
+
+// httpBackend keeps the lock in an HTTP lock service at a single URL:
+//
+//	POST   acquire: {"owner", "ttl_seconds"} → 200 {"token"}, or 409/423 {"owner"} while held
+//	PUT    renew:   {"owner", "token", "ttl_seconds"} → 200, or 404/409/410 once lost
+//	DELETE release: {"owner", "token"} → 2xx, 404 or 409
+//
+// The service is responsible for issuing increasing fencing tokens.
+type httpBackend struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPLocker creates a locker backed by the lock service at url. A
+// non-empty token is sent as a bearer token.
+func NewHTTPLocker(url, token string, opts Options) *Locker {
+	return newLocker(&httpBackend{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, opts)
+}
+
+func (h *httpBackend) acquire(ctx context.Context, owner string, ttl time.Duration) (uint64, error) {
+	status, resp, err := h.send(ctx, http.MethodPost, httpRequest{Owner: owner, TTLSeconds: ttlSeconds(ttl)})
+	if err != nil {
+		return 0, err
+	}
+
+	switch status {
+	case http.StatusOK, http.StatusCreated:
+		if resp.Token == 0 {
+			return 0, fmt.Errorf("lock service returned no fencing token")
+		}
+		return resp.Token, nil
+	case http.StatusConflict, http.StatusLocked:
+		return 0, &HeldError{Owner: resp.Owner}
+	default:
+		return 0, fmt.Errorf("lock service returned status %d", status)
+	}
+}
+
+func (h *httpBackend) renew(ctx context.Context, owner string, token uint64, ttl time.Duration) error {
+	status, _, err := h.send(ctx, http.MethodPut, httpRequest{Owner: owner, Token: token, TTLSeconds: ttlSeconds(ttl)})
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound, http.StatusConflict, http.StatusGone:
+		return ErrLost
+	default:
+		return fmt.Errorf("lock service returned status %d", status)
+	}
+}
+
+func (h *httpBackend) release(ctx context.Context, owner string, token uint64) error {
+	status, _, err := h.send(ctx, http.MethodDelete, httpRequest{Owner: owner, Token: token})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case status >= 200 && status < 300, status == http.StatusNotFound, status == http.StatusConflict:
+		return nil
+	default:
+		return fmt.Errorf("lock service returned status %d", status)
+	}
+}
+
+// send makes a request to the lock service, decoding a JSON response body
+// when there is one
+func (h *httpBackend) send(ctx context.Context, method string, body httpRequest) (int, httpResponse, error) {
+	var result httpResponse
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, result, fmt.Errorf("failed to encode lock request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, h.url, bytes.NewReader(data))
+	if err != nil {
+		return 0, result, fmt.Errorf("failed to create lock request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return 0, result, fmt.Errorf("failed to reach lock service: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return 0, result, fmt.Errorf("failed to read lock service response: %w", err)
+	}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		// Error responses need not be JSON; only the status matters then
+		if err := json.Unmarshal(respBody, &result); err != nil && resp.StatusCode < 300 {
+			return 0, result, fmt.Errorf("failed to parse lock service response: %w", err)
+		}
+	}
+	return resp.StatusCode, result, nil
+}
+
+func ttlSeconds(ttl time.Duration) int {
+	return max(int(ttl.Round(time.Second)/time.Second), 1)
+}
@@ -0,0 +1,135 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLockService is an in-memory lock service implementing the HTTP
+// backend's protocol
+type fakeLockService struct {
+	mu     sync.Mutex
+	owner  string
+	token  uint64
+	fence  uint64
+	bearer string
+}
+
+func (s *fakeLockService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.bearer != "" && r.Header.Get("Authorization") != "Bearer "+s.bearer {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req httpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPost:
+		if s.owner != "" {
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(httpResponse{Owner: s.owner})
+			return
+		}
+		s.fence++
+		s.owner, s.token = req.Owner, s.fence
+		_ = json.NewEncoder(w).Encode(httpResponse{Token: s.token})
+	case http.MethodPut:
+		if s.owner != req.Owner || s.token != req.Token {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if s.owner != req.Owner || s.token != req.Token {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.owner, s.token = "", 0
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// takeOver simulates the service expiring the lease and granting it to
+// another runner
+func (s *fakeLockService) takeOver(owner string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fence++
+	s.owner, s.token = owner, s.fence
+}
+
+func TestHTTPLocker(t *testing.T) {
+	service := &fakeLockService{bearer: "secret"}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	ctx := context.Background()
+	a := NewHTTPLocker(server.URL, "secret", Options{Owner: "runner-a"})
+	b := NewHTTPLocker(server.URL, "secret", Options{Owner: "runner-b", RetryInterval: time.Millisecond})
+	b.logf = func(string, ...any) {}
+
+	lease, err := a.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if lease.Token() != 1 {
+		t.Errorf("Expected token 1, got %d", lease.Token())
+	}
+	if err := lease.Check(ctx); err != nil {
+		t.Errorf("Expected lease to be held, got %v", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = b.Acquire(timeout)
+	var held *HeldError
+	if !errors.As(err, &held) || held.Owner != "runner-a" {
+		t.Errorf("Expected timeout waiting for runner-a, got %v", err)
+	}
+
+	if err := lease.Release(ctx); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	lease, err = b.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("Acquire after release failed: %v", err)
+	}
+	if lease.Token() != 2 {
+		t.Errorf("Expected token 2, got %d", lease.Token())
+	}
+
+	service.takeOver("runner-c")
+	if err := lease.Check(ctx); !errors.Is(err, ErrLost) {
+		t.Errorf("Expected ErrLost after takeover, got %v", err)
+	}
+	if err := lease.Release(ctx); err != nil {
+		t.Errorf("Expected releasing a lost lease to succeed, got %v", err)
+	}
+}
+
+func TestHTTPLockerErrors(t *testing.T) {
+	server := httptest.NewServer(&fakeLockService{bearer: "secret"})
+	defer server.Close()
+
+	_, err := NewHTTPLocker(server.URL, "wrong", Options{}).Acquire(context.Background())
+	if err == nil {
+		t.Fatal("Expected error with a bad token")
+	}
+	var held *HeldError
+	if errors.As(err, &held) {
+		t.Errorf("Expected an unauthorized error not to be treated as held, got %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+// Package lock provides distributed locks so that only one of several CI
+// runners syncing the same repository mutates it at a time.
+//
+// A lock is held through a Lease that is renewed in the background until
+// released. Every acquisition gets a fencing token greater than any before
+// it; holders call Lease.Check before writing, which fails once the lease has
+// expired or been taken over, so a runner that stalled past its lease cannot
+// overwrite the work of the next holder.
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTTL is how long a lease lasts without renewal. Leases are
+	// renewed every third of it while held.
+	DefaultTTL = 2 * time.Minute
+
+	// defaultRetryInterval is how often a held lock is polled
+	defaultRetryInterval = 5 * time.Second
+)
+
+// ErrLost is returned once a lease has expired or another holder has taken
+// over the lock
+var ErrLost = errors.New("lock lost")
+
+// HeldError is returned when the lock is held by someone else
+type HeldError struct {
+	Owner string
+}
+
+func (e *HeldError) Error() string {
+	if e.Owner == "" {
+		return "lock is held"
+	}
+	return fmt.Sprintf("lock is held by %s", e.Owner)
+}
+
+// backend implements a lock store
+type backend interface {
+	// acquire takes the lock for owner, returning a new fencing token, or a
+	// *HeldError while another owner holds an unexpired lease
+	acquire(ctx context.Context, owner string, ttl time.Duration) (uint64, error)
+	// renew extends a lease, returning ErrLost if it is no longer held
+	renew(ctx context.Context, owner string, token uint64, ttl time.Duration) error
+	// release gives up a lease; releasing a lost lease is not an error
+	release(ctx context.Context, owner string, token uint64) error
+}
+
+// Options controls lease timing and identity
+type Options struct {
+	Owner         string        // Identifies the holder, defaults to host:pid plus a random suffix
+	TTL           time.Duration // Lease duration, defaults to DefaultTTL
+	RetryInterval time.Duration // Poll interval while the lock is held elsewhere
+}
+
+// Locker acquires leases from a lock backend
+type Locker struct {
+	backend       backend
+	owner         string
+	ttl           time.Duration
+	retryInterval time.Duration
+	logf          func(format string, args ...any)
+}
+
+func newLocker(b backend, opts Options) *Locker {
+	if opts.Owner == "" {
+		opts.Owner = defaultOwner()
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultRetryInterval
+	}
+
+	return &Locker{
+		backend:       b,
+		owner:         opts.Owner,
+		ttl:           opts.TTL,
+		retryInterval: opts.RetryInterval,
+		logf: func(format string, args ...any) {
+			fmt.Printf(format, args...)
+		},
+	}
+}
+
+// Owner returns the identity leases are held under
+func (l *Locker) Owner() string {
+	return l.owner
+}
+
+// Acquire takes the lock, waiting while another owner holds it until ctx is
+// done. The returned lease is renewed in the background until released.
+func (l *Locker) Acquire(ctx context.Context) (*Lease, error) {
+	waiting := false
+	var held *HeldError
+	for {
+		token, err := l.backend.acquire(ctx, l.owner, l.ttl)
+		if err == nil {
+			return l.newLease(token), nil
+		}
+
+		if held != nil && ctx.Err() != nil {
+			// The deadline passed mid-request while waiting
+			return nil, fmt.Errorf("timed out waiting for lock: %w", held)
+		}
+		if !errors.As(err, &held) {
+			return nil, fmt.Errorf("failed to acquire lock: %w", err)
+		}
+		if !waiting {
+			l.logf("Waiting for sync lock (%v)...\n", held)
+			waiting = true
+		}
+
+		timer := time.NewTimer(l.retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("timed out waiting for lock: %w", held)
+		case <-timer.C:
+		}
+	}
+}
+
+// TryAcquire takes the lock if it is free, returning a *HeldError otherwise
+func (l *Locker) TryAcquire(ctx context.Context) (*Lease, error) {
+	token, err := l.backend.acquire(ctx, l.owner, l.ttl)
+	if err != nil {
+		var held *HeldError
+		if errors.As(err, &held) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	return l.newLease(token), nil
+}
+
+// Lease is a held lock. The nil Lease, returned when locking is disabled,
+// always passes Check.
+type Lease struct {
+	locker *Locker
+	token  uint64
+
+	mu   sync.Mutex
+	lost error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (l *Locker) newLease(token uint64) *Lease {
+	lease := &Lease{
+		locker: l,
+		token:  token,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go lease.keepAlive()
+	return lease
+}
+
+// Token returns the lease's fencing token
+func (l *Lease) Token() uint64 {
+	if l == nil {
+		return 0
+	}
+	return l.token
+}
+
+// keepAlive renews the lease every third of its TTL until it is released
+// or lost
+func (l *Lease) keepAlive() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.locker.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if err := l.renew(context.Background()); errors.Is(err, ErrLost) {
+				l.locker.logf("⚠ Warning: sync lock lost, another runner may be syncing\n")
+				return
+			} else if err != nil {
+				// Transient failures are retried on the next tick; the lease
+				// only expires after three missed renewals
+				l.locker.logf("⚠ Warning: failed to renew sync lock: %v\n", err)
+			}
+		}
+	}
+}
+
+// renew extends the lease, remembering if it was lost
+func (l *Lease) renew(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lost != nil {
+		return l.lost
+	}
+	err := l.locker.backend.renew(ctx, l.locker.owner, l.token, l.locker.ttl)
+	if errors.Is(err, ErrLost) {
+		l.lost = err
+	}
+	return err
+}
+
+// Check confirms the lease is still held, renewing it. Call it before each
+// write to the locked resource; it returns ErrLost once another runner may
+// have taken over.
+func (l *Lease) Check(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if err := l.renew(ctx); err != nil {
+		return fmt.Errorf("sync lock no longer held: %w", err)
+	}
+	return nil
+}
+
+// Release stops renewing the lease and frees the lock
+func (l *Lease) Release(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case <-l.stop:
+		return nil
+	default:
+		close(l.stop)
+	}
+	<-l.done
+
+	if err := l.locker.backend.release(ctx, l.locker.owner, l.token); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
+
+// defaultOwner identifies this process across runners
+func defaultOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
@@ -0,0 +1,102 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLeaseKeepAlive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.lock")
+	locker := NewFileLocker(path, Options{Owner: "runner-a", TTL: 60 * time.Millisecond})
+
+	lease, err := locker.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	// Well past the TTL, the background renewal keeps the lease alive
+	time.Sleep(150 * time.Millisecond)
+	other := NewFileLocker(path, Options{Owner: "runner-b", TTL: time.Minute})
+	var held *HeldError
+	if _, err := other.backend.acquire(context.Background(), "runner-b", time.Minute); !errors.As(err, &held) {
+		t.Errorf("Expected lock to still be held, got %v", err)
+	}
+
+	if err := lease.Check(context.Background()); err != nil {
+		t.Errorf("Expected lease to be held, got %v", err)
+	}
+	if err := lease.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if err := lease.Release(context.Background()); err != nil {
+		t.Errorf("Expected second Release to be a no-op, got %v", err)
+	}
+}
+
+func TestLockerSerialisesHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync.lock")
+
+	var (
+		mu      sync.Mutex
+		holders int
+		overlap bool
+		tokens  = make(map[uint64]bool)
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locker := NewFileLocker(path, Options{RetryInterval: time.Millisecond})
+			locker.logf = func(string, ...any) {}
+
+			lease, err := locker.Acquire(context.Background())
+			if err != nil {
+				t.Errorf("Acquire failed: %v", err)
+				return
+			}
+
+			mu.Lock()
+			holders++
+			overlap = overlap || holders > 1
+			tokens[lease.Token()] = true
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+
+			if err := lease.Release(context.Background()); err != nil {
+				t.Errorf("Release failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if overlap {
+		t.Error("Expected at most one holder at a time")
+	}
+	if len(tokens) != 4 {
+		t.Errorf("Expected 4 distinct fencing tokens, got %d", len(tokens))
+	}
+}
+
+func TestNilLease(t *testing.T) {
+	var lease *Lease
+	if err := lease.Check(context.Background()); err != nil {
+		t.Errorf("Expected nil lease to pass Check, got %v", err)
+	}
+	if err := lease.Release(context.Background()); err != nil {
+		t.Errorf("Expected nil lease Release to succeed, got %v", err)
+	}
+	if lease.Token() != 0 {
+		t.Errorf("Expected nil lease token 0, got %d", lease.Token())
+	}
+}
@@ -0,0 +1,362 @@
+// Package markup converts Jira rich text, Atlassian Document Format (ADF)
+// from the v3 API and wiki markup from the v2 API, into Markdown.
+package markup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// adfNode is a node of an ADF document
+type adfNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []adfMark      `json:"marks,omitempty"`
+	Content []adfNode      `json:"content,omitempty"`
+}
+
+// adfMark is inline formatting applied to a text node
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// ADFToMarkdown renders an ADF document as Markdown. Nodes without a
+// Markdown equivalent (panels, expands, media) keep their text content.
+func ADFToMarkdown(document []byte) (string, error) {
+	var doc adfNode
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+	if doc.Type != "doc" {
+		return "", fmt.Errorf("expected ADF doc node, got %q", doc.Type)
+	}
+
+	return strings.TrimSpace(renderBlocks(doc.Content, "")), nil
+}
+
+// renderBlocks renders block nodes separated by blank lines, prefixing
+// every line (for quotes and list continuation)
+func renderBlocks(nodes []adfNode, prefix string) string {
+	var blocks []string
+	for _, node := range nodes {
+		if block := renderBlock(node); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return prefixLines(strings.Join(blocks, "\n\n"), prefix)
+}
+
+// renderBlock renders one block node
+func renderBlock(node adfNode) string {
+	switch node.Type {
+	case "paragraph":
+		return renderInline(node.Content)
+	case "heading":
+		level := min(max(intAttr(node.Attrs, "level"), 1), 6)
+		return strings.Repeat("#", level) + " " + renderInline(node.Content)
+	case "bulletList":
+		return renderList(node.Content, func(int) string { return "- " })
+	case "orderedList":
+		start := max(intAttr(node.Attrs, "order"), 1)
+		return renderList(node.Content, func(i int) string { return fmt.Sprintf("%d. ", start+i) })
+	case "taskList":
+		return renderList(node.Content, func(int) string { return "- " })
+	case "decisionList":
+		return renderList(node.Content, func(int) string { return "- " })
+	case "codeBlock":
+		language, _ := node.Attrs["language"].(string)
+		return fence(plainText(node.Content), language)
+	case "blockquote":
+		return renderBlocks(node.Content, "> ")
+	case "panel":
+		// Info, note, warning... panels become quotes led by their type
+		panelType, _ := node.Attrs["panelType"].(string)
+		body := renderBlocks(node.Content, "")
+		if panelType != "" {
+			body = "**" + capitalize(panelType) + ":** " + body
+		}
+		return prefixLines(body, "> ")
+	case "expand", "nestedExpand":
+		body := renderBlocks(node.Content, "")
+		if title, _ := node.Attrs["title"].(string); title != "" {
+			return "**" + title + "**\n\n" + body
+		}
+		return body
+	case "rule":
+		return "---"
+	case "table":
+		return renderTable(node)
+	case "mediaSingle", "mediaGroup":
+		var media []string
+		for _, child := range node.Content {
+			if child.Type == "media" {
+				media = append(media, renderMedia(child))
+			}
+		}
+		return strings.Join(media, "\n")
+	case "blockCard", "embedCard":
+		if url, _ := node.Attrs["url"].(string); url != "" {
+			return "<" + url + ">"
+		}
+		return ""
+	default:
+		// Unknown blocks keep their content
+		if len(node.Content) == 0 {
+			return renderInline([]adfNode{node})
+		}
+		return renderBlocks(node.Content, "")
+	}
+}
+
+// renderList renders list items, indenting nested content under the marker
+func renderList(items []adfNode, marker func(i int) string) string {
+	var lines []string
+	for i, item := range items {
+		prefix := marker(i)
+		switch item.Type {
+		case "taskItem":
+			if state, _ := item.Attrs["state"].(string); state == "DONE" {
+				prefix += "[x] "
+			} else {
+				prefix += "[ ] "
+			}
+		case "decisionItem":
+			prefix += "[decision] "
+		}
+
+		// Task and decision items hold inline content directly
+		var body string
+		if item.Type == "taskItem" || item.Type == "decisionItem" {
+			body = renderInline(item.Content)
+		} else {
+			body = renderListItem(item.Content)
+		}
+
+		indent := strings.Repeat(" ", len(marker(i)))
+		body = prefixLines(body, indent)
+		lines = append(lines, prefix+strings.TrimPrefix(body, indent))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderListItem renders the blocks of a list item; nested lists follow
+// the item text without a blank line so the list stays tight
+func renderListItem(nodes []adfNode) string {
+	var parts []string
+	for _, node := range nodes {
+		block := renderBlock(node)
+		if block == "" {
+			continue
+		}
+		if len(parts) > 0 && !isList(node.Type) {
+			block = "\n" + block
+		}
+		parts = append(parts, block)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func isList(nodeType string) bool {
+	return nodeType == "bulletList" || nodeType == "orderedList" || nodeType == "taskList"
+}
+
+// renderTable renders a table as a Markdown pipe table. Markdown tables
+// need a header row, so the first row is used as one.
+func renderTable(node adfNode) string {
+	var rows [][]string
+	columns := 0
+	for _, row := range node.Content {
+		if row.Type != "tableRow" {
+			continue
+		}
+		var cells []string
+		for _, cell := range row.Content {
+			text := renderBlocks(cell.Content, "")
+			text = strings.ReplaceAll(text, "\n\n", "<br>")
+			text = strings.ReplaceAll(text, "\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+		columns = max(columns, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderMedia renders an attachment reference
+func renderMedia(node adfNode) string {
+	name, _ := node.Attrs["alt"].(string)
+	if name == "" {
+		name, _ = node.Attrs["id"].(string)
+	}
+	if url, _ := node.Attrs["url"].(string); url != "" {
+		return fmt.Sprintf("![%s](%s)", name, url)
+	}
+	return fmt.Sprintf("[attachment: %s]", name)
+}
+
+// renderInline renders inline nodes
+func renderInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(applyMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("  \n")
+		case "mention":
+			text, _ := node.Attrs["text"].(string)
+			if text == "" {
+				text, _ = node.Attrs["id"].(string)
+			}
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			b.WriteString(text)
+		case "emoji":
+			if text, _ := node.Attrs["text"].(string); text != "" {
+				b.WriteString(text)
+			} else if shortName, _ := node.Attrs["shortName"].(string); shortName != "" {
+				b.WriteString(shortName)
+			}
+		case "inlineCard":
+			if url, _ := node.Attrs["url"].(string); url != "" {
+				b.WriteString("<" + url + ">")
+			}
+		case "status":
+			if text, _ := node.Attrs["text"].(string); text != "" {
+				b.WriteString("`" + strings.ToUpper(text) + "`")
+			}
+		case "date":
+			b.WriteString(formatDate(node.Attrs["timestamp"]))
+		case "media", "mediaInline":
+			b.WriteString(renderMedia(node))
+		default:
+			b.WriteString(renderInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// applyMarks wraps text in the Markdown for its marks. Code is applied
+// innermost since Markdown doesn't format inside code spans.
+func applyMarks(text string, marks []adfMark) string {
+	if text == "" {
+		return ""
+	}
+
+	var link string
+	for _, mark := range marks {
+		if mark.Type == "code" {
+			text = "`" + text + "`"
+		}
+	}
+	for _, mark := range marks {
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			link, _ = mark.Attrs["href"].(string)
+		}
+	}
+	if link != "" {
+		text = "[" + text + "](" + link + ")"
+	}
+	return text
+}
+
+// plainText concatenates the text of inline nodes without formatting
+func plainText(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(node.Text)
+		case "hardBreak":
+			b.WriteString("\n")
+		default:
+			b.WriteString(plainText(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// fence wraps code in a fenced block long enough not to clash with
+// backticks in the code itself. The block ends a line, so only one
+// trailing newline is dropped; further blank lines are part of the code.
+func fence(code, language string) string {
+	marker := "```"
+	for strings.Contains(code, marker) {
+		marker += "`"
+	}
+	return marker + language + "\n" + strings.TrimSuffix(code, "\n") + "\n" + marker
+}
+
+// formatDate renders an ADF date attribute (milliseconds since the epoch)
+func formatDate(value any) string {
+	var millis int64
+	switch v := value.(type) {
+	case string:
+		if _, err := fmt.Sscan(v, &millis); err != nil {
+			return v
+		}
+	case float64:
+		millis = int64(v)
+	default:
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format("2006-01-02")
+}
+
+// intAttr returns a numeric attribute, or 0
+func intAttr(attrs map[string]any, key string) int {
+	if v, ok := attrs[key].(float64); ok {
+		return int(v)
+	}
+	return 0
+}
+
+// prefixLines prefixes every line of text, without trailing spaces on
+// blank lines
+func prefixLines(text, prefix string) string {
+	if prefix == "" || text == "" {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,134 @@
+package markup
+
+import "testing"
+
+func TestADFToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "paragraphs and marks",
+			input: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"Hello "},
+					{"type":"text","text":"bold","marks":[{"type":"strong"}]},
+					{"type":"text","text":" and "},
+					{"type":"text","text":"code","marks":[{"type":"code"}]}
+				]},
+				{"type":"paragraph","content":[
+					{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}
+				]}
+			]}`,
+			expected: "Hello **bold** and `code`\n\n[docs](https://example.com)",
+		},
+		{
+			name: "heading",
+			input: `{"type":"doc","content":[
+				{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Steps"}]}
+			]}`,
+			expected: "## Steps",
+		},
+		{
+			name: "nested lists",
+			input: `{"type":"doc","content":[
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[
+						{"type":"paragraph","content":[{"type":"text","text":"one"}]},
+						{"type":"orderedList","content":[
+							{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},
+							{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}
+						]}
+					]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"two"}]}]}
+				]}
+			]}`,
+			expected: "- one\n  1. first\n  2. second\n- two",
+		},
+		{
+			name: "task list",
+			input: `{"type":"doc","content":[
+				{"type":"taskList","content":[
+					{"type":"taskItem","attrs":{"state":"DONE"},"content":[{"type":"text","text":"done"}]},
+					{"type":"taskItem","attrs":{"state":"TODO"},"content":[{"type":"text","text":"todo"}]}
+				]}
+			]}`,
+			expected: "- [x] done\n- [ ] todo",
+		},
+		{
+			name: "code block",
+			input: `{"type":"doc","content":[
+				{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"x := 1"}]}
+			]}`,
+			expected: "```go\nx := 1\n```",
+		},
+		{
+			name: "mentions, emoji and status",
+			input: `{"type":"doc","content":[
+				{"type":"paragraph","content":[
+					{"type":"mention","attrs":{"id":"abc","text":"@Jane Doe"}},
+					{"type":"text","text":" "},
+					{"type":"emoji","attrs":{"shortName":":tada:","text":"🎉"}},
+					{"type":"text","text":" "},
+					{"type":"status","attrs":{"text":"in review"}}
+				]}
+			]}`,
+			expected: "@Jane Doe 🎉 `IN REVIEW`",
+		},
+		{
+			name: "table",
+			input: `{"type":"doc","content":[
+				{"type":"table","content":[
+					{"type":"tableRow","content":[
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Key"}]}]},
+						{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"Value"}]}]}
+					]},
+					{"type":"tableRow","content":[
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"a|b"}]}]},
+						{"type":"tableCell","content":[{"type":"paragraph","content":[{"type":"text","text":"1"}]}]}
+					]}
+				]}
+			]}`,
+			expected: "| Key | Value |\n| --- | --- |\n| a\\|b | 1 |",
+		},
+		{
+			name: "blockquote, panel and rule",
+			input: `{"type":"doc","content":[
+				{"type":"blockquote","content":[{"type":"paragraph","content":[{"type":"text","text":"quoted"}]}]},
+				{"type":"panel","attrs":{"panelType":"warning"},"content":[{"type":"paragraph","content":[{"type":"text","text":"careful"}]}]},
+				{"type":"rule"}
+			]}`,
+			expected: "> quoted\n\n> **Warning:** careful\n\n---",
+		},
+		{
+			name: "hard break",
+			input: `{"type":"doc","content":[
+				{"type":"paragraph","content":[
+					{"type":"text","text":"one"},{"type":"hardBreak"},{"type":"text","text":"two"}
+				]}
+			]}`,
+			expected: "one  \ntwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ADFToMarkdown([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ADFToMarkdown failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestADFToMarkdownInvalid(t *testing.T) {
+	for _, input := range []string{`not json`, `{"type":"paragraph"}`} {
+		if _, err := ADFToMarkdown([]byte(input)); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+}
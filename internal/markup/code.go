@@ -0,0 +1,82 @@
+package markup
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Code blocks are kept byte for byte: indentation, tabs, trailing blank
+// lines and markup-like text inside them are never touched, so commands
+// and YAML snippets copied from an issue still work.
+
+// openingFence returns the fence marker (``` or ~~~, possibly longer) a
+// Markdown line opens a code block with, ignoring indentation and quote
+// markers
+func openingFence(line string) (string, bool) {
+	line = stripQuote(line)
+	for _, c := range []byte{'`', '~'} {
+		n := 0
+		for n < len(line) && line[n] == c {
+			n++
+		}
+		if n >= 3 && (c == '~' || !strings.Contains(line[n:], "`")) {
+			return line[:n], true
+		}
+	}
+	return "", false
+}
+
+// closesFence reports whether a Markdown line closes a code block opened
+// with marker
+func closesFence(line, marker string) bool {
+	line = strings.TrimRight(stripQuote(line), " \t\r\n")
+	return len(line) >= len(marker) && strings.Trim(line, marker[:1]) == ""
+}
+
+// stripQuote removes indentation and blockquote markers from a line
+func stripQuote(line string) string {
+	for {
+		trimmed := strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(trimmed, ">") {
+			return trimmed
+		}
+		line = trimmed[1:]
+	}
+}
+
+// Truncate shortens Markdown to at most limit characters. A cut that would
+// fall inside a fenced code block is moved before the block, since half a
+// command or config file is worse than none.
+func Truncate(markdown string, limit int) string {
+	if utf8.RuneCountInString(markdown) <= limit {
+		return markdown
+	}
+	// Walked in bytes, since re-encoding runes would turn invalid bytes into
+	// three-byte replacement characters and move the cut
+	cut := 0
+	for range limit {
+		_, size := utf8.DecodeRuneInString(markdown[cut:])
+		cut += size
+	}
+
+	marker, blockStart := "", -1
+	offset := 0
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		if offset >= cut {
+			break
+		}
+		if marker == "" {
+			if m, ok := openingFence(line); ok {
+				marker, blockStart = m, offset
+			}
+		} else if closesFence(line, marker) && offset+len(line) <= cut {
+			marker, blockStart = "", -1
+		}
+		offset += len(line)
+	}
+
+	if blockStart < 0 {
+		return markdown[:cut]
+	}
+	return strings.TrimRight(markdown[:blockStart], " \t\n")
+}
@@ -0,0 +1,35 @@
+package markup
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		limit    int
+		expected string
+	}{
+		{"short enough", "Steps:\n```\nmake\n```", 100, "Steps:\n```\nmake\n```"},
+		{"cut in text", "First paragraph.\n\nSecond.", 10, "First para"},
+		{"cut inside code moves before block", "Steps:\n\n```bash\nrm -rf build && make all\n```\nDone.", 25, "Steps:"},
+		{"cut inside closing fence", "Run:\n```\nmake\n```", 15, "Run:"},
+		{"cut after code keeps block", "Run:\n```\nmake\n```\nthen deploy it", 22, "Run:\n```\nmake\n```\nthen"},
+		{"cut in quoted code", "> ```\n> make all\n> ```", 10, ""},
+		{"cut after invalid byte", "ab\xf5cdef", 4, "ab\xf5c"},
+		{"cut after multibyte rune", "größer als", 3, "grö"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.input, tt.limit); got != tt.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.limit, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFenceKeepsTrailingBlankLines(t *testing.T) {
+	if got := fence("a:\n  b: 1\n\n", "yaml"); got != "```yaml\na:\n  b: 1\n\n```" {
+		t.Errorf("Unexpected fence %q", got)
+	}
+}
@@ -0,0 +1,25 @@
+package markup
+
+import "testing"
+
+func FuzzADFToMarkdown(f *testing.F) {
+	f.Add([]byte(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"docs","marks":[{"type":"link","attrs":{"href":"https://example.com"}}]}]}]}`))
+	f.Add([]byte(`{"type":"doc","content":[{"type":"table","content":[{"type":"tableRow","content":[{"type":"tableHeader","content":[{"type":"paragraph","content":[{"type":"text","text":"a|b"}]}]}]}]}]}`))
+	f.Add([]byte(`{"type":"doc","content":[{"type":"bulletList","content":[{"type":"listItem","content":[{"type":"orderedList","attrs":{"order":-3},"content":null}]}]}]}`))
+	f.Add([]byte(`{"type":"doc","content":[{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"` + "```" + `"}]}]}`))
+
+	f.Fuzz(func(t *testing.T, document []byte) {
+		if markdown, err := ADFToMarkdown(document); err == nil {
+			_ = Truncate(markdown, 32)
+		}
+	})
+}
+
+func FuzzWikiToMarkdown(f *testing.F) {
+	f.Add("h1. Title\n* item\n** nested\n{code:java}x{code}\n||a||b||\n|c|d|\n[link|http://x] !img.png|thumbnail!")
+	f.Add("{quote}{noformat}*_{{")
+
+	f.Fuzz(func(t *testing.T, text string) {
+		_ = Truncate(WikiToMarkdown(text), 32)
+	})
+}
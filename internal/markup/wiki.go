@@ -0,0 +1,370 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	wikiHeading   = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiQuoteLine = regexp.MustCompile(`^bq\.\s+(.*)$`)
+	wikiListItem  = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	wikiRule      = regexp.MustCompile(`^-{4,}\s*$`)
+	wikiCodeStart = regexp.MustCompile(`^\{(code|noformat)(?::([^}]*))?\}(.*)$`)
+	wikiBlockTag  = regexp.MustCompile(`^\{(quote|panel)(?::[^}]*)?\}(.*)$`)
+
+	wikiMonospace = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	wikiMention   = regexp.MustCompile(`\[~(?:accountid:)?([^\]]+)\]`)
+	wikiLink      = regexp.MustCompile(`\[([^\]|]+)\|([^\]|]+)(?:\|[^\]]*)?\]`)
+	wikiBareLink  = regexp.MustCompile(`\[((?:https?|mailto|ftp):[^\]|]+)\]`)
+	wikiImage     = regexp.MustCompile(`!([^!\s|]+\.[A-Za-z0-9]+)(?:\|[^!]*)?!`)
+	wikiColor     = regexp.MustCompile(`\{color(?::[^}]*)?\}`)
+
+	// placeholder protects converted code spans and links from inline
+	// formatting; \x00 cannot occur in Jira text
+	placeholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// WikiToMarkdown renders Jira wiki markup as Markdown. Plain text passes
+// through unchanged apart from markup-like sequences.
+func WikiToMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	w := &wikiConverter{}
+	for _, line := range strings.Split(text, "\n") {
+		w.line(line)
+	}
+	w.closeCode()
+	return strings.TrimRight(strings.Join(w.out, "\n"), "\n")
+}
+
+// wikiConverter converts wiki markup line by line, tracking the block
+// (code, quote, table) the current line belongs to
+type wikiConverter struct {
+	out []string
+
+	codeTag      string // "code" or "noformat" while inside a code block
+	codeLanguage string
+	codeLines    []string
+
+	fenceMarker string // Marker of the Markdown code fence being copied
+
+	inQuote bool
+	inTable bool
+}
+
+func (w *wikiConverter) emit(line string) {
+	if w.inQuote {
+		line = strings.TrimRight("> "+line, " ")
+	}
+	w.out = append(w.out, line)
+}
+
+// emitCode emits a line of a code block exactly as it is
+func (w *wikiConverter) emitCode(line string) {
+	switch {
+	case !w.inQuote:
+	case line == "":
+		line = ">"
+	default:
+		line = "> " + line
+	}
+	w.out = append(w.out, line)
+}
+
+func (w *wikiConverter) line(line string) {
+	if w.codeTag != "" {
+		w.codeLine(line)
+		return
+	}
+	if w.fenceMarker != "" {
+		// Markdown pasted into Jira: copy the fenced block unchanged
+		w.emitCode(line)
+		if closesFence(line, w.fenceMarker) {
+			w.fenceMarker = ""
+		}
+		return
+	}
+
+	trimmed := strings.TrimSpace(line)
+
+	if marker, ok := openingFence(line); ok {
+		w.endTable()
+		w.fenceMarker = marker
+		w.emitCode(line)
+		return
+	}
+
+	if m := wikiCodeStart.FindStringSubmatch(trimmed); m != nil {
+		w.endTable()
+		w.codeTag = m[1]
+		w.codeLanguage = codeLanguage(m[2])
+		w.codeLines = nil
+		if m[3] != "" {
+			w.codeLine(m[3])
+		}
+		return
+	}
+
+	if m := wikiBlockTag.FindStringSubmatch(trimmed); m != nil {
+		w.endTable()
+		if m[1] == "quote" {
+			w.inQuote = !w.inQuote
+		}
+		if rest := strings.TrimSpace(m[2]); rest != "" {
+			w.line(rest)
+		}
+		return
+	}
+
+	if strings.HasPrefix(trimmed, "|") {
+		w.tableRow(trimmed)
+		return
+	}
+	w.endTable()
+
+	if wikiRule.MatchString(trimmed) {
+		w.emit("---")
+		return
+	}
+	if m := wikiHeading.FindStringSubmatch(trimmed); m != nil {
+		level := int(m[1][0] - '0')
+		w.emit(strings.Repeat("#", level) + " " + inline(m[2]))
+		return
+	}
+	if m := wikiQuoteLine.FindStringSubmatch(trimmed); m != nil {
+		w.out = append(w.out, "> "+inline(m[1]))
+		return
+	}
+	if m := wikiListItem.FindStringSubmatch(trimmed); m != nil {
+		w.emit(listMarker(m[1]) + inline(m[2]))
+		return
+	}
+	w.emit(inline(line))
+}
+
+// codeLine adds a line to the open code block, closing it at the end tag
+func (w *wikiConverter) codeLine(line string) {
+	end := "{" + w.codeTag + "}"
+	before, after, closed := strings.Cut(line, end)
+	if !closed {
+		w.codeLines = append(w.codeLines, line)
+		return
+	}
+
+	if strings.TrimSpace(before) != "" {
+		w.codeLines = append(w.codeLines, before)
+	}
+	w.closeCode()
+	if strings.TrimSpace(after) != "" {
+		w.line(after)
+	}
+}
+
+// closeCode writes the open code block, if any. An unterminated block runs
+// to the end of the text, as in Jira; so does an unterminated Markdown
+// fence, which is closed to keep the rest of the output intact.
+func (w *wikiConverter) closeCode() {
+	if w.fenceMarker != "" {
+		w.emitCode(w.fenceMarker)
+		w.fenceMarker = ""
+	}
+	if w.codeTag == "" {
+		return
+	}
+
+	block := fence(strings.Join(w.codeLines, "\n")+"\n", w.codeLanguage)
+	for _, line := range strings.Split(block, "\n") {
+		w.emitCode(line)
+	}
+	w.codeTag = ""
+	w.codeLines = nil
+}
+
+// tableRow converts a || header || or | cell | row
+func (w *wikiConverter) tableRow(line string) {
+	header := strings.HasPrefix(line, "||")
+	separator := "|"
+	if header {
+		separator = "||"
+	}
+
+	cells := splitCells(strings.TrimSpace(line), separator)
+	for i, cell := range cells {
+		cells[i] = strings.ReplaceAll(inline(strings.TrimSpace(cell)), "|", `\|`)
+	}
+
+	w.emit("| " + strings.Join(cells, " | ") + " |")
+	if !w.inTable {
+		// Markdown tables need a header row; a table without one uses its
+		// first row
+		w.emit("|" + strings.Repeat(" --- |", len(cells)))
+		w.inTable = true
+	}
+}
+
+func (w *wikiConverter) endTable() {
+	w.inTable = false
+}
+
+// splitCells splits a table row on separator, ignoring separators inside
+// links and macros
+func splitCells(line, separator string) []string {
+	line = strings.TrimPrefix(line, separator)
+	line = strings.TrimSuffix(line, separator)
+
+	var cells []string
+	depth, start := 0, 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth = max(depth-1, 0)
+		case '|':
+			if depth == 0 && strings.HasPrefix(line[i:], separator) {
+				cells = append(cells, line[start:i])
+				i += len(separator) - 1
+				start = i + 1
+			}
+		}
+	}
+	return append(cells, line[start:])
+}
+
+// listMarker converts wiki list markers ("*", "##", "*#") to an indented
+// Markdown list marker
+func listMarker(markers string) string {
+	var indent strings.Builder
+	for _, m := range markers[:len(markers)-1] {
+		if m == '#' {
+			indent.WriteString("   ")
+		} else {
+			indent.WriteString("  ")
+		}
+	}
+	if markers[len(markers)-1] == '#' {
+		return indent.String() + "1. "
+	}
+	return indent.String() + "- "
+}
+
+// codeLanguage extracts the language from {code} parameters, e.g.
+// "java" or "title=Example.java|language=java"
+func codeLanguage(params string) string {
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+		switch {
+		case !found && strings.TrimSpace(key) != "":
+			return strings.TrimSpace(key)
+		case found && strings.TrimSpace(key) == "language":
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// inline converts inline wiki markup: monospace, links, mentions, images
+// and text effects
+func inline(text string) string {
+	var protected []string
+	protect := func(s string) string {
+		protected = append(protected, s)
+		return fmt.Sprintf("\x00%d\x00", len(protected)-1)
+	}
+
+	text = wikiMonospace.ReplaceAllStringFunc(text, func(m string) string {
+		return protect("`" + wikiMonospace.FindStringSubmatch(m)[1] + "`")
+	})
+	text = wikiMention.ReplaceAllStringFunc(text, func(m string) string {
+		return protect("@" + wikiMention.FindStringSubmatch(m)[1])
+	})
+	text = wikiLink.ReplaceAllStringFunc(text, func(m string) string {
+		sub := wikiLink.FindStringSubmatch(m)
+		return protect("[" + sub[1] + "](" + strings.TrimSpace(sub[2]) + ")")
+	})
+	text = wikiBareLink.ReplaceAllStringFunc(text, func(m string) string {
+		return protect("<" + wikiBareLink.FindStringSubmatch(m)[1] + ">")
+	})
+	text = wikiImage.ReplaceAllStringFunc(text, func(m string) string {
+		name := wikiImage.FindStringSubmatch(m)[1]
+		return protect("![" + name + "](" + name + ")")
+	})
+	text = wikiColor.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, `\\`, "  \n")
+
+	text = replaceDelimited(text, '*', "**")
+	text = replaceDelimited(text, '_', "*")
+	text = replaceDelimited(text, '-', "~~")
+	text = replaceDelimited(text, '+', "")
+
+	return placeholder.ReplaceAllStringFunc(text, func(m string) string {
+		var i int
+		_, _ = fmt.Sscanf(strings.Trim(m, "\x00"), "%d", &i)
+		return protected[i]
+	})
+}
+
+// replaceDelimited replaces text effects such as *bold* with markdown.
+// Like Jira, an effect must open after a non-word character and close
+// before one, so snake_case and hyphenated-words are left alone.
+func replaceDelimited(text string, delim byte, markdown string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		if text[i] != delim || !opensEffect(text, i) {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := closingDelimiter(text, i, delim)
+		if end < 0 {
+			b.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		b.WriteString(markdown + text[i+1:end] + markdown)
+		i = end + 1
+	}
+	return b.String()
+}
+
+// opensEffect reports whether the delimiter at i can open a text effect
+func opensEffect(text string, i int) bool {
+	if i+1 >= len(text) || text[i+1] == ' ' || text[i+1] == text[i] {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWordRune(prev)
+}
+
+// closingDelimiter finds the delimiter closing the effect opened at start
+// on the same line, or returns -1
+func closingDelimiter(text string, start int, delim byte) int {
+	for j := start + 2; j < len(text); j++ {
+		switch {
+		case text[j] == '\n':
+			return -1
+		case text[j] != delim || text[j-1] == ' ':
+			continue
+		}
+		if j+1 == len(text) {
+			return j
+		}
+		next, _ := utf8.DecodeRuneInString(text[j+1:])
+		if !isWordRune(next) {
+			return j
+		}
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
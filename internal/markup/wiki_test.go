@@ -0,0 +1,121 @@
+package markup
+
+import "testing"
+
+func TestWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text",
+			input:    "Just a description.\n\nSecond paragraph.",
+			expected: "Just a description.\n\nSecond paragraph.",
+		},
+		{
+			name:     "headings",
+			input:    "h1. Title\nh3. Section",
+			expected: "# Title\n### Section",
+		},
+		{
+			name:     "text effects",
+			input:    "*bold* _italic_ -deleted- +inserted+",
+			expected: "**bold** *italic* ~~deleted~~ inserted",
+		},
+		{
+			name:     "effects need word boundaries",
+			input:    "snake_case_name, well-known-thing, 2*3*4",
+			expected: "snake_case_name, well-known-thing, 2*3*4",
+		},
+		{
+			name:     "monospace protects content",
+			input:    "run {{make *all*}} first",
+			expected: "run `make *all*` first",
+		},
+		{
+			name:     "nested lists",
+			input:    "* one\n** nested\n# first\n## sub",
+			expected: "- one\n  - nested\n1. first\n   1. sub",
+		},
+		{
+			name:     "links and mentions",
+			input:    "[docs|https://example.com/docs] [https://example.com] [~accountid:5b10a] [~jdoe]",
+			expected: "[docs](https://example.com/docs) <https://example.com> @5b10a @jdoe",
+		},
+		{
+			name:     "image",
+			input:    "!screenshot.png|thumbnail!",
+			expected: "![screenshot.png](screenshot.png)",
+		},
+		{
+			name:     "code block keeps markup",
+			input:    "{code:language=go}\nx := *p\n{code}",
+			expected: "```go\nx := *p\n```",
+		},
+		{
+			name:     "single line noformat",
+			input:    "{noformat}raw _text_{noformat}",
+			expected: "```\nraw _text_\n```",
+		},
+		{
+			name:     "unterminated code block",
+			input:    "{code}\nfmt.Println()",
+			expected: "```\nfmt.Println()\n```",
+		},
+		{
+			name:     "code block keeps indentation and blank lines",
+			input:    "{code:yaml}\nservices:\n  web:\n\t\timage: \"nginx:*latest*\"\n\n  {code}",
+			expected: "```yaml\nservices:\n  web:\n\t\timage: \"nginx:*latest*\"\n\n```",
+		},
+		{
+			name:     "markdown fence is copied unchanged",
+			input:    "Repro:\n```bash\ncurl -H 'X: {{token}}' https://x.io/[a|b]  \n# *not* a list\n```\n*done*",
+			expected: "Repro:\n```bash\ncurl -H 'X: {{token}}' https://x.io/[a|b]  \n# *not* a list\n```\n**done**",
+		},
+		{
+			name:     "unterminated markdown fence is closed",
+			input:    "~~~~\nh1. not a heading",
+			expected: "~~~~\nh1. not a heading\n~~~~",
+		},
+		{
+			name:     "code block in quote keeps trailing spaces",
+			input:    "{quote}\n{noformat}\nkey: value  \n{noformat}\n{quote}",
+			expected: "> ```\n> key: value  \n> ```",
+		},
+		{
+			name:     "table",
+			input:    "||Name||Value||\n|a|[x|http://x.io]|",
+			expected: "| Name | Value |\n| --- | --- |\n| a | [x](http://x.io) |",
+		},
+		{
+			name:     "quote block",
+			input:    "{quote}\nfirst\n\nsecond\n{quote}\nafter",
+			expected: "> first\n>\n> second\nafter",
+		},
+		{
+			name:     "bq line and rule",
+			input:    "bq. quoted\n----",
+			expected: "> quoted\n---",
+		},
+		{
+			name:     "color and panel tags are dropped",
+			input:    "{panel:title=Note}\n{color:red}warning{color}\n{panel}",
+			expected: "warning",
+		},
+		{
+			name:     "line break",
+			input:    `one\\two`,
+			expected: "one  \ntwo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WikiToMarkdown(tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
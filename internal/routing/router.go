@@ -0,0 +1,154 @@
+package routing
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+// Route sends issues with a given Jira component to a beads repository
+type Route struct {
+	Component string
+	Repo      string
+}
+
+// Router splits a beads export across repositories based on Jira components
+type Router struct {
+	routes      []Route
+	defaultRepo string
+}
+
+// NewRouter creates a router. Routes are matched in order; issues matching
+// no route go to defaultRepo. An empty defaultRepo means the base directory.
+func NewRouter(routes []Route, defaultRepo string) *Router {
+	return &Router{
+		routes:      routes,
+		defaultRepo: defaultRepo,
+	}
+}
+
+// RepoFor returns the repository for an issue with the given components.
+// The first route whose component the issue has wins.
+func (r *Router) RepoFor(components []*jirapb.Component) string {
+	for _, route := range r.routes {
+		for _, component := range components {
+			if strings.EqualFold(component.GetName(), route.Component) {
+				return route.Repo
+			}
+		}
+	}
+	return r.defaultRepo
+}
+
+// Split partitions a beads export by repository. Components are looked up in
+// the Jira export by each item's Jira key. Epics are placed in their own
+// repository and copied into every other repository holding one of their
+// issues, so epic references always resolve locally.
+func (r *Router) Split(jiraExport *jirapb.Export, beadsExport *beadspb.Export) map[string]*beadspb.Export {
+	components := make(map[string][]*jirapb.Component)
+	for _, issue := range jiraExport.GetIssues() {
+		components[issue.Key] = issue.GetFields().GetComponents()
+	}
+
+	exports := make(map[string]*beadspb.Export)
+	exportFor := func(repo string) *beadspb.Export {
+		if exports[repo] == nil {
+			exports[repo] = &beadspb.Export{
+				Issues: []*beadspb.Issue{},
+				Epics:  []*beadspb.Epic{},
+			}
+		}
+		return exports[repo]
+	}
+
+	epicRepos := make(map[string]map[string]bool)
+	epicsByID := make(map[string]*beadspb.Epic)
+	for _, epic := range beadsExport.Epics {
+		repo := r.RepoFor(components[epic.GetMetadata().GetJiraKey()])
+		exportFor(repo).Epics = append(exportFor(repo).Epics, epic)
+		epicRepos[epic.Id] = map[string]bool{repo: true}
+		epicsByID[epic.Id] = epic
+	}
+
+	for _, issue := range beadsExport.Issues {
+		repo := r.RepoFor(components[issue.GetMetadata().GetJiraKey()])
+		exportFor(repo).Issues = append(exportFor(repo).Issues, issue)
+
+		if epic, ok := epicsByID[issue.Epic]; ok && !epicRepos[issue.Epic][repo] {
+			epicRepos[issue.Epic][repo] = true
+			exportFor(repo).Epics = append(exportFor(repo).Epics, epic)
+		}
+	}
+
+	return exports
+}
+
+// Repos returns the repositories in a split export in sorted order
+func Repos(exports map[string]*beadspb.Export) []string {
+	repos := make([]string, 0, len(exports))
+	for repo := range exports {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+// ResolveRepo returns the directory for a repository. Relative repositories
+// are resolved against baseDir.
+func ResolveRepo(baseDir, repo string) string {
+	if filepath.IsAbs(repo) {
+		return repo
+	}
+	return filepath.Join(baseDir, repo)
+}
+
+// RenderAll renders each export in a split to its repository's .beads directory
+// in the given format and returns the Jira key changes detected across all
+// repositories. With merge set, exports are merged into existing files (see
+// JSONLRenderer.MergeExport).
+func RenderAll(baseDir string, exports map[string]*beadspb.Export, merge bool, format beads.Format, opts beads.RendererOptions) ([]beads.KeyChange, error) {
+	var changes []beads.KeyChange
+	for _, repo := range Repos(exports) {
+		renderer, err := beads.NewRendererWithOptions(ResolveRepo(baseDir, repo), format, opts)
+		if err != nil {
+			return nil, err
+		}
+		render := renderer.RenderExport
+		if merge {
+			render = renderer.MergeExport
+		}
+		if err := render(exports[repo]); err != nil {
+			return nil, fmt.Errorf("failed to render repository %s: %w", ResolveRepo(baseDir, repo), err)
+		}
+		changes = append(changes, renderer.KeyChanges()...)
+	}
+	return changes, nil
+}
+
+// PlanAll compares each export in a split with its repository's .beads
+// directory and returns the combined changes rendering would make, each
+// tagged with its repository. Nothing is written.
+func PlanAll(baseDir string, exports map[string]*beadspb.Export, merge bool, format beads.Format, opts beads.RendererOptions) (*beads.Plan, error) {
+	plan := &beads.Plan{}
+	for _, repo := range Repos(exports) {
+		dir := ResolveRepo(baseDir, repo)
+		renderer, err := beads.NewRendererWithOptions(dir, format, opts)
+		if err != nil {
+			return nil, err
+		}
+		repoPlan, err := renderer.PlanExport(exports[repo], merge)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan repository %s: %w", dir, err)
+		}
+		for i := range repoPlan.Changes {
+			repoPlan.Changes[i].Repo = dir
+		}
+		plan.Append(repoPlan)
+	}
+	return plan, nil
+}
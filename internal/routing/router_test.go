@@ -0,0 +1,130 @@
+package routing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	beadspb "github.com/conallob/jira-beads-sync/gen/beads"
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+func jiraIssue(key string, components ...string) *jirapb.Issue {
+	issue := &jirapb.Issue{Key: key, Fields: &jirapb.Fields{}}
+	for _, name := range components {
+		issue.Fields.Components = append(issue.Fields.Components, &jirapb.Component{Name: name})
+	}
+	return issue
+}
+
+func TestRepoFor(t *testing.T) {
+	router := NewRouter([]Route{
+		{Component: "Frontend", Repo: "../web"},
+		{Component: "Infra", Repo: "../platform"},
+	}, "")
+
+	tests := []struct {
+		name       string
+		components []string
+		want       string
+	}{
+		{name: "no components", want: ""},
+		{name: "matching component", components: []string{"Infra"}, want: "../platform"},
+		{name: "case insensitive", components: []string{"frontend"}, want: "../web"},
+		{name: "route order wins", components: []string{"Infra", "Frontend"}, want: "../web"},
+		{name: "unrouted component", components: []string{"Docs"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := router.RepoFor(jiraIssue("X-1", tt.components...).Fields.Components)
+			if got != tt.want {
+				t.Errorf("Expected repo %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	router := NewRouter([]Route{
+		{Component: "Frontend", Repo: "web"},
+		{Component: "Infra", Repo: "platform"},
+	}, "misc")
+
+	jiraExport := &jirapb.Export{
+		Issues: []*jirapb.Issue{
+			jiraIssue("PROJ-1", "Infra"),
+			jiraIssue("PROJ-2", "Frontend"),
+			jiraIssue("PROJ-3", "Infra"),
+			jiraIssue("PROJ-4"),
+		},
+	}
+
+	beadsExport := &beadspb.Export{
+		Epics: []*beadspb.Epic{
+			{Id: "proj-1", Metadata: &beadspb.Metadata{JiraKey: "PROJ-1"}},
+		},
+		Issues: []*beadspb.Issue{
+			{Id: "proj-2", Epic: "proj-1", Metadata: &beadspb.Metadata{JiraKey: "PROJ-2"}},
+			{Id: "proj-3", Epic: "proj-1", Metadata: &beadspb.Metadata{JiraKey: "PROJ-3"}},
+			{Id: "proj-4", Metadata: &beadspb.Metadata{JiraKey: "PROJ-4"}},
+		},
+	}
+
+	exports := router.Split(jiraExport, beadsExport)
+
+	if len(exports) != 3 {
+		t.Fatalf("Expected 3 repositories, got %d", len(exports))
+	}
+
+	platform := exports["platform"]
+	if len(platform.Epics) != 1 || len(platform.Issues) != 1 || platform.Issues[0].Id != "proj-3" {
+		t.Errorf("Unexpected platform export: %v", platform)
+	}
+
+	web := exports["web"]
+	if len(web.Issues) != 1 || web.Issues[0].Id != "proj-2" {
+		t.Errorf("Expected proj-2 in web repo, got %v", web.Issues)
+	}
+	if len(web.Epics) != 1 || web.Epics[0].Id != "proj-1" {
+		t.Errorf("Expected epic proj-1 to be copied into web repo, got %v", web.Epics)
+	}
+
+	misc := exports["misc"]
+	if len(misc.Issues) != 1 || len(misc.Epics) != 0 {
+		t.Errorf("Expected only proj-4 in misc repo, got %v", misc)
+	}
+}
+
+func TestResolveRepo(t *testing.T) {
+	if got := ResolveRepo("/work/app", "../web"); got != "/work/web" {
+		t.Errorf("Expected /work/web, got %s", got)
+	}
+	if got := ResolveRepo("/work/app", "/srv/platform"); got != "/srv/platform" {
+		t.Errorf("Expected /srv/platform, got %s", got)
+	}
+	if got := ResolveRepo("/work/app", ""); got != "/work/app" {
+		t.Errorf("Expected /work/app, got %s", got)
+	}
+}
+
+func TestRenderAll(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	exports := map[string]*beadspb.Export{
+		"web":      {Issues: []*beadspb.Issue{{Id: "proj-2", Title: "Web"}}},
+		"platform": {Issues: []*beadspb.Issue{{Id: "proj-3", Title: "Platform"}}},
+	}
+
+	if _, err := RenderAll(tmpDir, exports, false, beads.FormatJSONL, beads.RendererOptions{}); err != nil {
+		t.Fatalf("RenderAll failed: %v", err)
+	}
+
+	for _, repo := range []string{"web", "platform"} {
+		path := filepath.Join(tmpDir, repo, ".beads", "issues.jsonl")
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to exist: %v", path, err)
+		}
+	}
+}
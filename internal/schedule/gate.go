@@ -0,0 +1,115 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Gate runs sync triggers only while the schedule allows it. Triggers
+// submitted outside a window are queued, coalesced by key, and run in
+// submission order when the next window opens.
+type Gate struct {
+	schedule *Schedule
+	now      func() time.Time
+	after    func(time.Duration) <-chan time.Time
+
+	mu      sync.Mutex
+	order   []string
+	pending map[string]func()
+	wake    chan struct{}
+}
+
+// NewGate creates a gate for a schedule. A nil schedule always allows syncing.
+func NewGate(schedule *Schedule) *Gate {
+	return &Gate{
+		schedule: schedule,
+		now:      time.Now,
+		after:    time.After,
+		pending:  make(map[string]func()),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Submit runs trigger immediately when syncing is allowed and reports true.
+// Otherwise it queues the trigger for Run, replacing any queued trigger
+// with the same key, and reports false.
+func (g *Gate) Submit(key string, trigger func()) bool {
+	if g.schedule.Allowed(g.now()) {
+		trigger()
+		return true
+	}
+
+	g.mu.Lock()
+	if _, queued := g.pending[key]; !queued {
+		g.order = append(g.order, key)
+	}
+	g.pending[key] = trigger
+	g.mu.Unlock()
+
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+	return false
+}
+
+// Pending returns the number of queued triggers
+func (g *Gate) Pending() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.order)
+}
+
+// Run waits for queued triggers and runs them when the next window opens,
+// until ctx is cancelled. Triggers still queued at cancellation are dropped.
+func (g *Gate) Run(ctx context.Context) {
+	for {
+		if g.Pending() == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-g.wake:
+				continue
+			}
+		}
+
+		now := g.now()
+		next, ok := g.schedule.Next(now)
+		if !ok {
+			// Never allowed: wait for cancellation
+			<-ctx.Done()
+			return
+		}
+
+		if next.After(now) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-g.after(next.Sub(now)):
+			}
+			continue // Re-check: the clock may have drifted or jumped
+		}
+
+		for _, trigger := range g.drain() {
+			if ctx.Err() != nil {
+				return
+			}
+			trigger()
+		}
+	}
+}
+
+// drain removes and returns the queued triggers in submission order
+func (g *Gate) drain() []func() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	triggers := make([]func(), 0, len(g.order))
+	for _, key := range g.order {
+		triggers = append(triggers, g.pending[key])
+	}
+	g.order = nil
+	g.pending = make(map[string]func())
+	return triggers
+}
@@ -0,0 +1,126 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for gate tests
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiting chan time.Duration
+	fire    chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan time.Duration, 1), fire: make(chan time.Time)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waiting <- d
+	return c.fire
+}
+
+// advance moves the clock forward and fires the pending timer
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	c.mu.Unlock()
+	c.fire <- now
+}
+
+func TestGateRunsImmediatelyInsideWindow(t *testing.T) {
+	gate := NewGate(businessHours())
+	gate.now = func() time.Time { return at(time.Monday, "10:00") }
+
+	ran := false
+	if !gate.Submit("PROJ-1", func() { ran = true }) {
+		t.Error("Expected Submit to report the trigger ran")
+	}
+	if !ran {
+		t.Error("Expected trigger to run immediately")
+	}
+	if gate.Pending() != 0 {
+		t.Errorf("Expected nothing queued, got %d", gate.Pending())
+	}
+}
+
+func TestGateQueuesUntilWindowOpens(t *testing.T) {
+	clock := newFakeClock(at(time.Monday, "07:00"))
+	gate := NewGate(businessHours())
+	gate.now = clock.Now
+	gate.after = clock.After
+
+	var mu sync.Mutex
+	var ran []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+		}
+	}
+
+	if gate.Submit("PROJ-1", record("first")) {
+		t.Error("Expected trigger outside the window to be queued")
+	}
+	gate.Submit("PROJ-2", record("second"))
+	gate.Submit("PROJ-1", record("first-updated"))
+
+	if gate.Pending() != 2 {
+		t.Errorf("Expected 2 queued triggers after coalescing, got %d", gate.Pending())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		gate.Run(ctx)
+		close(done)
+	}()
+
+	if wait := <-clock.waiting; wait != time.Hour {
+		t.Errorf("Expected to wait 1h for the window, got %v", wait)
+	}
+	clock.advance(time.Hour)
+
+	deadline := time.After(5 * time.Second)
+	for gate.Pending() > 0 {
+		select {
+		case <-deadline:
+			t.Fatal("Timed out waiting for queued triggers")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ran) != 2 || ran[0] != "first-updated" || ran[1] != "second" {
+		t.Errorf("Expected [first-updated second], got %v", ran)
+	}
+}
+
+func TestGateRunStopsOnCancel(t *testing.T) {
+	gate := NewGate(businessHours())
+	gate.now = func() time.Time { return at(time.Saturday, "10:00") }
+	gate.Submit("PROJ-1", func() { t.Error("Expected queued trigger not to run") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	gate.Run(ctx)
+
+	if gate.Pending() != 1 {
+		t.Errorf("Expected trigger to stay queued, got %d", gate.Pending())
+	}
+}
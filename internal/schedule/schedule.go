@@ -0,0 +1,182 @@
+// Package schedule restricts when syncs may run, e.g. to business hours,
+// and blacks out periods such as Jira maintenance.
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// lookahead bounds the search for the next allowed time. Weekly windows
+// repeat within eight days, so anything beyond that is never allowed.
+const lookahead = 8
+
+// Window is a recurring daily period. End at or before Start wraps past
+// midnight, so 22:00–06:00 is an overnight window and Start == End spans a
+// full day.
+type Window struct {
+	Days  []time.Weekday // Days the window starts on; empty means every day
+	Start time.Duration  // Offset from midnight
+	End   time.Duration  // Offset from midnight
+}
+
+// Period is a one-off interval, e.g. announced Jira maintenance
+type Period struct {
+	From  time.Time
+	Until time.Time
+}
+
+// Schedule decides when syncing may run. Without windows syncing is allowed
+// at any time outside the blackouts.
+type Schedule struct {
+	Windows     []Window
+	Blackouts   []Window // Recurring periods when syncing must not run
+	Maintenance []Period // One-off periods when syncing must not run
+	Location    *time.Location
+}
+
+// Allowed reports whether syncing may run at t
+func (s *Schedule) Allowed(t time.Time) bool {
+	if s == nil {
+		return true
+	}
+	t = t.In(s.location())
+
+	for _, period := range s.Maintenance {
+		if !t.Before(period.From) && t.Before(period.Until) {
+			return false
+		}
+	}
+	for _, blackout := range s.Blackouts {
+		if blackout.contains(t) {
+			return false
+		}
+	}
+
+	if len(s.Windows) == 0 {
+		return true
+	}
+	for _, window := range s.Windows {
+		if window.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the earliest time at or after t when syncing may run. The
+// second return value is false when the schedule never allows syncing.
+func (s *Schedule) Next(t time.Time) (time.Time, bool) {
+	if s.Allowed(t) {
+		return t, true
+	}
+
+	// Whether syncing is allowed only changes where a window opens or a
+	// blackout ends, so those are the only candidates
+	loc := s.location()
+	local := t.In(loc)
+	var candidates []time.Time
+	for day := -1; day <= lookahead; day++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, loc)
+		for _, window := range s.Windows {
+			candidates = append(candidates, onClock(date, window.Start))
+		}
+		for _, blackout := range s.Blackouts {
+			candidates = append(candidates, onClock(date, blackout.end()))
+		}
+	}
+	for _, period := range s.Maintenance {
+		candidates = append(candidates, period.Until)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	for _, candidate := range candidates {
+		if candidate.After(t) && s.Allowed(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// location returns the schedule's time zone, defaulting to local time
+func (s *Schedule) location() *time.Location {
+	if s.Location == nil {
+		return time.Local
+	}
+	return s.Location
+}
+
+// contains reports whether t, in the schedule's location, falls in the window
+func (w Window) contains(t time.Time) bool {
+	offset := clock(t)
+	end := w.end()
+
+	if end <= 24*time.Hour {
+		return w.startsOn(t.Weekday()) && offset >= w.Start && offset < end
+	}
+
+	// Wrapping window: the tail of yesterday's or the head of today's
+	yesterday := (t.Weekday() + 6) % 7
+	return (w.startsOn(t.Weekday()) && offset >= w.Start) ||
+		(w.startsOn(yesterday) && offset < end-24*time.Hour)
+}
+
+// clock returns the wall-clock time of day of t as an offset from midnight.
+// It is read from the clock rather than measured from midnight, which is off
+// by the shift on days that change to or from daylight saving time.
+func clock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+// onClock returns the time the wall clock shows offset on the day of date, an
+// offset beyond 24h falling on the following day
+func onClock(date time.Time, offset time.Duration) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, date.Location())
+}
+
+// end returns the window end as an offset from the midnight it starts after,
+// exceeding 24h for windows that wrap past midnight
+func (w Window) end() time.Duration {
+	if w.End <= w.Start {
+		return w.End + 24*time.Hour
+	}
+	return w.End
+}
+
+// startsOn reports whether the window starts on the given weekday
+func (w Window) startsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock parses a time of day such as "08:30" into an offset from midnight
+func ParseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseWeekday parses a weekday name or its three-letter abbreviation
+func ParseWeekday(value string) (time.Weekday, error) {
+	name := strings.ToLower(value)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday %q", value)
+}
@@ -0,0 +1,210 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// at returns a UTC time in the week of Monday 2024-01-01
+func at(day time.Weekday, clock string) time.Time {
+	offset, err := ParseClock(clock)
+	if err != nil {
+		panic(err)
+	}
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return monday.AddDate(0, 0, (int(day)+6)%7).Add(offset)
+}
+
+func businessHours() *Schedule {
+	return &Schedule{
+		Windows: []Window{{
+			Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Start: 8 * time.Hour,
+			End:   18 * time.Hour,
+		}},
+		Location: time.UTC,
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	sched := businessHours()
+	sched.Blackouts = []Window{{Days: []time.Weekday{time.Wednesday}, Start: 12 * time.Hour, End: 13 * time.Hour}}
+	sched.Maintenance = []Period{{From: at(time.Thursday, "09:00"), Until: at(time.Thursday, "11:00")}}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"inside window", at(time.Monday, "10:00"), true},
+		{"window start is inclusive", at(time.Monday, "08:00"), true},
+		{"window end is exclusive", at(time.Monday, "18:00"), false},
+		{"before window", at(time.Monday, "07:59"), false},
+		{"weekend", at(time.Saturday, "10:00"), false},
+		{"recurring blackout", at(time.Wednesday, "12:30"), false},
+		{"after recurring blackout", at(time.Wednesday, "13:00"), true},
+		{"maintenance", at(time.Thursday, "10:00"), false},
+		{"after maintenance", at(time.Thursday, "11:00"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sched.Allowed(tt.t); got != tt.want {
+				t.Errorf("Allowed(%s) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedWithoutWindows(t *testing.T) {
+	var unset *Schedule
+	if !unset.Allowed(time.Now()) {
+		t.Error("Expected a nil schedule to always allow syncing")
+	}
+
+	blackoutOnly := &Schedule{
+		Blackouts: []Window{{Days: []time.Weekday{time.Sunday}, Start: 2 * time.Hour, End: 4 * time.Hour}},
+		Location:  time.UTC,
+	}
+	if !blackoutOnly.Allowed(at(time.Saturday, "03:00")) {
+		t.Error("Expected syncing outside the blackout to be allowed")
+	}
+	if blackoutOnly.Allowed(at(time.Sunday, "03:00")) {
+		t.Error("Expected syncing during the blackout to be blocked")
+	}
+}
+
+func TestAllowedOvernightWindow(t *testing.T) {
+	sched := &Schedule{
+		Windows:  []Window{{Days: []time.Weekday{time.Friday}, Start: 22 * time.Hour, End: 6 * time.Hour}},
+		Location: time.UTC,
+	}
+
+	if !sched.Allowed(at(time.Friday, "23:00")) {
+		t.Error("Expected Friday 23:00 to be inside the overnight window")
+	}
+	if !sched.Allowed(at(time.Saturday, "05:00")) {
+		t.Error("Expected Saturday 05:00 to be inside Friday's overnight window")
+	}
+	if sched.Allowed(at(time.Saturday, "23:00")) {
+		t.Error("Expected Saturday 23:00 to be outside the window")
+	}
+	if sched.Allowed(at(time.Friday, "05:00")) {
+		t.Error("Expected Friday 05:00 to be outside the window")
+	}
+}
+
+func TestAllowedUsesLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	sched := businessHours()
+	sched.Location = tokyo
+
+	// 00:00 UTC Monday is 09:00 Monday in Tokyo
+	if !sched.Allowed(at(time.Monday, "00:00")) {
+		t.Error("Expected the window to be evaluated in the schedule's time zone")
+	}
+}
+
+func TestAllowedOnDaylightSavingChange(t *testing.T) {
+	dublin, err := time.LoadLocation("Europe/Dublin")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	sched := &Schedule{Windows: []Window{{Start: 8 * time.Hour, End: 18 * time.Hour}}, Location: dublin}
+
+	// Clocks go forward an hour at 01:00 on 2024-03-31, so the day is 23h long
+	local := func(hour, minute int) time.Time { return time.Date(2024, 3, 31, hour, minute, 0, 0, dublin) }
+	if !sched.Allowed(local(8, 30)) {
+		t.Error("Expected 08:30 to be inside the window on the day clocks go forward")
+	}
+	if sched.Allowed(local(18, 30)) {
+		t.Error("Expected 18:30 to be outside the window on the day clocks go forward")
+	}
+	if got, ok := sched.Next(local(7, 0)); !ok || !got.Equal(local(8, 0)) {
+		t.Errorf("Expected the window to open at 08:00 local time, got %s (ok=%v)", got, ok)
+	}
+}
+
+func TestNext(t *testing.T) {
+	sched := businessHours()
+	sched.Blackouts = []Window{{Start: 8 * time.Hour, End: 9 * time.Hour}}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"already allowed", at(time.Monday, "10:00"), at(time.Monday, "10:00")},
+		{"blackout at window start", at(time.Monday, "07:00"), at(time.Monday, "09:00")},
+		{"evening", at(time.Monday, "19:00"), at(time.Tuesday, "09:00")},
+		{"weekend", at(time.Saturday, "10:00"), at(time.Monday, "09:00").AddDate(0, 0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sched.Next(tt.t)
+			if !ok {
+				t.Fatal("Expected a next window")
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAfterMaintenance(t *testing.T) {
+	sched := &Schedule{
+		Maintenance: []Period{{From: at(time.Monday, "01:00"), Until: at(time.Monday, "03:30")}},
+		Location:    time.UTC,
+	}
+
+	got, ok := sched.Next(at(time.Monday, "02:00"))
+	if !ok || !got.Equal(at(time.Monday, "03:30")) {
+		t.Errorf("Expected next window at the end of maintenance, got %s (ok=%v)", got, ok)
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	sched := &Schedule{
+		Windows:   []Window{{Start: 8 * time.Hour, End: 9 * time.Hour}},
+		Blackouts: []Window{{Start: 0, End: 0}},
+		Location:  time.UTC,
+	}
+
+	if _, ok := sched.Next(at(time.Monday, "10:00")); ok {
+		t.Error("Expected no next window when a blackout covers every day")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	got, err := ParseClock("08:30")
+	if err != nil {
+		t.Fatalf("ParseClock failed: %v", err)
+	}
+	if got != 8*time.Hour+30*time.Minute {
+		t.Errorf("Expected 8h30m, got %v", got)
+	}
+
+	for _, invalid := range []string{"8", "25:00", "noon"} {
+		if _, err := ParseClock(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	for _, name := range []string{"mon", "Monday", "MON"} {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			t.Fatalf("ParseWeekday(%q) failed: %v", name, err)
+		}
+		if day != time.Monday {
+			t.Errorf("ParseWeekday(%q) = %v, want Monday", name, day)
+		}
+	}
+
+	if _, err := ParseWeekday("funday"); err == nil {
+		t.Error("Expected error for unknown weekday")
+	}
+}
@@ -0,0 +1,146 @@
+package serve
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// TenantsPath prefixes the routes of each tenant served by a Daemon, e.g.
+// /tenants/web/webhook
+const TenantsPath = "/tenants/"
+
+// AdminTenantsPath is the admin endpoint listing a Daemon's tenants
+const AdminTenantsPath = "/admin/tenants"
+
+// Tenant is a beads repository served by a Daemon
+type Tenant struct {
+	Name   string
+	Server *Server
+}
+
+// Daemon serves several beads repositories from one process. Each tenant
+// keeps its own webhook endpoint, secret, debounce batcher, schedule, lock
+// and syncer, so one tenant's failures or lock contention don't hold up the
+// others.
+type Daemon struct {
+	addr       string
+	adminToken string
+	tenants    []Tenant // Sorted by name
+	logf       func(format string, args ...any)
+}
+
+// NewDaemon creates a daemon listening on addr. The admin endpoint requires
+// adminToken as a bearer token, and is not served when it is empty, since
+// it exposes every tenant's status.
+func NewDaemon(addr, adminToken string, tenants []Tenant) (*Daemon, error) {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	sorted := append([]Tenant(nil), tenants...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for i, tenant := range sorted {
+		if tenant.Name == "" || strings.Contains(tenant.Name, "/") {
+			return nil, fmt.Errorf("invalid tenant name %q", tenant.Name)
+		}
+		if i > 0 && sorted[i-1].Name == tenant.Name {
+			return nil, fmt.Errorf("duplicate tenant %q", tenant.Name)
+		}
+	}
+
+	d := &Daemon{
+		addr:       addr,
+		adminToken: adminToken,
+		tenants:    sorted,
+		logf: func(format string, args ...any) {
+			fmt.Printf(format, args...)
+		},
+	}
+	for _, tenant := range sorted {
+		name, server := tenant.Name, tenant.Server
+		server.logf = func(format string, args ...any) {
+			d.logf("[%s] "+format, append([]any{name}, args...)...)
+		}
+		server.record(func(status *Status) {
+			status.Tenant = name
+			status.Webhook = TenantsPath + name + WebhookPath
+		})
+	}
+	return d, nil
+}
+
+// Handler returns the daemon's HTTP routes: each tenant's routes under
+// /tenants/<name>/, metrics for all tenants, the admin endpoint if there is
+// an admin token and a health check
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, tenant := range d.tenants {
+		prefix := TenantsPath + tenant.Name
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, tenant.Server.Handler()))
+	}
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(MetricsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeMetrics(w, d.Statuses())
+	})
+	if d.adminToken != "" {
+		mux.HandleFunc(AdminTenantsPath, d.serveTenants)
+	}
+	return mux
+}
+
+// Statuses returns the status of every tenant, sorted by name
+func (d *Daemon) Statuses() []Status {
+	statuses := make([]Status, 0, len(d.tenants))
+	for _, tenant := range d.tenants {
+		statuses = append(statuses, tenant.Server.Status())
+	}
+	return statuses
+}
+
+// serveTenants lists the tenants and their status as JSON
+func (d *Daemon) serveTenants(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(d.adminToken)) != 1 {
+		http.Error(w, "invalid admin token", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(d.Statuses())
+}
+
+// Run serves every tenant until ctx is cancelled, then stops accepting
+// requests and applies the changes each tenant still has queued
+func (d *Daemon) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", d.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", d.addr, err)
+	}
+	return d.serve(ctx, listener)
+}
+
+// serve runs the daemon on an existing listener
+func (d *Daemon) serve(ctx context.Context, listener net.Listener) error {
+	servers := make([]*Server, 0, len(d.tenants))
+	for _, tenant := range d.tenants {
+		d.logf("Listening for Jira webhooks for %s on %s%s%s%s\n", tenant.Name, listener.Addr(), TenantsPath, tenant.Name, WebhookPath)
+		servers = append(servers, tenant.Server)
+	}
+	if d.adminToken == "" {
+		d.logf("Admin endpoint %s disabled: no admin token configured\n", AdminTenantsPath)
+	}
+	return serveHTTP(ctx, listener, d.Handler(), d.logf, servers)
+}
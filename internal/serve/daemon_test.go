@@ -0,0 +1,174 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/jira/jiratest"
+)
+
+// newTestDaemon creates a daemon with tenants "web" and "platform", each
+// with its own secret, source and repository
+func newTestDaemon(t *testing.T, adminToken string) (*Daemon, map[string]string) {
+	t.Helper()
+	repos := map[string]string{"web": t.TempDir(), "platform": t.TempDir()}
+
+	var tenants []Tenant
+	for name, repo := range repos {
+		source := jiratest.NewFetcher(syncTestIssue(strings.ToUpper(name)+"-1", "Task"))
+		server := NewServer(Config{Secret: name + "-secret", Debounce: time.Hour}, NewSyncer(source, converter.Options{}, repo, false))
+		tenants = append(tenants, Tenant{Name: name, Server: server})
+	}
+
+	daemon, err := NewDaemon("", adminToken, tenants)
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+	daemon.logf = func(string, ...any) {}
+	return daemon, repos
+}
+
+func postWebhook(t *testing.T, client *http.Client, url, key string) int {
+	t.Helper()
+	body := fmt.Sprintf(`{"webhookEvent": "jira:issue_updated", "issue": {"key": %q}}`, key)
+	resp, err := client.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to post webhook: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestNewDaemonRejectsDuplicateTenants(t *testing.T) {
+	server := NewServer(Config{}, NewSyncer(jiratest.NewFetcher(), converter.Options{}, t.TempDir(), false))
+	if _, err := NewDaemon("", "", []Tenant{{Name: "web", Server: server}, {Name: "web", Server: server}}); err == nil {
+		t.Error("Expected an error for duplicate tenants")
+	}
+}
+
+func TestDaemonRoutesWebhooksPerTenant(t *testing.T) {
+	daemon, repos := newTestDaemon(t, "")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- daemon.serve(ctx, listener) }()
+
+	// A client of its own, so its kept-alive connections can be closed
+	// before shutdown, which otherwise waits for them to go idle
+	client := &http.Client{}
+	base := "http://" + listener.Addr().String()
+	if status := postWebhook(t, client, base+"/tenants/web/webhook?secret=web-secret", "WEB-1"); status != http.StatusAccepted {
+		t.Errorf("Expected status 202 for web, got %d", status)
+	}
+	if status := postWebhook(t, client, base+"/tenants/platform/webhook?secret=web-secret", "PLATFORM-1"); status != http.StatusUnauthorized {
+		t.Errorf("Expected another tenant's secret to be rejected, got %d", status)
+	}
+	if status := postWebhook(t, client, base+"/tenants/platform/webhook?secret=platform-secret", "PLATFORM-1"); status != http.StatusAccepted {
+		t.Errorf("Expected status 202 for platform, got %d", status)
+	}
+	if status := postWebhook(t, client, base+"/tenants/mobile/webhook?secret=web-secret", "MOBILE-1"); status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown tenant, got %d", status)
+	}
+
+	client.CloseIdleConnections()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Daemon returned error: %v", err)
+		}
+	case <-time.After(shutdownTimeout + 5*time.Second):
+		t.Fatal("Timed out waiting for shutdown")
+	}
+
+	for name, repo := range repos {
+		issues, err := beads.ReadIssues(repo)
+		if err != nil {
+			t.Fatalf("ReadIssues failed for %s: %v", name, err)
+		}
+		want := strings.ToUpper(name) + "-1"
+		if len(issues) != 1 || issues[0].Metadata["jiraKey"] != want {
+			t.Errorf("Expected only %s in the %s repository, got %v", want, name, issues)
+		}
+	}
+}
+
+func TestDaemonWithoutAdminTokenHasNoAdminEndpoint(t *testing.T) {
+	daemon, _ := newTestDaemon(t, "")
+	for _, tenant := range daemon.tenants {
+		defer tenant.Server.batcher.Stop()
+	}
+
+	rec := httptest.NewRecorder()
+	daemon.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AdminTenantsPath, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for the admin endpoint without an admin token, got %d", rec.Code)
+	}
+}
+
+func TestDaemonAdminAndMetrics(t *testing.T) {
+	daemon, _ := newTestDaemon(t, "admin123")
+	for _, tenant := range daemon.tenants {
+		defer tenant.Server.batcher.Stop()
+	}
+	handler := daemon.Handler()
+
+	request := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	daemon.tenants[1].Server.queue(Change{Issue: "WEB-1"})
+
+	if rec := request(AdminTenantsPath, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without the admin token, got %d", rec.Code)
+	}
+	if rec := request(AdminTenantsPath, "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 with a wrong admin token, got %d", rec.Code)
+	}
+
+	rec := request(AdminTenantsPath, "admin123")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	var statuses []Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &statuses); err != nil {
+		t.Fatalf("Failed to decode tenants: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].Tenant != "platform" || statuses[1].Tenant != "web" {
+		t.Fatalf("Expected tenants platform and web, got %+v", statuses)
+	}
+	if web := statuses[1]; web.Webhook != "/tenants/web/webhook" || web.Queued != 1 || web.ChangesReceived != 1 {
+		t.Errorf("Unexpected web status: %+v", web)
+	}
+
+	body, _ := io.ReadAll(request(MetricsPath, "").Body)
+	for _, line := range []string{
+		`jira_beads_sync_changes_received_total{tenant="platform"} 0`,
+		`jira_beads_sync_changes_received_total{tenant="web"} 1`,
+		`jira_beads_sync_changes_queued{tenant="web"} 1`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
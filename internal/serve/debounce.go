@@ -0,0 +1,98 @@
+package serve
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultDebounce is how long the server waits for further webhook events
+// before applying a batch
+const DefaultDebounce = 5 * time.Second
+
+// maxDelayFactor bounds how long a steady stream of events can postpone a
+// batch, as a multiple of the debounce window
+const maxDelayFactor = 10
+
+// Batcher collects changes and calls ready once no change has arrived for
+// the debounce window, so a burst of events produces a single write.
+// Repeated changes to the same issue are coalesced; the latest wins.
+type Batcher struct {
+	window   time.Duration
+	maxDelay time.Duration
+	ready    func()
+
+	mu      sync.Mutex
+	order   []string
+	pending map[string]Change
+	first   time.Time
+	timer   *time.Timer
+}
+
+// NewBatcher creates a batcher with the given debounce window
+func NewBatcher(window time.Duration, ready func()) *Batcher {
+	return &Batcher{
+		window:   window,
+		maxDelay: maxDelayFactor * window,
+		ready:    ready,
+		pending:  make(map[string]Change),
+	}
+}
+
+// Add queues a change and restarts the debounce window
+func (b *Batcher) Add(change Change) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, queued := b.pending[change.Issue]; !queued {
+		b.order = append(b.order, change.Issue)
+	}
+	b.pending[change.Issue] = change
+
+	now := time.Now()
+	if b.first.IsZero() {
+		b.first = now
+	}
+
+	wait := b.window
+	if remaining := b.maxDelay - now.Sub(b.first); remaining < wait {
+		wait = max(remaining, 0)
+	}
+
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	b.timer = time.AfterFunc(wait, b.ready)
+}
+
+// Len returns the number of queued changes
+func (b *Batcher) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.order)
+}
+
+// Drain removes and returns the queued changes in arrival order
+func (b *Batcher) Drain() []Change {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	changes := make([]Change, 0, len(b.order))
+	for _, issue := range b.order {
+		changes = append(changes, b.pending[issue])
+	}
+	b.order = nil
+	b.pending = make(map[string]Change)
+	b.first = time.Time{}
+	return changes
+}
+
+// Stop cancels a pending ready call. Queued changes stay queued.
+func (b *Batcher) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
@@ -0,0 +1,78 @@
+package serve
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatcherDebouncesBursts(t *testing.T) {
+	var calls int32
+	ready := make(chan struct{}, 10)
+	batcher := NewBatcher(50*time.Millisecond, func() {
+		atomic.AddInt32(&calls, 1)
+		ready <- struct{}{}
+	})
+
+	for i := 0; i < 5; i++ {
+		batcher.Add(Change{Issue: "PROJ-1"})
+		batcher.Add(Change{Issue: "PROJ-2"})
+		time.Sleep(5 * time.Millisecond)
+	}
+	batcher.Add(Change{Issue: "PROJ-1", Deleted: true})
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for batch")
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Expected one ready call for the burst, got %d", got)
+	}
+
+	changes := batcher.Drain()
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 coalesced changes, got %v", changes)
+	}
+	if changes[0] != (Change{Issue: "PROJ-1", Deleted: true}) {
+		t.Errorf("Expected the latest change to PROJ-1 to win, got %+v", changes[0])
+	}
+	if changes[1].Issue != "PROJ-2" {
+		t.Errorf("Expected arrival order to be kept, got %+v", changes[1])
+	}
+	if len(batcher.Drain()) != 0 {
+		t.Error("Expected Drain to empty the batch")
+	}
+}
+
+func TestBatcherMaxDelay(t *testing.T) {
+	ready := make(chan struct{}, 10)
+	batcher := NewBatcher(20*time.Millisecond, func() { ready <- struct{}{} })
+
+	// Events arrive faster than the window, so only the max delay (10× the
+	// window) can release the batch
+	deadline := time.After(5 * time.Second)
+	for {
+		batcher.Add(Change{Issue: "PROJ-1"})
+		select {
+		case <-ready:
+			return
+		case <-deadline:
+			t.Fatal("Expected a steady stream of events to be flushed after the max delay")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestBatcherStop(t *testing.T) {
+	batcher := NewBatcher(20*time.Millisecond, func() { t.Error("Expected stopped batcher not to fire") })
+	batcher.Add(Change{Issue: "PROJ-1"})
+	batcher.Stop()
+	time.Sleep(50 * time.Millisecond)
+
+	if len(batcher.Drain()) != 1 {
+		t.Error("Expected queued changes to survive Stop")
+	}
+}
@@ -0,0 +1,23 @@
+package serve
+
+import "testing"
+
+func FuzzParseEvent(f *testing.F) {
+	f.Add([]byte(`{"webhookEvent":"jira:issue_updated","issue":{"id":"10001","key":"PROJ-1"}}`))
+	f.Add([]byte(`{"webhookEvent":"jira:issue_deleted","issue":{"key":""}}`))
+	f.Add([]byte(`{"webhookEvent":"issuelink_created","issueLink":{"sourceIssueId":10001,"destinationIssueId":10002}}`))
+	f.Add([]byte(`{"webhookEvent":"issuelink_deleted","issueLink":null}`))
+	f.Add([]byte(`{"webhookEvent":`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		changes, err := ParseEvent(body)
+		if err != nil {
+			return
+		}
+		for _, change := range changes {
+			if change.Issue == "" {
+				t.Errorf("Expected every change to name an issue, got %+v", changes)
+			}
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/lock"
+	"github.com/conallob/jira-beads-sync/internal/schedule"
+)
+
+// DefaultAddr is the address the server listens on by default
+const DefaultAddr = ":8080"
+
+// WebhookPath is the URL path Jira webhooks are delivered to
+const WebhookPath = "/webhook"
+
+// shutdownTimeout bounds how long in-flight requests may take on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// Config controls the webhook server
+type Config struct {
+	Addr     string
+	Secret   string
+	Debounce time.Duration
+	Schedule *schedule.Schedule // Batches outside its windows wait for the next one
+	Locker   *lock.Locker       // Held while applying each batch, if set
+	LockWait time.Duration      // How long a batch waits for the lock
+}
+
+// Server receives Jira webhooks and applies the affected issues in
+// debounced batches
+type Server struct {
+	config  Config
+	syncer  *Syncer
+	batcher *Batcher
+	gate    *schedule.Gate
+	logf    func(format string, args ...any)
+
+	applyMu sync.Mutex // Held while a batch is applied, so shutdown waits for it
+
+	statusMu sync.Mutex
+	status   Status
+
+	stopGate func()        // Stops the sync window gate, set by start
+	gateDone chan struct{} // Closed once the gate has stopped
+}
+
+// NewServer creates a webhook server applying changes with syncer
+func NewServer(config Config, syncer *Syncer) *Server {
+	if config.Addr == "" {
+		config.Addr = DefaultAddr
+	}
+	if config.Debounce <= 0 {
+		config.Debounce = DefaultDebounce
+	}
+
+	s := &Server{
+		config: config,
+		syncer: syncer,
+		gate:   schedule.NewGate(config.Schedule),
+		logf: func(format string, args ...any) {
+			fmt.Printf(format, args...)
+		},
+	}
+	s.batcher = NewBatcher(config.Debounce, s.flush)
+	s.status.Repo = syncer.outputDir
+	s.status.Webhook = WebhookPath
+	return s
+}
+
+// Handler returns the server's HTTP routes: the webhook endpoint and a
+// health check
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(WebhookPath, NewHandler(s.config.Secret, s.queue))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(MetricsPath, func(w http.ResponseWriter, r *http.Request) {
+		writeMetrics(w, []Status{s.Status()})
+	})
+	return mux
+}
+
+// Run serves webhooks until ctx is cancelled, then stops accepting
+// requests and applies the changes still queued
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Addr, err)
+	}
+	return s.serve(ctx, listener)
+}
+
+// serve runs the server on an existing listener
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	s.logf("Listening for Jira webhooks on %s%s\n", listener.Addr(), WebhookPath)
+	return serveHTTP(ctx, listener, s.Handler(), s.logf, []*Server{s})
+}
+
+// serveHTTP serves handler on listener until ctx is cancelled, with the
+// servers' sync window gates running meanwhile. On shutdown the servers
+// apply their queued changes.
+func serveHTTP(ctx context.Context, listener net.Listener, handler http.Handler, logf func(format string, args ...any), servers []*Server) error {
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	for _, s := range servers {
+		s.start()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		for _, s := range servers {
+			s.stop()
+		}
+		return fmt.Errorf("webhook server failed: %w", err)
+	}
+
+	logf("Shutting down...\n")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := httpServer.Shutdown(shutdownCtx)
+	if serr := <-serveErr; serr != nil && !errors.Is(serr, http.ErrServerClosed) && err == nil {
+		err = serr
+	}
+
+	// No more events can arrive: apply what is queued
+	for _, s := range servers {
+		s.stop()
+		s.drain()
+	}
+
+	return err
+}
+
+// start runs the sync window gate in the background
+func (s *Server) start() {
+	gateCtx, stopGate := context.WithCancel(context.Background())
+	s.stopGate = stopGate
+	s.gateDone = make(chan struct{})
+	go func() {
+		s.gate.Run(gateCtx)
+		close(s.gateDone)
+	}()
+}
+
+// stop stops the sync window gate and waits for it
+func (s *Server) stop() {
+	s.stopGate()
+	<-s.gateDone
+}
+
+// drain applies the queued changes, unless outside a sync window
+func (s *Server) drain() {
+	s.batcher.Stop()
+	if s.config.Schedule.Allowed(time.Now()) {
+		s.apply()
+	} else if pending := len(s.batcher.Drain()); pending > 0 {
+		s.logf("⚠ Warning: dropping %d queued change(s) outside the sync window\n", pending)
+	}
+}
+
+// queue adds a webhook change to the next batch
+func (s *Server) queue(change Change) {
+	s.record(func(status *Status) { status.ChangesReceived++ })
+	s.batcher.Add(change)
+}
+
+// flush is called when a batch is ready; outside a sync window it is
+// deferred until the window opens
+func (s *Server) flush() {
+	if !s.gate.Submit("webhook", s.apply) {
+		s.logf("Outside the sync window, deferring queued changes\n")
+	}
+}
+
+// apply applies every queued change. With a locker configured the batch
+// waits for the sync lock; if it can't be taken the changes stay queued for
+// the next batch.
+func (s *Server) apply() {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	if s.batcher.Len() == 0 {
+		return
+	}
+
+	lease, err := s.lock()
+	if err != nil {
+		s.logf("⚠ Warning: keeping changes queued: %v\n", err)
+		return
+	}
+	defer func() {
+		if err := lease.Release(context.Background()); err != nil {
+			s.logf("⚠ Warning: %v\n", err)
+		}
+	}()
+
+	changes := s.batcher.Drain()
+	if len(changes) == 0 {
+		return
+	}
+
+	result, err := s.syncer.Apply(changes)
+	s.recordBatch(result, err)
+	if err != nil {
+		s.logf("⚠ Warning: failed to apply %d change(s): %v\n", len(changes), err)
+		return
+	}
+
+	for _, skipped := range result.Skipped {
+		s.logf("⚠ Warning: skipping %s\n", skipped)
+	}
+	for _, warning := range result.Warnings {
+		s.logf("⚠ Warning: %s\n", warning)
+	}
+
+	status := ""
+	if result.Committed {
+		status = " (committed)"
+	}
+	s.logf("✓ Applied %d change(s): %d updated, %d deleted%s\n", len(changes), result.Updated, result.Deleted, status)
+}
+
+// lock takes the sync lock for a batch, or returns a nil lease when no
+// locker is configured
+func (s *Server) lock() (*lock.Lease, error) {
+	if s.config.Locker == nil {
+		return nil, nil
+	}
+	if s.config.LockWait <= 0 {
+		return s.config.Locker.TryAcquire(context.Background())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.LockWait)
+	defer cancel()
+	return s.config.Locker.Acquire(ctx)
+}
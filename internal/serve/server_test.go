@@ -0,0 +1,125 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/lock"
+	"github.com/conallob/jira-beads-sync/jira/jiratest"
+)
+
+func TestServerAppliesWebhooksAndShutsDown(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := jiratest.NewFetcher(syncTestIssue("PROJ-1", "Task"), syncTestIssue("PROJ-2", "Task"))
+	server := NewServer(Config{Secret: "s3cret", Debounce: time.Hour}, NewSyncer(source, converter.Options{}, tmpDir, false))
+	server.logf = func(string, ...any) {}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.serve(ctx, listener) }()
+
+	// A client of its own, so its kept-alive connections can be closed
+	// before shutdown, which otherwise waits for them to go idle
+	client := &http.Client{}
+	url := fmt.Sprintf("http://%s%s?secret=s3cret", listener.Addr(), WebhookPath)
+	for _, key := range []string{"PROJ-1", "PROJ-2", "PROJ-1"} {
+		body := fmt.Sprintf(`{"webhookEvent": "jira:issue_updated", "issue": {"key": %q}}`, key)
+		resp, err := client.Post(url, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Failed to post webhook: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Expected status 202, got %d", resp.StatusCode)
+		}
+	}
+
+	// The debounce window is an hour, so nothing is written until shutdown
+	if issues, _ := beads.ReadIssues(tmpDir); len(issues) != 0 {
+		t.Errorf("Expected no writes before the debounce window ends, got %d issue(s)", len(issues))
+	}
+
+	client.CloseIdleConnections()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Server returned error: %v", err)
+		}
+	case <-time.After(shutdownTimeout + 5*time.Second):
+		t.Fatal("Timed out waiting for shutdown")
+	}
+
+	issues, err := beads.ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected queued changes to be applied on shutdown, got %d issue(s)", len(issues))
+	}
+	if len(source.Calls()) != 2 {
+		t.Errorf("Expected each issue to be fetched once, got %v", source.Calls())
+	}
+
+	status := server.Status()
+	if status.ChangesReceived != 3 || status.Batches != 1 || status.Updated != 2 || status.Queued != 0 {
+		t.Errorf("Unexpected status after shutdown: %+v", status)
+	}
+	if status.LastSync == nil || status.LastError != "" {
+		t.Errorf("Expected a successful last sync, got %+v", status)
+	}
+}
+
+func TestServerKeepsChangesQueuedWhileLocked(t *testing.T) {
+	tmpDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), "sync.lock")
+	other, err := lock.NewFileLocker(lockPath, lock.Options{Owner: "other-runner"}).TryAcquire(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to take lock: %v", err)
+	}
+
+	source := jiratest.NewFetcher(syncTestIssue("PROJ-1", "Task"))
+	server := NewServer(Config{
+		Secret:   "s3cret",
+		Debounce: time.Hour,
+		Locker:   lock.NewFileLocker(lockPath, lock.Options{Owner: "server"}),
+	}, NewSyncer(source, converter.Options{}, tmpDir, false))
+	var logs []string
+	server.logf = func(format string, args ...any) { logs = append(logs, fmt.Sprintf(format, args...)) }
+
+	server.batcher.Add(Change{Issue: "PROJ-1"})
+	defer server.batcher.Stop()
+	server.apply()
+
+	if server.batcher.Len() != 1 {
+		t.Errorf("Expected the change to stay queued while locked, got %d queued", server.batcher.Len())
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "other-runner") {
+		t.Errorf("Expected a warning naming the lock holder, got %v", logs)
+	}
+
+	if err := other.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	server.apply()
+
+	if server.batcher.Len() != 0 {
+		t.Errorf("Expected the change to be applied once unlocked, got %d queued", server.batcher.Len())
+	}
+	if issues, _ := beads.ReadIssues(tmpDir); len(issues) != 1 {
+		t.Errorf("Expected 1 issue written, got %d", len(issues))
+	}
+}
@@ -0,0 +1,115 @@
+package serve
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsPath is the URL path serving Prometheus metrics
+const MetricsPath = "/metrics"
+
+// Status describes a server's repository and its activity since startup
+type Status struct {
+	Tenant          string     `json:"tenant,omitempty"` // Empty when serving a single repository
+	Repo            string     `json:"repo"`
+	Webhook         string     `json:"webhook"` // URL path Jira delivers webhooks to
+	Queued          int        `json:"queued"`  // Changes waiting for the next batch
+	ChangesReceived int64      `json:"changesReceived"`
+	Batches         int64      `json:"batches"`
+	FailedBatches   int64      `json:"failedBatches"`
+	Updated         int64      `json:"updated"`
+	Deleted         int64      `json:"deleted"`
+	Skipped         int64      `json:"skipped"`
+	LastSync        *time.Time `json:"lastSync,omitempty"`  // End of the last successful batch
+	LastError       string     `json:"lastError,omitempty"` // Error of the last batch, if it failed
+}
+
+// Status returns the server's current status
+func (s *Server) Status() Status {
+	s.statusMu.Lock()
+	status := s.status
+	s.statusMu.Unlock()
+
+	status.Queued = s.batcher.Len()
+	return status
+}
+
+// record updates the status
+func (s *Server) record(update func(status *Status)) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	update(&s.status)
+}
+
+// recordBatch adds the outcome of an applied batch to the status
+func (s *Server) recordBatch(result Result, err error) {
+	s.record(func(status *Status) {
+		status.Batches++
+		if err != nil {
+			status.FailedBatches++
+			status.LastError = err.Error()
+			return
+		}
+		status.Updated += int64(result.Updated)
+		status.Deleted += int64(result.Deleted)
+		status.Skipped += int64(len(result.Skipped))
+		now := time.Now()
+		status.LastSync = &now
+		status.LastError = ""
+	})
+}
+
+// metric is a Prometheus metric derived from a status
+type metric struct {
+	name  string
+	kind  string
+	help  string
+	value func(status Status) float64
+}
+
+var metrics = []metric{
+	{"jira_beads_sync_changes_received_total", "counter", "Issue changes received from Jira webhooks.",
+		func(s Status) float64 { return float64(s.ChangesReceived) }},
+	{"jira_beads_sync_changes_queued", "gauge", "Changes waiting for the next batch.",
+		func(s Status) float64 { return float64(s.Queued) }},
+	{"jira_beads_sync_batches_total", "counter", "Batches applied, including failed ones.",
+		func(s Status) float64 { return float64(s.Batches) }},
+	{"jira_beads_sync_batches_failed_total", "counter", "Batches that failed to apply.",
+		func(s Status) float64 { return float64(s.FailedBatches) }},
+	{"jira_beads_sync_records_updated_total", "counter", "Issues and epics written.",
+		func(s Status) float64 { return float64(s.Updated) }},
+	{"jira_beads_sync_records_deleted_total", "counter", "Records removed for deleted issues.",
+		func(s Status) float64 { return float64(s.Deleted) }},
+	{"jira_beads_sync_changes_skipped_total", "counter", "Changes whose issue could not be fetched.",
+		func(s Status) float64 { return float64(s.Skipped) }},
+	{"jira_beads_sync_last_sync_timestamp_seconds", "gauge", "Unix time of the last successful batch, 0 if none.",
+		func(s Status) float64 {
+			if s.LastSync == nil {
+				return 0
+			}
+			return float64(s.LastSync.Unix())
+		}},
+}
+
+// writeMetrics writes the statuses in the Prometheus text format, labelled
+// with their tenant when serving several repositories
+func writeMetrics(w io.Writer, statuses []Status) {
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, status := range statuses {
+			labels := ""
+			if status.Tenant != "" {
+				labels = fmt.Sprintf(`{tenant="%s"}`, escapeLabel(status.Tenant))
+			}
+			fmt.Fprintf(w, "%s%s %s\n", m.name, labels, strconv.FormatFloat(m.value(status), 'f', -1, 64))
+		}
+	}
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
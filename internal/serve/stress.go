@@ -0,0 +1,501 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/integrity"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+	"github.com/conallob/jira-beads-sync/internal/lock"
+)
+
+// Stress test defaults, used when StressOptions doesn't say otherwise
+const (
+	DefaultStressIssues   = 50
+	DefaultStressDuration = 10 * time.Second
+	DefaultStressServers  = 2
+)
+
+// stressSecret signs the simulated webhook deliveries
+const stressSecret = "stress"
+
+// stressLockGrace is how much longer than the run a server waits for the
+// lock, since a configured locker polls no faster than it would in
+// production
+const stressLockGrace = time.Minute
+
+// StressOptions configures a stress test
+type StressOptions struct {
+	OutputDir      string        // Repository the actors write to
+	Issues         int           // Simulated Jira issues, DefaultStressIssues if zero
+	Duration       time.Duration // How long events are generated, DefaultStressDuration if zero
+	Servers        int           // Webhook servers sharing the repository, DefaultStressServers if zero
+	Senders        int           // Concurrent webhook senders, twice the servers if zero
+	DaemonInterval time.Duration // Time between full sync cycles, a tenth of the duration if zero
+	ManualRuns     int           // Manual full syncs started at random times during the run
+
+	// Locker is the lock every actor takes, as configured for real runs; a
+	// file lock in the repository if nil
+	Locker *lock.Locker
+	// ManualRun, if set, performs a manual sync of StressJQL from the
+	// simulated Jira served at jiraURL, e.g. by running the CLI. It must take
+	// the same lock as Locker. Manual runs use an in-process syncer if nil.
+	ManualRun func(ctx context.Context, jiraURL string) error
+}
+
+// StressJQL is the query manual runs sync. The simulated Jira matches every
+// issue for any query.
+const StressJQL = "project = SIM"
+
+// StressReport is the outcome of a stress test
+type StressReport struct {
+	Webhooks     int      // Webhook deliveries accepted
+	Updates      int      // Simulated edits in Jira
+	Batches      int      // Webhook batches applied
+	DaemonCycles int      // Scheduled full syncs completed
+	ManualRuns   int      // Manual full syncs completed
+	Records      int      // Issues in the repository afterwards
+	Problems     []string // Lost updates, corrupted files and failed syncs
+}
+
+// OK reports whether the run found no problems
+func (r *StressReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Print writes the report in a human-readable form
+func (r *StressReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "Webhooks delivered: %d (%d edit(s) in Jira)\n", r.Webhooks, r.Updates)
+	fmt.Fprintf(w, "Webhook batches:    %d\n", r.Batches)
+	fmt.Fprintf(w, "Daemon cycles:      %d\n", r.DaemonCycles)
+	fmt.Fprintf(w, "Manual runs:        %d\n", r.ManualRuns)
+	fmt.Fprintf(w, "Issues written:     %d\n\n", r.Records)
+
+	if r.OK() {
+		fmt.Fprintln(w, "✓ No lost updates or corrupted files")
+		return
+	}
+	fmt.Fprintf(w, "✗ %d problem(s) found:\n", len(r.Problems))
+	for _, problem := range r.Problems {
+		fmt.Fprintf(w, "  - %s\n", problem)
+	}
+}
+
+// Stress simulates webhook deliveries, scheduled daemon cycles and manual
+// runs against one repository at the same time, then checks that the
+// files still parse, match their integrity manifest and hold the latest
+// revision of every issue a webhook was delivered for. Every actor has its
+// own syncer and takes a file lock in the repository, as separate
+// processes would. Jira is simulated in memory, so no credentials are
+// needed.
+func Stress(ctx context.Context, opts StressOptions) (*StressReport, error) {
+	if opts.Issues <= 0 {
+		opts.Issues = DefaultStressIssues
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = DefaultStressDuration
+	}
+	if opts.Servers <= 0 {
+		opts.Servers = DefaultStressServers
+	}
+	if opts.Senders <= 0 {
+		opts.Senders = 2 * opts.Servers
+	}
+	if opts.DaemonInterval <= 0 {
+		opts.DaemonInterval = opts.Duration / 10
+	}
+
+	source := newStressJira(opts.Issues)
+	locker := opts.Locker
+	if locker == nil {
+		locker = lock.NewFileLocker(filepath.Join(opts.OutputDir, "stress.lock"), lock.Options{
+			Owner:         "stress",
+			RetryInterval: 5 * time.Millisecond,
+		})
+	}
+	newSyncer := func() *Syncer {
+		syncer := NewSyncer(source, converter.Options{}, opts.OutputDir, false)
+		syncer.SetManifest(nil)
+		return syncer
+	}
+
+	report := &StressReport{}
+	var mu sync.Mutex
+	problem := func(format string, args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Problems = append(report.Problems, fmt.Sprintf(format, args...))
+	}
+	count := func(n *int) {
+		mu.Lock()
+		defer mu.Unlock()
+		*n++
+	}
+
+	// Seed the repository so that every actor starts from existing files
+	if _, err := newSyncer().Apply(source.all()); err != nil {
+		return nil, fmt.Errorf("failed to seed repository: %w", err)
+	}
+
+	servers := make([]*Server, opts.Servers)
+	handlers := make([]http.Handler, opts.Servers)
+	for i := range servers {
+		servers[i] = NewServer(Config{
+			Secret:   stressSecret,
+			Debounce: 20 * time.Millisecond,
+			Locker:   locker,
+			LockWait: opts.Duration + stressLockGrace,
+		}, newSyncer())
+		servers[i].logf = func(format string, args ...any) {
+			if strings.Contains(format, "Warning") {
+				problem("server %d: %s", i, strings.TrimSpace(fmt.Sprintf(format, args...)))
+			}
+		}
+		servers[i].start()
+		handlers[i] = servers[i].Handler()
+	}
+
+	// fullSync applies every issue under the lock, like a scheduled or
+	// manual sync run
+	fullSync := func(name string, n *int) {
+		lease, err := locker.Acquire(ctx)
+		if err != nil {
+			problem("%s: %v", name, err)
+			return
+		}
+		defer func() {
+			if err := lease.Release(context.Background()); err != nil {
+				problem("%s: %v", name, err)
+			}
+		}()
+		if _, err := newSyncer().Apply(source.all()); err != nil {
+			problem("%s: %v", name, err)
+			return
+		}
+		count(n)
+	}
+
+	// manualRun syncs through the configured manual run, which talks to the
+	// simulated Jira over HTTP like any Jira client
+	manualRun := func() { fullSync("manual run", &report.ManualRuns) }
+	if opts.ManualRun != nil {
+		jiraServer := httptest.NewServer(source.searchHandler())
+		defer jiraServer.Close()
+		manualRun = func() {
+			if err := opts.ManualRun(ctx, jiraServer.URL); err != nil {
+				problem("manual run: %v", err)
+				return
+			}
+			count(&report.ManualRuns)
+		}
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+	var wg sync.WaitGroup
+
+	for i := 0; i < opts.Senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for runCtx.Err() == nil {
+				key := source.update(rand.IntN(opts.Issues))
+				body := fmt.Sprintf(`{"webhookEvent": %q, "issue": {"key": %q}}`, EventIssueUpdated, key)
+				req := httptest.NewRequest(http.MethodPost, WebhookPath+"?secret="+stressSecret, strings.NewReader(body))
+				recorder := httptest.NewRecorder()
+				handlers[rand.IntN(len(handlers))].ServeHTTP(recorder, req)
+				if recorder.Code != http.StatusAccepted {
+					problem("webhook for %s returned status %d", key, recorder.Code)
+					continue
+				}
+				count(&report.Webhooks)
+				time.Sleep(time.Duration(rand.IntN(5)) * time.Millisecond)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(opts.DaemonInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				fullSync("daemon cycle", &report.DaemonCycles)
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		watcher := newStressWatcher(opts.OutputDir)
+		for runCtx.Err() == nil {
+			watcher.check(problem)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	for i := 0; i < opts.ManualRuns; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-runCtx.Done():
+			case <-time.After(rand.N(opts.Duration)):
+				manualRun()
+			}
+		}()
+	}
+
+	wg.Wait()
+	for _, server := range servers {
+		server.stop()
+		server.drain()
+		status := server.Status()
+		report.Batches += int(status.Batches - status.FailedBatches)
+		if status.Queued > 0 {
+			problem("%d change(s) still queued after shutdown", status.Queued)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Updates = source.updates()
+	report.Records = verifyStress(opts.OutputDir, source, problem)
+	return report, nil
+}
+
+// verifyStress checks the repository after a stress run and returns the
+// number of issues in it
+func verifyStress(outputDir string, source *stressJira, problem func(format string, args ...any)) int {
+	result, err := integrity.Verify(outputDir, nil)
+	if err != nil {
+		problem("%v", err)
+	} else {
+		for _, p := range result.Problems {
+			problem("integrity manifest: %s", p)
+		}
+	}
+
+	issues, err := beads.ReadIssues(outputDir)
+	if err != nil {
+		problem("%v", err)
+		return 0
+	}
+
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		key, rev, err := stressRevision(issue)
+		if err != nil {
+			problem("%v", err)
+			continue
+		}
+		if seen[key] {
+			problem("%s is written more than once", key)
+		}
+		seen[key] = true
+		if want := source.revision(key); rev != want {
+			problem("lost update: %s is at revision %d, Jira is at %d", key, rev, want)
+		}
+	}
+	for i := 0; i < source.size(); i++ {
+		if key := stressKey(i); !seen[key] {
+			problem("%s is missing", key)
+		}
+	}
+	return len(issues)
+}
+
+// stressWatcher reads the repository while a stress run writes to it, as
+// git or bd would, and reports files that don't parse and issues going
+// back to an older revision. The final state alone can't show the latter,
+// since a later sync may have repaired it.
+type stressWatcher struct {
+	outputDir string
+	revisions map[string]int
+	reported  map[string]bool
+}
+
+func newStressWatcher(outputDir string) *stressWatcher {
+	return &stressWatcher{
+		outputDir: outputDir,
+		revisions: make(map[string]int),
+		reported:  make(map[string]bool),
+	}
+}
+
+// check reads the repository once
+func (w *stressWatcher) check(problem func(format string, args ...any)) {
+	issues, err := beads.ReadIssues(w.outputDir)
+	if err != nil {
+		if !w.reported[""] {
+			w.reported[""] = true
+			problem("read during the run: %v", err)
+		}
+		return
+	}
+	for _, issue := range issues {
+		key, rev, err := stressRevision(issue)
+		if err != nil {
+			continue // Reported by verifyStress
+		}
+		if last := w.revisions[key]; rev < last {
+			if !w.reported[key] {
+				w.reported[key] = true
+				problem("lost update: %s went back from revision %d to %d during the run", key, last, rev)
+			}
+			continue
+		}
+		w.revisions[key] = rev
+	}
+}
+
+// stressRevision returns the Jira key and revision of a written issue
+func stressRevision(issue *beads.BeadsIssue) (string, int, error) {
+	key := issue.Metadata["jiraKey"]
+	var rev int
+	if _, err := fmt.Sscanf(issue.Title, "Issue "+key+" rev %d", &rev); err != nil {
+		return key, 0, fmt.Errorf("%s has an unexpected title %q", key, issue.Title)
+	}
+	return key, rev, nil
+}
+
+// stressJira is a simulated Jira whose issues are edited during a stress
+// run. Each edit bumps the issue's revision, which is part of its summary.
+type stressJira struct {
+	mu        sync.Mutex
+	revisions []int
+	edits     int
+}
+
+var _ jira.Fetcher = (*stressJira)(nil)
+
+func newStressJira(issues int) *stressJira {
+	return &stressJira{revisions: make([]int, issues)}
+}
+
+func stressKey(i int) string {
+	return fmt.Sprintf("SIM-%d", i+1)
+}
+
+// update edits an issue and returns its key
+func (j *stressJira) update(i int) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.revisions[i]++
+	j.edits++
+	return stressKey(i)
+}
+
+func (j *stressJira) updates() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.edits
+}
+
+func (j *stressJira) size() int {
+	return len(j.revisions)
+}
+
+func (j *stressJira) revision(key string) int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var n int
+	if _, err := fmt.Sscanf(key, "SIM-%d", &n); err != nil || n < 1 || n > len(j.revisions) {
+		return -1
+	}
+	return j.revisions[n-1]
+}
+
+// all returns a change for every issue
+func (j *stressJira) all() []Change {
+	changes := make([]Change, len(j.revisions))
+	for i := range changes {
+		changes[i] = Change{Issue: stressKey(i)}
+	}
+	return changes
+}
+
+// FetchIssue implements jira.Fetcher
+func (j *stressJira) FetchIssue(issueKey string) (*jirapb.Issue, error) {
+	rev := j.revision(issueKey)
+	if rev < 0 {
+		return nil, fmt.Errorf("jira API returned status 404: issue %s does not exist", issueKey)
+	}
+	return &jirapb.Issue{
+		Id:  issueKey + "-id",
+		Key: issueKey,
+		Fields: &jirapb.Fields{
+			Summary:   fmt.Sprintf("Issue %s rev %d", issueKey, rev),
+			IssueType: &jirapb.IssueType{Name: "Task"},
+			Status:    &jirapb.Status{Name: "To Do", StatusCategory: &jirapb.StatusCategory{Key: "new"}},
+			Priority:  &jirapb.Priority{Name: "Medium"},
+		},
+	}, nil
+}
+
+// searchHandler serves the issues from the Jira search API, page by page,
+// so manual runs can sync through a real Jira client. Every query matches
+// every issue.
+func (j *stressJira) searchHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			http.NotFound(w, r)
+			return
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, err := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		if err != nil || maxResults <= 0 {
+			maxResults = 50
+		}
+
+		issues := []map[string]any{}
+		for i := max(startAt, 0); i < min(startAt+maxResults, j.size()); i++ {
+			key := stressKey(i)
+			issues = append(issues, map[string]any{
+				"id":  key + "-id",
+				"key": key,
+				"fields": map[string]any{
+					"summary":   fmt.Sprintf("Issue %s rev %d", key, j.revision(key)),
+					"issuetype": map[string]any{"name": "Task"},
+					"status":    map[string]any{"name": "To Do", "statusCategory": map[string]any{"key": "new"}},
+					"priority":  map[string]any{"name": "Medium"},
+				},
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"startAt":    startAt,
+			"maxResults": maxResults,
+			"total":      j.size(),
+			"issues":     issues,
+		})
+	})
+}
+
+// FetchIssueWithDependencies implements jira.Fetcher
+func (j *stressJira) FetchIssueWithDependencies(issueKey string) (*jirapb.Export, error) {
+	issue, err := j.FetchIssue(issueKey)
+	if err != nil {
+		return nil, err
+	}
+	return &jirapb.Export{Issues: []*jirapb.Issue{issue}}, nil
+}
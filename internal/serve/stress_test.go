@@ -0,0 +1,100 @@
+package serve
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+	"github.com/conallob/jira-beads-sync/internal/lock"
+)
+
+func TestStress(t *testing.T) {
+	report, err := Stress(context.Background(), StressOptions{
+		OutputDir:      t.TempDir(),
+		Issues:         100,
+		Duration:       500 * time.Millisecond,
+		DaemonInterval: 100 * time.Millisecond,
+		ManualRuns:     2,
+	})
+	if err != nil {
+		t.Fatalf("Stress failed: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("Expected no problems, got:\n%s", strings.Join(report.Problems, "\n"))
+	}
+	if report.Webhooks == 0 || report.Batches == 0 || report.DaemonCycles == 0 {
+		t.Errorf("Expected webhooks, batches and daemon cycles, got %+v", report)
+	}
+	if report.Records != 100 {
+		t.Errorf("Expected 100 issues, got %d", report.Records)
+	}
+}
+
+func TestStressManualRunUsesJiraAPI(t *testing.T) {
+	tmpDir := t.TempDir()
+	locker := lock.NewFileLocker(tmpDir+"/custom.lock", lock.Options{RetryInterval: 5 * time.Millisecond})
+
+	var runs atomic.Int32
+	report, err := Stress(context.Background(), StressOptions{
+		OutputDir:      tmpDir,
+		Issues:         60,
+		Duration:       300 * time.Millisecond,
+		DaemonInterval: 100 * time.Millisecond,
+		ManualRuns:     2,
+		Locker:         locker,
+		ManualRun: func(ctx context.Context, jiraURL string) error {
+			lease, err := locker.Acquire(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = lease.Release(context.Background()) }()
+
+			export, err := jira.NewClient(jiraURL, "stress", "stress", "basic").FetchByJQLContext(ctx, StressJQL)
+			if err != nil {
+				return err
+			}
+			if len(export.Issues) != 60 {
+				t.Errorf("Expected 60 issues from the simulated Jira, got %d", len(export.Issues))
+			}
+			for _, issue := range export.Issues {
+				if !strings.HasPrefix(issue.Fields.Summary, "Issue "+issue.Key+" rev ") {
+					t.Errorf("Unexpected summary %q for %s", issue.Fields.Summary, issue.Key)
+				}
+			}
+			runs.Add(1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Stress failed: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("Expected no problems, got:\n%s", strings.Join(report.Problems, "\n"))
+	}
+	if runs.Load() != 2 || report.ManualRuns != 2 {
+		t.Errorf("Expected 2 manual runs, got %d (reported %d)", runs.Load(), report.ManualRuns)
+	}
+}
+
+func TestVerifyStressFindsLostUpdates(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := newStressJira(2)
+	syncer := NewSyncer(source, converter.Options{}, tmpDir, false)
+	syncer.SetManifest(nil)
+	if _, err := syncer.Apply(source.all()); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	source.update(1)
+
+	var problems []string
+	verifyStress(tmpDir, source, func(format string, args ...any) {
+		problems = append(problems, format)
+	})
+	if len(problems) != 1 {
+		t.Errorf("Expected the edit of SIM-2 to be reported lost, got %v", problems)
+	}
+}
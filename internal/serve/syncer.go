@@ -0,0 +1,195 @@
+package serve
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/integrity"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// Result summarises an applied batch
+type Result struct {
+	Updated   int      // Issues and epics written
+	Deleted   int      // Records removed
+	Skipped   []string // Changes whose issue could not be fetched, with the reason
+	Warnings  converter.Warnings
+	Committed bool
+}
+
+// Syncer applies batches of changes to a beads repository. Changed issues
+// are re-fetched, converted and merged into the existing JSONL files;
+// deleted issues are removed from them.
+type Syncer struct {
+	source    jira.Fetcher
+	options   converter.Options
+	outputDir string
+	commit    bool
+	runGit    func(dir string, args ...string) error
+
+	format     beads.Format         // Output format, JSONL when empty
+	durability beads.Durability     // When written files are flushed, batch when empty
+	provenance *beads.SourceOptions // Source block added to each record, if set
+	manifest   bool                 // Write the integrity manifest after each batch
+	signingKey ed25519.PrivateKey   // Signs the manifest, if set
+
+	mu sync.Mutex // Serialises batches
+}
+
+// NewSyncer creates a syncer writing to the .beads directory in outputDir.
+// With commit set, each batch that changes the files is committed to git.
+func NewSyncer(source jira.Fetcher, opts converter.Options, outputDir string, commit bool) *Syncer {
+	return &Syncer{
+		source:    source,
+		options:   opts,
+		outputDir: outputDir,
+		commit:    commit,
+		runGit:    runGit,
+	}
+}
+
+// SetFormat selects the output format, JSONL by default
+func (s *Syncer) SetFormat(format beads.Format) {
+	s.format = format
+}
+
+// SetDurability selects when written files are flushed to stable storage
+func (s *Syncer) SetDurability(durability beads.Durability) {
+	s.durability = durability
+}
+
+// SetSource adds a source block to every record written
+func (s *Syncer) SetSource(source *beads.SourceOptions) {
+	s.provenance = source
+}
+
+// SetManifest writes the integrity manifest after each batch, signed with
+// key unless it is nil
+func (s *Syncer) SetManifest(key ed25519.PrivateKey) {
+	s.manifest = true
+	s.signingKey = key
+}
+
+// Apply fetches, converts and writes one batch of changes
+func (s *Syncer) Apply(changes []Change) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result Result
+	var deleted []string
+	export := &jirapb.Export{}
+	fetched := make(map[string]bool)
+
+	fetch := func(issue string) *jirapb.Issue {
+		jiraIssue, err := s.source.FetchIssue(issue)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", issue, err))
+			return nil
+		}
+		if fetched[jiraIssue.Key] {
+			return nil
+		}
+		fetched[jiraIssue.Key] = true
+		export.Issues = append(export.Issues, jiraIssue)
+		return jiraIssue
+	}
+
+	classifier := converter.NewProtoConverterWithOptions(s.options)
+	for _, change := range changes {
+		if change.Deleted {
+			deleted = append(deleted, change.Issue)
+			continue
+		}
+		jiraIssue := fetch(change.Issue)
+		if jiraIssue == nil {
+			continue
+		}
+
+		// The converter only links issues to epics it converts, so the parent
+		// epic is refreshed alongside the issue
+		if parent := jiraIssue.Fields.Parent; parent != nil && classifier.IsEpicType(parent.Fields.GetIssueType()) && !fetched[parent.Key] {
+			fetch(parent.Key)
+		}
+	}
+
+	renderer, err := beads.NewRendererWithOptions(s.outputDir, s.format, beads.RendererOptions{Durability: s.durability, Source: s.provenance})
+	if err != nil {
+		return result, err
+	}
+
+	if len(export.Issues) > 0 {
+		protoConverter := converter.NewProtoConverterWithOptions(s.options)
+		beadsExport, warnings, err := protoConverter.ConvertWithWarnings(export)
+		if err != nil {
+			return result, fmt.Errorf("failed to convert: %w", err)
+		}
+		// Issues labelled to opt out since the last sync, or of skipped
+		// types, are removed
+		deleted = append(deleted, protoConverter.OptedOut()...)
+		deleted = append(deleted, protoConverter.SkippedTypes()...)
+		if err := renderer.MergeExport(beadsExport); err != nil {
+			return result, fmt.Errorf("failed to render: %w", err)
+		}
+		result.Updated = len(beadsExport.Issues) + len(beadsExport.Epics)
+		result.Warnings = warnings
+	}
+
+	if len(deleted) > 0 {
+		removed, err := renderer.RemoveJiraKeys(deleted)
+		if err != nil {
+			return result, fmt.Errorf("failed to remove deleted issues: %w", err)
+		}
+		result.Deleted = removed
+	}
+
+	if s.manifest && (result.Updated > 0 || result.Deleted > 0) {
+		if err := integrity.Write(s.outputDir, s.signingKey); err != nil {
+			return result, fmt.Errorf("failed to write integrity manifest: %w", err)
+		}
+	}
+
+	if s.commit && (result.Updated > 0 || result.Deleted > 0) {
+		committed, err := s.commitChanges(result)
+		if err != nil {
+			return result, err
+		}
+		result.Committed = committed
+	}
+
+	return result, nil
+}
+
+// commitChanges commits the .beads directory, reporting false when the
+// batch left it unchanged
+func (s *Syncer) commitChanges(result Result) (bool, error) {
+	if err := s.runGit(s.outputDir, "add", "--", ".beads"); err != nil {
+		return false, fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	// Exit status 0 means nothing is staged
+	if err := s.runGit(s.outputDir, "diff", "--cached", "--quiet", "--", ".beads"); err == nil {
+		return false, nil
+	}
+
+	message := fmt.Sprintf("Sync from Jira: %d updated, %d deleted", result.Updated, result.Deleted)
+	if err := s.runGit(s.outputDir, "commit", "--quiet", "-m", message, "--", ".beads"); err != nil {
+		return false, fmt.Errorf("failed to commit changes: %w", err)
+	}
+	return true, nil
+}
+
+// runGit runs a git command in dir
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
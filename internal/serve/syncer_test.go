@@ -0,0 +1,140 @@
+package serve
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	jirapb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/integrity"
+	"github.com/conallob/jira-beads-sync/jira/jiratest"
+)
+
+func syncTestIssue(key, issueType string) *jirapb.Issue {
+	return &jirapb.Issue{
+		Id:  key + "-id",
+		Key: key,
+		Fields: &jirapb.Fields{
+			Summary:   "Issue " + key,
+			IssueType: &jirapb.IssueType{Name: issueType},
+			Status:    &jirapb.Status{Name: "To Do", StatusCategory: &jirapb.StatusCategory{Key: "new"}},
+			Priority:  &jirapb.Priority{Name: "Medium"},
+		},
+	}
+}
+
+func TestSyncerApplyUpdatesAndDeletes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	epic := syncTestIssue("PROJ-10", "Epic")
+	story := syncTestIssue("PROJ-1", "Story")
+	story.Fields.Parent = &jirapb.Parent{
+		Key:    "PROJ-10",
+		Fields: &jirapb.LinkedFields{IssueType: &jirapb.IssueType{Name: "Epic"}},
+	}
+	other := syncTestIssue("PROJ-2", "Task")
+
+	source := jiratest.NewFetcher(epic, story, other)
+	syncer := NewSyncer(source, converter.Options{}, tmpDir, false)
+
+	result, err := syncer.Apply([]Change{{Issue: "PROJ-1"}, {Issue: "PROJ-2-id"}})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Updated != 3 {
+		t.Errorf("Expected 3 records updated (story, task and parent epic), got %d", result.Updated)
+	}
+
+	issues, err := beads.ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Epic != "proj-10" {
+		t.Errorf("Expected story to stay linked to its epic, got %q", issues[0].Epic)
+	}
+
+	result, err = syncer.Apply([]Change{{Issue: "PROJ-2", Deleted: true}, {Issue: "PROJ-404"}})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Deleted != 1 {
+		t.Errorf("Expected 1 record deleted, got %d", result.Deleted)
+	}
+	if len(result.Skipped) != 1 || !strings.HasPrefix(result.Skipped[0], "PROJ-404") {
+		t.Errorf("Expected PROJ-404 to be skipped, got %v", result.Skipped)
+	}
+
+	issues, err = beads.ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "proj-1" {
+		t.Errorf("Expected only proj-1 to remain, got %v", issues)
+	}
+}
+
+func TestSyncerApplyCommits(t *testing.T) {
+	tmpDir := t.TempDir()
+	syncer := NewSyncer(jiratest.NewFetcher(syncTestIssue("PROJ-1", "Task")), converter.Options{}, tmpDir, true)
+
+	var commands []string
+	staged := true
+	syncer.runGit = func(dir string, args ...string) error {
+		commands = append(commands, strings.Join(args, " "))
+		if args[0] == "diff" && staged {
+			return errors.New("exit status 1") // Changes are staged
+		}
+		return nil
+	}
+
+	result, err := syncer.Apply([]Change{{Issue: "PROJ-1"}})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !result.Committed {
+		t.Error("Expected batch to be committed")
+	}
+	if len(commands) != 3 || !strings.HasPrefix(commands[2], "commit") {
+		t.Errorf("Expected add, diff and commit, got %v", commands)
+	}
+
+	commands = nil
+	staged = false
+	result, err = syncer.Apply([]Change{{Issue: "PROJ-1"}})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Committed {
+		t.Error("Expected unchanged batch not to be committed")
+	}
+	if len(commands) != 2 {
+		t.Errorf("Expected add and diff only, got %v", commands)
+	}
+}
+
+func TestSyncerApplyWritesManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := jiratest.NewFetcher(syncTestIssue("PROJ-1", "Task"), syncTestIssue("PROJ-2", "Task"))
+	syncer := NewSyncer(source, converter.Options{}, tmpDir, false)
+	syncer.SetManifest(nil)
+
+	if _, err := syncer.Apply([]Change{{Issue: "PROJ-1"}}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if _, err := syncer.Apply([]Change{{Issue: "PROJ-2"}}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	result, err := integrity.Verify(tmpDir, nil)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(result.Problems) != 0 {
+		t.Errorf("Expected manifest to match after each batch, got %v", result.Problems)
+	}
+}
@@ -0,0 +1,160 @@
+// Package serve keeps a beads repository in sync with Jira by applying
+// Jira webhook events as they arrive.
+package serve
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Jira webhook events that trigger a sync
+const (
+	EventIssueCreated     = "jira:issue_created"
+	EventIssueUpdated     = "jira:issue_updated"
+	EventIssueDeleted     = "jira:issue_deleted"
+	EventIssueLinkCreated = "issuelink_created"
+	EventIssueLinkDeleted = "issuelink_deleted"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body for webhooks
+// registered with a secret
+const SignatureHeader = "X-Hub-Signature"
+
+// maxPayloadSize bounds webhook bodies; issue payloads with a changelog
+// rarely exceed a few hundred kilobytes
+const maxPayloadSize = 5 << 20
+
+// Change is an issue affected by a webhook event
+type Change struct {
+	Issue   string // Issue key, or numeric ID for link events
+	Deleted bool
+}
+
+type webhookPayload struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        *struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"issue,omitempty"`
+	IssueLink *struct {
+		SourceIssueID      json.Number `json:"sourceIssueId"`
+		DestinationIssueID json.Number `json:"destinationIssueId"`
+	} `json:"issueLink,omitempty"`
+}
+
+// ParseEvent returns the issues affected by a webhook payload. Events other
+// than issue and issue link changes affect nothing.
+func ParseEvent(body []byte) ([]Change, error) {
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	switch payload.WebhookEvent {
+	case EventIssueCreated, EventIssueUpdated, EventIssueDeleted:
+		if payload.Issue == nil || payload.Issue.Key == "" {
+			return nil, fmt.Errorf("%s event has no issue key", payload.WebhookEvent)
+		}
+		return []Change{{
+			Issue:   payload.Issue.Key,
+			Deleted: payload.WebhookEvent == EventIssueDeleted,
+		}}, nil
+	case EventIssueLinkCreated, EventIssueLinkDeleted:
+		if payload.IssueLink == nil {
+			return nil, fmt.Errorf("%s event has no issue link", payload.WebhookEvent)
+		}
+		// Both ends of the link may have changed dependencies
+		var changes []Change
+		for _, id := range []json.Number{payload.IssueLink.SourceIssueID, payload.IssueLink.DestinationIssueID} {
+			if id != "" {
+				changes = append(changes, Change{Issue: id.String()})
+			}
+		}
+		return changes, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Handler receives Jira webhooks and passes the affected issues on
+type Handler struct {
+	secret   string
+	onChange func(Change)
+}
+
+// NewHandler creates a webhook handler. Requests must be signed with secret
+// (X-Hub-Signature) or carry it as the "secret" query parameter.
+func NewHandler(secret string, onChange func(Change)) *Handler {
+	return &Handler{
+		secret:   secret,
+		onChange: onChange,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if !h.authorized(r, body) {
+		http.Error(w, "invalid webhook secret", http.StatusUnauthorized)
+		return
+	}
+
+	changes, err := ParseEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, change := range changes {
+		h.onChange(change)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authorized checks the request signature, falling back to the secret
+// query parameter used by webhooks registered without signing
+func (h *Handler) authorized(r *http.Request, body []byte) bool {
+	if signature := r.Header.Get(SignatureHeader); signature != "" {
+		return validSignature(h.secret, body, signature)
+	}
+	secret := r.URL.Query().Get("secret")
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(h.secret)) == 1
+}
+
+// validSignature verifies a "sha256=<hex>" HMAC of body
+func validSignature(secret string, body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
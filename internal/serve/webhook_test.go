@@ -0,0 +1,141 @@
+package serve
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []Change
+		wantErr bool
+	}{
+		{
+			name: "issue updated",
+			body: `{"webhookEvent": "jira:issue_updated", "issue": {"id": "10001", "key": "PROJ-1"}}`,
+			want: []Change{{Issue: "PROJ-1"}},
+		},
+		{
+			name: "issue deleted",
+			body: `{"webhookEvent": "jira:issue_deleted", "issue": {"id": "10001", "key": "PROJ-1"}}`,
+			want: []Change{{Issue: "PROJ-1", Deleted: true}},
+		},
+		{
+			name: "link created",
+			body: `{"webhookEvent": "issuelink_created", "issueLink": {"id": 5, "sourceIssueId": 10001, "destinationIssueId": 10002}}`,
+			want: []Change{{Issue: "10001"}, {Issue: "10002"}},
+		},
+		{
+			name: "unrelated event",
+			body: `{"webhookEvent": "sprint_started"}`,
+		},
+		{
+			name:    "issue event without key",
+			body:    `{"webhookEvent": "jira:issue_created", "issue": {}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			body:    `{`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEvent([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d change(s), got %v", len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Change %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerAuthentication(t *testing.T) {
+	const secret = "s3cret"
+	const body = `{"webhookEvent": "jira:issue_updated", "issue": {"key": "PROJ-1"}}`
+
+	tests := []struct {
+		name       string
+		target     string
+		signature  string
+		wantStatus int
+	}{
+		{"valid signature", "/webhook", sign(secret, body), http.StatusAccepted},
+		{"wrong signature", "/webhook", sign("other", body), http.StatusUnauthorized},
+		{"malformed signature", "/webhook", "md5=abc", http.StatusUnauthorized},
+		{"valid query secret", "/webhook?secret=s3cret", "", http.StatusAccepted},
+		{"wrong query secret", "/webhook?secret=guess", "", http.StatusUnauthorized},
+		{"no secret", "/webhook", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes []Change
+			handler := NewHandler(secret, func(change Change) { changes = append(changes, change) })
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(SignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			accepted := tt.wantStatus == http.StatusAccepted
+			if accepted && len(changes) != 1 {
+				t.Errorf("Expected 1 change, got %d", len(changes))
+			}
+			if !accepted && len(changes) != 0 {
+				t.Errorf("Expected rejected request to produce no changes, got %v", changes)
+			}
+		})
+	}
+}
+
+func TestHandlerRejectsNonPost(t *testing.T) {
+	handler := NewHandler("secret", func(Change) { t.Error("Expected no changes") })
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", rec.Code)
+	}
+}
+
+func TestHandlerRejectsInvalidPayload(t *testing.T) {
+	const body = `{"webhookEvent": "jira:issue_updated"}`
+	handler := NewHandler("secret", func(Change) { t.Error("Expected no changes") })
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(SignatureHeader, sign("secret", body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,172 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// syntheticProject is the project key of generated issues
+const syntheticProject = "SIM"
+
+// defaultTemplate is the issue synthetic data is generated from when no
+// fixture is given. It exercises the usual conversion paths: wiki markup,
+// an assignee, labels, components, a comment and an attachment.
+const defaultTemplate = `{
+  "fields": {
+    "summary": "Synthetic issue",
+    "description": "h2. Context\nThe *checkout* service times out under load.\n\n# Reproduce with {{make load}}\n# Check the [dashboard|https://grafana.example.com/d/checkout]\n\n{code:go}\nctx, cancel := context.WithTimeout(ctx, 5*time.Second)\n{code}",
+    "issuetype": {"name": "Story", "subtask": false},
+    "status": {"name": "In Progress", "statusCategory": {"key": "indeterminate", "name": "In Progress"}},
+    "priority": {"name": "High", "id": "2"},
+    "assignee": {"accountId": "sim-1", "displayName": "Sim User", "emailAddress": "sim@example.com"},
+    "created": "2024-01-01T10:00:00.000+0000",
+    "updated": "2024-01-05T14:30:00.000+0000",
+    "labels": ["backend", "performance"],
+    "components": [{"id": "1", "name": "Checkout"}],
+    "comment": {"comments": [{"id": "1", "author": {"accountId": "sim-1", "displayName": "Sim User", "emailAddress": "sim@example.com"}, "body": "Reproduced on staging.", "created": "2024-01-02T09:00:00.000+0000", "updated": "2024-01-02T09:00:00.000+0000"}], "total": 1},
+    "attachment": [{"id": "1", "filename": "trace.log", "created": "2024-01-02T09:05:00.000+0000", "size": 2048, "mimeType": "text/plain", "content": "https://jira.example.com/secure/attachment/1/trace.log"}]
+  }
+}`
+
+// template is a Jira issue in export JSON form that synthetic issues are
+// copied from
+type template map[string]any
+
+// LoadTemplates reads the issues of every Jira export (*.json) in a fixture
+// directory, or of a single export file. Epics and subtasks are skipped,
+// since the generator creates its own hierarchy.
+func LoadTemplates(fixture string) ([]template, error) {
+	files := []string{fixture}
+	if info, err := os.Stat(fixture); err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	} else if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(fixture, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list fixture files: %w", err)
+		}
+	}
+
+	var templates []template
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture file: %w", err)
+		}
+		var export struct {
+			Issues []template `json:"issues"`
+		}
+		if err := json.Unmarshal(data, &export); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture file %s: %w", filepath.Base(file), err)
+		}
+		for _, issue := range export.Issues {
+			if usableTemplate(issue) {
+				templates = append(templates, issue)
+			}
+		}
+	}
+
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("fixture %s contains no issues other than epics and subtasks", fixture)
+	}
+	return templates, nil
+}
+
+// defaultTemplates returns the built-in template
+func defaultTemplates() []template {
+	var issue template
+	if err := json.Unmarshal([]byte(defaultTemplate), &issue); err != nil {
+		panic(fmt.Sprintf("invalid default template: %v", err))
+	}
+	return []template{issue}
+}
+
+// usableTemplate reports whether an issue can be copied as a regular issue
+func usableTemplate(issue template) bool {
+	fields, ok := issue["fields"].(map[string]any)
+	if !ok {
+		return false
+	}
+	issueType, _ := fields["issuetype"].(map[string]any)
+	name, _ := issueType["name"].(string)
+	subtask, _ := issueType["subtask"].(bool)
+	return name != "" && !strings.EqualFold(name, "Epic") && !subtask
+}
+
+// generate builds a Jira export of n issues copied round-robin from the
+// templates, grouped into epics of epicSize issues. Every fourth issue is
+// blocked by the one before it, so dependency handling is exercised too.
+func generate(templates []template, n, epicSize int) ([]byte, int, error) {
+	epics := (n + epicSize - 1) / epicSize
+	issues := make([]map[string]any, 0, n+epics)
+
+	for e := 0; e < epics; e++ {
+		key := syntheticKey(e + 1)
+		issues = append(issues, map[string]any{
+			"id":  syntheticID(e + 1),
+			"key": key,
+			"fields": map[string]any{
+				"summary":   fmt.Sprintf("Synthetic epic %d", e+1),
+				"issuetype": map[string]any{"name": "Epic", "subtask": false},
+				"status":    map[string]any{"name": "In Progress", "statusCategory": map[string]any{"key": "indeterminate"}},
+				"priority":  map[string]any{"name": "Medium"},
+				"created":   "2024-01-01T10:00:00.000+0000",
+				"updated":   "2024-01-01T10:00:00.000+0000",
+			},
+		})
+	}
+
+	for i := 0; i < n; i++ {
+		number := epics + i + 1
+		source := templates[i%len(templates)]
+
+		fields := make(map[string]any)
+		for name, value := range source["fields"].(map[string]any) {
+			fields[name] = value
+		}
+		delete(fields, "subtasks")
+		delete(fields, "issuelinks")
+
+		summary, _ := fields["summary"].(string)
+		fields["summary"] = fmt.Sprintf("%s %d", summary, number)
+
+		epic := i / epicSize
+		fields["parent"] = map[string]any{
+			"id":  syntheticID(epic + 1),
+			"key": syntheticKey(epic + 1),
+			"fields": map[string]any{
+				"summary":   fmt.Sprintf("Synthetic epic %d", epic+1),
+				"issuetype": map[string]any{"name": "Epic"},
+			},
+		}
+		if i%4 == 3 {
+			fields["issuelinks"] = []any{map[string]any{
+				"id":          fmt.Sprintf("%d", number),
+				"type":        map[string]any{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+				"inwardIssue": map[string]any{"id": syntheticID(number - 1), "key": syntheticKey(number - 1)},
+			}}
+		}
+
+		issues = append(issues, map[string]any{
+			"id":     syntheticID(number),
+			"key":    syntheticKey(number),
+			"fields": fields,
+		})
+	}
+
+	data, err := json.Marshal(map[string]any{"issues": issues})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode synthetic export: %w", err)
+	}
+	return data, epics, nil
+}
+
+func syntheticKey(n int) string {
+	return fmt.Sprintf("%s-%d", syntheticProject, n)
+}
+
+func syntheticID(n int) string {
+	return fmt.Sprintf("%d", 100000+n)
+}
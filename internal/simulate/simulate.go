@@ -0,0 +1,231 @@
+// Package simulate runs the conversion pipeline against synthetic data, so
+// admins can size sync runners and daemons before pointing them at a
+// production Jira instance.
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+const (
+	// DefaultIssues is the number of synthetic issues generated when
+	// Options doesn't say otherwise
+	DefaultIssues = 10000
+
+	// DefaultEpicSize is the number of issues per synthetic epic
+	DefaultEpicSize = 25
+)
+
+// Options configures a simulation
+type Options struct {
+	Issues     int               // Synthetic issues to generate, DefaultIssues if zero
+	EpicSize   int               // Issues per epic, DefaultEpicSize if zero
+	Fixture    string            // Jira export file or directory of exports used as templates; built-in template if empty
+	OutputDir  string            // Directory the .beads files are written to
+	Format     beads.Format      // Output format, jsonl if empty; bd-import is rendered as bd
+	Durability beads.Durability  // When written files are flushed, batch if empty
+	Converter  converter.Options // Conversion settings, normally those of the config
+}
+
+// Stage is the cost of one pipeline step
+type Stage struct {
+	Name      string
+	Duration  time.Duration
+	Allocated uint64 // Bytes allocated during the stage
+}
+
+// Result holds the measurements of a simulation
+type Result struct {
+	Issues      int
+	Epics       int
+	Templates   int
+	Generation  Stage   // Building the synthetic export, not part of a real sync
+	Stages      []Stage // Pipeline stages: parse, convert, render
+	PeakHeap    uint64  // Largest live heap seen after a stage
+	OutputBytes int64   // Size of the written .beads files
+	Warnings    int
+}
+
+// Run generates synthetic issues and runs them through the full pipeline:
+// JSON parsing, conversion and rendering. Fetching from Jira is not
+// simulated, since its cost depends on the instance and network.
+func Run(opts Options) (*Result, error) {
+	if opts.Issues <= 0 {
+		opts.Issues = DefaultIssues
+	}
+	if opts.EpicSize <= 0 {
+		opts.EpicSize = DefaultEpicSize
+	}
+	if opts.Format == beads.FormatBDImport {
+		// Measure the bd rendering without importing into a bd database
+		opts.Format = beads.FormatBD
+	}
+
+	templates := defaultTemplates()
+	if opts.Fixture != "" {
+		var err error
+		if templates, err = LoadTemplates(opts.Fixture); err != nil {
+			return nil, err
+		}
+	}
+
+	renderer, err := beads.NewRendererWithOptions(opts.OutputDir, opts.Format, beads.RendererOptions{Durability: opts.Durability})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Issues: opts.Issues, Templates: len(templates)}
+	meter := newMeter()
+
+	data, epics, err := generate(templates, opts.Issues, opts.EpicSize)
+	if err != nil {
+		return nil, err
+	}
+	result.Epics = epics
+	result.Generation = meter.stage("generate")
+
+	jiraExport, err := jira.NewAdapter().Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse synthetic export: %w", err)
+	}
+	data = nil
+	result.Stages = append(result.Stages, meter.stage("parse"))
+
+	beadsExport, warnings, err := converter.NewProtoConverterWithOptions(opts.Converter).ConvertWithWarnings(jiraExport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert synthetic export: %w", err)
+	}
+	result.Warnings = len(warnings)
+	result.Stages = append(result.Stages, meter.stage("convert"))
+
+	if err := renderer.RenderExport(beadsExport); err != nil {
+		return nil, fmt.Errorf("failed to render synthetic export: %w", err)
+	}
+	result.Stages = append(result.Stages, meter.stage("render"))
+
+	// Keep the exports live until every stage is measured, as a real sync does
+	runtime.KeepAlive(jiraExport)
+	runtime.KeepAlive(beadsExport)
+	result.PeakHeap = meter.peak
+
+	result.OutputBytes, err = dirSize(filepath.Join(opts.OutputDir, ".beads"))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Total returns the time taken by the pipeline stages
+func (r *Result) Total() time.Duration {
+	var total time.Duration
+	for _, stage := range r.Stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// Print writes the measurements and a sizing estimate
+func (r *Result) Print(w io.Writer) {
+	fmt.Fprintf(w, "Simulated %d issue(s) in %d epic(s) from %d template issue(s)\n\n", r.Issues, r.Epics, r.Templates)
+
+	fmt.Fprintf(w, "  %-10s %12s %12s\n", "STAGE", "TIME", "ALLOCATED")
+	for _, stage := range r.Stages {
+		fmt.Fprintf(w, "  %-10s %12s %12s\n", stage.Name, stage.Duration.Round(time.Millisecond), formatBytes(stage.Allocated))
+	}
+	fmt.Fprintf(w, "  %-10s %12s\n\n", "total", r.Total().Round(time.Millisecond))
+	fmt.Fprintf(w, "Generating the synthetic data took %s and is not included above.\n\n", r.Generation.Duration.Round(time.Millisecond))
+
+	total := r.Total()
+	if total > 0 {
+		fmt.Fprintf(w, "Throughput:  %.0f issues/s\n", float64(r.Issues)/total.Seconds())
+	}
+	fmt.Fprintf(w, "Peak heap:   %s\n", formatBytes(r.PeakHeap))
+	fmt.Fprintf(w, "Output size: %s in .beads/\n", formatBytes(uint64(r.OutputBytes)))
+	if r.Warnings > 0 {
+		fmt.Fprintf(w, "Warnings:    %d\n", r.Warnings)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Sizing estimate:")
+	fmt.Fprintf(w, "  A full sync of %d issues spends about %s converting and writing, plus the time to fetch them from Jira\n",
+		r.Issues, total.Round(100*time.Millisecond))
+	fmt.Fprintf(w, "  Allow about %s of memory per runner or daemon (twice the peak heap, for garbage collection headroom)\n",
+		formatBytes(2*r.PeakHeap))
+	fmt.Fprintf(w, "  Allow about %s of disk per beads repository\n", formatBytes(uint64(r.OutputBytes)))
+}
+
+// meter measures the time and allocations between stages
+type meter struct {
+	start time.Time
+	stats runtime.MemStats
+	peak  uint64
+}
+
+func newMeter() *meter {
+	m := &meter{}
+	runtime.GC()
+	runtime.ReadMemStats(&m.stats)
+	m.start = time.Now()
+	return m
+}
+
+// stage records the cost since the previous stage
+func (m *meter) stage(name string) Stage {
+	duration := time.Since(m.start)
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if stats.HeapAlloc > m.peak {
+		m.peak = stats.HeapAlloc
+	}
+	stage := Stage{Name: name, Duration: duration, Allocated: stats.TotalAlloc - m.stats.TotalAlloc}
+
+	m.stats = stats
+	m.start = time.Now()
+	return stage
+}
+
+// dirSize returns the total size of the files under dir
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure output: %w", err)
+	}
+	return size, nil
+}
+
+// formatBytes formats a byte count with a binary unit
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,106 @@
+package simulate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+)
+
+func TestRunWithDefaultTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result, err := Run(Options{Issues: 60, EpicSize: 25, OutputDir: tmpDir})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Issues != 60 || result.Epics != 3 || result.Templates != 1 {
+		t.Errorf("Expected 60 issues in 3 epics from 1 template, got %+v", result)
+	}
+	if len(result.Stages) != 3 || result.PeakHeap == 0 || result.OutputBytes == 0 {
+		t.Errorf("Expected 3 measured stages and output, got %+v", result)
+	}
+
+	issues, err := beads.ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 60 {
+		t.Fatalf("Expected 60 issues written, got %d", len(issues))
+	}
+	blocked := 0
+	for _, issue := range issues {
+		if issue.Epic == "" {
+			t.Errorf("Expected %s to belong to an epic", issue.ID)
+		}
+		blocked += len(issue.DependsOn)
+	}
+	if blocked != 15 {
+		t.Errorf("Expected every fourth issue to have a blocker, got %d dependencies", blocked)
+	}
+
+	var out bytes.Buffer
+	result.Print(&out)
+	for _, want := range []string{"Simulated 60 issue(s) in 3 epic(s)", "convert", "Peak heap:", "Sizing estimate:"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunWithFixture(t *testing.T) {
+	fixtureDir := t.TempDir()
+	fixture := `{"issues": [
+  {"key": "PROJ-1", "fields": {"summary": "Epic", "issuetype": {"name": "Epic"}, "status": {"name": "Open"}, "priority": {"name": "Low"}}},
+  {"key": "PROJ-2", "fields": {"summary": "Fix login", "issuetype": {"name": "Bug"}, "status": {"name": "Done", "statusCategory": {"key": "done"}}, "priority": {"name": "Low"}, "created": "2024-01-01T10:00:00.000+0000", "updated": "2024-01-01T10:00:00.000+0000"}},
+  {"key": "PROJ-3", "fields": {"summary": "Subtask", "issuetype": {"name": "Sub-task", "subtask": true}, "status": {"name": "Open"}, "priority": {"name": "Low"}}}
+]}`
+	if err := os.WriteFile(filepath.Join(fixtureDir, "export.json"), []byte(fixture), 0600); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	result, err := Run(Options{Issues: 10, Fixture: fixtureDir, OutputDir: tmpDir})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Templates != 1 {
+		t.Errorf("Expected epics and subtasks to be skipped as templates, got %d template(s)", result.Templates)
+	}
+
+	issues, err := beads.ReadIssues(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadIssues failed: %v", err)
+	}
+	if len(issues) != 10 || !strings.HasPrefix(issues[0].Title, "Fix login") || issues[0].Status != "closed" {
+		t.Errorf("Expected issues copied from the fixture, got %d, first %+v", len(issues), issues[0])
+	}
+}
+
+func TestLoadTemplatesWithoutUsableIssues(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "epics.json")
+	content := `{"issues": [{"key": "PROJ-1", "fields": {"summary": "Epic", "issuetype": {"name": "Epic"}}}]}`
+	if err := os.WriteFile(fixture, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	if _, err := LoadTemplates(fixture); err == nil {
+		t.Error("Expected an error for a fixture with only epics")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[uint64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
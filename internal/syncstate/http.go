@@ -0,0 +1,103 @@
+package syncstate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// remoteTimeout bounds each request to a remote store
+const remoteTimeout = 60 * time.Second
+
+// remoteStore keeps the state as a JSON object at a URL, read with GET and
+// written with PUT. A missing object is an empty state.
+type remoteStore struct {
+	url        string
+	name       string // Described in messages instead of the URL, e.g. s3://bucket/key
+	authorize  func(req *http.Request, body []byte) error
+	httpClient *http.Client
+}
+
+// NewHTTPStore creates a store keeping the state at url on any HTTP server
+// accepting GET and PUT, such as a pre-authorized object storage URL or a
+// WebDAV share. A non-empty token is sent as a bearer token.
+func NewHTTPStore(url, token string) Store {
+	return &remoteStore{
+		url:  url,
+		name: url,
+		authorize: func(req *http.Request, body []byte) error {
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			return nil
+		},
+		httpClient: &http.Client{Timeout: remoteTimeout},
+	}
+}
+
+func (r *remoteStore) Load(ctx context.Context) (*State, error) {
+	resp, err := r.send(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return New(), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read sync state: %s returned status %d: %s", r.name, resp.StatusCode, errorBody(resp))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	return decode(data)
+}
+
+func (r *remoteStore) Save(ctx context.Context, state *State) error {
+	data, err := state.encode()
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.send(ctx, http.MethodPut, data)
+	if err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to write sync state: %s returned status %d: %s", r.name, resp.StatusCode, errorBody(resp))
+	}
+	return nil
+}
+
+func (r *remoteStore) String() string {
+	return r.name
+}
+
+// send makes an authorized request for the state object
+func (r *remoteStore) send(ctx context.Context, method string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := r.authorize(req, body); err != nil {
+		return nil, err
+	}
+	return r.httpClient.Do(req)
+}
+
+// errorBody returns the start of an error response for messages
+func errorBody(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	return string(bytes.TrimSpace(body))
+}
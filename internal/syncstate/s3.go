@@ -0,0 +1,153 @@
+package syncstate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultS3Region is the region requests are signed for when S3Options
+// doesn't name one
+const DefaultS3Region = "us-east-1"
+
+// S3Options locates the state object in S3 or an S3-compatible object
+// store, such as Google Cloud Storage with HMAC keys, MinIO or R2
+type S3Options struct {
+	Endpoint        string // e.g. https://storage.googleapis.com; AWS S3 for the region if empty
+	Region          string // DefaultS3Region if empty; "auto" for GCS and R2
+	Bucket          string
+	Key             string // Object key, e.g. team-a/sync-state.json
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Temporary credentials only
+
+	now func() time.Time // Signing time, time.Now if nil
+}
+
+// NewS3Store creates a store keeping the state in an S3 object. Requests
+// use path-style URLs and are signed with AWS Signature Version 4.
+func NewS3Store(opts S3Options) (Store, error) {
+	if opts.Bucket == "" || opts.Key == "" {
+		return nil, fmt.Errorf("the s3 state store requires a bucket and key")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("the s3 state store requires an access key ID and secret access key")
+	}
+	if opts.Region == "" {
+		opts.Region = DefaultS3Region
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", opts.Region)
+	}
+	if opts.now == nil {
+		opts.now = time.Now
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(opts.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("the s3 state store requires an http(s) endpoint, got %q", opts.Endpoint)
+	}
+
+	segments := []string{uriEncode(opts.Bucket)}
+	for _, segment := range strings.Split(strings.TrimPrefix(opts.Key, "/"), "/") {
+		segments = append(segments, uriEncode(segment))
+	}
+
+	store := &remoteStore{
+		url:        endpoint.String() + "/" + strings.Join(segments, "/"),
+		name:       fmt.Sprintf("s3://%s/%s", opts.Bucket, strings.TrimPrefix(opts.Key, "/")),
+		httpClient: &http.Client{Timeout: remoteTimeout},
+	}
+	store.authorize = func(req *http.Request, body []byte) error {
+		signV4(req, body, opts, opts.now())
+		return nil
+	}
+	return store, nil
+}
+
+// signV4 adds AWS Signature Version 4 headers for the s3 service to req
+func signV4(req *http.Request, body []byte, opts S3Options, at time.Time) {
+	amzDate := at.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", opts.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, opts.Region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(signingKey(opts.SecretAccessKey, date, opts.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// signingKey derives the Signature Version 4 key for a day, region and service
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// uriEncode percent-encodes everything but unreserved characters, as
+// Signature Version 4 canonical URIs require
+func uriEncode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
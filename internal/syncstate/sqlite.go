@@ -0,0 +1,82 @@
+package syncstate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sqliteStore keeps the state as a row of a SQLite database, so several
+// repositories can share one database file on a mounted volume. The
+// database is accessed through the sqlite3 command-line shell, which must
+// be on the PATH, rather than a linked driver.
+type sqliteStore struct {
+	path string
+	name string // Row holding this repository's state
+}
+
+// NewSQLiteStore creates a store keeping the state in the sync_state table
+// of the SQLite database at path, under name. The table is created on the
+// first save.
+func NewSQLiteStore(path, name string) Store {
+	return &sqliteStore{path: path, name: name}
+}
+
+func (s *sqliteStore) Load(ctx context.Context) (*State, error) {
+	out, err := s.run(ctx, fmt.Sprintf(
+		"SELECT hex(state) FROM sync_state WHERE name = CAST(X'%s' AS TEXT);", hex.EncodeToString([]byte(s.name))))
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return New(), nil
+		}
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return New(), nil
+	}
+	data, err := hex.DecodeString(string(out))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: unexpected sqlite3 output: %w", err)
+	}
+	return decode(data)
+}
+
+func (s *sqliteStore) Save(ctx context.Context, state *State) error {
+	data, err := state.encode()
+	if err != nil {
+		return err
+	}
+
+	// Values are passed as hex blobs, so nothing in them needs quoting
+	script := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS sync_state (name TEXT PRIMARY KEY, state BLOB NOT NULL);
+INSERT INTO sync_state (name, state) VALUES (CAST(X'%s' AS TEXT), X'%s')
+  ON CONFLICT (name) DO UPDATE SET state = excluded.state;`,
+		hex.EncodeToString([]byte(s.name)), hex.EncodeToString(data))
+	if _, err := s.run(ctx, script); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
+
+func (s *sqliteStore) String() string {
+	return fmt.Sprintf("sqlite:%s#%s", s.path, s.name)
+}
+
+// run executes an SQL script against the database, waiting for other
+// writers to release it, and returns the output
+func (s *sqliteStore) run(ctx context.Context, script string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sqlite3", "-batch", "-bail", "-noheader", "-cmd", ".timeout 10000", s.path)
+	cmd.Stdin = strings.NewReader(script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3 %s: %w: %s", s.path, err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
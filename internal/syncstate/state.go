@@ -0,0 +1,223 @@
+// Package syncstate persists what previous syncs fetched so later runs can
+// fetch only issues updated since then.
+package syncstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FileName is the state file name inside the .beads directory
+const FileName = ".jira-sync-state.json"
+
+// overlap is subtracted from the last sync time to absorb clock skew
+// between this machine and Jira
+const overlap = time.Minute
+
+// State records the last successful sync per query and the last known
+// version of every synced issue
+type State struct {
+	Queries map[string]time.Time  `json:"queries,omitempty"` // JQL → start of last successful sync
+	Issues  map[string]IssueState `json:"issues,omitempty"`  // Jira key → last synced version
+	Payload map[string]string     `json:"payload,omitempty"` // Jira key → hash of the payload last synced
+}
+
+// IssueState is the last synced version of an issue
+type IssueState struct {
+	Updated time.Time `json:"updated"`
+	Hash    string    `json:"hash"`              // Hash of the rendered beads record
+	BeadsID string    `json:"beadsId,omitempty"` // Beads ID the issue was written as
+}
+
+// New creates an empty state
+func New() *State {
+	return &State{
+		Queries: make(map[string]time.Time),
+		Issues:  make(map[string]IssueState),
+		Payload: make(map[string]string),
+	}
+}
+
+// Path returns the state file path for a beads output directory
+func Path(outputDir string) string {
+	return filepath.Join(outputDir, ".beads", FileName)
+}
+
+// Load reads the sync state from outputDir. A missing file yields an empty state.
+func Load(outputDir string) (*State, error) {
+	data, err := os.ReadFile(Path(outputDir))
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	return decode(data)
+}
+
+// Save writes the sync state to outputDir
+func (s *State) Save(outputDir string) error {
+	path := Path(outputDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+
+	return nil
+}
+
+// decode parses a saved sync state
+func decode(data []byte) (*State, error) {
+	state := New()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state: %w", err)
+	}
+	if state.Queries == nil {
+		state.Queries = make(map[string]time.Time)
+	}
+	if state.Issues == nil {
+		state.Issues = make(map[string]IssueState)
+	}
+	if state.Payload == nil {
+		state.Payload = make(map[string]string)
+	}
+	return state, nil
+}
+
+// encode formats the sync state for saving
+func (s *State) encode() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// LastSync returns when a query was last synced successfully
+func (s *State) LastSync(jql string) (time.Time, bool) {
+	at, ok := s.Queries[jql]
+	return at, ok
+}
+
+// MarkSynced records a successful sync of a query that started at the given time
+func (s *State) MarkSynced(jql string, at time.Time) {
+	s.Queries[jql] = at
+}
+
+// Record stores the synced version of an issue and reports whether its
+// rendered content differs from the previous sync
+func (s *State) Record(jiraKey string, updated time.Time, hash string) bool {
+	return s.RecordIssue(jiraKey, IssueState{Updated: updated, Hash: hash})
+}
+
+// RecordIssue is Record with the full issue state
+func (s *State) RecordIssue(jiraKey string, issue IssueState) bool {
+	previous, known := s.Issues[jiraKey]
+	s.Issues[jiraKey] = issue
+	return !known || previous.Hash != issue.Hash
+}
+
+// Unknown returns the keys that have no recorded version, e.g. because the
+// state file was lost
+func (s *State) Unknown(jiraKeys []string) []string {
+	var unknown []string
+	for _, key := range jiraKeys {
+		if _, ok := s.Issues[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
+// Restore records an issue's version from another copy of the state, such
+// as the Jira issue property, unless a version is already known. It
+// reports whether the version was restored.
+func (s *State) Restore(jiraKey string, issue IssueState) bool {
+	if _, ok := s.Issues[jiraKey]; ok {
+		return false
+	}
+	s.Issues[jiraKey] = issue
+	return true
+}
+
+// PayloadMatches reports whether an issue's payload hash matches the last
+// sync, so converting it again would give the records already written
+func (s *State) PayloadMatches(jiraKey, hash string) bool {
+	return hash != "" && s.Payload[jiraKey] == hash
+}
+
+// ForgetPayloads drops the payload hashes, so the next sync converts every
+// fetched issue again
+func (s *State) ForgetPayloads() {
+	s.Payload = make(map[string]string)
+}
+
+// RecordPayload stores the payload hashes of synced issues
+func (s *State) RecordPayload(hashes map[string]string) {
+	for key, hash := range hashes {
+		s.Payload[key] = hash
+	}
+}
+
+// orderBy matches a trailing ORDER BY clause
+var orderBy = regexp.MustCompile(`(?is)(^|\s)order\s+by\s.*$`)
+
+// maskQuoted replaces the contents of quoted JQL strings with a placeholder
+// of the same length, so clauses are only matched outside of values such as
+// summary ~ "sort order by date"
+func maskQuoted(jql string) string {
+	masked := []byte(jql)
+	var quote byte
+	for i := 0; i < len(masked); i++ {
+		switch c := masked[i]; {
+		case quote == 0:
+			if c == '"' || c == '\'' {
+				quote = c
+			}
+		case c == '\\' && i+1 < len(masked):
+			masked[i], masked[i+1] = '_', '_'
+			i++
+		case c == quote:
+			quote = 0
+		default:
+			masked[i] = '_'
+		}
+	}
+	return string(masked)
+}
+
+// IncrementalJQL restricts a query to issues updated since the given time.
+// A relative offset in minutes is used because absolute JQL dates are
+// interpreted in the Jira user's time zone. Any ORDER BY clause is kept;
+// "order by" inside a quoted value is not mistaken for one.
+func IncrementalJQL(jql string, since, now time.Time) string {
+	minutes := int(math.Ceil(now.Sub(since.Add(-overlap)).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	where := strings.TrimSpace(jql)
+	order := ""
+	if loc := orderBy.FindStringIndex(maskQuoted(where)); loc != nil {
+		where, order = strings.TrimSpace(where[:loc[0]]), " "+strings.TrimSpace(where[loc[0]:])
+	}
+
+	if where == "" {
+		return fmt.Sprintf("updated >= -%dm%s", minutes, order)
+	}
+	return fmt.Sprintf("(%s) AND updated >= -%dm%s", where, minutes, order)
+}
@@ -0,0 +1,192 @@
+package syncstate
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadMissingState(t *testing.T) {
+	state, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if _, ok := state.LastSync("project = PROJ"); ok {
+		t.Error("Expected no last sync for empty state")
+	}
+	if len(state.Issues) != 0 {
+		t.Errorf("Expected no issues, got %d", len(state.Issues))
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	syncedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 4, 30, 9, 30, 0, 0, time.UTC)
+
+	state := New()
+	state.MarkSynced("project = PROJ", syncedAt)
+	state.Record("PROJ-1", updated, "abc123")
+
+	if err := state.Save(tmpDir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(Path(tmpDir)); err != nil {
+		t.Fatalf("Expected state file to exist: %v", err)
+	}
+
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	lastSync, ok := loaded.LastSync("project = PROJ")
+	if !ok || !lastSync.Equal(syncedAt) {
+		t.Errorf("Expected last sync %v, got %v (found: %v)", syncedAt, lastSync, ok)
+	}
+
+	issue := loaded.Issues["PROJ-1"]
+	if !issue.Updated.Equal(updated) || issue.Hash != "abc123" {
+		t.Errorf("Unexpected issue state: %+v", issue)
+	}
+}
+
+func TestLoadInvalidState(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(tmpDir+"/.beads", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(Path(tmpDir), []byte("not json"), 0644); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+
+	if _, err := Load(tmpDir); err == nil {
+		t.Error("Expected error for invalid state file")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	state := New()
+	updated := time.Now()
+
+	if !state.Record("PROJ-1", updated, "hash-1") {
+		t.Error("Expected first record to be reported as changed")
+	}
+	if state.Record("PROJ-1", updated, "hash-1") {
+		t.Error("Expected identical record to be reported as unchanged")
+	}
+	if !state.Record("PROJ-1", updated, "hash-2") {
+		t.Error("Expected new content to be reported as changed")
+	}
+}
+
+func TestPayloadMatches(t *testing.T) {
+	state := New()
+	hashes := map[string]string{"PROJ-1": "a", "PROJ-2": "b"}
+
+	if state.PayloadMatches("PROJ-1", "a") {
+		t.Error("Expected an unknown payload not to match")
+	}
+	state.RecordPayload(hashes)
+	if !state.PayloadMatches("PROJ-1", "a") || !state.PayloadMatches("PROJ-2", "b") {
+		t.Error("Expected recorded payloads to match")
+	}
+	if state.PayloadMatches("PROJ-2", "changed") || state.PayloadMatches("PROJ-3", "c") {
+		t.Error("Expected new payloads and issues not to match")
+	}
+	if state.PayloadMatches("PROJ-1", "") {
+		t.Error("Expected an empty hash never to match")
+	}
+
+	// Payload hashes survive saving and loading
+	tmpDir := t.TempDir()
+	if err := state.Save(tmpDir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.PayloadMatches("PROJ-1", "a") {
+		t.Errorf("Expected the payload hashes to be saved, got %v", loaded.Payload)
+	}
+
+	loaded.ForgetPayloads()
+	if loaded.PayloadMatches("PROJ-1", "a") {
+		t.Error("Expected forgotten payloads not to match")
+	}
+}
+
+func TestIncrementalJQL(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		jql   string
+		since time.Time
+		want  string
+	}{
+		{
+			name:  "adds updated clause with overlap",
+			jql:   "project = PROJ",
+			since: now.Add(-2 * time.Hour),
+			want:  "(project = PROJ) AND updated >= -121m",
+		},
+		{
+			name:  "keeps order by",
+			jql:   "project = PROJ OR labels = x ORDER BY updated DESC",
+			since: now.Add(-30 * time.Second),
+			want:  "(project = PROJ OR labels = x) AND updated >= -2m ORDER BY updated DESC",
+		},
+		{
+			name:  "order by only",
+			jql:   "order by created",
+			since: now.Add(-10 * time.Minute),
+			want:  "updated >= -11m order by created",
+		},
+		{
+			name:  "ignores order by inside quotes",
+			jql:   `summary ~ "sort order by date" AND text ~ 'x \' order by y'`,
+			since: now.Add(-10 * time.Minute),
+			want:  `(summary ~ "sort order by date" AND text ~ 'x \' order by y') AND updated >= -11m`,
+		},
+		{
+			name:  "keeps order by after quoted value",
+			jql:   `summary ~ "order by" ORDER BY key`,
+			since: now.Add(-10 * time.Minute),
+			want:  `(summary ~ "order by") AND updated >= -11m ORDER BY key`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IncrementalJQL(tt.jql, tt.since, now); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	state := New()
+	state.Record("PROJ-1", time.Now(), "local")
+
+	if unknown := state.Unknown([]string{"PROJ-1", "PROJ-2"}); len(unknown) != 1 || unknown[0] != "PROJ-2" {
+		t.Errorf("Expected only PROJ-2 to be unknown, got %v", unknown)
+	}
+
+	if state.Restore("PROJ-1", IssueState{Hash: "remote"}) {
+		t.Error("Expected a known issue not to be restored")
+	}
+	if state.Issues["PROJ-1"].Hash != "local" {
+		t.Errorf("Expected the local version to win, got %s", state.Issues["PROJ-1"].Hash)
+	}
+
+	if !state.Restore("PROJ-2", IssueState{Hash: "remote", BeadsID: "proj-2"}) {
+		t.Error("Expected an unknown issue to be restored")
+	}
+	if state.Record("PROJ-2", time.Now(), "remote") {
+		t.Error("Expected a restored issue with the same content to be unchanged")
+	}
+}
@@ -0,0 +1,41 @@
+package syncstate
+
+import "context"
+
+// Store persists the sync state between runs. The file store keeps it next
+// to the beads files; the remote stores let stateless runners, such as CI
+// jobs, share incremental sync state without committing it.
+//
+// Stores don't coordinate concurrent writers: runners sharing a store
+// should also share a sync lock.
+type Store interface {
+	// Load returns the saved state, or an empty state if none was saved
+	Load(ctx context.Context) (*State, error)
+	// Save replaces the saved state
+	Save(ctx context.Context, state *State) error
+	// String describes where the state is kept, for messages
+	String() string
+}
+
+// fileStore keeps the state in the .beads directory of a beads repository
+type fileStore struct {
+	outputDir string
+}
+
+// NewFileStore creates a store keeping the state in outputDir's .beads
+// directory, as Load and Save do
+func NewFileStore(outputDir string) Store {
+	return &fileStore{outputDir: outputDir}
+}
+
+func (f *fileStore) Load(ctx context.Context) (*State, error) {
+	return Load(f.outputDir)
+}
+
+func (f *fileStore) Save(ctx context.Context, state *State) error {
+	return state.Save(f.outputDir)
+}
+
+func (f *fileStore) String() string {
+	return Path(f.outputDir)
+}
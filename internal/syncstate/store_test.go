@@ -0,0 +1,188 @@
+package syncstate
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// objectServer is an in-memory object store accepting GET and PUT
+type objectServer struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	requests []*http.Request
+}
+
+func newObjectServer(t *testing.T) (*objectServer, *httptest.Server) {
+	objects := &objectServer{objects: make(map[string][]byte)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		objects.mu.Lock()
+		defer objects.mu.Unlock()
+		objects.requests = append(objects.requests, r)
+
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := objects.objects[r.URL.EscapedPath()]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("Failed to read body: %v", err)
+			}
+			objects.objects[r.URL.EscapedPath()] = data
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return objects, server
+}
+
+// roundTrip saves a state to the store and loads it back
+func roundTrip(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	empty, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(empty.Queries) != 0 || len(empty.Issues) != 0 {
+		t.Errorf("Expected an empty state before saving, got %+v", empty)
+	}
+
+	state := New()
+	state.MarkSynced("project = PROJ", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	state.Record("PROJ-1", time.Date(2024, 4, 30, 9, 30, 0, 0, time.UTC), "abc123")
+	if err := store.Save(ctx, state); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, ok := loaded.LastSync("project = PROJ"); !ok {
+		t.Error("Expected the saved query to be loaded")
+	}
+	if loaded.Issues["PROJ-1"].Hash != "abc123" {
+		t.Errorf("Expected the saved issue to be loaded, got %+v", loaded.Issues)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+	roundTrip(t, store)
+
+	if store.String() != Path(dir) {
+		t.Errorf("Expected store %s, got %s", Path(dir), store)
+	}
+}
+
+func TestHTTPStore(t *testing.T) {
+	objects, server := newObjectServer(t)
+	roundTrip(t, NewHTTPStore(server.URL+"/repo/state.json", "secret"))
+
+	for _, req := range objects.requests {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Expected bearer token on %s, got %q", req.Method, req.Header.Get("Authorization"))
+		}
+	}
+}
+
+func TestSQLiteStore(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+	path := filepath.Join(t.TempDir(), "state.db")
+	roundTrip(t, NewSQLiteStore(path, "team 'a'"))
+
+	// Another repository sharing the database starts from an empty state
+	other, err := NewSQLiteStore(path, "team b").Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(other.Issues) != 0 {
+		t.Errorf("Expected states to be kept per name, got %+v", other.Issues)
+	}
+}
+
+func TestHTTPStoreError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "access denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	store := NewHTTPStore(server.URL+"/state.json", "")
+	if _, err := store.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Expected status in load error, got: %v", err)
+	}
+	if err := store.Save(context.Background(), New()); err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("Expected response body in save error, got: %v", err)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	objects, server := newObjectServer(t)
+	store, err := NewS3Store(S3Options{
+		Endpoint:        server.URL,
+		Region:          "eu-west-1",
+		Bucket:          "ci-state",
+		Key:             "team a/state.json",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		SessionToken:    "session",
+		now:             func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store failed: %v", err)
+	}
+	roundTrip(t, store)
+
+	if store.String() != "s3://ci-state/team a/state.json" {
+		t.Errorf("Unexpected store name %s", store)
+	}
+	for _, req := range objects.requests {
+		if req.URL.EscapedPath() != "/ci-state/team%20a/state.json" {
+			t.Errorf("Expected a path-style object URL, got %s", req.URL.EscapedPath())
+		}
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240501/eu-west-1/s3/aws4_request, ") ||
+			!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, ") {
+			t.Errorf("Unexpected Authorization header on %s: %s", req.Method, auth)
+		}
+		if req.Header.Get("X-Amz-Date") != "20240501T120000Z" || req.Header.Get("X-Amz-Security-Token") != "session" {
+			t.Errorf("Expected date and session token headers, got %v", req.Header)
+		}
+	}
+}
+
+func TestNewS3StoreRequiresLocation(t *testing.T) {
+	if _, err := NewS3Store(S3Options{Bucket: "ci-state", AccessKeyID: "AKID", SecretAccessKey: "secret"}); err == nil {
+		t.Error("Expected error without a key")
+	}
+	if _, err := NewS3Store(S3Options{Bucket: "ci-state", Key: "state.json", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: "storage"}); err == nil {
+		t.Error("Expected error for an endpoint without a scheme")
+	}
+}
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("Unexpected signing key %s", got)
+	}
+}
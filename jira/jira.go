@@ -0,0 +1,69 @@
+// Package jira exposes the Jira client of jira-beads-sync to embedders: the
+// interfaces the sync code accepts, the Client implementing them, a
+// read-through CachedClient and, in jiratest, fakes of each interface, so
+// code built on them can be unit-tested without an HTTP test server.
+//
+// The types are those of the client the CLI uses, exposed here since the
+// package implementing them is internal.
+package jira
+
+import (
+	"time"
+
+	"github.com/conallob/jira-beads-sync/internal/jira"
+)
+
+// Fetcher looks up issues by key, with or without the issues they depend on
+type Fetcher = jira.Fetcher
+
+// Searcher finds issues with JQL or by label
+type Searcher = jira.Searcher
+
+// OwnershipFetcher looks up the component leads and watchers a sync records
+// as ownership metadata
+type OwnershipFetcher = jira.OwnershipFetcher
+
+// PropertyStore keeps the sync state of issues in Jira issue properties
+type PropertyStore = jira.PropertyStore
+
+// SyncProperty is the sync state stored in an issue property
+type SyncProperty = jira.SyncProperty
+
+// Client is the Jira REST API client, implementing every interface above
+type Client = jira.Client
+
+// ClientOptions tunes a client's concurrency, retries and API version
+type ClientOptions = jira.ClientOptions
+
+// ErrNoIssuesFound is returned by FetchByJQL when the query matches nothing
+var ErrNoIssuesFound = jira.ErrNoIssuesFound
+
+// NewClient creates a client for the Jira instance at baseURL. authMethod
+// is "basic" (username and API token) or "bearer" (personal access token).
+func NewClient(baseURL, username, apiToken, authMethod string) *Client {
+	return jira.NewClient(baseURL, username, apiToken, authMethod)
+}
+
+// NewClientWithOptions is NewClient with options
+func NewClientWithOptions(baseURL, username, apiToken, authMethod string, opts ClientOptions) *Client {
+	return jira.NewClientWithOptions(baseURL, username, apiToken, authMethod, opts)
+}
+
+// CachedClient is a Fetcher serving issues from an on-disk cache until
+// their TTL expires, and from Jira otherwise. Cache write failures don't
+// fail lookups; they are returned by Warnings.
+type CachedClient = jira.CachedClient
+
+// DefaultCacheTTL is how long cached issues are served before being refetched
+const DefaultCacheTTL = jira.DefaultCacheTTL
+
+// NewCachedClient wraps a client with an on-disk issue cache in dir. A
+// non-positive ttl uses DefaultCacheTTL.
+func NewCachedClient(client *Client, dir string, ttl time.Duration) *CachedClient {
+	return jira.NewCachedClient(client, dir, ttl)
+}
+
+// DefaultCacheDir returns the per-user issue cache directory
+func DefaultCacheDir() (string, error) {
+	return jira.DefaultCacheDir()
+}
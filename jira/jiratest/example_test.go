@@ -0,0 +1,46 @@
+package jiratest_test
+
+import (
+	"fmt"
+	"log"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/jira"
+	"github.com/conallob/jira-beads-sync/jira/jiratest"
+)
+
+// summaries is code under test, accepting any jira.Fetcher: a *jira.Client
+// in production, a fake in tests
+func summaries(source jira.Fetcher, issueKey string) ([]string, error) {
+	export, err := source.FetchIssueWithDependencies(issueKey)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, issue := range export.Issues {
+		lines = append(lines, issue.Key+" "+issue.Fields.Summary)
+	}
+	return lines, nil
+}
+
+func ExampleNewFetcher() {
+	story := &pb.Issue{Key: "PROJ-1", Fields: &pb.Fields{
+		Summary:  "Add login",
+		Subtasks: []*pb.Subtask{{Key: "PROJ-2"}},
+	}}
+	subtask := &pb.Issue{Key: "PROJ-2", Fields: &pb.Fields{Summary: "Write tests"}}
+
+	fetcher := jiratest.NewFetcher(story, subtask)
+	lines, err := summaries(fetcher, "PROJ-1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	fmt.Println(fetcher.Calls())
+	// Output:
+	// PROJ-1 Add login
+	// PROJ-2 Write tests
+	// [PROJ-1]
+}
@@ -0,0 +1,282 @@
+// Package jiratest provides mocks of the jira package's client interfaces,
+// so code built on jira.Fetcher, jira.Searcher, jira.OwnershipFetcher and
+// jira.PropertyStore can be unit-tested without an HTTP test server.
+package jiratest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/jira"
+)
+
+// ErrNotMocked is returned by mock methods whose function field is nil
+var ErrNotMocked = errors.New("jiratest: method not mocked")
+
+var (
+	_ jira.Fetcher          = (*Fetcher)(nil)
+	_ jira.Searcher         = (*Searcher)(nil)
+	_ jira.OwnershipFetcher = (*OwnershipFetcher)(nil)
+	_ jira.PropertyStore    = (*PropertyStore)(nil)
+)
+
+// Fetcher is a mock jira.Fetcher. Each method calls the matching function
+// field and records the call; methods without a function return
+// ErrNotMocked. It is safe for concurrent use.
+type Fetcher struct {
+	FetchIssueFunc                 func(issueKey string) (*pb.Issue, error)
+	FetchIssueWithDependenciesFunc func(issueKey string) (*pb.Export, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewFetcher returns a Fetcher serving the given issues from memory, by key
+// or ID. FetchIssueWithDependencies follows subtasks, issue links and
+// parents among them; unknown issues fail like a 404 from Jira.
+func NewFetcher(issues ...*pb.Issue) *Fetcher {
+	byKey := make(map[string]*pb.Issue, 2*len(issues))
+	for _, issue := range issues {
+		byKey[issue.Key] = issue
+		if issue.Id != "" {
+			byKey[issue.Id] = issue
+		}
+	}
+
+	lookup := func(issueKey string) (*pb.Issue, error) {
+		if issue, ok := byKey[issueKey]; ok {
+			return issue, nil
+		}
+		return nil, fmt.Errorf("jira API returned status 404: issue %s does not exist", issueKey)
+	}
+
+	return &Fetcher{
+		FetchIssueFunc: lookup,
+		FetchIssueWithDependenciesFunc: func(issueKey string) (*pb.Export, error) {
+			root, err := lookup(issueKey)
+			if err != nil {
+				return nil, err
+			}
+
+			export := &pb.Export{}
+			visited := make(map[string]bool)
+			queue := []*pb.Issue{root}
+			for len(queue) > 0 {
+				issue := queue[0]
+				queue = queue[1:]
+				if visited[issue.Key] {
+					continue
+				}
+				visited[issue.Key] = true
+				export.Issues = append(export.Issues, issue)
+
+				for _, key := range relatedKeys(issue) {
+					if related, ok := byKey[key]; ok && !visited[related.Key] {
+						queue = append(queue, related)
+					}
+				}
+			}
+			return export, nil
+		},
+	}
+}
+
+// relatedKeys returns the keys of an issue's subtasks, linked issues and parent
+func relatedKeys(issue *pb.Issue) []string {
+	var keys []string
+	for _, subtask := range issue.Fields.GetSubtasks() {
+		keys = append(keys, subtask.GetKey())
+	}
+	for _, link := range issue.Fields.GetIssueLinks() {
+		if link.InwardIssue != nil {
+			keys = append(keys, link.InwardIssue.GetKey())
+		}
+		if link.OutwardIssue != nil {
+			keys = append(keys, link.OutwardIssue.GetKey())
+		}
+	}
+	if parent := issue.Fields.GetParent(); parent != nil {
+		keys = append(keys, parent.GetKey())
+	}
+	return keys
+}
+
+// FetchIssue implements jira.Fetcher
+func (f *Fetcher) FetchIssue(issueKey string) (*pb.Issue, error) {
+	f.record(issueKey)
+	if f.FetchIssueFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return f.FetchIssueFunc(issueKey)
+}
+
+// FetchIssueWithDependencies implements jira.Fetcher
+func (f *Fetcher) FetchIssueWithDependencies(issueKey string) (*pb.Export, error) {
+	f.record(issueKey)
+	if f.FetchIssueWithDependenciesFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return f.FetchIssueWithDependenciesFunc(issueKey)
+}
+
+// Calls returns the issue keys requested so far, in order
+func (f *Fetcher) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *Fetcher) record(issueKey string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, issueKey)
+}
+
+// Searcher is a mock jira.Searcher. Each method calls the matching function
+// field and records the query; methods without a function return
+// ErrNotMocked. It is safe for concurrent use.
+type Searcher struct {
+	FetchByJQLFunc         func(jql string) (*pb.Export, error)
+	FetchIssuesByLabelFunc func(label string) (*pb.Export, error)
+	FetchIssuesByKeyFunc   func(ctx context.Context, keys []string) ([]*pb.Issue, error)
+	SearchIssuesFunc       func(jql string) ([]string, error)
+
+	mu      sync.Mutex
+	queries []string
+}
+
+// FetchByJQL implements jira.Searcher
+func (s *Searcher) FetchByJQL(jql string) (*pb.Export, error) {
+	s.record(jql)
+	if s.FetchByJQLFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return s.FetchByJQLFunc(jql)
+}
+
+// FetchIssuesByLabel implements jira.Searcher
+func (s *Searcher) FetchIssuesByLabel(label string) (*pb.Export, error) {
+	s.record(label)
+	if s.FetchIssuesByLabelFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return s.FetchIssuesByLabelFunc(label)
+}
+
+// FetchIssuesByKey implements jira.Searcher, recording the keys joined
+// with commas
+func (s *Searcher) FetchIssuesByKey(ctx context.Context, keys []string) ([]*pb.Issue, error) {
+	s.record(strings.Join(keys, ","))
+	if s.FetchIssuesByKeyFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return s.FetchIssuesByKeyFunc(ctx, keys)
+}
+
+// SearchIssues implements jira.Searcher
+func (s *Searcher) SearchIssues(jql string) ([]string, error) {
+	s.record(jql)
+	if s.SearchIssuesFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return s.SearchIssuesFunc(jql)
+}
+
+// Queries returns the JQL queries, labels and keys searched for so far, in
+// order
+func (s *Searcher) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+func (s *Searcher) record(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, query)
+}
+
+// OwnershipFetcher is a mock jira.OwnershipFetcher. Each method calls the
+// matching function field; methods without a function return ErrNotMocked.
+type OwnershipFetcher struct {
+	FetchComponentOwnersFunc func(export *pb.Export) (map[string]map[string]string, error)
+	FetchWatchersFunc        func(ctx context.Context, export *pb.Export) (map[string][]string, error)
+}
+
+// FetchComponentOwners implements jira.OwnershipFetcher
+func (o *OwnershipFetcher) FetchComponentOwners(export *pb.Export) (map[string]map[string]string, error) {
+	if o.FetchComponentOwnersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return o.FetchComponentOwnersFunc(export)
+}
+
+// FetchWatchers implements jira.OwnershipFetcher
+func (o *OwnershipFetcher) FetchWatchers(ctx context.Context, export *pb.Export) (map[string][]string, error) {
+	if o.FetchWatchersFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return o.FetchWatchersFunc(ctx, export)
+}
+
+// PropertyStore is an in-memory jira.PropertyStore. It is safe for
+// concurrent use.
+type PropertyStore struct {
+	mu         sync.Mutex
+	properties map[string]jira.SyncProperty
+}
+
+// NewPropertyStore returns a PropertyStore holding the given properties, by
+// issue key
+func NewPropertyStore(properties map[string]jira.SyncProperty) *PropertyStore {
+	store := &PropertyStore{properties: make(map[string]jira.SyncProperty, len(properties))}
+	for key, property := range properties {
+		store.properties[key] = property
+	}
+	return store
+}
+
+// FetchSyncProperties implements jira.PropertyStore, leaving out issues
+// without a property
+func (p *PropertyStore) FetchSyncProperties(ctx context.Context, issueKeys []string) (map[string]jira.SyncProperty, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	found := make(map[string]jira.SyncProperty)
+	for _, key := range issueKeys {
+		if property, ok := p.properties[key]; ok {
+			found[key] = property
+		}
+	}
+	return found, nil
+}
+
+// StoreSyncProperties implements jira.PropertyStore
+func (p *PropertyStore) StoreSyncProperties(ctx context.Context, properties map[string]jira.SyncProperty) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.properties == nil {
+		p.properties = make(map[string]jira.SyncProperty, len(properties))
+	}
+	for key, property := range properties {
+		p.properties[key] = property
+	}
+	return len(properties), nil
+}
+
+// Properties returns a copy of the stored properties, by issue key
+func (p *PropertyStore) Properties() map[string]jira.SyncProperty {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	properties := make(map[string]jira.SyncProperty, len(p.properties))
+	for key, property := range p.properties {
+		properties[key] = property
+	}
+	return properties
+}
@@ -0,0 +1,120 @@
+package jiratest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/conallob/jira-beads-sync/gen/jira"
+	"github.com/conallob/jira-beads-sync/jira"
+)
+
+func testIssue(key string) *pb.Issue {
+	return &pb.Issue{Id: key + "-id", Key: key, Fields: &pb.Fields{Summary: "Issue " + key}}
+}
+
+func TestNewFetcher(t *testing.T) {
+	story := testIssue("PROJ-1")
+	subtask := testIssue("PROJ-2")
+	blocker := testIssue("PROJ-3")
+	unrelated := testIssue("PROJ-4")
+	story.Fields.Subtasks = []*pb.Subtask{{Key: "PROJ-2"}}
+	subtask.Fields.Parent = &pb.Parent{Key: "PROJ-1"}
+	story.Fields.IssueLinks = []*pb.IssueLink{
+		{InwardIssue: &pb.LinkedIssue{Key: "PROJ-3"}},
+		{OutwardIssue: &pb.LinkedIssue{Key: "OTHER-1"}}, // Not served
+	}
+
+	fetcher := NewFetcher(story, subtask, blocker, unrelated)
+
+	issue, err := fetcher.FetchIssue("PROJ-3-id")
+	if err != nil || issue != blocker {
+		t.Errorf("Expected lookup by ID to return PROJ-3, got %v (%v)", issue, err)
+	}
+	if _, err := fetcher.FetchIssue("PROJ-9"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error for an unknown issue, got %v", err)
+	}
+
+	export, err := fetcher.FetchIssueWithDependencies("PROJ-1")
+	if err != nil {
+		t.Fatalf("FetchIssueWithDependencies failed: %v", err)
+	}
+	var keys []string
+	for _, issue := range export.Issues {
+		keys = append(keys, issue.Key)
+	}
+	if got := strings.Join(keys, ","); got != "PROJ-1,PROJ-2,PROJ-3" {
+		t.Errorf("Expected PROJ-1,PROJ-2,PROJ-3, got %s", got)
+	}
+
+	if got := strings.Join(fetcher.Calls(), ","); got != "PROJ-3-id,PROJ-9,PROJ-1" {
+		t.Errorf("Expected calls to be recorded, got %s", got)
+	}
+}
+
+func TestMocksWithoutFunctions(t *testing.T) {
+	if _, err := (&Fetcher{}).FetchIssue("PROJ-1"); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("Expected ErrNotMocked, got %v", err)
+	}
+	if _, err := (&Searcher{}).SearchIssues("project = PROJ"); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("Expected ErrNotMocked, got %v", err)
+	}
+	if _, err := (&OwnershipFetcher{}).FetchWatchers(context.Background(), &pb.Export{}); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("Expected ErrNotMocked, got %v", err)
+	}
+}
+
+func TestSearcher(t *testing.T) {
+	searcher := &Searcher{
+		FetchByJQLFunc: func(jql string) (*pb.Export, error) {
+			return &pb.Export{Issues: []*pb.Issue{testIssue("PROJ-1")}}, nil
+		},
+		FetchIssuesByLabelFunc: func(label string) (*pb.Export, error) {
+			return &pb.Export{}, nil
+		},
+		FetchIssuesByKeyFunc: func(ctx context.Context, keys []string) ([]*pb.Issue, error) {
+			return []*pb.Issue{testIssue(keys[0])}, nil
+		},
+	}
+
+	export, err := searcher.FetchByJQL("project = PROJ")
+	if err != nil || len(export.Issues) != 1 {
+		t.Errorf("Expected the mocked export, got %v (%v)", export, err)
+	}
+	if _, err := searcher.FetchIssuesByLabel("backend"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if issues, err := searcher.FetchIssuesByKey(context.Background(), []string{"PROJ-7", "PROJ-8"}); err != nil || len(issues) != 1 {
+		t.Errorf("Expected the mocked issues, got %v (%v)", issues, err)
+	}
+	if got := strings.Join(searcher.Queries(), "|"); got != "project = PROJ|backend|PROJ-7,PROJ-8" {
+		t.Errorf("Expected queries to be recorded, got %s", got)
+	}
+}
+
+func TestPropertyStore(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewPropertyStore(map[string]jira.SyncProperty{
+		"PROJ-1": {BeadsID: "proj-1", Hash: "abc", Updated: updated},
+	})
+
+	found, err := store.FetchSyncProperties(context.Background(), []string{"PROJ-1", "PROJ-2"})
+	if err != nil {
+		t.Fatalf("FetchSyncProperties failed: %v", err)
+	}
+	if len(found) != 1 || found["PROJ-1"].Hash != "abc" {
+		t.Errorf("Expected only the property of PROJ-1, got %v", found)
+	}
+
+	stored, err := store.StoreSyncProperties(context.Background(), map[string]jira.SyncProperty{
+		"PROJ-2": {BeadsID: "proj-2", Hash: "def", Updated: updated},
+	})
+	if err != nil || stored != 1 {
+		t.Errorf("Expected 1 stored property, got %d (%v)", stored, err)
+	}
+	if properties := store.Properties(); len(properties) != 2 || properties["PROJ-2"].BeadsID != "proj-2" {
+		t.Errorf("Expected both properties, got %v", properties)
+	}
+}
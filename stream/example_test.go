@@ -0,0 +1,61 @@
+package stream_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/conallob/jira-beads-sync/stream"
+)
+
+// Each record gets its own buffer, as it would get its own message when
+// publishing to a queue
+type message struct {
+	doc stream.Document
+	bytes.Buffer
+}
+
+func (m *message) Close() error {
+	fmt.Printf("publish %s %s (%s)\n", m.doc.Kind, m.doc.ID, m.doc.JiraKey)
+	return nil
+}
+
+func ExampleConvert() {
+	export, err := os.Open("../testdata/sample-jira-export.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = export.Close() }()
+
+	open := func(doc stream.Document) (io.Writer, error) {
+		return &message{doc: doc}, nil
+	}
+	if _, err := stream.Convert(export, open, stream.FormatJSONL); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// publish epic proj-1 (PROJ-1)
+	// publish issue proj-2 (PROJ-2)
+	// publish issue proj-3 (PROJ-3)
+	// publish issue proj-4 (PROJ-4)
+}
+
+func ExampleWriterStream() {
+	export, err := os.Open("../testdata/sample-jira-export.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = export.Close() }()
+
+	var issues, epics bytes.Buffer
+	if _, err := stream.Convert(export, stream.WriterStream(&issues, &epics), stream.FormatBD); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(bytes.Count(epics.Bytes(), []byte("\n")), "epic(s)")
+	fmt.Println(bytes.Count(issues.Bytes(), []byte("\n")), "issue(s)")
+	// Output:
+	// 1 epic(s)
+	// 3 issue(s)
+}
@@ -0,0 +1,84 @@
+// Package stream converts Jira JSON to beads records and emits each record
+// to writers provided by the caller instead of files, so services embedding
+// jira-beads-sync can ship synced data to message queues or HTTP sinks
+// without touching disk.
+//
+// The types are those of the converter and renderers the CLI uses, exposed
+// here since the packages implementing them are internal.
+package stream
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/conallob/jira-beads-sync/internal/beads"
+	"github.com/conallob/jira-beads-sync/internal/converter"
+)
+
+// DocumentKind tells the records of a stream apart
+type DocumentKind = beads.DocumentKind
+
+const (
+	// DocumentEpic is an epic record
+	DocumentEpic = beads.DocumentEpic
+	// DocumentIssue is an issue record
+	DocumentIssue = beads.DocumentIssue
+)
+
+// Document describes a record about to be emitted: its kind, beads ID and
+// Jira key
+type Document = beads.Document
+
+// OpenFunc returns the writer a document is emitted to. Writers that also
+// implement io.Closer are closed once the document is written, so each
+// document can become its own message or HTTP request body.
+type OpenFunc = beads.OpenFunc
+
+// Format selects the record format: FormatJSONL for the beads JSONL records,
+// FormatBD for the records `bd import` reads
+type Format = beads.Format
+
+const (
+	FormatJSONL = beads.FormatJSONL
+	FormatBD    = beads.FormatBD
+)
+
+// Renderer emits each epic and issue of a beads export as a marshaled JSON
+// document, followed by a newline
+type Renderer = beads.StreamRenderer
+
+// NewRenderer creates a renderer emitting records of the given format,
+// JSONL if empty, through open
+func NewRenderer(open OpenFunc, format Format) (*Renderer, error) {
+	return beads.NewStreamRendererWithOptions(open, format, beads.RendererOptions{})
+}
+
+// WriterStream emits issues to one writer and epics to another, e.g. two
+// files or the same pipe for both. The writers are never closed.
+func WriterStream(issues, epics io.Writer) OpenFunc {
+	return beads.WriterStream(issues, epics)
+}
+
+// Convert reads Jira JSON from r, either an export or a /search response,
+// converts it with the default mappings and emits its epics, then its
+// issues, through open. It returns the non-fatal conversion warnings, such
+// as unknown statuses, formatted for display.
+func Convert(r io.Reader, open OpenFunc, format Format) ([]string, error) {
+	renderer, err := NewRenderer(open, format)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := converter.NewPipeline("")
+	pipeline.SetStream(renderer)
+	err = pipeline.ConvertReader(r)
+
+	var warnings []string
+	for _, warning := range pipeline.Warnings() {
+		warnings = append(warnings, warning.String())
+	}
+	if err != nil {
+		return warnings, fmt.Errorf("failed to convert: %w", err)
+	}
+	return warnings, nil
+}